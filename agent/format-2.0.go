@@ -56,6 +56,8 @@ type format_2_0Serialization struct {
 	SharedSecret   string `yaml:"sharedsecret,omitempty"`
 	SystemIdentity string `yaml:"systemidentity,omitempty"`
 	MongoVersion   string `yaml:"mongoversion,omitempty"`
+
+	SecretConfigKey string `yaml:"secretconfigkey,omitempty"`
 }
 
 func init() {
@@ -115,13 +117,14 @@ func (formatter_2_0) unmarshal(data []byte) (*configInternal, error) {
 	}
 	if len(format.ControllerKey) != 0 {
 		config.servingInfo = &params.StateServingInfo{
-			Cert:           format.ControllerCert,
-			PrivateKey:     format.ControllerKey,
-			CAPrivateKey:   format.CAPrivateKey,
-			APIPort:        format.APIPort,
-			StatePort:      format.StatePort,
-			SharedSecret:   format.SharedSecret,
-			SystemIdentity: format.SystemIdentity,
+			Cert:            format.ControllerCert,
+			PrivateKey:      format.ControllerKey,
+			CAPrivateKey:    format.CAPrivateKey,
+			APIPort:         format.APIPort,
+			StatePort:       format.StatePort,
+			SharedSecret:    format.SharedSecret,
+			SystemIdentity:  format.SystemIdentity,
+			SecretConfigKey: format.SecretConfigKey,
 		}
 		// If private key is not present, infer it from the ports in the state addresses.
 		if config.servingInfo.StatePort == 0 {
@@ -179,6 +182,7 @@ func (formatter_2_0) marshal(config *configInternal) ([]byte, error) {
 		format.StatePort = config.servingInfo.StatePort
 		format.SharedSecret = config.servingInfo.SharedSecret
 		format.SystemIdentity = config.servingInfo.SystemIdentity
+		format.SecretConfigKey = config.servingInfo.SecretConfigKey
 	}
 	if config.stateDetails != nil {
 		if len(config.stateDetails.addresses) > 0 {
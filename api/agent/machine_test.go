@@ -52,6 +52,9 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		SharedSecret: ssi.SharedSecret,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
+		// The secret config key is taken from the API server's
+		// state, not from the stored serving info.
+		SecretConfigKey: coretesting.SecretConfigKey,
 	}
 	s.State.SetStateServingInfo(ssi)
 	info, err := apiagent.NewState(st).StateServingInfo()
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -80,6 +81,10 @@ type DeployArgs struct {
 	// Storage contains Constraints specifying how storage should be
 	// handled.
 	Storage map[string]storage.Constraints
+	// AttachStorage contains IDs of existing storage that should be
+	// attached to the application unit that will be deployed. This
+	// may be non-empty only if NumUnits is 1.
+	AttachStorage []string
 	// EndpointBindings
 	EndpointBindings map[string]string
 	// Collection of resource names for the application, with the value being the
@@ -91,6 +96,10 @@ type DeployArgs struct {
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	attachStorage, err := attachStorageTags(args.AttachStorage, args.NumUnits)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	deployArgs := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
@@ -102,12 +111,12 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Constraints:      args.Cons,
 			Placement:        args.Placement,
 			Storage:          args.Storage,
+			AttachStorage:    attachStorage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		}},
 	}
 	var results params.ErrorResults
-	var err error
 	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
 	if err != nil {
 		return err
@@ -145,6 +154,15 @@ type SetCharmConfig struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string
+	// BatchSize, when non-zero, limits the number of units that
+	// upgrade to the new charm at the same time.
+	BatchSize int
+	// WaitForActive holds back the next batch of units until the
+	// workload status of the upgraded units returns to active.
+	WaitForActive bool
+	// PauseOnError pauses the rolling upgrade when an upgraded unit
+	// goes into an error state.
+	PauseOnError bool
 }
 
 // SetCharm sets the charm for a given service.
@@ -156,6 +174,9 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceSeries:     cfg.ForceSeries,
 		ForceUnits:      cfg.ForceUnits,
 		ResourceIDs:     cfg.ResourceIDs,
+		BatchSize:       cfg.BatchSize,
+		WaitForActive:   cfg.WaitForActive,
+		PauseOnError:    cfg.PauseOnError,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
@@ -166,19 +187,63 @@ func (c *Client) Update(args params.ApplicationUpdate) error {
 	return c.facade.FacadeCall("Update", args, nil)
 }
 
+// AddUnitsParams contains parameters for the AddUnits API method.
+type AddUnitsParams struct {
+	// ApplicationName is the name of the application to which units
+	// will be added.
+	ApplicationName string
+
+	// NumUnits is the number of units to deploy.
+	NumUnits int
+
+	// Placement directives on where the machines for the unit must be
+	// created.
+	Placement []*instance.Placement
+
+	// AttachStorage contains IDs of existing storage that should be
+	// attached to the application unit that will be deployed. This
+	// may be non-empty only if NumUnits is 1.
+	AttachStorage []string
+}
+
 // AddUnits adds a given number of units to an application using the specified
 // placement directives to assign units to machines.
-func (c *Client) AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error) {
-	args := params.AddApplicationUnits{
-		ApplicationName: application,
-		NumUnits:        numUnits,
-		Placement:       placement,
+func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
+	attachStorage, err := attachStorageTags(args.AttachStorage, args.NumUnits)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	apiArgs := params.AddApplicationUnits{
+		ApplicationName: args.ApplicationName,
+		NumUnits:        args.NumUnits,
+		Placement:       args.Placement,
+		AttachStorage:   attachStorage,
 	}
 	results := new(params.AddApplicationUnitsResults)
-	err := c.facade.FacadeCall("AddUnits", args, results)
+	err = c.facade.FacadeCall("AddUnits", apiArgs, results)
 	return results.Units, err
 }
 
+// attachStorageTags validates the given storage IDs, and returns the
+// corresponding storage tag strings. Storage may only be attached when
+// exactly one unit is being added.
+func attachStorageTags(ids []string, numUnits int) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if numUnits != 1 {
+		return nil, errors.New("cannot attach existing storage when more than one unit is requested")
+	}
+	tags := make([]string, len(ids))
+	for i, id := range ids {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		tags[i] = names.NewStorageTag(id).String()
+	}
+	return tags, nil
+}
+
 // DestroyUnits decreases the number of units dedicated to an application.
 func (c *Client) DestroyUnits(unitNames ...string) error {
 	params := params.DestroyApplicationUnits{unitNames}
@@ -212,7 +277,28 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(application string) error {
-	params := params.ApplicationExpose{ApplicationName: application}
+	return c.ExposeTo(application, nil, nil)
+}
+
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, restricting access
+// to the given source CIDRs and to the subnets in the given spaces.
+// If both are empty, the ports are exposed to all sources.
+func (c *Client) ExposeTo(application string, cidrs, spaces []string) error {
+	return c.ExposeEndpoints(application, nil, cidrs, spaces)
+}
+
+// ExposeEndpoints changes the juju-managed firewall to expose the ports
+// explicitly marked by units as open for the given endpoints (or for
+// all endpoints), restricting access as with ExposeTo. If no endpoints
+// are given, the ports opened for all endpoints are exposed.
+func (c *Client) ExposeEndpoints(application string, endpoints, cidrs, spaces []string) error {
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		ToCIDRs:         cidrs,
+		ToSpaces:        spaces,
+		Endpoints:       endpoints,
+	}
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
@@ -223,14 +309,92 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
-// Get returns the configuration for the named application.
+// Get returns the configuration for the named application. The values
+// of secret settings are redacted.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
+	return c.get(application, false)
+}
+
+// GetWithSecrets returns the configuration for the named application,
+// including the values of secret settings. Only model administrators
+// may see them.
+func (c *Client) GetWithSecrets(application string) (*params.ApplicationGetResults, error) {
+	return c.get(application, true)
+}
+
+func (c *Client) get(application string, showSecrets bool) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
-	params := params.ApplicationGet{ApplicationName: application}
+	params := params.ApplicationGet{
+		ApplicationName: application,
+		ShowSecrets:     showSecrets,
+	}
 	err := c.facade.FacadeCall("Get", params, &results)
 	return &results, err
 }
 
+// ConfigHistory returns the recorded history of changes made to the
+// configuration of the named application, most recent first. The values
+// of secret settings are redacted unless showSecrets is true.
+func (c *Client) ConfigHistory(application string, showSecrets bool) ([]params.ConfigRevision, error) {
+	var result params.ConfigHistoryResult
+	args := params.ApplicationGet{
+		ApplicationName: application,
+		ShowSecrets:     showSecrets,
+	}
+	if err := c.facade.FacadeCall("ConfigHistory", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.History, nil
+}
+
+// RollbackConfig restores the configuration of the named application
+// to that recorded at the given revision of its history.
+func (c *Client) RollbackConfig(application string, revision int) error {
+	args := params.ApplicationConfigRollback{
+		ApplicationName: application,
+		Revision:        revision,
+	}
+	return c.facade.FacadeCall("RollbackConfig", args, nil)
+}
+
+// SetSecretConfigKeys marks the named config settings of an application
+// as secret, and only those, replacing those marked previously and
+// those chosen by name.
+func (c *Client) SetSecretConfigKeys(application string, keys []string) error {
+	args := params.ApplicationSecretConfigKeys{
+		ApplicationName: application,
+		Keys:            keys,
+	}
+	return c.facade.FacadeCall("SetSecretConfigKeys", args, nil)
+}
+
+// SetAutoUpgradePolicy sets whether the charm of an application is
+// upgraded automatically, and during which daily UTC maintenance
+// window ("HH:MM-HH:MM", or "" for any time).
+func (c *Client) SetAutoUpgradePolicy(application, mode, window string) error {
+	args := params.ApplicationAutoUpgrade{
+		ApplicationName:   application,
+		Mode:              mode,
+		MaintenanceWindow: window,
+	}
+	return c.facade.FacadeCall("SetAutoUpgradePolicy", args, nil)
+}
+
+// SetSeries changes the series that new units of an application are
+// deployed to. If charmURL is not empty the application is switched to
+// that charm too, which is needed when the current charm's URL names a
+// series. If force is true, a series the charm does not declare support
+// for is allowed.
+func (c *Client) SetSeries(application, series, charmURL string, force bool) error {
+	args := params.ApplicationSetSeries{
+		ApplicationName: application,
+		Series:          series,
+		CharmURL:        charmURL,
+		Force:           force,
+	}
+	return c.facade.FacadeCall("SetSeries", args, nil)
+}
+
 // Set sets configuration options on an application.
 func (c *Client) Set(application string, options map[string]string) error {
 	p := params.ApplicationSet{
@@ -270,3 +434,120 @@ func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
 	return c.facade.FacadeCall("DestroyRelation", params, nil)
 }
+
+// SetRelationSuspended suspends or resumes the relation with the given
+// id. The message records why the relation was suspended.
+func (c *Client) SetRelationSuspended(relationId int, suspended bool, message string) error {
+	args := params.RelationSuspendedArgs{
+		Args: []params.RelationSuspendedArg{{
+			RelationId: relationId,
+			Suspended:  suspended,
+			Message:    message,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetRelationsSuspended", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Bind changes the spaces the given endpoints of the application are
+// bound to. Endpoints not mentioned keep their existing bindings.
+func (c *Client) Bind(application string, bindings map[string]string) error {
+	args := params.ApplicationsEndpointBindings{
+		Args: []params.ApplicationEndpointBindings{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Bindings:       bindings,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetEndpointBindings", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ResumeCharmUpgrade resumes the paused rolling charm upgrade of the
+// application.
+func (c *Client) ResumeCharmUpgrade(application string) error {
+	return c.charmUpgradeCall("ResumeCharmUpgrades", application)
+}
+
+// AbortCharmUpgrade aborts the rolling charm upgrade of the application,
+// returning it and all its units to the charm they were running before.
+func (c *Client) AbortCharmUpgrade(application string) error {
+	return c.charmUpgradeCall("AbortCharmUpgrades", application)
+}
+
+func (c *Client) charmUpgradeCall(method, application string) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// UnitsInfo returns the details of the specified units, including the
+// relation settings each unit can see.
+func (c *Client) UnitsInfo(units []names.UnitTag) ([]params.UnitInfoResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(units)),
+	}
+	for i, tag := range units {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.UnitInfoResults
+	if err := c.facade.FacadeCall("UnitsInfo", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ApplicationsInfo returns the details of the specified applications.
+func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(applications)),
+	}
+	for i, tag := range applications {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ApplicationInfoResults
+	if err := c.facade.FacadeCall("ApplicationsInfo", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(applications) {
+		return nil, errors.Errorf("expected %d results, got %d", len(applications), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// Consume adds a remote application to the model, standing for the
+// application offered at the given URL by another model on the
+// controller. The remote application is named after the offer unless
+// an alias is given. The name of the remote application is returned.
+func (c *Client) Consume(offerURL, alias string) (string, error) {
+	args := params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{
+			OfferURL:         offerURL,
+			ApplicationAlias: alias,
+		}},
+	}
+	var results params.ConsumeApplicationResults
+	if err := c.facade.FacadeCall("Consume", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].LocalName, nil
+}
@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/common"
@@ -119,6 +120,127 @@ func (s *serviceSuite) TestSetServiceDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestDeployAttachStorage(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Deploy")
+		args, ok := a.(params.ApplicationsDeploy)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.Applications, gc.HasLen, 1)
+		c.Assert(args.Applications[0].AttachStorage, jc.DeepEquals, []string{"storage-data-0"})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		return nil
+	})
+
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		NumUnits:        1,
+		AttachStorage:   []string{"data/0"},
+	}
+	err := s.client.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestDeployAttachStorageMultipleUnits(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		return nil
+	})
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "serviceA",
+		NumUnits:        2,
+		AttachStorage:   []string{"data/0"},
+	}
+	err := s.client.Deploy(args)
+	c.Assert(err, gc.ErrorMatches, "cannot attach existing storage when more than one unit is requested")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *serviceSuite) TestAddUnits(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "AddUnits")
+		c.Assert(a, jc.DeepEquals, params.AddApplicationUnits{
+			ApplicationName: "foo",
+			NumUnits:        1,
+			Placement:       []*instance.Placement{{"scope", "directive"}},
+			AttachStorage:   []string{"storage-data-0"},
+		})
+		result := response.(*params.AddApplicationUnitsResults)
+		result.Units = []string{"foo/0"}
+		return nil
+	})
+	units, err := s.client.AddUnits(application.AddUnitsParams{
+		ApplicationName: "foo",
+		NumUnits:        1,
+		Placement:       []*instance.Placement{{"scope", "directive"}},
+		AttachStorage:   []string{"data/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"foo/0"})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		return nil
+	})
+	_, err := s.client.AddUnits(application.AddUnitsParams{
+		ApplicationName: "foo",
+		NumUnits:        2,
+		AttachStorage:   []string{"data/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot attach existing storage when more than one unit is requested")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *serviceSuite) TestExposeTo(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "wordpress",
+			ToCIDRs:         []string{"10.0.0.0/8"},
+			ToSpaces:        []string{"public"},
+		})
+		return nil
+	})
+	err := s.client.ExposeTo("wordpress", []string{"10.0.0.0/8"}, []string{"public"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "wordpress",
+			ToCIDRs:         []string{"10.0.0.0/8"},
+			Endpoints:       []string{"url"},
+		})
+		return nil
+	})
+	err := s.client.ExposeEndpoints("wordpress", []string{"url"}, []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -138,6 +260,112 @@ func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestConfigHistory(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ConfigHistory")
+		c.Assert(a, jc.DeepEquals, params.ApplicationGet{
+			ApplicationName: "application",
+			ShowSecrets:     true,
+		})
+
+		result := response.(*params.ConfigHistoryResult)
+		result.History = []params.ConfigRevision{{Revision: 1, User: "bob"}}
+		return nil
+	})
+	history, err := s.client.ConfigHistory("application", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []params.ConfigRevision{{Revision: 1, User: "bob"}})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestRollbackConfig(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "RollbackConfig")
+		c.Assert(a, jc.DeepEquals, params.ApplicationConfigRollback{
+			ApplicationName: "application",
+			Revision:        3,
+		})
+		return nil
+	})
+	err := s.client.RollbackConfig("application", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestGetWithSecrets(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Get")
+		c.Assert(a, jc.DeepEquals, params.ApplicationGet{
+			ApplicationName: "application",
+			ShowSecrets:     true,
+		})
+		result := response.(*params.ApplicationGetResults)
+		result.Application = "application"
+		return nil
+	})
+	results, err := s.client.GetWithSecrets("application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Application, gc.Equals, "application")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetSecretConfigKeys(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetSecretConfigKeys")
+		c.Assert(a, jc.DeepEquals, params.ApplicationSecretConfigKeys{
+			ApplicationName: "application",
+			Keys:            []string{"password"},
+		})
+		return nil
+	})
+	err := s.client.SetSecretConfigKeys("application", []string{"password"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetAutoUpgradePolicy(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetAutoUpgradePolicy")
+		c.Assert(a, jc.DeepEquals, params.ApplicationAutoUpgrade{
+			ApplicationName:   "application",
+			Mode:              "channel",
+			MaintenanceWindow: "01:00-03:00",
+		})
+		return nil
+	})
+	err := s.client.SetAutoUpgradePolicy("application", "channel", "01:00-03:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetSeries(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetSeries")
+		c.Assert(a, jc.DeepEquals, params.ApplicationSetSeries{
+			ApplicationName: "application",
+			Series:          "xenial",
+			CharmURL:        "cs:xenial/application-2",
+			Force:           true,
+		})
+		return nil
+	})
+	err := s.client.SetSeries("application", "xenial", "cs:xenial/application-2", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -163,3 +391,179 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestUnitsInfo(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UnitsInfo")
+		args, ok := a.(params.Entities)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		result := response.(*params.UnitInfoResults)
+		result.Results = []params.UnitInfoResult{{
+			Result: &params.UnitInfo{
+				Tag:     "unit-mysql-0",
+				Charm:   "cs:trusty/mysql-1",
+				Machine: "0",
+				Leader:  true,
+			},
+		}}
+		return nil
+	})
+	results, err := s.client.UnitsInfo([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.UnitInfoResult{{
+		Result: &params.UnitInfo{
+			Tag:     "unit-mysql-0",
+			Charm:   "cs:trusty/mysql-1",
+			Machine: "0",
+			Leader:  true,
+		},
+	}})
+}
+
+func (s *serviceSuite) TestApplicationsInfo(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ApplicationsInfo")
+		args, ok := a.(params.Entities)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.ApplicationInfoResults)
+		result.Results = []params.ApplicationInfoResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	results, err := s.client.ApplicationsInfo([]names.ApplicationTag{names.NewApplicationTag("mysql")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ApplicationInfoResult{{
+		Error: &params.Error{Message: "boom"},
+	}})
+}
+
+func (s *serviceSuite) TestConsume(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Consume")
+		args, ok := a.(params.ConsumeApplicationArgs)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ConsumeApplicationArgs{
+			Args: []params.ConsumeApplicationArg{{
+				OfferURL:         "prod.hosted-mysql",
+				ApplicationAlias: "db",
+			}},
+		})
+		result := response.(*params.ConsumeApplicationResults)
+		result.Results = []params.ConsumeApplicationResult{{LocalName: "db"}}
+		return nil
+	})
+	name, err := s.client.Consume("prod.hosted-mysql", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(name, gc.Equals, "db")
+}
+
+func (s *serviceSuite) TestSetRelationSuspended(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetRelationsSuspended")
+		args, ok := a.(params.RelationSuspendedArgs)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.RelationSuspendedArgs{
+			Args: []params.RelationSuspendedArg{{
+				RelationId: 123,
+				Suspended:  true,
+				Message:    "misbehaving",
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := s.client.SetRelationSuspended(123, true, "misbehaving")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestBind(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEndpointBindings")
+		args, ok := a.(params.ApplicationsEndpointBindings)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ApplicationsEndpointBindings{
+			Args: []params.ApplicationEndpointBindings{{
+				ApplicationTag: "application-mysql",
+				Bindings:       map[string]string{"server": "db"},
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := s.client.Bind("mysql", map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetCharmRolling(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.BatchSize, gc.Equals, 2)
+		c.Assert(args.WaitForActive, jc.IsTrue)
+		c.Assert(args.PauseOnError, jc.IsTrue)
+		return nil
+	})
+	err := s.client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "mysql",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("cs:trusty/mysql-2"),
+		},
+		BatchSize:     2,
+		WaitForActive: true,
+		PauseOnError:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestResumeCharmUpgrade(c *gc.C) {
+	s.testCharmUpgradeCall(c, "ResumeCharmUpgrades", s.client.ResumeCharmUpgrade)
+}
+
+func (s *serviceSuite) TestAbortCharmUpgrade(c *gc.C) {
+	s.testCharmUpgradeCall(c, "AbortCharmUpgrades", s.client.AbortCharmUpgrade)
+}
+
+func (s *serviceSuite) testCharmUpgradeCall(c *gc.C, method string, call func(string) error) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, method)
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := call("mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationoffers provides access to the application offers
+// API facade, used to offer application endpoints for consumption by
+// other models on the controller.
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the application offers API end point.
+type Client struct {
+	base.ClientFacade
+	st     base.APICallCloser
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the application offers
+// API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ApplicationOffers")
+	return &Client{ClientFacade: frontend, st: st, facade: backend}
+}
+
+// Offer offers the given endpoints of an application in the model of
+// the API connection for consumption by other models. The endpoints
+// map the names of the offered endpoints to those of the application.
+// If offerName is empty, the application name is used.
+func (c *Client) Offer(application string, endpoints map[string]string, offerName, description string) error {
+	modelTag, ok := c.st.ModelTag()
+	if !ok {
+		return errors.New("controller-only API connection has no model tag")
+	}
+	args := params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			ModelTag:               modelTag.String(),
+			OfferName:              offerName,
+			ApplicationName:        application,
+			ApplicationDescription: description,
+			Endpoints:              endpoints,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Offer", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListOffers returns the application offers of the model of the API
+// connection.
+func (c *Client) ListOffers() ([]params.ApplicationOffer, error) {
+	modelTag, ok := c.st.ModelTag()
+	if !ok {
+		return nil, errors.New("controller-only API connection has no model tag")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: modelTag.String()}},
+	}
+	var results params.ListApplicationOffersResults
+	if err := c.facade.FacadeCall("ListApplicationOffers", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Offers, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/applicationoffers"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type offersSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&offersSuite{})
+
+func (s *offersSuite) TestOffer(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Offer")
+			c.Check(a, jc.DeepEquals, params.AddApplicationOffers{
+				Offers: []params.AddApplicationOffer{{
+					ModelTag:               coretesting.ModelTag.String(),
+					OfferName:              "hosted-mysql",
+					ApplicationName:        "mysql",
+					ApplicationDescription: "a database",
+					Endpoints:              map[string]string{"db": "server"},
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.Offer("mysql", map[string]string{"db": "server"}, "hosted-mysql", "a database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *offersSuite) TestListOffers(c *gc.C) {
+	offers := []params.ApplicationOffer{{
+		OfferURL:        "admin/prod.hosted-mysql",
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(request, gc.Equals, "ListApplicationOffers")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+			})
+			*(result.(*params.ListApplicationOffersResults)) = params.ListApplicationOffersResults{
+				Results: []params.ListApplicationOffersResult{{Offers: offers}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	result, err := client.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, offers)
+}
+
+func (s *offersSuite) TestListOffersError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.ListApplicationOffersResults)) = params.ListApplicationOffersResults{
+				Results: []params.ListApplicationOffersResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.ListOffers()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
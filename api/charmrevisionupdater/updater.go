@@ -31,3 +31,17 @@ func (st *State) UpdateLatestRevisions() error {
 	}
 	return nil
 }
+
+// AutoUpgradeCharms upgrades the charms of applications whose
+// auto-upgrade policy allows it to the latest known revisions.
+func (st *State) AutoUpgradeCharms() error {
+	result := new(params.ErrorResult)
+	err := st.facade.FacadeCall("AutoUpgradeCharms", nil, result)
+	if err != nil {
+		return err
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
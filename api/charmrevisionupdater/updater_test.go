@@ -8,6 +8,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable/csclient"
 
 	"github.com/juju/juju/api/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/charmrevisionupdater/testing"
@@ -68,3 +69,22 @@ func (s *versionUpdaterSuite) TestUpdateRevisions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(pending.String(), gc.Equals, "cs:quantal/mysql-23")
 }
+
+func (s *versionUpdaterSuite) TestAutoUpgradeCharms(c *gc.C) {
+	s.SetupScenario(c)
+	s.PatchValue(&csclient.ServerURL, s.Server.URL)
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{Mode: state.AutoUpgradeChannel})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.updater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.updater.AutoUpgradeCharms()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := mysql.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollouts
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+var logger = loggo.GetLogger("juju.api.charmrollouts")
+
+// NewWatcherFunc exists to let us test Watch properly.
+type NewWatcherFunc func(base.APICaller, params.StringsWatchResult) watcher.StringsWatcher
+
+// API makes calls to the CharmRollouts facade.
+type API struct {
+	caller     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller, newWatcher NewWatcherFunc) *API {
+	return &API{
+		caller:     base.NewFacadeCaller(caller, "CharmRollouts"),
+		newWatcher: newWatcher,
+	}
+}
+
+// Watch returns a StringsWatcher that delivers the names of
+// applications whose rolling charm upgrade may be able to advance.
+func (api *API) Watch() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := api.caller.FacadeCall("Watch", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := api.newWatcher(api.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// Advance requests that the rolling charm upgrades of the supplied
+// applications let their next batch of units upgrade. It returns the
+// first error it encounters.
+func (api *API) Advance(applications []string) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(applications)),
+	}
+	for i, application := range applications {
+		if !names.IsValidApplication(application) {
+			return errors.NotValidf("application name %q", application)
+		}
+		args.Entities[i].Tag = names.NewApplicationTag(application).String()
+	}
+	var results params.ErrorResults
+	err := api.caller.FacadeCall("Advance", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results.Results {
+		if result.Error != nil {
+			if err == nil {
+				err = result.Error
+			} else {
+				logger.Errorf("additional advance error: %v", result.Error)
+			}
+		}
+	}
+	return errors.Trace(err)
+}
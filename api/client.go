@@ -49,6 +49,20 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusWithRelationUnits returns the status of the juju model,
+// including the status of each unit participating in each relation.
+func (c *Client) StatusWithRelationUnits(patterns []string) (*params.FullStatus, error) {
+	var result params.FullStatus
+	p := params.StatusParams{
+		Patterns:             patterns,
+		IncludeRelationUnits: true,
+	}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
@@ -17,11 +17,13 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  1,
+	"Application":                  2,
+	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
-	"CharmRevisionUpdater":         2,
+	"CharmRevisionUpdater":         3,
+	"CharmRollouts":                1,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
@@ -32,7 +34,7 @@ var facadeVersions = map[string]int{
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
+	"Firewaller":                   4,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -45,7 +47,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               2,
+	"MachineManager":               3,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -57,7 +59,7 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelConfig":                  1,
+	"ModelConfig":                  2,
 	"ModelManager":                 2,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
@@ -67,21 +69,24 @@ var facadeVersions = map[string]int{
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationUnitsWatcher":         1,
+	"RemoteRelations":              1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    1,
 	"StatusHistory":                2,
-	"Storage":                      3,
-	"StorageProvisioner":           3,
+	"Storage":                      4,
+	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       4,
+	"Uniter":                       5,
+	"UpgradeSeries":                1,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
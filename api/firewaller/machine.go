@@ -108,9 +108,34 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
+// PortRangeOwner identifies the unit that opened a port range and the
+// endpoints the range was opened for.
+type PortRangeOwner struct {
+	UnitTag names.UnitTag
+
+	// Endpoints holds the sorted names of the endpoints the range was
+	// opened for. If empty, the range was opened for all endpoints.
+	Endpoints []string
+}
+
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	owners, err := m.OpenedPortRangeOwners(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[network.PortRange]names.UnitTag)
+	for portRange, owner := range owners {
+		result[portRange] = owner.UnitTag
+	}
+	return result, nil
+}
+
+// OpenedPortRangeOwners returns a map of network.PortRange to the unit
+// and endpoints it was opened for, for all opened port ranges on the
+// machine for the subnet matching given subnetTag.
+func (m *Machine) OpenedPortRangeOwners(subnetTag names.SubnetTag) (map[network.PortRange]PortRangeOwner, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +158,29 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]PortRangeOwner)
+	openForAll := make(map[network.PortRange]bool)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		portRange := ports.PortRange.NetworkPortRange()
+		owner := endResult[portRange]
+		owner.UnitTag = unitTag
+		if ports.Endpoint == "" {
+			openForAll[portRange] = true
+		} else {
+			owner.Endpoints = append(owner.Endpoints, ports.Endpoint)
+		}
+		endResult[portRange] = owner
+	}
+	for portRange := range openForAll {
+		// Opened for all endpoints, so the
+		// specific endpoints are irrelevant.
+		owner := endResult[portRange]
+		owner.Endpoints = nil
+		endResult[portRange] = owner
 	}
 	return endResult, nil
 }
@@ -134,3 +134,29 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
 	})
 }
+
+func (s *machineSuite) TestOpenedPortRangeOwners(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenEndpointPorts("admin-api", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenEndpointPorts("url", "tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	owners, err := s.apiMachine.OpenedPortRangeOwners(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owners, jc.DeepEquals, map[network.PortRange]firewaller.PortRangeOwner{
+		network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}: {
+			UnitTag:   unitTag,
+			Endpoints: []string{"admin-api", "url"},
+		},
+		// Opened for all endpoints as well as for "url".
+		network.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"}: {
+			UnitTag: unitTag,
+		},
+	})
+}
@@ -91,3 +91,35 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposedEndpoints(c *gc.C) {
+	err := s.application.SetExposedEndpoints([]string{"url"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	endpoints, err := s.apiApplication.ExposedEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, []string{"url"})
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	endpoints, err = s.apiApplication.ExposedEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, gc.HasLen, 0)
+}
+
+func (s *serviceSuite) TestExposedIngressCIDRs(c *gc.C) {
+	err := s.application.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+}
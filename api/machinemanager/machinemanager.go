@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -36,3 +37,36 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// PrepareUpgradeSeries records that the operating system of the given
+// machine is about to be upgraded to the given series. The agents of
+// the units on the machine stop running hooks until
+// CompleteUpgradeSeries is called. If force is true, the charms of
+// those units need not support the new series.
+func (client *Client) PrepareUpgradeSeries(machine, series string, force bool) error {
+	args := params.UpgradeSeriesArgs{
+		Args: []params.UpgradeSeriesArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machine).String()},
+			Series: series,
+			Force:  force,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("PrepareUpgradeSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CompleteUpgradeSeries records that the operating system of the given
+// machine has been upgraded to the series it was prepared for.
+func (client *Client) CompleteUpgradeSeries(machine string) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machine).String()}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("CompleteUpgradeSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -167,9 +167,10 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 	}
 
 	return migration.SerializedModel{
-		Bytes:  serialized.Bytes,
-		Charms: serialized.Charms,
-		Tools:  tools,
+		Bytes:     serialized.Bytes,
+		Charms:    serialized.Charms,
+		Tools:     tools,
+		SecretKey: serialized.SecretKey,
 	}, nil
 }
 
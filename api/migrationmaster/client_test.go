@@ -216,6 +216,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				Version: "2.0.0-trusty-amd64",
 				URI:     "/tools/0",
 			}},
+			SecretKey: "key",
 		}
 		return nil
 	})
@@ -231,6 +232,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.0.0-trusty-amd64"): "/tools/0",
 		},
+		SecretKey: "key",
 	})
 }
 
@@ -33,10 +33,11 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
-// Import takes a serialized model and imports it into the target
+// Import takes a serialized model, and the key with which its secret
+// config values are encrypted, and imports it into the target
 // controller.
-func (c *Client) Import(bytes []byte) error {
-	serialized := params.SerializedModel{Bytes: bytes}
+func (c *Client) Import(bytes []byte, secretKey string) error {
+	serialized := params.SerializedModel{Bytes: bytes, SecretKey: secretKey}
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
@@ -60,9 +60,9 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
-	err := client.Import([]byte("foo"))
+	err := client.Import([]byte("foo"), "key")
 
-	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	expectedArg := params.SerializedModel{Bytes: []byte("foo"), SecretKey: "key"}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Import", []interface{}{"", expectedArg}},
 	})
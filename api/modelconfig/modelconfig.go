@@ -73,3 +73,20 @@ func (c *Client) ModelUnset(keys ...string) error {
 	args := params.ModelUnset{Keys: keys}
 	return c.facade.FacadeCall("ModelUnset", args, nil)
 }
+
+// ModelConfigHistory returns the recorded history of changes made to
+// the model configuration, most recent first.
+func (c *Client) ModelConfigHistory() ([]params.ConfigRevision, error) {
+	var result params.ConfigHistoryResult
+	if err := c.facade.FacadeCall("ModelConfigHistory", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.History, nil
+}
+
+// ModelRollback restores the model configuration to that recorded at
+// the given revision of its history.
+func (c *Client) ModelRollback(revision int) error {
+	args := params.ModelRollback{Revision: revision}
+	return c.facade.FacadeCall("ModelRollback", args, nil)
+}
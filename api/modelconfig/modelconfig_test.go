@@ -127,3 +127,49 @@ func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelConfigHistory")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ConfigHistoryResult{})
+			*(result.(*params.ConfigHistoryResult)) = params.ConfigHistoryResult{
+				History: []params.ConfigRevision{{Revision: 2, User: "bob"}},
+			}
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	history, err := client.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []params.ConfigRevision{{Revision: 2, User: "bob"}})
+}
+
+func (s *modelconfigSuite) TestModelRollback(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelRollback")
+			c.Check(a, jc.DeepEquals, params.ModelRollback{Revision: 2})
+			called = true
+			return nil
+		},
+	)
+	client := modelconfig.NewClient(apiCaller)
+	err := client.ModelRollback(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides access to the RemoteRelations API
+// facade, used to mirror relations with remote applications into the
+// models offering them.
+package remoterelations
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const remoteRelationsFacade = "RemoteRelations"
+
+// Client provides access to the RemoteRelations API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side RemoteRelations facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, remoteRelationsFacade),
+	}
+}
+
+// WatchRemoteApplications returns a strings watcher that notifies of
+// the addition, removal, and lifecycle changes of remote applications
+// in the model.
+func (c *Client) WatchRemoteApplications() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchRemoteApplications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// RemoteApplications returns the details of the named remote
+// applications.
+func (c *Client) RemoteApplications(applications []string) ([]params.RemoteApplicationResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(applications)),
+	}
+	for i, name := range applications {
+		if !names.IsValidApplication(name) {
+			return nil, errors.NotValidf("application name %q", name)
+		}
+		args.Entities[i].Tag = names.NewApplicationTag(name).String()
+	}
+	var results params.RemoteApplicationResults
+	if err := c.facade.FacadeCall("RemoteApplications", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(applications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(applications), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// WatchRemoteApplicationRelations returns a strings watcher that
+// notifies of changes to the lifecycles of the relations of the named
+// remote application.
+func (c *Client) WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error) {
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchRemoteApplicationRelations", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// ExportRelations ensures that the relations with the given keys have
+// counterparts in the models offering their remote applications, and
+// returns the details of both.
+func (c *Client) ExportRelations(keys []string) ([]params.RemoteRelationResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(keys)),
+	}
+	for i, key := range keys {
+		if !names.IsValidRelation(key) {
+			return nil, errors.NotValidf("relation key %q", key)
+		}
+		args.Entities[i].Tag = names.NewRelationTag(key).String()
+	}
+	var results params.RemoteRelationResults
+	if err := c.facade.FacadeCall("ExportRelations", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(keys) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(keys), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// DestroyRelation destroys the relation with the given key in the
+// model offering an application consumed by the API's model.
+func (c *Client) DestroyRelation(modelUUID, key string) error {
+	args := params.RemoteRelationArgs{
+		Args: []params.RemoteRelationArg{{
+			ModelUUID:   modelUUID,
+			RelationKey: key,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DestroyRelations", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchRelationUnits returns a relation units watcher for the units of
+// an application in a relation of the API's model, or of a model
+// offering an application it consumes.
+func (c *Client) WatchRelationUnits(arg params.RemoteRelationUnitsArg) (watcher.RelationUnitsWatcher, error) {
+	args := params.RemoteRelationUnitsArgs{
+		Args: []params.RemoteRelationUnitsArg{arg},
+	}
+	var results params.RelationUnitsWatchResults
+	if err := c.facade.FacadeCall("WatchRelationUnits", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewRelationUnitsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// PublishRelationChange mirrors the given units of a relation, with
+// their settings, as remote units of its counterpart in another model.
+func (c *Client) PublishRelationChange(change params.RemoteRelationChange) error {
+	args := params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("PublishRelationChanges", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
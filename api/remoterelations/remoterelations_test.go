@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type remoteRelationsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(request, gc.Equals, "RemoteApplications")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-hosted-mysql"}},
+			})
+			*(result.(*params.RemoteApplicationResults)) = params.RemoteApplicationResults{
+				Results: []params.RemoteApplicationResult{{
+					Result: &params.RemoteApplication{Name: "hosted-mysql", Life: "alive"},
+				}},
+			}
+			return nil
+		})
+	client := remoterelations.NewClient(apiCaller)
+	results, err := client.RemoteApplications([]string{"hosted-mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.RemoteApplicationResult{{
+		Result: &params.RemoteApplication{Name: "hosted-mysql", Life: "alive"},
+	}})
+}
+
+func (s *remoteRelationsSuite) TestExportRelations(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(request, gc.Equals, "ExportRelations")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "relation-wordpress.db#hosted-mysql.database"}},
+			})
+			*(result.(*params.RemoteRelationResults)) = params.RemoteRelationResults{
+				Results: []params.RemoteRelationResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	client := remoterelations.NewClient(apiCaller)
+	results, err := client.ExportRelations([]string{"wordpress:db hosted-mysql:database"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *remoteRelationsSuite) TestDestroyRelation(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(request, gc.Equals, "DestroyRelations")
+			c.Check(a, jc.DeepEquals, params.RemoteRelationArgs{
+				Args: []params.RemoteRelationArg{{
+					ModelUUID:   "offering-uuid",
+					RelationKey: "mysql:server remote-abc:db",
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	client := remoterelations.NewClient(apiCaller)
+	err := client.DestroyRelation("offering-uuid", "mysql:server remote-abc:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *remoteRelationsSuite) TestPublishRelationChange(c *gc.C) {
+	change := params.RemoteRelationChange{
+		Source: params.RemoteRelationUnitsArg{
+			ModelUUID:       "consuming-uuid",
+			RelationKey:     "wordpress:db hosted-mysql:database",
+			ApplicationName: "wordpress",
+		},
+		TargetModelUUID:   "offering-uuid",
+		TargetRelationKey: "mysql:server remote-abc:db",
+		ChangedUnits:      []string{"wordpress/0"},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "PublishRelationChanges")
+			c.Check(a, jc.DeepEquals, params.RemoteRelationChanges{
+				Changes: []params.RemoteRelationChange{change},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		})
+	client := remoterelations.NewClient(apiCaller)
+	err := client.PublishRelationChange(change)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides access to the secrets API facade, used to
+// inspect the metadata of the secrets owned by applications.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the secrets API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the secrets API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListSecrets returns the metadata of the secrets in the model of the
+// API connection. If owner is not empty, only the secrets owned by that
+// application are returned.
+func (c *Client) ListSecrets(owner string) ([]params.SecretMetadata, error) {
+	var result params.ListSecretResults
+	args := params.ListSecretsArgs{Owner: owner}
+	if err := c.facade.FacadeCall("ListSecrets", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// ShowSecret returns the metadata, including the grants of access, of
+// the secret with the given ID.
+func (c *Client) ShowSecret(id string) (*params.SecretMetadata, error) {
+	var results params.SecretMetadataResults
+	args := params.SecretIDs{IDs: []string{id}}
+	if err := c.facade.FacadeCall("ShowSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
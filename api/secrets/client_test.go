@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	metadata := []params.SecretMetadata{{
+		ID:       "secret:1",
+		Owner:    "mysql",
+		Revision: 2,
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSecrets")
+			c.Check(a, jc.DeepEquals, params.ListSecretsArgs{Owner: "mysql"})
+			*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+				Results: metadata,
+			}
+			return nil
+		})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, metadata)
+}
+
+func (s *secretsSuite) TestShowSecret(c *gc.C) {
+	metadata := &params.SecretMetadata{
+		ID:     "secret:1",
+		Owner:  "mysql",
+		Grants: []params.SecretGrant{{Application: "wordpress", Relation: "wordpress:db mysql:server"}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Secrets")
+			c.Check(request, gc.Equals, "ShowSecrets")
+			c.Check(a, jc.DeepEquals, params.SecretIDs{IDs: []string{"secret:1"}})
+			*(result.(*params.SecretMetadataResults)) = params.SecretMetadataResults{
+				Results: []params.SecretMetadataResult{{Result: metadata}},
+			}
+			return nil
+		})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ShowSecret("secret:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, metadata)
+}
+
+func (s *secretsSuite) TestShowSecretError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.SecretMetadataResults)) = params.SecretMetadataResults{
+				Results: []params.SecretMetadataResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ShowSecret("secret:1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
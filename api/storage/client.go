@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
 )
 
 // Client allows access to the storage API end point.
@@ -89,6 +90,22 @@ func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}
 	return c.facade.FacadeCall("CreatePool", args, nil)
 }
 
+// UpdatePool updates the attributes of the named pool. Attributes
+// with empty values are removed from the pool's configuration.
+func (c *Client) UpdatePool(pname string, attrs map[string]interface{}) error {
+	args := params.StoragePool{
+		Name:  pname,
+		Attrs: attrs,
+	}
+	return c.facade.FacadeCall("UpdatePool", args, nil)
+}
+
+// RemovePool removes the named pool.
+func (c *Client) RemovePool(pname string) error {
+	args := params.StoragePoolDeleteArg{Name: pname}
+	return c.facade.FacadeCall("RemovePool", args, nil)
+}
+
 // ListVolumes lists volumes for desired machines.
 // If no machines provided, a list of all volumes is returned.
 func (c *Client) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
@@ -147,3 +164,175 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Attach attaches the specified detached storage instances to a unit.
+func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	unitTag := names.NewUnitTag(unitId).String()
+	args := params.StorageAttachmentIds{
+		Ids: make([]params.StorageAttachmentId, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		args.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			UnitTag:    unitTag,
+		}
+	}
+	return c.bulkCall("Attach", args, len(storageIds))
+}
+
+// Detach detaches the specified storage instances from the units
+// they are attached to, retaining the storage in the model.
+func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	args := params.StorageAttachmentIds{
+		Ids: make([]params.StorageAttachmentId, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		args.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+		}
+	}
+	return c.bulkCall("Detach", args, len(storageIds))
+}
+
+// Remove removes the specified storage instances from the model. If
+// release is true, the volumes or filesystems backing the storage are
+// retained; otherwise they are destroyed.
+func (c *Client) Remove(storageIds []string, release bool) ([]params.ErrorResult, error) {
+	args := params.RemoveStorage{
+		Storage: make([]params.RemoveStorageInstance, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		args.Storage[i] = params.RemoveStorageInstance{
+			Tag:     names.NewStorageTag(storageId).String(),
+			Release: release,
+		}
+	}
+	return c.bulkCall("Remove", args, len(storageIds))
+}
+
+// Resize grows the volumes backing the specified storage instances to
+// the given size in MiB.
+func (c *Client) Resize(storageIds []string, size uint64) ([]params.ErrorResult, error) {
+	args := params.ResizeStorage{
+		Storage: make([]params.ResizeStorageInstance, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		args.Storage[i] = params.ResizeStorageInstance{
+			Tag:  names.NewStorageTag(storageId).String(),
+			Size: size,
+		}
+	}
+	return c.bulkCall("Resize", args, len(storageIds))
+}
+
+// CreateSnapshots requests snapshots of the volumes backing the
+// specified storage instances, returning the IDs of the new snapshots.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.StringResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(storageIds)),
+	}
+	for i, storageId := range storageIds {
+		args.Entities[i].Tag = names.NewStorageTag(storageId).String()
+	}
+	return c.stringsCall("CreateSnapshots", args, len(storageIds))
+}
+
+// ListSnapshots lists volume snapshots for the specified storage
+// instances. If no storage instances are specified, a list of all
+// volume snapshots is returned.
+func (c *Client) ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetailsListResult, error) {
+	filters := make([]params.VolumeSnapshotFilter, len(storageIds))
+	for i, storageId := range storageIds {
+		filters[i].StorageTags = []string{names.NewStorageTag(storageId).String()}
+	}
+	if len(filters) == 0 {
+		filters = []params.VolumeSnapshotFilter{{}}
+	}
+	args := params.VolumeSnapshotFilters{filters}
+	var results params.VolumeSnapshotDetailsListResults
+	if err := c.facade.FacadeCall("ListSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(filters) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(filters), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// RestoreSnapshots creates new storage instances from the specified
+// volume snapshots, returning the tags of the new storage instances.
+func (c *Client) RestoreSnapshots(snapshotIds []string) ([]params.StringResult, error) {
+	args := params.VolumeSnapshotIds{Ids: snapshotIds}
+	return c.stringsCall("RestoreSnapshots", args, len(snapshotIds))
+}
+
+// Import imports existing storage into the model, returning the tag
+// of the newly created storage instance. The storage is recorded in
+// the model as detached, and may subsequently be attached to a unit.
+func (c *Client) Import(
+	kind jujustorage.StorageKind,
+	storagePool string,
+	storageProviderId string,
+	storageName string,
+) (names.StorageTag, error) {
+	var paramsKind params.StorageKind
+	switch kind {
+	case jujustorage.StorageKindBlock:
+		paramsKind = params.StorageKindBlock
+	case jujustorage.StorageKindFilesystem:
+		paramsKind = params.StorageKindFilesystem
+	}
+	args := params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        paramsKind,
+			Pool:        storagePool,
+			ProviderId:  storageProviderId,
+			StorageName: storageName,
+		}},
+	}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf(
+			"expected 1 result, got %d",
+			len(results.Results),
+		)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
+
+func (c *Client) stringsCall(method string, args interface{}, expected int) ([]params.StringResult, error) {
+	var results params.StringResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != expected {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			expected, len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+func (c *Client) bulkCall(method string, args interface{}, expected int) ([]params.ErrorResult, error) {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != expected {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			expected, len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
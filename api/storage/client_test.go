@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 )
 
@@ -288,6 +289,59 @@ func (s *storageMockSuite) TestCreatePoolFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
 
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	var called bool
+	poolName := "poolName"
+	poolConfig := map[string]interface{}{
+		"test": "two",
+	}
+
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdatePool")
+
+			args, ok := a.(params.StoragePool)
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(args.Name, gc.Equals, poolName)
+			c.Assert(args.Provider, gc.Equals, "")
+			c.Assert(args.Attrs, gc.DeepEquals, poolConfig)
+
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.UpdatePool(poolName, poolConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *storageMockSuite) TestRemovePool(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RemovePool")
+			c.Assert(a, jc.DeepEquals, params.StoragePoolDeleteArg{Name: "poolName"})
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.RemovePool("poolName")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *storageMockSuite) TestListVolumes(c *gc.C) {
 	var called bool
 	machines := []string{"0", "1"}
@@ -581,3 +635,234 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{
+				Ids: []params.StorageAttachmentId{
+					{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+					{StorageTag: "storage-data-1", UnitTag: "unit-mysql-1"},
+				},
+			})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{}, {Error: &params.Error{Message: "storage is not detached"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Attach("mysql/1", []string{"data/0", "data/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{}, {Error: &params.Error{Message: "storage is not detached"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{
+				Ids: []params.StorageAttachmentId{{StorageTag: "storage-data-0"}},
+			})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Detach([]string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+}
+
+func (s *storageMockSuite) TestRemove(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "Remove")
+			c.Check(a, jc.DeepEquals, params.RemoveStorage{
+				Storage: []params.RemoveStorageInstance{
+					{Tag: "storage-data-0", Release: true},
+				},
+			})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Remove([]string{"data/0"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+}
+
+func (s *storageMockSuite) TestRemoveResultCountMismatch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Remove([]string{"data/0"}, false)
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 0`)
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "Resize")
+			c.Check(a, jc.DeepEquals, params.ResizeStorage{
+				Storage: []params.ResizeStorageInstance{
+					{Tag: "storage-data-0", Size: 20480},
+				},
+			})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{{}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Resize([]string{"data/0"}, 20480)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "CreateSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "storage-data-0"}},
+			})
+			results := result.(*params.StringResults)
+			results.Results = []params.StringResult{{Result: "0"}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.CreateSnapshots([]string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{{Result: "0"}})
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "ListSnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotFilters{
+				Filters: []params.VolumeSnapshotFilter{{}},
+			})
+			results := result.(*params.VolumeSnapshotDetailsListResults)
+			results.Results = []params.VolumeSnapshotDetailsListResult{{
+				Result: []params.VolumeSnapshotDetails{{Id: "0", VolumeTag: "volume-0"}},
+			}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.ListSnapshots(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsListResult{{
+		Result: []params.VolumeSnapshotDetails{{Id: "0", VolumeTag: "volume-0"}},
+	}})
+}
+
+func (s *storageMockSuite) TestRestoreSnapshots(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "RestoreSnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0"}})
+			results := result.(*params.StringResults)
+			results.Results = []params.StringResult{{Result: "storage-data-1"}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.RestoreSnapshots([]string{"0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{{Result: "storage-data-1"}})
+}
+
+func (s *storageMockSuite) TestImport(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.BulkImportStorageParams{
+				Storage: []params.ImportStorageParams{{
+					Kind:        params.StorageKindFilesystem,
+					Pool:        "foo",
+					ProviderId:  "bar",
+					StorageName: "baz",
+				}},
+			})
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{{
+				Result: &params.ImportStorageDetails{
+					StorageTag: "storage-baz-0",
+				},
+			}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	storageTag, err := storageClient.Import(jujustorage.StorageKindFilesystem, "foo", "bar", "baz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("baz/0"))
+}
+
+func (s *storageMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			results := result.(*params.ImportStorageResults)
+			results.Results = []params.ImportStorageResult{{
+				Error: &params.Error{Message: "qux"},
+			}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Import(jujustorage.StorageKindFilesystem, "foo", "bar", "baz")
+	c.Check(err, gc.ErrorMatches, "qux")
+}
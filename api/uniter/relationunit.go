@@ -104,6 +104,24 @@ func (ru *RelationUnit) LeaveScope() error {
 	return result.OneError()
 }
 
+// SetSettled records whether the unit has run all the relation hooks
+// due so far.
+func (ru *RelationUnit) SetSettled(settled bool) error {
+	var result params.ErrorResults
+	args := params.RelationUnitsSettled{
+		RelationUnits: []params.RelationUnitSettled{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+			Settled:  settled,
+		}},
+	}
+	err := ru.st.facade.FacadeCall("SetRelationsSettled", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // Settings returns a Settings which allows access to the unit's settings
 // within the relation.
 func (ru *RelationUnit) Settings() (*Settings, error) {
@@ -189,6 +189,20 @@ func (s *relationUnitSuite) TestLeaveScope(c *gc.C) {
 	s.assertInScope(c, wpRelUnit, false)
 }
 
+func (s *relationUnitSuite) TestSetSettled(c *gc.C) {
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err := apiRelUnit.SetSettled(true)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+
+	err = wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = apiRelUnit.SetSettled(true)
+	c.Assert(err, jc.ErrorIsNil)
+	settled, err := wpRelUnit.Settled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settled, jc.IsTrue)
+}
+
 func (s *relationUnitSuite) TestSettings(c *gc.C) {
 	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
 	settings := map[string]interface{}{
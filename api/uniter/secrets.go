@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// CreateSecret creates a secret owned by the unit's application, and
+// returns its ID. Only the leader unit may create secrets.
+func (u *Unit) CreateSecret(label, description string, rotateInterval time.Duration, data map[string]string) (string, error) {
+	var results params.StringResults
+	args := params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag:        u.tag.String(),
+			Label:          label,
+			Description:    description,
+			RotateInterval: rotateInterval,
+			Data:           data,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("CreateSecrets", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// UpdateSecret adds a new revision, with the given content, to a
+// secret owned by the unit's application. Only the leader unit may
+// update secrets.
+func (u *Unit) UpdateSecret(id string, data map[string]string) error {
+	var results params.ErrorResults
+	args := params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag: u.tag.String(),
+			ID:      id,
+			Data:    data,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("UpdateSecrets", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SecretValue returns the content of the given revision of a secret,
+// or of its latest revision if revision is zero.
+func (u *Unit) SecretValue(id string, revision int) (map[string]string, error) {
+	var results params.SecretValueResults
+	args := params.GetSecretArgs{
+		Args: []params.GetSecretArg{{
+			UnitTag:  u.tag.String(),
+			ID:       id,
+			Revision: revision,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("SecretValues", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Data, nil
+}
+
+// GrantSecret allows the application at the other end of the given
+// relation to read a secret owned by the unit's application.
+func (u *Unit) GrantSecret(id string, relation names.RelationTag) error {
+	return u.changeSecretGrant("GrantSecrets", id, relation)
+}
+
+// RevokeSecret removes the access to a secret owned by the unit's
+// application granted to the application at the other end of the
+// given relation.
+func (u *Unit) RevokeSecret(id string, relation names.RelationTag) error {
+	return u.changeSecretGrant("RevokeSecrets", id, relation)
+}
+
+func (u *Unit) changeSecretGrant(method, id string, relation names.RelationTag) error {
+	var results params.ErrorResults
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			UnitTag:     u.tag.String(),
+			ID:          id,
+			RelationTag: relation.String(),
+		}},
+	}
+	if err := u.st.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchSecrets returns a watcher that notifies when secrets in the
+// model are added, updated, granted or revoked.
+func (u *Unit) WatchSecrets() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("WatchSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// SecretRevisions returns the latest revisions of the secrets the
+// unit's application has been granted access to, keyed by secret ID,
+// and, if the unit is the leader, the revisions of the secrets its
+// application owns that are due to be rotated.
+func (u *Unit) SecretRevisions() (granted, toRotate map[string]int, _ error) {
+	var results params.SecretRevisionsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("SecretRevisions", args, &results); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	granted = make(map[string]int)
+	for _, r := range result.Granted {
+		granted[r.ID] = r.Revision
+	}
+	toRotate = make(map[string]int)
+	for _, r := range result.ToRotate {
+		toRotate[r.ID] = r.Revision
+	}
+	return granted, toRotate, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type secretsSuite struct {
+	uniterSuite
+
+	apiUnit *uniter.Unit
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.uniterSuite.SetUpTest(c)
+
+	var err error
+	s.apiUnit, err = s.uniter.Unit(s.wordpressUnit.Tag().(names.UnitTag))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) claimLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestCreateSecretNotLeader(c *gc.C) {
+	_, err := s.apiUnit.CreateSecret("", "", 0, map[string]string{"password": "sekrit"})
+	c.Assert(err, gc.ErrorMatches, ".* is not leader of .*")
+}
+
+func (s *secretsSuite) TestCreateUpdateAndGetSecret(c *gc.C) {
+	s.claimLeadership(c)
+	id, err := s.apiUnit.CreateSecret("db", "database password", time.Hour, map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Owner, gc.Equals, "wordpress")
+	c.Assert(secret.Label, gc.Equals, "db")
+	c.Assert(secret.RotateInterval, gc.Equals, time.Hour)
+
+	err = s.apiUnit.UpdateSecret(id, map[string]string{"password": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := s.apiUnit.SecretValue(id, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, map[string]string{"password": "changed"})
+	data, err = s.apiUnit.SecretValue(id, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *secretsSuite) TestGrantAndRevokeSecret(c *gc.C) {
+	s.claimLeadership(c)
+	s.addMachineServiceCharmAndUnit(c, "mysql")
+	rel := s.addRelation(c, "wordpress", "mysql")
+	id, err := s.apiUnit.CreateSecret("", "", 0, map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.apiUnit.GrantSecret(id, rel.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	ok, err := s.State.CanReadSecret(id, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+
+	err = s.apiUnit.RevokeSecret(id, rel.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	ok, err = s.State.CanReadSecret(id, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *secretsSuite) TestWatchSecretsAndRevisions(c *gc.C) {
+	s.addMachineServiceCharmAndUnit(c, "mysql")
+	rel := s.addRelation(c, "wordpress", "mysql")
+
+	w, err := s.apiUnit.WatchSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: "mysql",
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.GrantSecret(secret.ID, rel, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	granted, toRotate, err := s.apiUnit.SecretRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(granted, jc.DeepEquals, map[string]int{secret.ID: 1})
+	c.Assert(toRotate, gc.HasLen, 0)
+}
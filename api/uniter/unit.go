@@ -425,6 +425,13 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
+	return u.OpenEndpointPorts("", protocol, fromPort, toPort)
+}
+
+// OpenEndpointPorts sets the policy of the port range with protocol to
+// be opened for the given endpoint. An empty endpoint means all
+// endpoints.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -432,6 +439,7 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
@@ -444,6 +452,13 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
+	return u.CloseEndpointPorts("", protocol, fromPort, toPort)
+}
+
+// CloseEndpointPorts sets the policy of the port range with protocol
+// to be closed for the given endpoint. An empty endpoint closes the
+// port range for all the endpoints it was opened for.
+func (u *Unit) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -451,6 +466,7 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("ClosePorts", args, &result)
@@ -492,6 +508,36 @@ func (u *Unit) CharmURL() (*charm.URL, error) {
 	return nil, ErrNoCharmURLSet
 }
 
+// TargetCharmURL returns the URL of the charm the unit should be
+// running, and whether the upgrade to it is forced. This is the charm
+// of the unit's application, unless the unit is held at its current
+// charm during a rolling charm upgrade.
+func (u *Unit) TargetCharmURL() (*charm.URL, bool, error) {
+	var results params.StringBoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("TargetCharmURL", args, &results)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(results.Results) != 1 {
+		return nil, false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.Result == "" {
+		return nil, false, ErrNoCharmURLSet
+	}
+	curl, err := charm.ParseURL(result.Result)
+	if err != nil {
+		return nil, false, err
+	}
+	return curl, result.Ok, nil
+}
+
 // SetCharmURL marks the unit as currently using the supplied charm URL.
 // An error will be returned if the unit is dead, or the charm URL not known.
 func (u *Unit) SetCharmURL(curl *charm.URL) error {
@@ -524,6 +570,41 @@ func (u *Unit) ClearResolved() error {
 	return result.OneError()
 }
 
+// UpgradeSeriesStatus returns the progress of the unit through the
+// series upgrade of its machine.
+func (u *Unit) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error) {
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpgradeSeriesStatus", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return params.UpgradeSeriesStatus(result.Result), nil
+}
+
+// FinishUpgradeSeries records that the unit has run its
+// post-series-upgrade hook.
+func (u *Unit) FinishUpgradeSeries() error {
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("FinishUpgradeSeries", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's service configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -711,6 +792,22 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	return results.Combine()
 }
 
+// NetworkInfo requests the network info for the unit and each of the
+// given binding names. Results are keyed by binding name; errors for
+// individual bindings are reported in the result's Error field.
+func (u *Unit) NetworkInfo(bindings []string) (map[string]params.NetworkInfoResult, error) {
+	var results params.NetworkInfoResults
+	args := params.NetworkInfoParams{
+		Unit:     u.tag.String(),
+		Bindings: bindings,
+	}
+	err := u.st.facade.FacadeCall("NetworkInfo", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
 // NetworkConfig requests network config information for the unit and the given
 // bindingName.
 func (u *Unit) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
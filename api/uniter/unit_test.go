@@ -347,6 +347,40 @@ func (s *unitSuite) TestNetworkConfig(c *gc.C) {
 	c.Assert(netConfig, gc.IsNil)
 }
 
+func (s *unitSuite) TestNetworkInfo(c *gc.C) {
+	uniter.PatchUnitResponse(s, s.apiUnit, "NetworkInfo",
+		func(result interface{}) error {
+			if results, ok := result.(*params.NetworkInfoResults); ok {
+				results.Results = map[string]params.NetworkInfoResult{
+					"db": {
+						Info: []params.NetworkInfo{{
+							InterfaceName: "eth0",
+							Addresses: []params.InterfaceAddress{{
+								Address: "10.0.0.10",
+								CIDR:    "10.0.0.0/24",
+							}},
+						}},
+						IngressAddresses: []string{"10.0.0.10"},
+						EgressSubnets:    []string{"10.0.0.10/32"},
+					},
+					"unknown": {
+						Error: &params.Error{Message: "not found"},
+					},
+				}
+			}
+			return nil
+		},
+	)
+
+	results, err := s.apiUnit.NetworkInfo([]string{"db", "unknown"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results["db"].IngressAddresses, jc.DeepEquals, []string{"10.0.0.10"})
+	c.Check(results["db"].EgressSubnets, jc.DeepEquals, []string{"10.0.0.10/32"})
+	c.Check(results["db"].Info[0].Addresses[0].Address, gc.Equals, "10.0.0.10")
+	c.Check(results["unknown"].Error, gc.ErrorMatches, "not found")
+}
+
 func (s *unitSuite) TestAvailabilityZone(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "AvailabilityZone",
 		func(result interface{}) error {
@@ -401,6 +435,27 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenCloseEndpointPorts(c *gc.C) {
+	err := s.apiUnit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.OpenEndpointPorts("missing", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `.*endpoint "missing" not found`)
+
+	machinePorts, err := s.uniter.AllMachinePortRanges(s.wordpressMachine.Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machinePorts, jc.DeepEquals, []params.MachinePortRange{{
+		UnitTag:   s.wordpressUnit.Tag().String(),
+		PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		Endpoint:  "url",
+	}})
+
+	err = s.apiUnit.CloseEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -420,6 +475,58 @@ func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, s.wordpressCharm.String())
 }
 
+func (s *unitSuite) TestTargetCharmURL(c *gc.C) {
+	curl, force, err := s.apiUnit.TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, s.wordpressCharm.URL())
+	c.Assert(force, jc.IsFalse)
+
+	newCharm := s.Factory.MakeCharm(c, &jujufactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-42",
+	})
+	err = s.wordpressService.SetCharm(state.SetCharmConfig{
+		Charm:   newCharm,
+		Rollout: &state.CharmRolloutParams{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _, err = s.apiUnit.TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, s.wordpressCharm.URL())
+}
+
+func (s *unitSuite) TestUpgradeSeries(c *gc.C) {
+	upgradeStatus, err := s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgradeStatus, gc.Equals, params.UpgradeSeriesNotStarted)
+
+	trustyCharm := s.Factory.MakeCharm(c, &jujufactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:trusty/wordpress-3",
+	})
+	err = s.wordpressService.SetSeries(state.SetSeriesConfig{Series: "trusty", Charm: trustyCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressMachine.PrepareUpgradeSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeStatus, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgradeStatus, gc.Equals, params.UpgradeSeriesPrepared)
+
+	err = s.apiUnit.FinishUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot finish series upgrade of unit "wordpress/0": upgrade not completed`)
+
+	err = s.wordpressMachine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeStatus, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgradeStatus, gc.Equals, params.UpgradeSeriesCompleted)
+	err = s.apiUnit.FinishUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	upgradeStatus, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgradeStatus, gc.Equals, params.UpgradeSeriesNotStarted)
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
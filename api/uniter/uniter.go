@@ -69,12 +69,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV4 creates a new client-side Uniter facade, version 4.
-var newStateV4 = newStateForVersionFn(4)
+// newStateV5 creates a new client-side Uniter facade, version 5.
+var newStateV5 = newStateForVersionFn(5)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV4
+var NewState = newStateV5
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -321,6 +321,26 @@ func (st *State) Model() (*Model, error) {
 // machine, mapped to the tags of the unit that opened them and the
 // relation that applies.
 func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[network.PortRange]params.RelationUnit, error) {
+	portRanges, err := st.AllMachinePortRanges(machineTag)
+	if err != nil {
+		return nil, err
+	}
+	portsMap := make(map[network.PortRange]params.RelationUnit)
+	for _, ports := range portRanges {
+		portRange := ports.PortRange.NetworkPortRange()
+		portsMap[portRange] = params.RelationUnit{
+			Unit:     ports.UnitTag,
+			Relation: ports.RelationTag,
+		}
+	}
+	return portsMap, nil
+}
+
+// AllMachinePortRanges returns all port ranges currently open on the
+// given machine, along with the tags of the unit that opened them, the
+// relation that applies, and the endpoint they were opened for. A port
+// range opened for several endpoints is returned once per endpoint.
+func (st *State) AllMachinePortRanges(machineTag names.MachineTag) ([]params.MachinePortRange, error) {
 	if st.BestAPIVersion() < 1 {
 		// AllMachinePorts() was introduced in UniterAPIV1.
 		return nil, errors.NotImplementedf("AllMachinePorts() (need V1+)")
@@ -340,15 +360,7 @@ func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[network.PortR
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	portsMap := make(map[network.PortRange]params.RelationUnit)
-	for _, ports := range result.Ports {
-		portRange := ports.PortRange.NetworkPortRange()
-		portsMap[portRange] = params.RelationUnit{
-			Unit:     ports.UnitTag,
-			Relation: ports.RelationTag,
-		}
-	}
-	return portsMap, nil
+	return result.Ports, nil
 }
 
 // WatchRelationUnits returns a watcher that notifies of changes to the
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the client-side API facade used
+// by the upgradeseries worker.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Facade provides access to the UpgradeSeries API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side UpgradeSeries facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "UpgradeSeries"),
+	}
+}
+
+// WatchUpgradeSeries returns a watcher that fires when the given
+// machine changes.
+func (f *Facade) WatchUpgradeSeries(machineId string) (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	err := f.caller.FacadeCall("WatchUpgradeSeries", entities(machineId), &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// UpgradeSeriesInfo returns the progress of the series upgrade of the
+// given machine, its series, and the units running on it.
+func (f *Facade) UpgradeSeriesInfo(machineId string) (params.UpgradeSeriesInfo, error) {
+	var results params.UpgradeSeriesInfoResults
+	err := f.caller.FacadeCall("UpgradeSeriesInfo", entities(machineId), &results)
+	if err != nil {
+		return params.UpgradeSeriesInfo{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.UpgradeSeriesInfo{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.UpgradeSeriesInfo{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// FinishUpgradeSeries records that the agent of the given machine has
+// seen its series upgrade completed.
+func (f *Facade) FinishUpgradeSeries(machineId string) error {
+	var results params.ErrorResults
+	err := f.caller.FacadeCall("FinishUpgradeSeries", entities(machineId), &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func entities(machineId string) params.Entities {
+	return params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) newFacade(c *gc.C, stub *testing.Stub, respond func(response interface{})) *upgradeseries.Facade {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UpgradeSeries")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		respond(response)
+		return stub.NextErr()
+	})
+	return upgradeseries.NewFacade(apiCaller)
+}
+
+var machine42 = params.Entities{Entities: []params.Entity{{Tag: "machine-42"}}}
+
+func (s *facadeSuite) TestWatchUpgradeSeriesError(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, func(response interface{}) {
+		*response.(*params.NotifyWatchResults) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "permission denied"},
+			}},
+		}
+	})
+	_, err := facade.WatchUpgradeSeries("42")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	stub.CheckCalls(c, []testing.StubCall{{"WatchUpgradeSeries", []interface{}{machine42}}})
+}
+
+func (s *facadeSuite) TestUpgradeSeriesInfo(c *gc.C) {
+	stub := new(testing.Stub)
+	info := params.UpgradeSeriesInfo{
+		Status: params.UpgradeSeriesCompleted,
+		Series: "xenial",
+		Units:  []string{"mysql/0"},
+	}
+	facade := s.newFacade(c, stub, func(response interface{}) {
+		*response.(*params.UpgradeSeriesInfoResults) = params.UpgradeSeriesInfoResults{
+			Results: []params.UpgradeSeriesInfoResult{{Result: &info}},
+		}
+	})
+	result, err := facade.UpgradeSeriesInfo("42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, info)
+	stub.CheckCalls(c, []testing.StubCall{{"UpgradeSeriesInfo", []interface{}{machine42}}})
+}
+
+func (s *facadeSuite) TestFinishUpgradeSeries(c *gc.C) {
+	stub := new(testing.Stub)
+	facade := s.newFacade(c, stub, func(response interface{}) {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+	})
+	err := facade.FinishUpgradeSeries("42")
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{"FinishUpgradeSeries", []interface{}{machine42}}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	stub := new(testing.Stub)
+	stub.SetErrors(errors.New("boom"))
+	facade := s.newFacade(c, stub, func(interface{}) {})
+	err := facade.FinishUpgradeSeries("42")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
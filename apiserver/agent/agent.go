@@ -117,6 +117,9 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		CAPrivateKey:   info.CAPrivateKey,
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
+		// The secret config key is not stored in the database, so
+		// new controllers are given the key this one was given.
+		SecretConfigKey: api.st.SecretConfigKey(),
 	}
 
 	return result, nil
@@ -13,12 +13,14 @@ import (
 	_ "github.com/juju/juju/apiserver/action" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/agent"
 	_ "github.com/juju/juju/apiserver/agenttools"
-	_ "github.com/juju/juju/apiserver/annotations" // ModelUser Write
-	_ "github.com/juju/juju/apiserver/application" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/annotations"       // ModelUser Write
+	_ "github.com/juju/juju/apiserver/application"       // ModelUser Write
+	_ "github.com/juju/juju/apiserver/applicationoffers" // ModelUser Admin
 	_ "github.com/juju/juju/apiserver/applicationscaler"
 	_ "github.com/juju/juju/apiserver/backups" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/block"   // ModelUser Write
 	_ "github.com/juju/juju/apiserver/charmrevisionupdater"
+	_ "github.com/juju/juju/apiserver/charmrollouts"
 	_ "github.com/juju/juju/apiserver/charms" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/cleaner"
 	_ "github.com/juju/juju/apiserver/client"     // ModelUser Write
@@ -56,8 +58,10 @@ import (
 	_ "github.com/juju/juju/apiserver/provisioner"
 	_ "github.com/juju/juju/apiserver/proxyupdater"
 	_ "github.com/juju/juju/apiserver/reboot"
+	_ "github.com/juju/juju/apiserver/remoterelations"
 	_ "github.com/juju/juju/apiserver/resumer"
 	_ "github.com/juju/juju/apiserver/retrystrategy"
+	_ "github.com/juju/juju/apiserver/secrets" // ModelUser Admin
 	_ "github.com/juju/juju/apiserver/singular"
 	_ "github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/sshclient" // ModelUser Write
@@ -69,5 +73,6 @@ import (
 	_ "github.com/juju/juju/apiserver/unitassigner"
 	_ "github.com/juju/juju/apiserver/uniter"
 	_ "github.com/juju/juju/apiserver/upgrader"
+	_ "github.com/juju/juju/apiserver/upgradeseries"
 	_ "github.com/juju/juju/apiserver/usermanager"
 )
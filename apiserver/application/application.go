@@ -9,8 +9,10 @@ package application
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -30,7 +32,8 @@ var (
 )
 
 func init() {
-	common.RegisterStandardFacade("Application", 1, NewAPI)
+	common.RegisterStandardFacade("Application", 1, NewAPIV1)
+	common.RegisterStandardFacade("Application", 2, NewAPI)
 }
 
 // Application defines the methods on the application API end point.
@@ -64,6 +67,64 @@ func NewAPI(
 	}, nil
 }
 
+// APIV1 provides the Application API facade, version 1, which lacks
+// the methods added in version 2.
+type APIV1 struct {
+	*API
+}
+
+// NewAPIV1 returns a new application API facade, version 1.
+func NewAPIV1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV1, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV1{api}, nil
+}
+
+// The RPC machinery ignores methods taking two arguments, so the
+// methods below hide those added in version 2 from version 1.
+
+// UnitsInfo isn't on the version 1 API.
+func (*APIV1) UnitsInfo(_, _ struct{}) {}
+
+// ApplicationsInfo isn't on the version 1 API.
+func (*APIV1) ApplicationsInfo(_, _ struct{}) {}
+
+// Consume isn't on the version 1 API.
+func (*APIV1) Consume(_, _ struct{}) {}
+
+// SetRelationsSuspended isn't on the version 1 API.
+func (*APIV1) SetRelationsSuspended(_, _ struct{}) {}
+
+// SetEndpointBindings isn't on the version 1 API.
+func (*APIV1) SetEndpointBindings(_, _ struct{}) {}
+
+// ConfigHistory isn't on the version 1 API.
+func (*APIV1) ConfigHistory(_, _ struct{}) {}
+
+// RollbackConfig isn't on the version 1 API.
+func (*APIV1) RollbackConfig(_, _ struct{}) {}
+
+// SetSecretConfigKeys isn't on the version 1 API.
+func (*APIV1) SetSecretConfigKeys(_, _ struct{}) {}
+
+// ResumeCharmUpgrades isn't on the version 1 API.
+func (*APIV1) ResumeCharmUpgrades(_, _ struct{}) {}
+
+// AbortCharmUpgrades isn't on the version 1 API.
+func (*APIV1) AbortCharmUpgrades(_, _ struct{}) {}
+
+// SetAutoUpgradePolicy isn't on the version 1 API.
+func (*APIV1) SetAutoUpgradePolicy(_, _ struct{}) {}
+
+// SetSeries isn't on the version 1 API.
+func (*APIV1) SetSeries(_, _ struct{}) {}
+
 func (api *API) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(description.ReadAccess, api.state.ModelTag())
 	if err != nil {
@@ -86,6 +147,38 @@ func (api *API) checkCanWrite() error {
 	return nil
 }
 
+// userName returns the name of the authenticated user, for recording
+// in the settings history.
+func (api *API) userName() string {
+	return api.authorizer.GetAuthTag().Id()
+}
+
+// revealSecrets reports whether the values of secret config settings
+// are to be included in a response. Asking for them is an error for
+// any user who is not a model administrator.
+func (api *API) revealSecrets(asked bool) (bool, error) {
+	if !asked {
+		return false, nil
+	}
+	isAdmin, err := api.authorizer.HasPermission(description.AdminAccess, api.state.ModelTag())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !isAdmin {
+		return false, common.ErrPerm
+	}
+	return true, nil
+}
+
+// redactSecrets replaces the values of the named secret settings.
+func redactSecrets(settings map[string]interface{}, secrets []string) {
+	for _, name := range secrets {
+		if _, ok := settings[name]; ok {
+			settings[name] = state.RedactedConfigValue
+		}
+	}
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -176,13 +269,25 @@ func deployApplication(st *state.State, args params.ApplicationDeploy) error {
 
 	channel := csparams.Channel(args.Channel)
 
-	_, err = jjj.DeployApplication(st,
+	attachStorage, err := parseAttachStorage(args.AttachStorage, args.NumUnits)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	numUnits := args.NumUnits
+	if len(attachStorage) > 0 {
+		// Storage can only be attached to units once they are
+		// added, so the application is deployed without units
+		// and the unit added separately below.
+		numUnits = 0
+	}
+
+	application, err := jjj.DeployApplication(st,
 		jjj.DeployApplicationParams{
 			ApplicationName:  args.ApplicationName,
 			Series:           args.Series,
 			Charm:            ch,
 			Channel:          channel,
-			NumUnits:         args.NumUnits,
+			NumUnits:         numUnits,
 			ConfigSettings:   settings,
 			Constraints:      args.Constraints,
 			Placement:        args.Placement,
@@ -190,12 +295,41 @@ func deployApplication(st *state.State, args params.ApplicationDeploy) error {
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		})
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(attachStorage) > 0 {
+		_, err := jjj.AddUnits(st, application, 1, args.Placement, attachStorage)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// parseAttachStorage parses the tags of storage instances to attach
+// to a single new unit. Storage may only be attached when exactly
+// one unit is being added.
+func parseAttachStorage(args []string, numUnits int) ([]names.StorageTag, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if numUnits != 1 {
+		return nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", numUnits)
+	}
+	storageTags := make([]names.StorageTag, len(args))
+	for i, arg := range args {
+		tag, err := names.ParseStorageTag(arg)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing storage tag")
+		}
+		storageTags[i] = tag
+	}
+	return storageTags, nil
 }
 
 // ApplicationSetSettingsStrings updates the settings for the given application,
-// taking the configuration from a map of strings.
-func ApplicationSetSettingsStrings(application *state.Application, settings map[string]string) error {
+// taking the configuration from a map of strings. The change is recorded in
+// the settings history as having been made by the named user.
+func ApplicationSetSettingsStrings(application *state.Application, user string, settings map[string]string) error {
 	ch, _, err := application.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -205,7 +339,7 @@ func ApplicationSetSettingsStrings(application *state.Application, settings map[
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateConfigSettings(changes)
+	return application.UpdateConfigSettingsAs(user, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -262,7 +396,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		// For now we do not support changing the channel through Update().
 		// TODO(ericsnow) Support it?
 		channel := svc.Channel()
-		if err = api.applicationSetCharm(svc, args.CharmUrl, channel, args.ForceSeries, args.ForceCharmUrl, nil); err != nil {
+		if err = api.applicationSetCharm(svc, args.CharmUrl, channel, args.ForceSeries, args.ForceCharmUrl, nil, nil); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -274,11 +408,11 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
-		if err = applicationSetSettingsYAML(svc, args.SettingsYAML); err != nil {
+		if err = applicationSetSettingsYAML(svc, api.userName(), args.SettingsYAML); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 	} else if len(args.SettingsStrings) > 0 {
-		if err = ApplicationSetSettingsStrings(svc, args.SettingsStrings); err != nil {
+		if err = ApplicationSetSettingsStrings(svc, api.userName(), args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -305,11 +439,21 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		return errors.Trace(err)
 	}
 	channel := csparams.Channel(args.Channel)
-	return api.applicationSetCharm(application, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs)
+	var rollout *state.CharmRolloutParams
+	if args.BatchSize > 0 {
+		rollout = &state.CharmRolloutParams{
+			BatchSize:     args.BatchSize,
+			WaitForActive: args.WaitForActive,
+			PauseOnError:  args.PauseOnError,
+		}
+	} else if args.WaitForActive || args.PauseOnError {
+		return errors.NotValidf("wait-for-active or pause-on-error without batch size")
+	}
+	return api.applicationSetCharm(application, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs, rollout)
 }
 
 // applicationSetCharm sets the charm for the given for the application.
-func (api *API) applicationSetCharm(application *state.Application, url string, channel csparams.Channel, forceSeries, forceUnits bool, resourceIDs map[string]string) error {
+func (api *API) applicationSetCharm(application *state.Application, url string, channel csparams.Channel, forceSeries, forceUnits bool, resourceIDs map[string]string, rollout *state.CharmRolloutParams) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
 		return errors.Trace(err)
@@ -324,6 +468,7 @@ func (api *API) applicationSetCharm(application *state.Application, url string,
 		ForceSeries: forceSeries,
 		ForceUnits:  forceUnits,
 		ResourceIDs: resourceIDs,
+		Rollout:     rollout,
 	}
 	return application.SetCharm(cfg)
 }
@@ -357,8 +502,9 @@ func settingsFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, e
 }
 
 // applicationSetSettingsYAML updates the settings for the given application,
-// taking the configuration from a YAML string.
-func applicationSetSettingsYAML(application *state.Application, settings string) error {
+// taking the configuration from a YAML string. The change is recorded in
+// the settings history as having been made by the named user.
+func applicationSetSettingsYAML(application *state.Application, user, settings string) error {
 	b := []byte(settings)
 	var all map[string]interface{}
 	if err := goyaml.Unmarshal(b, &all); err != nil {
@@ -370,7 +516,7 @@ func applicationSetSettingsYAML(application *state.Application, settings string)
 		if err != nil {
 			return errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings with application YAML")
+		return errors.Annotate(application.UpdateConfigSettingsAs(user, changes), "updating settings with application YAML")
 	}
 
 	ch, _, err := application.Charm()
@@ -382,7 +528,7 @@ func applicationSetSettingsYAML(application *state.Application, settings string)
 	if err != nil {
 		return errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings")
+	return errors.Annotate(application.UpdateConfigSettingsAs(user, changes), "updating settings")
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -423,7 +569,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return svc.UpdateConfigSettings(changes)
+	return svc.UpdateConfigSettingsAs(api.userName(), changes)
 
 }
 
@@ -443,7 +589,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return svc.UpdateConfigSettings(settings)
+	return svc.UpdateConfigSettingsAs(api.userName(), settings)
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -481,7 +627,7 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	return svc.SetExposedEndpoints(args.Endpoints, args.ToCIDRs, args.ToSpaces)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -509,7 +655,11 @@ func addApplicationUnits(st *state.State, args params.AddApplicationUnits) ([]*s
 	if args.NumUnits < 1 {
 		return nil, errors.New("must add at least one unit")
 	}
-	return jjj.AddUnits(st, application, args.NumUnits, args.Placement)
+	attachStorage, err := parseAttachStorage(args.AttachStorage, args.NumUnits)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jjj.AddUnits(st, application, args.NumUnits, args.Placement, attachStorage)
 }
 
 // AddUnits adds a given number of units to an application.
@@ -569,6 +719,16 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 		return errors.Trace(err)
 	}
 	svc, err := api.state.Application(args.ApplicationName)
+	if errors.IsNotFound(err) {
+		// The application may be a remote application consumed
+		// from an offer.
+		remoteApp, remoteErr := api.state.RemoteApplication(args.ApplicationName)
+		if remoteErr == nil {
+			return remoteApp.Destroy()
+		} else if !errors.IsNotFound(remoteErr) {
+			return remoteErr
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -655,3 +815,243 @@ func (api *API) DestroyRelation(args params.DestroyRelation) error {
 	}
 	return rel.Destroy()
 }
+
+// SetRelationsSuspended suspends or resumes the specified relations.
+// Suspending a relation causes its units to depart from it, while
+// retaining the relation and its settings until it is resumed.
+func (api *API) SetRelationsSuspended(args params.RelationSuspendedArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		rel, err := api.state.Relation(arg.RelationId)
+		if err == nil {
+			err = rel.SetSuspended(arg.Suspended, arg.Message)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetEndpointBindings changes the spaces the endpoints of each
+// application are bound to. Endpoints not mentioned keep their
+// existing bindings.
+func (api *API) SetEndpointBindings(args params.ApplicationsEndpointBindings) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		results.Results[i].Error = common.ServerError(api.setEndpointBindings(arg))
+	}
+	return results, nil
+}
+
+func (api *API) setEndpointBindings(arg params.ApplicationEndpointBindings) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return err
+	}
+	application, err := api.state.Application(tag.Id())
+	if err != nil {
+		return err
+	}
+	return application.SetEndpointBindings(arg.Bindings)
+}
+
+// ConfigHistory returns the recorded history of changes made to the
+// configuration of an application, most recent first.
+func (api *API) ConfigHistory(args params.ApplicationGet) (params.ConfigHistoryResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ConfigHistoryResult{}, err
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return params.ConfigHistoryResult{}, errors.Trace(err)
+	}
+	reveal, err := api.revealSecrets(args.ShowSecrets)
+	if err != nil {
+		return params.ConfigHistoryResult{}, errors.Trace(err)
+	}
+	history, err := application.ConfigSettingsHistory()
+	if err != nil {
+		return params.ConfigHistoryResult{}, errors.Trace(err)
+	}
+	if !reveal {
+		secrets, err := application.SecretConfigKeys()
+		if err != nil {
+			return params.ConfigHistoryResult{}, errors.Trace(err)
+		}
+		redactSecretHistory(history, secrets)
+	}
+	return common.ConfigHistoryResult(history), nil
+}
+
+// redactSecretHistory replaces the values of the named secret settings
+// throughout the given settings history.
+func redactSecretHistory(history []state.SettingsRevision, secrets []string) {
+	isSecret := set.NewStrings(secrets...)
+	for _, rev := range history {
+		redactSecrets(rev.Settings, secrets)
+		for i, change := range rev.Changes {
+			if !isSecret.Contains(change.Key) {
+				continue
+			}
+			if change.OldValue != nil {
+				rev.Changes[i].OldValue = state.RedactedConfigValue
+			}
+			if change.NewValue != nil {
+				rev.Changes[i].NewValue = state.RedactedConfigValue
+			}
+		}
+	}
+}
+
+// RollbackConfig restores the configuration of an application to that
+// recorded at the given revision of its history.
+func (api *API) RollbackConfig(args params.ApplicationConfigRollback) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.RollbackConfigSettings(api.userName(), args.Revision)
+}
+
+// SetSecretConfigKeys marks the named config settings of an application
+// as secret, and only those, overriding the settings whose names suggest
+// they are secret. The values of secret settings are stored encrypted,
+// and are only shown to model administrators who ask for them.
+func (api *API) SetSecretConfigKeys(args params.ApplicationSecretConfigKeys) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.SetSecretConfigKeys(args.Keys)
+}
+
+// SetAutoUpgradePolicy sets whether, and when, the charm of an
+// application is upgraded automatically to newer revisions in its
+// tracked channel.
+func (api *API) SetAutoUpgradePolicy(args params.ApplicationAutoUpgrade) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	window, err := state.ParseMaintenanceWindow(args.MaintenanceWindow)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.SetAutoUpgradePolicy(state.AutoUpgradePolicy{
+		Mode:   state.AutoUpgradeMode(args.Mode),
+		Window: window,
+	})
+}
+
+// SetSeries changes the series of an application, and optionally
+// its charm.
+func (api *API) SetSeries(args params.ApplicationSetSeries) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg := state.SetSeriesConfig{
+		Series: args.Series,
+		Force:  args.Force,
+	}
+	if args.CharmURL != "" {
+		curl, err := charm.ParseURL(args.CharmURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sch, err := api.state.Charm(curl)
+		if errors.IsNotFound(err) && curl.Schema == "cs" {
+			err = AddCharmWithAuthorization(api.state, params.AddCharmWithAuthorization{
+				URL:     curl.String(),
+				Channel: string(application.Channel()),
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			sch, err = api.state.Charm(curl)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cfg.Charm = sch
+	}
+	return application.SetSeries(cfg)
+}
+
+// ResumeCharmUpgrades resumes the paused rolling charm upgrades of the
+// given applications.
+func (api *API) ResumeCharmUpgrades(args params.Entities) (params.ErrorResults, error) {
+	return api.updateCharmRollouts(args, (*state.Application).ResumeCharmRollout)
+}
+
+// AbortCharmUpgrades aborts the rolling charm upgrades of the given
+// applications, returning them and all their units to the charm they
+// were running before the upgrade started.
+func (api *API) AbortCharmUpgrades(args params.Entities) (params.ErrorResults, error) {
+	return api.updateCharmRollouts(args, (*state.Application).AbortCharmRollout)
+}
+
+func (api *API) updateCharmRollouts(args params.Entities, update func(*state.Application) error) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		application, err := api.state.Application(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(update(application))
+	}
+	return results, nil
+}
@@ -590,7 +590,7 @@ func (s *serviceSuite) TestAddCharmOverwritesPlaceholders(c *gc.C) {
 
 func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	result, err := s.applicationAPI.GetCharmURL(params.ApplicationGet{"wordpress"})
+	result, err := s.applicationAPI.GetCharmURL(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, "local:quantal/wordpress-3")
@@ -1471,6 +1471,227 @@ func (s *serviceSuite) TestServiceSet(c *gc.C) {
 	})
 }
 
+func (s *serviceSuite) TestServiceConfigHistoryAndRollback(c *gc.C) {
+	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.Set(params.ApplicationSet{ApplicationName: "dummy", Options: map[string]string{
+		"title": "foobar",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.applicationAPI.Set(params.ApplicationSet{ApplicationName: "dummy", Options: map[string]string{
+		"title": "barfoo",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.applicationAPI.ConfigHistory(params.ApplicationGet{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.History, gc.HasLen, 2)
+	c.Assert(result.History[0].Revision, gc.Equals, 2)
+	c.Assert(result.History[0].User, gc.Equals, s.AdminUserTag(c).Id())
+	c.Assert(result.History[0].Changes, jc.DeepEquals, []params.ConfigChange{{
+		Key:      "title",
+		Type:     params.ConfigModified,
+		OldValue: "foobar",
+		NewValue: "barfoo",
+	}})
+
+	err = s.applicationAPI.RollbackConfig(params.ApplicationConfigRollback{
+		ApplicationName: "dummy",
+		Revision:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := dummy.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"title": "foobar"})
+
+	err = s.applicationAPI.RollbackConfig(params.ApplicationConfigRollback{
+		ApplicationName: "dummy",
+		Revision:        42,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot roll back config settings of application "dummy" to revision 42: settings revision 42 not found`)
+}
+
+func (s *serviceSuite) TestBlockChangeRollbackConfig(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangeRollbackConfig")
+	err := s.applicationAPI.RollbackConfig(params.ApplicationConfigRollback{
+		ApplicationName: "dummy",
+		Revision:        1,
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeRollbackConfig")
+}
+
+func (s *serviceSuite) TestSetSecretConfigKeys(c *gc.C) {
+	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.SetSecretConfigKeys(params.ApplicationSecretConfigKeys{
+		ApplicationName: "dummy",
+		Keys:            []string{"title"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err := dummy.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"title"})
+
+	err = s.applicationAPI.SetSecretConfigKeys(params.ApplicationSecretConfigKeys{
+		ApplicationName: "dummy",
+		Keys:            []string{"skill-level"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set secret config keys of application "dummy": config option "skill-level" has type "int", only string options can be secret`)
+}
+
+func (s *serviceSuite) TestBlockChangeSetSecretConfigKeys(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangeSetSecretConfigKeys")
+	err := s.applicationAPI.SetSecretConfigKeys(params.ApplicationSecretConfigKeys{
+		ApplicationName: "dummy",
+		Keys:            []string{"title"},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeSetSecretConfigKeys")
+}
+
+func (s *serviceSuite) TestSetAutoUpgradePolicy(c *gc.C) {
+	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.SetAutoUpgradePolicy(params.ApplicationAutoUpgrade{
+		ApplicationName:   "dummy",
+		Mode:              "patch-channel",
+		MaintenanceWindow: "22:00-02:00",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = dummy.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := dummy.AutoUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Mode, gc.Equals, state.AutoUpgradePatchChannel)
+	c.Assert(policy.Window.String(), gc.Equals, "22:00-02:00")
+
+	err = s.applicationAPI.SetAutoUpgradePolicy(params.ApplicationAutoUpgrade{
+		ApplicationName: "dummy",
+		Mode:            "always",
+	})
+	c.Assert(err, gc.ErrorMatches, `auto-upgrade mode "always" not valid`)
+
+	err = s.applicationAPI.SetAutoUpgradePolicy(params.ApplicationAutoUpgrade{
+		ApplicationName:   "dummy",
+		Mode:              "channel",
+		MaintenanceWindow: "25:00-02:00",
+	})
+	c.Assert(err, gc.ErrorMatches, `maintenance window "25:00-02:00" not valid`)
+}
+
+func (s *serviceSuite) TestBlockChangeSetAutoUpgradePolicy(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangeSetAutoUpgradePolicy")
+	err := s.applicationAPI.SetAutoUpgradePolicy(params.ApplicationAutoUpgrade{
+		ApplicationName: "dummy",
+		Mode:            "channel",
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeSetAutoUpgradePolicy")
+}
+
+func (s *serviceSuite) TestSetSeries(c *gc.C) {
+	curl, _ := s.UploadCharmMultiSeries(c, "~who/multi-series", "multi-series")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmUrl:        curl.String(),
+			ApplicationName: "application",
+			Series:          "precise",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	err = s.applicationAPI.SetSeries(params.ApplicationSetSeries{
+		ApplicationName: "application",
+		Series:          "trusty",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "trusty")
+
+	err = s.applicationAPI.SetSeries(params.ApplicationSetSeries{
+		ApplicationName: "application",
+		Series:          "xenial",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set series of application "application": series "xenial" not supported by charm .*`)
+}
+
+func (s *serviceSuite) TestSetSeriesWithCharm(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/dummy-1", "dummy")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmUrl:        curl.String(),
+			ApplicationName: "dummy",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	err = s.applicationAPI.SetSeries(params.ApplicationSetSeries{
+		ApplicationName: "dummy",
+		Series:          "trusty",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set series of application "dummy": charm "cs:~who/precise/dummy-1" is for series "precise", not "trusty"`)
+
+	// The charm for the new series is added from the charm store.
+	curl, _ = s.UploadCharm(c, "trusty/dummy-1", "dummy")
+	err = s.applicationAPI.SetSeries(params.ApplicationSetSeries{
+		ApplicationName: "dummy",
+		Series:          "trusty",
+		CharmURL:        curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "trusty")
+	appURL, _ := app.CharmURL()
+	c.Assert(appURL, gc.DeepEquals, curl)
+}
+
+func (s *serviceSuite) TestBlockChangeSetSeries(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangeSetSeries")
+	err := s.applicationAPI.SetSeries(params.ApplicationSetSeries{
+		ApplicationName: "dummy",
+		Series:          "xenial",
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeSetSeries")
+}
+
+func (s *serviceSuite) TestConfigHistoryRedactsSecrets(c *gc.C) {
+	dummy := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := dummy.SetSecretConfigKeys([]string{"outlook"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.applicationAPI.Set(params.ApplicationSet{ApplicationName: "dummy", Options: map[string]string{
+		"title":   "foobar",
+		"outlook": "gloomy",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.applicationAPI.ConfigHistory(params.ApplicationGet{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.History, gc.HasLen, 1)
+	c.Assert(result.History[0].Changes, jc.DeepEquals, []params.ConfigChange{{
+		Key:      "outlook",
+		Type:     params.ConfigAdded,
+		NewValue: "<redacted>",
+	}, {
+		Key:      "title",
+		Type:     params.ConfigAdded,
+		NewValue: "foobar",
+	}})
+
+	result, err = s.applicationAPI.ConfigHistory(params.ApplicationGet{ApplicationName: "dummy", ShowSecrets: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.History[0].Changes[0].NewValue, gc.Equals, "gloomy")
+}
+
 func (s *serviceSuite) assertServiceSetBlocked(c *gc.C, dummy *state.Application, msg string) {
 	err := s.applicationAPI.Set(params.ApplicationSet{
 		ApplicationName: "dummy",
@@ -1732,6 +1953,47 @@ func (s *serviceSuite) TestAddServiceUnits(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestAddServiceUnitsAttachStorage(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "environscoped-block"},
+		&state.VolumeInfo{VolumeId: "vol-123", Size: 1024},
+		"data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingServiceWithStorage(c, "storage-filesystem", s.AddTestingCharm(c, "storage-filesystem"),
+		map[string]state.StorageConstraints{
+			"data": {Pool: "environscoped-block", Size: 1024, Count: 1},
+		},
+	)
+
+	_, err = s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "storage-filesystem",
+		NumUnits:        2,
+		AttachStorage:   []string{storageTag.String()},
+	})
+	c.Assert(err, gc.ErrorMatches, "AttachStorage is non-empty, but NumUnits is 2")
+
+	_, err = s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "storage-filesystem",
+		NumUnits:        1,
+		AttachStorage:   []string{"volume-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `parsing storage tag: "volume-0" is not a valid storage tag`)
+
+	result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "storage-filesystem",
+		NumUnits:        1,
+		AttachStorage:   []string{storageTag.String()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Units, jc.DeepEquals, []string{"storage-filesystem/0"})
+
+	attachments, err := s.State.UnitStorageAttachments(names.NewUnitTag("storage-filesystem/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+}
+
 func (s *serviceSuite) assertAddServiceUnits(c *gc.C) {
 	result, err := s.applicationAPI.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "dummy",
@@ -1816,7 +2078,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1828,6 +2090,50 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeTo(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	_, err := s.State.AddSpace("public", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToCIDRs:         []string{"10.0.0.0/8"},
+		ToSpaces:        []string{"public"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(application.ExposedSpaces(), jc.DeepEquals, []string{"public"})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ToSpaces:        []string{"missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "dummy-service": space "missing" not found`)
+}
+
+func (s *serviceSuite) TestServiceExposeEndpoints(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		Endpoints:       []string{"url", "admin-api"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedEndpoints(), jc.DeepEquals, []string{"url", "admin-api"})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		Endpoints:       []string{"missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "wordpress": endpoint "missing" not found`)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1868,7 +2174,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1883,7 +2189,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2605,6 +2911,169 @@ func (s *serviceSuite) TestBlockDestroyDestroyRelation(c *gc.C) {
 	s.assertDestroyRelation(c, endpoints)
 }
 
+func (s *serviceSuite) TestSetRelationsSuspended(c *gc.C) {
+	relation := s.setupDestroyRelationScenario(c, []string{"wordpress", "mysql"})
+	results, err := s.applicationAPI.SetRelationsSuspended(params.RelationSuspendedArgs{
+		Args: []params.RelationSuspendedArg{{
+			RelationId: relation.Id(),
+			Suspended:  true,
+			Message:    "misbehaving",
+		}, {
+			RelationId: 42,
+			Suspended:  true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `relation 42 not found`)
+	err = relation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsTrue)
+	c.Assert(relation.SuspendedReason(), gc.Equals, "misbehaving")
+
+	results, err = s.applicationAPI.SetRelationsSuspended(params.RelationSuspendedArgs{
+		Args: []params.RelationSuspendedArg{{RelationId: relation.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	err = relation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsFalse)
+}
+
+func (s *serviceSuite) TestBlockChangeSetRelationsSuspended(c *gc.C) {
+	relation := s.setupDestroyRelationScenario(c, []string{"wordpress", "mysql"})
+	s.BlockAllChanges(c, "TestBlockChangeSetRelationsSuspended")
+	_, err := s.applicationAPI.SetRelationsSuspended(params.RelationSuspendedArgs{
+		Args: []params.RelationSuspendedArg{{RelationId: relation.Id(), Suspended: true}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeSetRelationsSuspended")
+}
+
+func (s *serviceSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	results, err := s.applicationAPI.SetEndpointBindings(params.ApplicationsEndpointBindings{
+		Args: []params.ApplicationEndpointBindings{{
+			ApplicationTag: "application-wordpress",
+			Bindings:       map[string]string{"db": "db"},
+		}, {
+			ApplicationTag: "application-missing",
+			Bindings:       map[string]string{"db": "db"},
+		}, {
+			ApplicationTag: "unit-wordpress-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+
+	application, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["db"], gc.Equals, "db")
+}
+
+func (s *serviceSuite) TestBlockChangeSetEndpointBindings(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.BlockAllChanges(c, "TestBlockChangeSetEndpointBindings")
+	_, err := s.applicationAPI.SetEndpointBindings(params.ApplicationsEndpointBindings{
+		Args: []params.ApplicationEndpointBindings{{ApplicationTag: "application-wordpress"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeSetEndpointBindings")
+}
+
+func (s *serviceSuite) setupRollingCharmUpgrade(c *gc.C) *state.Application {
+	ch := s.AddTestingCharm(c, "wordpress")
+	wordpress := s.AddTestingService(c, "wordpress", ch)
+	for i := 0; i < 2; i++ {
+		unit, err := wordpress.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(ch.URL())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "wordpress",
+		CharmUrl:        newCharm.URL().String(),
+		BatchSize:       1,
+		PauseOnError:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return wordpress
+}
+
+func (s *serviceSuite) TestSetCharmRolling(c *gc.C) {
+	wordpress := s.setupRollingCharmUpgrade(c)
+	rollout, err := wordpress.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.BatchSize, gc.Equals, 1)
+	c.Assert(rollout.PauseOnError, jc.IsTrue)
+	c.Assert(rollout.WaitForActive, jc.IsFalse)
+	c.Assert(rollout.Pending, jc.DeepEquals, []string{"wordpress/0", "wordpress/1"})
+}
+
+func (s *serviceSuite) TestSetCharmRollingFlagsNeedBatchSize(c *gc.C) {
+	ch := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "wordpress", ch)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "wordpress",
+		CharmUrl:        ch.URL().String(),
+		WaitForActive:   true,
+	})
+	c.Assert(err, gc.ErrorMatches, "wait-for-active or pause-on-error without batch size not valid")
+}
+
+func (s *serviceSuite) TestAbortCharmUpgrades(c *gc.C) {
+	wordpress := s.setupRollingCharmUpgrade(c)
+	results, err := s.applicationAPI.AbortCharmUpgrades(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-wordpress"},
+			{Tag: "application-wordpress"},
+			{Tag: "unit-wordpress-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `rolling charm upgrade of application "wordpress" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+
+	err = wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := wordpress.CharmURL()
+	c.Assert(curl.Revision, gc.Equals, 3)
+}
+
+func (s *serviceSuite) TestResumeCharmUpgrades(c *gc.C) {
+	s.setupRollingCharmUpgrade(c)
+	results, err := s.applicationAPI.ResumeCharmUpgrades(params.Entities{
+		Entities: []params.Entity{{Tag: "application-wordpress"}, {Tag: "application-missing"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+}
+
+func (s *serviceSuite) TestBlockChangeAbortCharmUpgrades(c *gc.C) {
+	s.setupRollingCharmUpgrade(c)
+	s.BlockAllChanges(c, "TestBlockChangeAbortCharmUpgrades")
+	_, err := s.applicationAPI.AbortCharmUpgrades(params.Entities{
+		Entities: []params.Entity{{Tag: "application-wordpress"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeAbortCharmUpgrades")
+}
+
 type mockStorageProvider struct {
 	storage.Provider
 	kind storage.StorageKind
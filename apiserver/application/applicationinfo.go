@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ApplicationsInfo returns the details of the specified applications.
+// It does not change any state.
+func (api *API) ApplicationsInfo(args params.Entities) (params.ApplicationInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationInfoResults{}, err
+	}
+	results := params.ApplicationInfoResults{
+		Results: make([]params.ApplicationInfoResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		info, err := api.applicationInfo(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
+}
+
+func (api *API) applicationInfo(tag names.ApplicationTag) (*params.ApplicationInfo, error) {
+	app, err := api.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.ApplicationInfo{
+		Tag:       tag.String(),
+		Charm:     ch.URL().String(),
+		Channel:   string(app.Channel()),
+		Series:    app.Series(),
+		Life:      app.Life().String(),
+		Principal: app.IsPrincipal(),
+		Exposed:   app.IsExposed(),
+		MinUnits:  app.MinUnits(),
+	}
+	if app.IsPrincipal() {
+		if info.Constraints, err = app.Constraints(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if info.EndpointBindings, err = app.EndpointBindings(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	settings, err := app.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secrets, err := app.SecretConfigKeys()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	redactSecrets(settings, secrets)
+	info.Config = make(map[string]interface{})
+	for name, value := range settings {
+		if option, ok := ch.Config().Options[name]; ok && reflect.DeepEqual(option.Default, value) {
+			continue
+		}
+		info.Config[name] = value
+	}
+
+	storageConstraints, err := app.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info.Storage = make(map[string]params.StorageConstraints)
+	for name, cons := range storageConstraints {
+		size, count := cons.Size, cons.Count
+		info.Storage[name] = params.StorageConstraints{
+			Pool:  cons.Pool,
+			Size:  &size,
+			Count: &count,
+		}
+	}
+
+	if info.Resources, err = api.applicationResources(app.Name()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.Units, err = applicationUnits(app); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.Relations, err = applicationRelations(app); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
+// applicationResources returns the resources in use by the named
+// application, if resources are supported.
+func (api *API) applicationResources(appName string) ([]params.ApplicationResourceInfo, error) {
+	resources, err := api.state.Resources()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appResources, err := resources.ListResources(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.ApplicationResourceInfo
+	for _, res := range appResources.Resources {
+		result = append(result, params.ApplicationResourceInfo{
+			Name:     res.Name,
+			Type:     res.Type.String(),
+			Origin:   res.Origin.String(),
+			Revision: res.Revision,
+		})
+	}
+	return result, nil
+}
+
+func applicationUnits(app *state.Application) ([]params.ApplicationUnitInfo, error) {
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.ApplicationUnitInfo, len(units))
+	for i, unit := range units {
+		result[i].Name = unit.Name()
+		if machineId, err := unit.AssignedMachineId(); err == nil {
+			result[i].Machine = machineId
+		}
+		workloadStatus, err := unit.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		agentStatus, err := unit.AgentStatus()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i].WorkloadStatus = string(workloadStatus.Status)
+		result[i].AgentStatus = string(agentStatus.Status)
+		result[i].Message = workloadStatus.Message
+	}
+	return result, nil
+}
+
+// applicationRelations returns the names of the applications related
+// to the application, keyed by the application's endpoint name.
+func applicationRelations(app *state.Application) (map[string][]string, error) {
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]string)
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, relatedEp := range related {
+			result[ep.Name] = append(result[ep.Name], relatedEp.ApplicationName)
+		}
+	}
+	for _, apps := range result {
+		sort.Strings(apps)
+	}
+	return result, nil
+}
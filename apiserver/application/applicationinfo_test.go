@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/status"
+)
+
+type applicationInfoSuite struct {
+	jujutesting.JujuConnSuite
+
+	applicationAPI *application.API
+}
+
+var _ = gc.Suite(&applicationInfoSuite{})
+
+func (s *applicationInfoSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.applicationAPI, err = application.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationInfoSuite) TestApplicationsInfo(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "Planet Juju"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{
+		Status:  status.StatusActive,
+		Message: "serving",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.ApplicationsInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-wordpress"},
+			{Tag: "application-unknown"},
+			{Tag: "unit-wordpress-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	info := results.Results[0].Result
+	curl, _ := wordpress.CharmURL()
+	c.Check(info.Tag, gc.Equals, "application-wordpress")
+	c.Check(info.Charm, gc.Equals, curl.String())
+	c.Check(info.Series, gc.Equals, "quantal")
+	c.Check(info.Life, gc.Equals, "alive")
+	c.Check(info.Principal, jc.IsTrue)
+	c.Check(info.Exposed, jc.IsTrue)
+	c.Check(info.Config, jc.DeepEquals, map[string]interface{}{"blog-title": "Planet Juju"})
+	space, ok := info.EndpointBindings["db"]
+	c.Check(ok, jc.IsTrue)
+	c.Check(space, gc.Equals, "")
+	c.Check(info.Units, jc.DeepEquals, []params.ApplicationUnitInfo{{
+		Name:           "wordpress/0",
+		WorkloadStatus: "active",
+		AgentStatus:    "allocating",
+		Message:        "serving",
+	}})
+	c.Check(info.Relations, jc.DeepEquals, map[string][]string{
+		"db": {"mysql"},
+	})
+
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+}
+
+func (s *applicationInfoSuite) TestApplicationsInfoRedactsSecrets(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.SetSecretConfigKeys([]string{"blog-title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "Planet Juju"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.ApplicationsInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Result.Config, jc.DeepEquals, map[string]interface{}{"blog-title": "<redacted>"})
+}
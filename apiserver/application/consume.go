@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+// Consume adds remote applications to the model, standing for the
+// applications offered by other models on the controller at the given
+// offer URLs.
+func (api *API) Consume(args params.ConsumeApplicationArgs) (params.ConsumeApplicationResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ConsumeApplicationResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ConsumeApplicationResults{}, errors.Trace(err)
+	}
+	result := params.ConsumeApplicationResults{
+		Results: make([]params.ConsumeApplicationResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		localName, err := api.consumeOne(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].LocalName = localName
+	}
+	return result, nil
+}
+
+func (api *API) consumeOne(arg params.ConsumeApplicationArg) (string, error) {
+	url, err := crossmodel.ParseApplicationURL(arg.OfferURL)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if url.User == "" {
+		url.User = api.authorizer.GetAuthTag().Id()
+	}
+	model, err := modelByOwnerAndName(api.state, url.User, url.ModelName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if model.UUID() == api.state.ModelUUID() {
+		return "", errors.Errorf("cannot consume offer %q from the same model", url)
+	}
+	canRead, err := api.authorizer.HasPermission(description.ReadAccess, model.ModelTag())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !canRead {
+		return "", common.ErrPerm
+	}
+
+	offeringSt, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer offeringSt.Close()
+	offer, err := offeringSt.ApplicationOffer(url.OfferName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	offeredApp, err := offeringSt.Application(offer.ApplicationName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var endpoints []charm.Relation
+	for alias, name := range offer.Endpoints {
+		ep, err := offeredApp.Endpoint(name)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		rel := ep.Relation
+		rel.Name = alias
+		endpoints = append(endpoints, rel)
+	}
+
+	appName := arg.ApplicationAlias
+	if appName == "" {
+		appName = url.OfferName
+	}
+	_, err = api.state.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        appName,
+		OfferName:   url.OfferName,
+		URL:         url.String(),
+		SourceModel: model.ModelTag(),
+		Endpoints:   endpoints,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return appName, nil
+}
+
+// modelByOwnerAndName returns the model on the controller with the
+// given owner and name.
+func modelByOwnerAndName(st *state.State, owner, modelName string) (*state.Model, error) {
+	if !names.IsValidUser(owner) {
+		return nil, errors.NotValidf("user name %q", owner)
+	}
+	ownerTag := names.NewUserTag(owner)
+	models, err := st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, model := range models {
+		if model.Name() == modelName && model.Owner().Id() == ownerTag.Id() {
+			return model, nil
+		}
+	}
+	return nil, errors.NotFoundf("model %q owned by %q", modelName, owner)
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type consumeSuite struct {
+	jujutesting.JujuConnSuite
+
+	applicationAPI *application.API
+	offeringSt     *state.State
+}
+
+var _ = gc.Suite(&consumeSuite{})
+
+func (s *consumeSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.applicationAPI, err = application.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.offeringSt = s.Factory.MakeModel(c, &factory.ModelParams{Name: "prod"})
+	s.AddCleanup(func(*gc.C) { s.offeringSt.Close() })
+	f := factory.NewFactory(s.offeringSt)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	_, err = s.offeringSt.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *consumeSuite) TestConsume(c *gc.C) {
+	results, err := s.applicationAPI.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{
+			OfferURL: "prod.hosted-mysql",
+		}, {
+			OfferURL:         "prod.hosted-mysql",
+			ApplicationAlias: "otherdb",
+		}, {
+			OfferURL: "prod.unknown",
+		}, {
+			OfferURL: "staging.hosted-mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0], jc.DeepEquals, params.ConsumeApplicationResult{LocalName: "hosted-mysql"})
+	c.Check(results.Results[1], jc.DeepEquals, params.ConsumeApplicationResult{LocalName: "otherdb"})
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `application offer "unknown" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `model "staging" owned by .* not found`)
+
+	remoteApp, err := s.State.RemoteApplication("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(remoteApp.OfferName(), gc.Equals, "hosted-mysql")
+	c.Check(remoteApp.SourceModel(), gc.Equals, s.offeringSt.ModelTag())
+	ep, err := remoteApp.Endpoint("database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ep.Relation, jc.DeepEquals, charm.Relation{
+		Name:      "database",
+		Role:      charm.RoleProvider,
+		Interface: "mysql",
+		Scope:     charm.ScopeGlobal,
+	})
+
+	// The remote application can be related to local applications.
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err = s.applicationAPI.AddRelation(params.AddRelation{
+		Endpoints: []string{"wordpress", "hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *consumeSuite) TestConsumeFromSameModel(c *gc.C) {
+	results, err := s.applicationAPI.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{OfferURL: "controller.hosted-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `cannot consume offer .* from the same model`)
+}
+
+func (s *consumeSuite) TestDestroyRemoteApplication(c *gc.C) {
+	results, err := s.applicationAPI.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{OfferURL: "prod.hosted-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	err = s.applicationAPI.Destroy(params.ApplicationDestroy{ApplicationName: "hosted-mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoteApplication("hosted-mysql")
+	c.Check(err, gc.ErrorMatches, `remote application "hosted-mysql" not found`)
+}
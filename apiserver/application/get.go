@@ -19,15 +19,26 @@ func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, e
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
+	reveal, err := api.revealSecrets(args.ShowSecrets)
+	if err != nil {
+		return params.ApplicationGetResults{}, err
+	}
 	settings, err := app.ConfigSettings()
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
+	secrets, err := app.SecretConfigKeys()
+	if err != nil {
+		return params.ApplicationGetResults{}, err
+	}
+	if !reveal {
+		redactSecrets(settings, secrets)
+	}
 	charm, _, err := app.Charm()
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
-	configInfo := describe(settings, charm.Config())
+	configInfo := describe(settings, charm.Config(), secrets)
 	var constraints constraints.Value
 	if app.IsPrincipal() {
 		constraints, err = app.Constraints()
@@ -44,7 +55,7 @@ func (api *API) Get(args params.ApplicationGet) (params.ApplicationGetResults, e
 	}, nil
 }
 
-func describe(settings charm.Settings, config *charm.Config) map[string]interface{} {
+func describe(settings charm.Settings, config *charm.Config, secrets []string) map[string]interface{} {
 	results := make(map[string]interface{})
 	for name, option := range config.Options {
 		info := map[string]interface{}{
@@ -61,5 +72,10 @@ func describe(settings charm.Settings, config *charm.Config) map[string]interfac
 		}
 		results[name] = info
 	}
+	for _, name := range secrets {
+		if info, ok := results[name]; ok {
+			info.(map[string]interface{})["secret"] = true
+		}
+	}
 	return results
 }
@@ -40,7 +40,7 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 
 func (s *getSuite) TestClientServiceGetSmoketest(c *gc.C) {
 	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	results, err := s.serviceAPI.Get(params.ApplicationGet{"wordpress"})
+	results, err := s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
 		Application: "wordpress",
@@ -58,7 +58,7 @@ func (s *getSuite) TestClientServiceGetSmoketest(c *gc.C) {
 }
 
 func (s *getSuite) TestServiceGetUnknownService(c *gc.C) {
-	_, err := s.serviceAPI.Get(params.ApplicationGet{"unknown"})
+	_, err := s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "unknown"})
 	c.Assert(err, gc.ErrorMatches, `application "unknown" not found`)
 }
 
@@ -186,6 +186,32 @@ func (s *getSuite) TestServiceGet(c *gc.C) {
 	}
 }
 
+func (s *getSuite) TestServiceGetSecrets(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := svc.SetSecretConfigKeys([]string{"outlook"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.UpdateConfigSettings(charm.Settings{"outlook": "gloomy"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Config["outlook"], jc.DeepEquals, map[string]interface{}{
+		"description": "No default outlook.",
+		"type":        "string",
+		"value":       "<redacted>",
+		"secret":      true,
+	})
+
+	results, err = s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "dummy", ShowSecrets: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Config["outlook"], jc.DeepEquals, map[string]interface{}{
+		"description": "No default outlook.",
+		"type":        "string",
+		"value":       "gloomy",
+		"secret":      true,
+	})
+}
+
 func (s *getSuite) TestGetMaxResolutionInt(c *gc.C) {
 	// See the bug http://pad.lv/1217742
 	// Get ends up pushing a map[string]interface{} which containts
@@ -0,0 +1,177 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// UnitsInfo returns the details of the specified units, including the
+// relation settings each unit can see. It does not change any state.
+func (api *API) UnitsInfo(args params.Entities) (params.UnitInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitInfoResults{}, err
+	}
+	results := params.UnitInfoResults{
+		Results: make([]params.UnitInfoResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		info, err := api.unitInfo(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
+}
+
+func (api *API) unitInfo(tag names.UnitTag) (*params.UnitInfo, error) {
+	unit, err := api.state.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.UnitInfo{
+		Tag:  tag.String(),
+		Life: unit.Life().String(),
+	}
+	if curl, _ := unit.CharmURL(); curl != nil {
+		info.Charm = curl.String()
+	} else if curl, _ := app.CharmURL(); curl != nil {
+		info.Charm = curl.String()
+	}
+	if machineId, err := unit.AssignedMachineId(); err == nil {
+		info.Machine = machineId
+	} else if !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	if addr, err := unit.PublicAddress(); err == nil {
+		info.PublicAddress = addr.Value
+	}
+	if addr, err := unit.PrivateAddress(); err == nil {
+		info.PrivateAddress = addr.Value
+	}
+	if info.Machine != "" {
+		portRanges, err := unit.OpenedPorts()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, pr := range portRanges {
+			info.OpenedPorts = append(info.OpenedPorts, pr.String())
+		}
+	}
+	if info.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	token := api.state.LeadershipChecker().LeadershipCheck(app.Name(), unit.Name())
+	info.Leader = token.Check(nil) == nil
+	if info.LeaderSettings, err = app.LeaderSettings(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	relations, err := unit.RelationsInScope()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		data, err := api.relationData(unit, rel)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info.RelationData = append(info.RelationData, data)
+	}
+	return info, nil
+}
+
+// relationData returns the unit's own settings in the relation,
+// and the settings of the remote units it shares the relation's
+// scope with.
+func (api *API) relationData(unit *state.Unit, rel *state.Relation) (params.EndpointRelationData, error) {
+	var result params.EndpointRelationData
+	ru, err := rel.Unit(unit)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	ep := ru.Endpoint()
+	result.RelationId = rel.Id()
+	result.Endpoint = ep.Name
+	result.RemoteUnitData = make(map[string]map[string]interface{})
+
+	settings, err := ru.Settings()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.UnitData = settings.Map()
+
+	// A relation has at most two endpoints, so the unit's endpoint has
+	// exactly one counterpart: the other application's endpoint, or its
+	// own in a peer relation.
+	related, err := rel.RelatedEndpoints(unit.ApplicationName())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	relatedEp := related[0]
+	result.RelatedEndpoint = relatedEp.ApplicationName + ":" + relatedEp.Name
+	relatedApp, err := api.state.Application(relatedEp.ApplicationName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	remoteUnits, err := relatedApp.AllUnits()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, remote := range remoteUnits {
+		if remote.Name() == unit.Name() {
+			continue
+		}
+		if ep.Scope == charm.ScopeContainer && !sameContainer(unit, remote) {
+			continue
+		}
+		remoteRU, err := rel.Unit(remote)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		inScope, err := remoteRU.InScope()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if !inScope {
+			continue
+		}
+		remoteSettings, err := ru.ReadSettings(remote.Name())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.RemoteUnitData[remote.Name()] = remoteSettings
+	}
+	return result, nil
+}
+
+// sameContainer returns whether the two units are deployed together,
+// that is one is a subordinate of the other, or both are subordinates
+// of the same principal.
+func sameContainer(u1, u2 *state.Unit) bool {
+	principal := func(u *state.Unit) string {
+		if name, ok := u.PrincipalName(); ok {
+			return name
+		}
+		return u.Name()
+	}
+	return principal(u1) == principal(u2)
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+)
+
+type unitInfoSuite struct {
+	jujutesting.JujuConnSuite
+
+	applicationAPI *application.API
+}
+
+var _ = gc.Suite(&unitInfoSuite{})
+
+func (s *unitInfoSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.applicationAPI, err = application.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *unitInfoSuite) TestUnitsInfo(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpress0, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysql0, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(wordpress0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"wanted": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err = rel.Unit(mysql0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"host": "mysql.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.UnitsInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-wordpress-0"},
+			{Tag: "unit-wordpress-9"},
+			{Tag: "application-wordpress"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	curl, _ := wordpress.CharmURL()
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, &params.UnitInfo{
+		Tag:            "unit-wordpress-0",
+		Charm:          curl.String(),
+		Life:           "alive",
+		LeaderSettings: map[string]string{},
+		RelationData: []params.EndpointRelationData{{
+			RelationId:      rel.Id(),
+			Endpoint:        "db",
+			RelatedEndpoint: "mysql:server",
+			UnitData:        map[string]interface{}{"wanted": "db"},
+			RemoteUnitData: map[string]map[string]interface{}{
+				"mysql/0": {"host": "mysql.example.com"},
+			},
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "wordpress/9" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"application-wordpress" is not a valid unit tag`)
+}
+
+func (s *unitInfoSuite) TestUnitsInfoPeerRelation(c *gc.C) {
+	riak := s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+	riak0, err := riak.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	riak1, err := riak.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	rels, err := riak.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	rel := rels[0]
+	ru, err := rel.Unit(riak0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"node": "0"})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err = rel.Unit(riak1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"node": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.UnitsInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-riak-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.RelationData, jc.DeepEquals, []params.EndpointRelationData{{
+		RelationId:      rel.Id(),
+		Endpoint:        "ring",
+		RelatedEndpoint: "riak:ring",
+		UnitData:        map[string]interface{}{"node": "0"},
+		RemoteUnitData: map[string]map[string]interface{}{
+			"riak/1": {"node": "1"},
+		},
+	}})
+}
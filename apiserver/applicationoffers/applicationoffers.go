@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationoffers provides the API server facade for offering
+// application endpoints for consumption by other models on the
+// controller.
+package applicationoffers
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ApplicationOffers", 1, NewAPI)
+}
+
+// API implements the ApplicationOffers facade.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+	check      *common.BlockChecker
+}
+
+// NewAPI returns a new ApplicationOffers API facade.
+func NewAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: authorizer,
+		check:      common.NewBlockChecker(st),
+	}, nil
+}
+
+func (api *API) checkPermission(access description.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Offer makes application endpoints available for consumption by
+// other models on the controller.
+func (api *API) Offer(args params.AddApplicationOffers) (params.ErrorResults, error) {
+	if err := api.checkPermission(description.AdminAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	for i, arg := range args.Offers {
+		err := api.addOffer(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) addOffer(arg params.AddApplicationOffer) error {
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelTag != api.st.ModelTag() {
+		return errors.Errorf("cannot offer applications of model %q from model %q", modelTag.Id(), api.st.ModelUUID())
+	}
+	offerName := arg.OfferName
+	if offerName == "" {
+		offerName = arg.ApplicationName
+	}
+	_, err = api.st.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:              offerName,
+		ApplicationName:        arg.ApplicationName,
+		ApplicationDescription: arg.ApplicationDescription,
+		Endpoints:              arg.Endpoints,
+	})
+	return errors.Trace(err)
+}
+
+// ListApplicationOffers returns the application offers of the given
+// models, which must be the model of the API connection.
+func (api *API) ListApplicationOffers(args params.Entities) (params.ListApplicationOffersResults, error) {
+	if err := api.checkPermission(description.ReadAccess); err != nil {
+		return params.ListApplicationOffersResults{}, errors.Trace(err)
+	}
+	result := params.ListApplicationOffersResults{
+		Results: make([]params.ListApplicationOffersResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		offers, err := api.listOffers(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Offers = offers
+	}
+	return result, nil
+}
+
+func (api *API) listOffers(tag string) ([]params.ApplicationOffer, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if modelTag != api.st.ModelTag() {
+		return nil, common.ErrPerm
+	}
+	model, err := api.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offers, err := api.st.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.ApplicationOffer, len(offers))
+	for i, offer := range offers {
+		paramsOffer, err := MakeOfferParams(api.st, model, offer)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = paramsOffer
+	}
+	return result, nil
+}
+
+// MakeOfferParams returns the API representation of the given offer of
+// an application in the given model.
+func MakeOfferParams(st *state.State, model *state.Model, offer *state.ApplicationOffer) (params.ApplicationOffer, error) {
+	app, err := st.Application(offer.ApplicationName)
+	if err != nil {
+		return params.ApplicationOffer{}, errors.Trace(err)
+	}
+	aliases := make([]string, 0, len(offer.Endpoints))
+	for alias := range offer.Endpoints {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	result := params.ApplicationOffer{
+		OfferURL:               crossmodel.MakeURL(model.Owner().Id(), model.Name(), offer.OfferName),
+		OfferName:              offer.OfferName,
+		ApplicationName:        offer.ApplicationName,
+		ApplicationDescription: offer.ApplicationDescription,
+	}
+	for _, alias := range aliases {
+		ep, err := app.Endpoint(offer.Endpoints[alias])
+		if err != nil {
+			return params.ApplicationOffer{}, errors.Trace(err)
+		}
+		result.Endpoints = append(result.Endpoints, params.RemoteEndpoint{
+			Name:      alias,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type applicationOffersSuite struct {
+	jujutesting.JujuConnSuite
+
+	api        *applicationoffers.API
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&applicationOffersSuite{})
+
+func (s *applicationOffersSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.api, err = applicationoffers.NewAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+}
+
+func (s *applicationOffersSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := applicationoffers.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *applicationOffersSuite) TestOfferAndList(c *gc.C) {
+	results, err := s.api.Offer(params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			ModelTag:               s.State.ModelTag().String(),
+			OfferName:              "hosted-mysql",
+			ApplicationName:        "mysql",
+			ApplicationDescription: "a database",
+			Endpoints:              map[string]string{"database": "server"},
+		}, {
+			ModelTag:        s.State.ModelTag().String(),
+			ApplicationName: "unknown",
+			Endpoints:       map[string]string{"database": "server"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `cannot add application offer "unknown": application "unknown" not found`)
+
+	list, err := s.api.ListApplicationOffers(params.Entities{
+		Entities: []params.Entity{{Tag: s.State.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Results, gc.HasLen, 1)
+	c.Assert(list.Results[0].Error, gc.IsNil)
+	c.Check(list.Results[0].Offers, jc.DeepEquals, []params.ApplicationOffer{{
+		OfferURL:               crossmodel.MakeURL(s.AdminUserTag(c).Id(), "controller", "hosted-mysql"),
+		OfferName:              "hosted-mysql",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "a database",
+		Endpoints: []params.RemoteEndpoint{{
+			Name:      "database",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+			Scope:     charm.ScopeGlobal,
+		}},
+	}})
+}
+
+func (s *applicationOffersSuite) TestOfferOtherModel(c *gc.C) {
+	results, err := s.api.Offer(params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{{
+			ModelTag:        names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String(),
+			ApplicationName: "mysql",
+			Endpoints:       map[string]string{"database": "server"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `cannot offer applications of model .*`)
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrevisionupdater
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AutoUpgradeCharms upgrades the applications whose auto-upgrade policy
+// allows it to the latest charm revisions found by
+// UpdateLatestRevisions.
+func (api *CharmRevisionUpdaterAPI) AutoUpgradeCharms() (params.ErrorResult, error) {
+	if err := api.autoUpgradeCharms(api.clock.Now()); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ErrorResult{}, nil
+}
+
+func (api *CharmRevisionUpdaterAPI) autoUpgradeCharms(now time.Time) error {
+	applications, err := api.state.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		// A failure to upgrade one application should not hold
+		// back the others.
+		if err := api.autoUpgradeCharm(app, now); err != nil {
+			logger.Errorf("cannot automatically upgrade application %q: %v", app.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (api *CharmRevisionUpdaterAPI) autoUpgradeCharm(app *state.Application, now time.Time) error {
+	policy, err := app.AutoUpgradePolicy()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !policy.Allows(now) {
+		return nil
+	}
+	curl, _ := app.CharmURL()
+	if curl.Schema != "cs" {
+		return nil
+	}
+	latest, err := api.state.LatestPlaceholderCharm(curl)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// Leave applications that are part way through a rolling
+	// upgrade alone.
+	if _, err := app.CharmRollout(); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	err = application.AddCharmWithAuthorization(api.state, params.AddCharmWithAuthorization{
+		URL:     latest.URL().String(),
+		Channel: string(app.Channel()),
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot add charm %q", latest.URL())
+	}
+	ch, err := api.state.Charm(latest.URL())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	upgraded, err := app.AutoUpgradeCharm(ch, now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if upgraded {
+		logger.Infof("automatically upgraded application %q to charm %q", app.Name(), ch.URL())
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package charmrevisionupdater
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -17,12 +18,14 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.charmrevisionupdater")
 
 func init() {
-	common.RegisterStandardFacade("CharmRevisionUpdater", 2, NewCharmRevisionUpdaterAPI)
+	common.RegisterStandardFacade("CharmRevisionUpdater", 2, newCharmRevisionUpdaterAPIV2)
+	common.RegisterStandardFacade("CharmRevisionUpdater", 3, newCharmRevisionUpdaterAPI)
 }
 
 // CharmRevisionUpdater defines the methods on the charmrevisionupdater API end point.
 type CharmRevisionUpdater interface {
 	UpdateLatestRevisions() (params.ErrorResult, error)
+	AutoUpgradeCharms() (params.ErrorResult, error)
 }
 
 // CharmRevisionUpdaterAPI implements the CharmRevisionUpdater interface and is the concrete
@@ -31,21 +34,59 @@ type CharmRevisionUpdaterAPI struct {
 	state      *state.State
 	resources  facade.Resources
 	authorizer facade.Authorizer
+	clock      clock.Clock
 }
 
 var _ CharmRevisionUpdater = (*CharmRevisionUpdaterAPI)(nil)
 
+// newCharmRevisionUpdaterAPI wraps NewCharmRevisionUpdaterAPI for
+// RegisterStandardFacade.
+func newCharmRevisionUpdaterAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*CharmRevisionUpdaterAPI, error) {
+	return NewCharmRevisionUpdaterAPI(st, resources, authorizer, clock.WallClock)
+}
+
+// CharmRevisionUpdaterAPIV2 implements version 2 of the
+// charmrevisionupdater API end point, which lacks AutoUpgradeCharms.
+type CharmRevisionUpdaterAPIV2 struct {
+	*CharmRevisionUpdaterAPI
+}
+
+// newCharmRevisionUpdaterAPIV2 wraps newCharmRevisionUpdaterAPI for
+// version 2 of the facade.
+func newCharmRevisionUpdaterAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*CharmRevisionUpdaterAPIV2, error) {
+	api, err := newCharmRevisionUpdaterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &CharmRevisionUpdaterAPIV2{api}, nil
+}
+
+// Methods with two arguments are not served over RPC, so the method
+// below keeps AutoUpgradeCharms off version 2.
+
+// AutoUpgradeCharms isn't on the version 2 API.
+func (*CharmRevisionUpdaterAPIV2) AutoUpgradeCharms(_, _ struct{}) {}
+
 // NewCharmRevisionUpdaterAPI creates a new server-side charmrevisionupdater API end point.
 func NewCharmRevisionUpdaterAPI(
 	st *state.State,
 	resources facade.Resources,
 	authorizer facade.Authorizer,
+	clock clock.Clock,
 ) (*CharmRevisionUpdaterAPI, error) {
 	if !authorizer.AuthMachineAgent() && !authorizer.AuthModelManager() {
 		return nil, common.ErrPerm
 	}
 	return &CharmRevisionUpdaterAPI{
-		state: st, resources: resources, authorizer: authorizer}, nil
+		state: st, resources: resources, authorizer: authorizer, clock: clock}, nil
 }
 
 // UpdateLatestRevisions retrieves the latest revision information from the charm store for all deployed charms
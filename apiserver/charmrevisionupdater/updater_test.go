@@ -7,12 +7,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"time"
 
 	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charmrepo.v2-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable/csclient"
 
 	"github.com/juju/juju/apiserver/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/charmrevisionupdater/testing"
@@ -31,6 +34,7 @@ type charmVersionSuite struct {
 	charmrevisionupdater *charmrevisionupdater.CharmRevisionUpdaterAPI
 	resources            *common.Resources
 	authoriser           apiservertesting.FakeAuthorizer
+	clock                *gitjujutesting.Clock
 }
 
 var _ = gc.Suite(&charmVersionSuite{})
@@ -53,8 +57,9 @@ func (s *charmVersionSuite) SetUpTest(c *gc.C) {
 	s.authoriser = apiservertesting.FakeAuthorizer{
 		EnvironManager: true,
 	}
+	s.clock = gitjujutesting.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
 	var err error
-	s.charmrevisionupdater, err = charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser)
+	s.charmrevisionupdater, err = charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -64,7 +69,7 @@ func (s *charmVersionSuite) TearDownTest(c *gc.C) {
 }
 
 func (s *charmVersionSuite) TestNewCharmRevisionUpdaterAPIAcceptsStateManager(c *gc.C) {
-	endPoint, err := charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser)
+	endPoint, err := charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(endPoint, gc.NotNil)
 }
@@ -72,7 +77,7 @@ func (s *charmVersionSuite) TestNewCharmRevisionUpdaterAPIAcceptsStateManager(c
 func (s *charmVersionSuite) TestNewCharmRevisionUpdaterAPIRefusesNonStateManager(c *gc.C) {
 	anAuthoriser := s.authoriser
 	anAuthoriser.EnvironManager = false
-	endPoint, err := charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, anAuthoriser)
+	endPoint, err := charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, anAuthoriser, s.clock)
 	c.Assert(endPoint, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
@@ -197,3 +202,100 @@ func (s *charmVersionSuite) TestJujuMetadataHeaderIsSent(c *gc.C) {
 		c.Assert(header[charmrepo.JujuMetadataHTTPHeader][i], gc.Equals, expected)
 	}
 }
+
+func (s *charmVersionSuite) TestAutoUpgradeCharms(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.PatchValue(&csclient.ServerURL, s.Server.URL)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{Mode: state.AutoUpgradeChannel})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	result, err = s.charmrevisionupdater.AutoUpgradeCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	err = mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := mysql.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+}
+
+func (s *charmVersionSuite) TestAutoUpgradeCharmsOff(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.PatchValue(&csclient.ServerURL, s.Server.URL)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	result, err = s.charmrevisionupdater.AutoUpgradeCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := mysql.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+}
+
+func (s *charmVersionSuite) setUpAutoUpgradeWindow(c *gc.C) *state.Application {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.PatchValue(&csclient.ServerURL, s.Server.URL)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	window, err := state.ParseMaintenanceWindow("22:00-02:00")
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{
+		Mode:   state.AutoUpgradeChannel,
+		Window: window,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	return mysql
+}
+
+func (s *charmVersionSuite) autoUpgradeAt(c *gc.C, app *state.Application, hour, minute int) string {
+	now := time.Date(2017, 6, 1, hour, minute, 0, 0, time.UTC)
+	s.clock.Advance(now.Sub(s.clock.Now()))
+	result, err := s.charmrevisionupdater.AutoUpgradeCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	return curl.String()
+}
+
+func (s *charmVersionSuite) TestAutoUpgradeCharmsOutsideWindow(c *gc.C) {
+	mysql := s.setUpAutoUpgradeWindow(c)
+	c.Assert(s.autoUpgradeAt(c, mysql, 12, 0), gc.Equals, "cs:quantal/mysql-22")
+	c.Assert(s.autoUpgradeAt(c, mysql, 21, 59), gc.Equals, "cs:quantal/mysql-22")
+}
+
+func (s *charmVersionSuite) TestAutoUpgradeCharmsWindowStart(c *gc.C) {
+	mysql := s.setUpAutoUpgradeWindow(c)
+	c.Assert(s.autoUpgradeAt(c, mysql, 22, 0), gc.Equals, "cs:quantal/mysql-23")
+}
+
+func (s *charmVersionSuite) TestAutoUpgradeCharmsWindowEnd(c *gc.C) {
+	mysql := s.setUpAutoUpgradeWindow(c)
+	c.Assert(s.autoUpgradeAt(c, mysql, 1, 59), gc.Equals, "cs:quantal/mysql-23")
+}
+
+func (s *charmVersionSuite) TestAutoUpgradeCharmsAfterWindow(c *gc.C) {
+	mysql := s.setUpAutoUpgradeWindow(c)
+	c.Assert(s.autoUpgradeAt(c, mysql, 2, 0), gc.Equals, "cs:quantal/mysql-22")
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollouts
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchCharmRollouts returns a watcher that sends the names of
+	// applications whose rolling charm upgrade may be able to advance.
+	WatchCharmRollouts() state.StringsWatcher
+
+	// AdvanceCharmRollout lets the next batch of units of the named
+	// application upgrade, if the current batch has completed.
+	AdvanceCharmRollout(name string) error
+}
+
+// Facade allows model-manager clients to watch and advance rolling
+// charm upgrades.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// Watch returns a watcher that sends the names of applications whose
+// rolling charm upgrade may be able to advance.
+func (facade *Facade) Watch() (params.StringsWatchResult, error) {
+	watch := facade.backend.WatchCharmRollouts()
+	if changes, ok := <-watch.Changes(); ok {
+		id := facade.resources.Register(watch)
+		return params.StringsWatchResult{
+			StringsWatcherId: id,
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// Advance advances the rolling charm upgrades of the supplied
+// applications.
+func (facade *Facade) Advance(args params.Entities) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := facade.advanceOne(entity.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+// advanceOne advances the rolling charm upgrade of the supplied
+// application; or returns a suitable error.
+func (facade *Facade) advanceOne(tagString string) error {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applicationTag, ok := tag.(names.ApplicationTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	return facade.backend.AdvanceCharmRollout(applicationTag.Id())
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollouts_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/charmrollouts"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := charmrollouts.NewFacade(nil, nil, auth(true))
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := charmrollouts.NewFacade(nil, nil, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatchError(c *gc.C) {
+	fix := newFixture(c, false)
+	result, err := fix.Facade.Watch()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(result, gc.DeepEquals, params.StringsWatchResult{})
+	c.Check(fix.Resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestWatchSuccess(c *gc.C) {
+	fix := newFixture(c, true)
+	result, err := fix.Facade.Watch()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result.Changes, jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Check(fix.Resources.Count(), gc.Equals, 1)
+	c.Check(fix.Resources.Get(result.StringsWatcherId), gc.NotNil)
+}
+
+func (s *FacadeSuite) TestAdvance(c *gc.C) {
+	fix := newFixture(c, true)
+	result := fix.Facade.Advance(entities(
+		"application-expected",
+		"application-missing",
+		"application-error",
+		"unit-foo-27",
+		"burble plink",
+	))
+	c.Assert(result.Results, gc.HasLen, 5)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, "application not found")
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "blammo")
+	c.Check(result.Results[3].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Check(result.Results[4].Error, gc.ErrorMatches, `"burble plink" is not a valid tag`)
+}
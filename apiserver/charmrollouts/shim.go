@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollouts
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+func init() {
+	common.RegisterStandardFacade("CharmRollouts", 1, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the Facade.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend.
+type backendShim struct {
+	st *state.State
+}
+
+// WatchCharmRollouts is part of the Backend interface.
+func (shim backendShim) WatchCharmRollouts() state.StringsWatcher {
+	return shim.st.WatchCharmRollouts()
+}
+
+// AdvanceCharmRollout is part of the Backend interface.
+func (shim backendShim) AdvanceCharmRollout(name string) error {
+	application, err := shim.st.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.AdvanceCharmRollout()
+}
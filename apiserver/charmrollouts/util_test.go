@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollouts_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/charmrollouts"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthModelManager() bool {
+	return mock.modelManager
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(modelManager bool) facade.Authorizer {
+	return mockAuth{modelManager: modelManager}
+}
+
+// mockWatcher implements state.StringsWatcher for the tests' convenience.
+type mockWatcher struct {
+	state.StringsWatcher
+	working bool
+}
+
+func (mock *mockWatcher) Changes() <-chan []string {
+	ch := make(chan []string, 1)
+	if mock.working {
+		ch <- []string{"mysql", "wordpress"}
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (mock *mockWatcher) Err() error {
+	return errors.New("blammo")
+}
+
+// mockBackend implements charmrollouts.Backend for the tests'
+// convenience.
+type mockBackend struct {
+	working bool
+}
+
+func (backend *mockBackend) WatchCharmRollouts() state.StringsWatcher {
+	return &mockWatcher{working: backend.working}
+}
+
+func (*mockBackend) AdvanceCharmRollout(name string) error {
+	switch name {
+	case "expected":
+		return nil
+	case "missing":
+		return errors.NotFoundf("application")
+	default:
+		return errors.New("blammo")
+	}
+}
+
+// fixture collects components needed to test the Facade.
+type fixture struct {
+	Facade    *charmrollouts.Facade
+	Resources *common.Resources
+}
+
+func newFixture(c *gc.C, working bool) *fixture {
+	resources := common.NewResources()
+	facade, err := charmrollouts.NewFacade(&mockBackend{working: working}, resources, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return &fixture{facade, resources}
+}
+
+// entities is a convenience constructor for params.Entities.
+func entities(tags ...string) params.Entities {
+	entities := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		entities.Entities[i].Tag = tag
+	}
+	return entities
+}
@@ -38,6 +38,7 @@ type Backend interface {
 	Machine(string) (*state.Machine, error)
 	AllMachines() ([]*state.Machine, error)
 	AllApplications() ([]*state.Application, error)
+	AllRemoteApplications() ([]*state.RemoteApplication, error)
 	AllRelations() ([]*state.Relation, error)
 	AddOneMachine(state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideMachine(state.MachineTemplate, string, instance.ContainerType) (*state.Machine, error)
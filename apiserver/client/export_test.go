@@ -7,8 +7,31 @@ package client
 var (
 	MatchPortRanges = matchPortRanges
 	MatchSubnet     = matchSubnet
+	MatchCharmURL   = matchCharmURL
+	IsStructured    = isStructured
 )
 
+// ParseStructuredPatterns returns the terms of each group parsed
+// from the given patterns, rendered as strings for easy comparison.
+func ParseStructuredPatterns(patterns []string) ([][]string, error) {
+	groups, err := parseStructuredPatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]string, len(groups))
+	for i, g := range groups {
+		for _, t := range g.terms {
+			term := t.key + "=" + t.value
+			if t.annotation {
+				term = annotationPrefix + term
+			}
+			result[i] = append(result[i], term)
+		}
+		result[i] = append(result[i], g.legacy...)
+	}
+	return result, nil
+}
+
 // Status exports
 var (
 	ProcessMachines   = processMachines
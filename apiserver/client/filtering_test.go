@@ -6,6 +6,7 @@ package client_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/network"
@@ -57,3 +58,80 @@ func (s *filteringUnitTests) TestMatchSubnet(c *gc.C) {
 	c.Check(ok, jc.IsTrue)
 	c.Check(match, jc.IsTrue)
 }
+
+func (s *filteringUnitTests) TestIsStructured(c *gc.C) {
+	c.Check(client.IsStructured([]string{"mysql", "not", "exposed"}), jc.IsFalse)
+	c.Check(client.IsStructured([]string{"status=error"}), jc.IsTrue)
+	c.Check(client.IsStructured([]string{"annotation:owner=ops"}), jc.IsTrue)
+	c.Check(client.IsStructured([]string{"mysql", "or", "wordpress"}), jc.IsTrue)
+	// Unknown keys are not considered structured, and are left
+	// for the legacy matchers to reject.
+	c.Check(client.IsStructured([]string{"foo=bar"}), jc.IsFalse)
+}
+
+func (s *filteringUnitTests) TestParseStructuredPatterns(c *gc.C) {
+	groups, err := client.ParseStructuredPatterns([]string{
+		"status=error", "series=xenial", "or",
+		"charm=cs:mysql", "and", "annotation:owner=ops", "or",
+		"wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, [][]string{
+		{"status=error", "series=xenial"},
+		{"charm=cs:mysql", "annotation:owner=ops"},
+		{"wordpress"},
+	})
+}
+
+func (s *filteringUnitTests) TestParseStructuredPatternsErrors(c *gc.C) {
+	for i, test := range []struct {
+		patterns []string
+		err      string
+	}{{
+		patterns: []string{"or", "status=error"},
+		err:      `before "or" at position 0: empty filter expression not valid`,
+	}, {
+		patterns: []string{"status=error", "or"},
+		err:      `at end of filter: empty filter expression not valid`,
+	}, {
+		patterns: []string{"and", "status=error"},
+		err:      `"and" at position 0 with no preceding term not valid`,
+	}, {
+		patterns: []string{"status=error", "colour=blue"},
+		err:      `filter key "colour" not valid`,
+	}, {
+		patterns: []string{"exposed=maybe"},
+		err:      `exposed filter value "maybe" not valid`,
+	}, {
+		patterns: []string{"unit=mysql/0/1"},
+		err:      `unit filter: pattern "mysql/0/1" contains too many '/' characters`,
+	}, {
+		patterns: []string{"annotation:owner"},
+		err:      `annotation filter "annotation:owner" \(expected annotation:key=value\) not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.patterns)
+		_, err := client.ParseStructuredPatterns(test.patterns)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *filteringUnitTests) TestMatchCharmURL(c *gc.C) {
+	curl := charm.MustParseURL("cs:trusty/mysql-42")
+	for i, test := range []struct {
+		value   string
+		matches bool
+	}{
+		{"cs:trusty/mysql-42", true},
+		{"mysql", true},
+		{"cs:mysql", true},
+		{"cs:trusty/mysql", true},
+		{"cs:xenial/mysql", false},
+		{"cs:trusty/mysql-41", false},
+		{"local:trusty/mysql-42", false},
+		{"cs:wordpress", false},
+	} {
+		c.Logf("test %d: %s", i, test.value)
+		c.Check(client.MatchCharmURL(curl, test.value), gc.Equals, test.matches)
+	}
+	c.Check(client.MatchCharmURL(nil, "mysql"), jc.IsFalse)
+}
@@ -0,0 +1,394 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// annotationPrefix marks a structured term which matches
+	// against an entity's annotations, e.g. "annotation:owner=ops".
+	annotationPrefix = "annotation:"
+
+	orKeyword  = "or"
+	andKeyword = "and"
+)
+
+// AnnotationGetter provides the annotations of an entity so that
+// they can be used in structured filter predicates.
+type AnnotationGetter interface {
+	Annotations(state.GlobalEntity) (map[string]string, error)
+}
+
+// structuredTerm is a single "key=value" filter term.
+type structuredTerm struct {
+	key   string
+	value string
+	// annotation is true if the term was of the form
+	// "annotation:key=value".
+	annotation bool
+	// units matches the value of a "unit" term.
+	units unitMatcher
+}
+
+// predicateGroup is a set of terms which must all match for the
+// group to match. Legacy (unstructured) patterns in the group are
+// evaluated together, as they always have been, and count as a
+// single term.
+type predicateGroup struct {
+	terms  []structuredTerm
+	legacy []string
+}
+
+// structuredKeys holds the keys which may be used in a structured
+// filter term, other than annotations.
+var structuredKeys = map[string]bool{
+	"status":      true,
+	"workload":    true,
+	"series":      true,
+	"charm":       true,
+	"machine":     true,
+	"space":       true,
+	"exposed":     true,
+	"application": true,
+	"unit":        true,
+}
+
+// isStructured reports whether the patterns need to be parsed as a
+// structured expression rather than handled as plain patterns.
+func isStructured(patterns []string) bool {
+	for _, p := range patterns {
+		if p == orKeyword || p == andKeyword {
+			return true
+		}
+		if strings.HasPrefix(p, annotationPrefix) {
+			return true
+		}
+		if key, _, ok := splitTerm(p); ok && structuredKeys[key] {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTerm(p string) (key, value string, ok bool) {
+	i := strings.Index(p, "=")
+	if i <= 0 {
+		return "", "", false
+	}
+	return p[:i], p[i+1:], true
+}
+
+// parseStructuredPatterns parses the given patterns into groups of
+// terms. Groups are separated by "or"; terms within a group are
+// implicitly and-ed together, or may be separated by "and".
+func parseStructuredPatterns(patterns []string) ([]predicateGroup, error) {
+	var groups []predicateGroup
+	var current predicateGroup
+	empty := true
+	closeGroup := func() error {
+		if empty {
+			return errors.NotValidf("empty filter expression")
+		}
+		groups = append(groups, current)
+		current = predicateGroup{}
+		empty = true
+		return nil
+	}
+	for i, p := range patterns {
+		switch {
+		case p == orKeyword:
+			if err := closeGroup(); err != nil {
+				return nil, errors.Annotatef(err, "before %q at position %d", p, i)
+			}
+		case p == andKeyword:
+			if empty {
+				return nil, errors.NotValidf("%q at position %d with no preceding term", p, i)
+			}
+		case strings.HasPrefix(p, annotationPrefix):
+			key, value, ok := splitTerm(strings.TrimPrefix(p, annotationPrefix))
+			if !ok {
+				return nil, errors.NotValidf("annotation filter %q (expected annotation:key=value)", p)
+			}
+			current.terms = append(current.terms, structuredTerm{key: key, value: value, annotation: true})
+			empty = false
+		default:
+			key, value, ok := splitTerm(p)
+			if !ok {
+				current.legacy = append(current.legacy, p)
+				empty = false
+				continue
+			}
+			if !structuredKeys[key] {
+				return nil, errors.NotValidf("filter key %q", key)
+			}
+			term := structuredTerm{key: key, value: value}
+			switch key {
+			case "exposed":
+				if _, err := strconv.ParseBool(value); err != nil {
+					return nil, errors.NotValidf("exposed filter value %q", value)
+				}
+			case "unit":
+				units, err := NewUnitMatcher([]string{value})
+				if err != nil {
+					return nil, errors.NewNotValid(err, "unit filter")
+				}
+				term.units = units
+			}
+			current.terms = append(current.terms, term)
+			empty = false
+		}
+	}
+	if err := closeGroup(); err != nil {
+		return nil, errors.Annotate(err, "at end of filter")
+	}
+	return groups, nil
+}
+
+// BuildStructuredPredicateFor returns a Predicate which will evaluate
+// a machine, application or unit against the given patterns. Patterns
+// may be structured terms such as "status=error", "series=xenial" or
+// "annotation:owner=ops", combined with "and" and "or". If none of the
+// patterns are structured, the result is the same as BuildPredicateFor.
+func BuildStructuredPredicateFor(patterns []string, annotations AnnotationGetter) (Predicate, error) {
+	if !isStructured(patterns) {
+		return BuildPredicateFor(patterns), nil
+	}
+	groups, err := parseStructuredPatterns(patterns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	legacy := make([]Predicate, len(groups))
+	for i, g := range groups {
+		if len(g.legacy) > 0 {
+			legacy[i] = BuildPredicateFor(g.legacy)
+		}
+	}
+	return func(entity interface{}) (bool, error) {
+		for i, g := range groups {
+			matches, err := matchGroup(entity, g, legacy[i], annotations)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if matches {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil
+}
+
+func matchGroup(entity interface{}, g predicateGroup, legacy Predicate, annotations AnnotationGetter) (bool, error) {
+	for _, term := range g.terms {
+		var matches bool
+		var err error
+		if term.annotation {
+			matches, err = matchAnnotation(entity, term, annotations)
+		} else {
+			switch entity := entity.(type) {
+			case *state.Machine:
+				matches, err = machineMatchTerm(entity, term)
+			case *state.Unit:
+				matches, err = unitMatchTerm(entity, term)
+			case *state.Application:
+				matches, err = applicationMatchTerm(entity, term)
+			default:
+				panic(errors.Errorf("Programming error. We should only ever pass in machines, applications, or units. Received %T.", entity))
+			}
+		}
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	if legacy != nil {
+		matches, err := legacy(entity)
+		if err == InvalidFormatErr {
+			// A legacy pattern which doesn't apply to this kind
+			// of entity simply doesn't match it.
+			return false, nil
+		}
+		return matches, err
+	}
+	return true, nil
+}
+
+// matchAnnotation matches an annotation term against the annotations
+// of a machine or application; units are matched against the
+// annotations of their application.
+func matchAnnotation(entity interface{}, term structuredTerm, getter AnnotationGetter) (bool, error) {
+	if getter == nil {
+		return false, nil
+	}
+	if unit, ok := entity.(*state.Unit); ok {
+		app, err := unit.Application()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		entity = app
+	}
+	globalEntity, ok := entity.(state.GlobalEntity)
+	if !ok {
+		return false, nil
+	}
+	annotations, err := getter.Annotations(globalEntity)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	value, ok := annotations[term.key]
+	if !ok {
+		return false, nil
+	}
+	return term.value == "*" || value == term.value, nil
+}
+
+func machineMatchTerm(m *state.Machine, term structuredTerm) (bool, error) {
+	switch term.key {
+	case "status":
+		statusInfo, err := m.Status()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return statusInfo.Status.Matches(status.Status(term.value)), nil
+	case "series":
+		return m.Series() == term.value, nil
+	case "machine":
+		return matchMachineIdValue(m.Id(), term.value), nil
+	case "space":
+		for _, addr := range m.Addresses() {
+			if addr.SpaceName != "" && string(addr.SpaceName) == term.value {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	// The remaining keys only apply to units and applications;
+	// a machine hosting a matching unit will be included anyway.
+	return false, nil
+}
+
+func unitMatchTerm(u *state.Unit, term structuredTerm) (bool, error) {
+	switch term.key {
+	case "status":
+		agentStatusInfo, err := u.AgentStatus()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		ps := status.Status(term.value)
+		if agentStatusInfo.Status.Matches(ps) {
+			return true, nil
+		}
+		workloadStatusInfo, err := u.Status()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return agentStatusInfo.Status != status.StatusError && workloadStatusInfo.Status.WorkloadMatches(ps), nil
+	case "workload":
+		workloadStatusInfo, err := u.Status()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return workloadStatusInfo.Status.WorkloadMatches(status.Status(term.value)), nil
+	case "series":
+		return u.Series() == term.value, nil
+	case "charm":
+		curl, _ := u.CharmURL()
+		return matchCharmURL(curl, term.value), nil
+	case "machine":
+		machineId, err := u.AssignedMachineId()
+		if err != nil {
+			return false, nil
+		}
+		return matchMachineIdValue(machineId, term.value), nil
+	case "application":
+		return u.ApplicationName() == term.value, nil
+	case "unit":
+		return term.units.matchUnit(u), nil
+	case "space", "exposed":
+		app, err := u.Application()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return applicationMatchTerm(app, term)
+	}
+	return false, nil
+}
+
+func applicationMatchTerm(app *state.Application, term structuredTerm) (bool, error) {
+	switch term.key {
+	case "status":
+		statusInfo, err := app.Status()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return statusInfo.Status.WorkloadMatches(status.Status(term.value)), nil
+	case "series":
+		return app.Series() == term.value, nil
+	case "charm":
+		curl, _ := app.CharmURL()
+		return matchCharmURL(curl, term.value), nil
+	case "application":
+		return app.Name() == term.value, nil
+	case "exposed":
+		// The value has been validated when parsing.
+		exposed, _ := strconv.ParseBool(term.value)
+		return app.IsExposed() == exposed, nil
+	case "space":
+		bindings, err := app.EndpointBindings()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		for _, space := range bindings {
+			if space == term.value {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	// The remaining keys only apply to units; an application with
+	// matching units will be included anyway.
+	return false, nil
+}
+
+// matchMachineIdValue returns true if the machine id is the given
+// value, or is a container hosted by it.
+func matchMachineIdValue(machineId, value string) bool {
+	return machineId == value || strings.HasPrefix(machineId, value+"/")
+}
+
+// matchCharmURL matches a charm URL against a value which may be a
+// full URL ("cs:trusty/mysql-42"), a URL without series or revision
+// ("cs:mysql") or just a charm name ("mysql").
+func matchCharmURL(curl *charm.URL, value string) bool {
+	if curl == nil {
+		return false
+	}
+	if curl.String() == value || curl.Name == value {
+		return true
+	}
+	want, err := charm.ParseURL(value)
+	if err != nil {
+		return false
+	}
+	if want.Schema != curl.Schema || want.Name != curl.Name {
+		return false
+	}
+	if want.User != "" && want.User != curl.User {
+		return false
+	}
+	if want.Series != "" && want.Series != curl.Series {
+		return false
+	}
+	return want.Revision < 0 || want.Revision == curl.Revision
+}
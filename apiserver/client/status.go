@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -169,6 +170,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	var noStatus params.FullStatus
 	var context statusContext
 	var err error
+	context.includeRelationUnits = args.IncludeRelationUnits
 	if context.services, context.units, context.latestCharms, err =
 		fetchAllApplicationsAndUnits(c.api.stateAccessor, len(args.Patterns) <= 0); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch services and units")
@@ -176,12 +178,17 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "could not fetch machines")
 	} else if context.relations, err = fetchRelations(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch relations")
+	} else if context.remoteApplications, err = fetchRemoteApplications(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch remote applications")
 	}
 
 	logger.Debugf("Applications: %v", context.services)
 
 	if len(args.Patterns) > 0 {
-		predicate := BuildPredicateFor(args.Patterns)
+		predicate, err := BuildStructuredPredicateFor(args.Patterns, c.api.stateAccessor)
+		if err != nil {
+			return noStatus, errors.Annotate(err, "invalid status filter")
+		}
 
 		// First, attempt to match machines. Any units on those
 		// machines are implicitly matched.
@@ -244,6 +251,16 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			}
 		}
 
+		// Filter remote applications: keep those named in the
+		// patterns, or related to a matched application.
+		patterns := set.NewStrings(args.Patterns...)
+		for appName := range context.remoteApplications {
+			if patterns.Contains(appName) || context.relatedToMatchedApplication(appName) {
+				continue
+			}
+			delete(context.remoteApplications, appName)
+		}
+
 		// Filter machines
 		for status, machineList := range context.machines {
 			matched := make([]*state.Machine, 0, len(machineList))
@@ -271,10 +288,11 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
 	return params.FullStatus{
-		Model:        modelStatus,
-		Machines:     processMachines(context.machines),
-		Applications: context.processApplications(),
-		Relations:    context.processRelations(),
+		Model:              modelStatus,
+		Machines:           processMachines(context.machines),
+		Applications:       context.processApplications(),
+		RemoteApplications: context.processRemoteApplications(),
+		Relations:          context.processRelations(),
 	}, nil
 }
 
@@ -349,6 +367,12 @@ type statusContext struct {
 	relations    map[string][]*state.Relation
 	units        map[string]map[string]*state.Unit
 	latestCharms map[charm.URL]*state.Charm
+	// remoteApplications: remote application name -> remote application
+	remoteApplications map[string]*state.RemoteApplication
+
+	// includeRelationUnits records whether the status of units
+	// participating in each relation should be reported.
+	includeRelationUnits bool
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -431,6 +455,25 @@ func fetchAllApplicationsAndUnits(
 	return svcMap, unitMap, latestCharms, nil
 }
 
+// fetchRemoteApplications returns a map from remote application name
+// to remote application, for the applications consumed by the model.
+// The proxies standing for consumers of the model's offers are
+// omitted.
+func fetchRemoteApplications(st Backend) (map[string]*state.RemoteApplication, error) {
+	apps, err := st.AllRemoteApplications()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*state.RemoteApplication)
+	for _, app := range apps {
+		if app.IsConsumerProxy() {
+			continue
+		}
+		out[app.Name()] = app
+	}
+	return out, nil
+}
+
 // fetchRelations returns a map of all relations keyed by service name.
 //
 // This structure is useful for processServiceRelations() which needs
@@ -552,17 +595,151 @@ func (context *statusContext) processRelations() []params.RelationStatus {
 			scope = ep.Scope
 		}
 		relStatus := params.RelationStatus{
-			Id:        relation.Id(),
-			Key:       relation.String(),
-			Interface: relationInterface,
-			Scope:     string(scope),
-			Endpoints: eps,
+			Id:              relation.Id(),
+			Key:             relation.String(),
+			Interface:       relationInterface,
+			Scope:           string(scope),
+			Endpoints:       eps,
+			Suspended:       relation.Suspended(),
+			SuspendedReason: relation.SuspendedReason(),
+		}
+		if context.includeRelationUnits {
+			relStatus.Life = relation.Life().String()
+			relStatus.Units = context.processRelationUnits(relation)
 		}
 		out = append(out, relStatus)
 	}
 	return out
 }
 
+// processRelationUnits returns the status of each unit
+// participating in the given relation.
+func (context *statusContext) processRelationUnits(relation *state.Relation) []params.RelationUnitStatus {
+	var out []params.RelationUnitStatus
+	endpoints := relation.Endpoints()
+	for _, ep := range endpoints {
+		units := context.units[ep.ApplicationName]
+		for _, name := range utils.SortStringsNaturally(unitNames(units)) {
+			unit := units[name]
+			if ep.Scope == charm.ScopeContainer && unit.IsPrincipal() && !hasSubordinateOf(unit, endpoints) {
+				// Only principals with a subordinate of the related
+				// application take part in a container scoped relation.
+				continue
+			}
+			out = append(out, relationUnitStatus(relation, ep, unit))
+		}
+	}
+	return out
+}
+
+// relationUnitStatus determines the status of the unit in the relation
+// from the relation scope, the relation hook progress and the agent
+// status reported by the uniter.
+func relationUnitStatus(relation *state.Relation, ep state.Endpoint, unit *state.Unit) params.RelationUnitStatus {
+	result := params.RelationUnitStatus{
+		UnitName: unit.Name(),
+		Endpoint: ep.Name,
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		result.Status = params.RelationUnitError
+		result.Info = err.Error()
+		return result
+	}
+	switch agentStatus.Status {
+	case status.StatusError:
+		if relationId, ok := statusRelationId(agentStatus.Data); ok && relationId == relation.Id() {
+			result.Status = params.RelationUnitError
+			result.Info = agentStatus.Message
+			return result
+		}
+	case status.StatusExecuting:
+		if strings.HasPrefix(agentStatus.Message, fmt.Sprintf("running %s-relation-", ep.Name)) {
+			result.Status = params.RelationUnitExecuting
+			result.Info = agentStatus.Message
+			return result
+		}
+	}
+
+	ru, err := relation.Unit(unit)
+	if err != nil {
+		result.Status = params.RelationUnitError
+		result.Info = err.Error()
+		return result
+	}
+	inScope, err := ru.InScope()
+	if err != nil {
+		result.Status = params.RelationUnitError
+		result.Info = err.Error()
+		return result
+	}
+	if !inScope {
+		result.Status = params.RelationUnitPending
+		return result
+	}
+	joined, err := ru.Joined()
+	if err != nil {
+		result.Status = params.RelationUnitError
+		result.Info = err.Error()
+		return result
+	}
+	if !joined {
+		result.Status = params.RelationUnitDeparting
+		return result
+	}
+	// The uniter reports the unit settled once it has run all the
+	// relation hooks due so far.
+	settled, err := ru.Settled()
+	if err != nil {
+		result.Status = params.RelationUnitError
+		result.Info = err.Error()
+		return result
+	}
+	if settled {
+		result.Status = params.RelationUnitJoined
+	} else {
+		result.Status = params.RelationUnitPending
+	}
+	return result
+}
+
+// statusRelationId extracts the id of the relation whose hook failed
+// from the status data recorded by the uniter.
+func statusRelationId(data map[string]interface{}) (int, bool) {
+	switch id := data["relation-id"].(type) {
+	case int:
+		return id, true
+	case int64:
+		return int(id), true
+	case float64:
+		return int(id), true
+	}
+	return 0, false
+}
+
+func hasSubordinateOf(unit *state.Unit, endpoints []state.Endpoint) bool {
+	for _, subName := range unit.SubordinateNames() {
+		subApp, err := names.UnitApplication(subName)
+		if err != nil {
+			continue
+		}
+		for _, ep := range endpoints {
+			if ep.ApplicationName == subApp {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func unitNames(units map[string]*state.Unit) []string {
+	result := make([]string, 0, len(units))
+	for name := range units {
+		result = append(result, name)
+	}
+	return result
+}
+
 // This method exists only to dedup the loaded relations as they will
 // appear multiple times in context.relations.
 func (context *statusContext) getAllRelations() []*state.Relation {
@@ -608,6 +785,89 @@ func (context *statusContext) processApplications() map[string]params.Applicatio
 	return servicesMap
 }
 
+// relatedToMatchedApplication reports whether the named application
+// is related to any of the applications in the status context.
+func (context *statusContext) relatedToMatchedApplication(appName string) bool {
+	for _, relation := range context.relations[appName] {
+		eps, err := relation.RelatedEndpoints(appName)
+		if err != nil {
+			continue
+		}
+		for _, ep := range eps {
+			if _, ok := context.services[ep.ApplicationName]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (context *statusContext) processRemoteApplications() map[string]params.RemoteApplicationStatus {
+	out := make(map[string]params.RemoteApplicationStatus)
+	for _, app := range context.remoteApplications {
+		out[app.Name()] = context.processRemoteApplication(app)
+	}
+	return out
+}
+
+func (context *statusContext) processRemoteApplication(app *state.RemoteApplication) params.RemoteApplicationStatus {
+	processedStatus := params.RemoteApplicationStatus{
+		OfferURL:  app.URL(),
+		OfferName: app.OfferName(),
+		Life:      processLife(app),
+	}
+	eps, err := app.Endpoints()
+	if err != nil {
+		processedStatus.Err = err
+		return processedStatus
+	}
+	for _, ep := range eps {
+		processedStatus.Endpoints = append(processedStatus.Endpoints, params.RemoteEndpoint{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		})
+	}
+	processedStatus.Relations, err = context.processRemoteApplicationRelations(app.Name())
+	if err != nil {
+		processedStatus.Err = err
+		return processedStatus
+	}
+	applicationStatus, err := app.Status()
+	if err != nil {
+		processedStatus.Err = err
+		return processedStatus
+	}
+	processedStatus.Status.Status = applicationStatus.Status.String()
+	processedStatus.Status.Info = applicationStatus.Message
+	processedStatus.Status.Data = applicationStatus.Data
+	processedStatus.Status.Since = applicationStatus.Since
+	return processedStatus
+}
+
+func (context *statusContext) processRemoteApplicationRelations(appName string) (map[string][]string, error) {
+	related := make(map[string][]string)
+	for _, relation := range context.relations[appName] {
+		ep, err := relation.Endpoint(appName)
+		if err != nil {
+			return nil, err
+		}
+		eps, err := relation.RelatedEndpoints(appName)
+		if err != nil {
+			return nil, err
+		}
+		for _, relatedEp := range eps {
+			related[ep.Name] = append(related[ep.Name], relatedEp.ApplicationName)
+		}
+	}
+	for relationName, appNames := range related {
+		related[relationName] = set.NewStrings(appNames...).SortedValues()
+	}
+	return related, nil
+}
+
 func (context *statusContext) processApplication(service *state.Application) params.ApplicationStatus {
 	serviceCharmURL, _ := service.CharmURL()
 	var processedStatus = params.ApplicationStatus{
@@ -8,7 +8,9 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
@@ -57,6 +59,116 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(resultMachine.Series, gc.Equals, machine.Series())
 }
 
+func (s *statusSuite) TestFullStatusRemoteApplications(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		OfferName:   "hosted-mysql",
+		URL:         "admin/prod.hosted-mysql",
+		SourceModel: names.NewModelTag(utils.MustNewUUID().String()),
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.RemoteApplications, gc.HasLen, 1)
+	remoteApp := status.RemoteApplications["mysql"]
+	c.Check(remoteApp.OfferURL, gc.Equals, "admin/prod.hosted-mysql")
+	c.Check(remoteApp.OfferName, gc.Equals, "hosted-mysql")
+	c.Check(remoteApp.Endpoints, jc.DeepEquals, []params.RemoteEndpoint{{
+		Name:      "db",
+		Role:      charm.RoleProvider,
+		Interface: "mysql",
+		Scope:     charm.ScopeGlobal,
+	}})
+	c.Check(remoteApp.Relations, jc.DeepEquals, map[string][]string{"db": {"wordpress"}})
+	c.Check(remoteApp.Status.Status, gc.Equals, "unknown")
+	c.Check(status.Applications["wordpress"].Relations, jc.DeepEquals, map[string][]string{"db": {"mysql"}})
+}
+
+func (s *statusSuite) TestFullStatusSuspendedRelation(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSuspended(true, "misbehaving")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Relations, gc.HasLen, 1)
+	c.Check(status.Relations[0].Suspended, jc.IsTrue)
+	c.Check(status.Relations[0].SuspendedReason, gc.Equals, "misbehaving")
+}
+
+func (s *statusSuite) TestFullStatusRelationUnits(c *gc.C) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	wordpressUnit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	relationUnitStatus := func() map[string]string {
+		status, err := s.APIState.Client().StatusWithRelationUnits(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(status.Relations, gc.HasLen, 1)
+		result := make(map[string]string)
+		for _, unit := range status.Relations[0].Units {
+			result[unit.UnitName] = unit.Status
+		}
+		return result
+	}
+
+	// A unit in scope is pending until its agent has run the
+	// relation hooks due.
+	c.Check(relationUnitStatus(), jc.DeepEquals, map[string]string{
+		"wordpress/0": params.RelationUnitPending,
+		"mysql/0":     params.RelationUnitPending,
+	})
+	err = ru.SetSettled(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(relationUnitStatus(), jc.DeepEquals, map[string]string{
+		"wordpress/0": params.RelationUnitJoined,
+		"mysql/0":     params.RelationUnitPending,
+	})
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -194,6 +306,25 @@ func (s *statusUnitTestSuite) TestWorkloadVersionLastWins(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit3, "zarkon")
 }
 
+func (s *statusUnitTestSuite) TestStructuredPredicateUnitAnnotations(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	err := s.State.SetAnnotations(application, map[string]string{"owner": "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	predicate, err := client.BuildStructuredPredicateFor([]string{"annotation:owner=ops"}, s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	matches, err := predicate(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(matches, jc.IsTrue)
+
+	predicate, err = client.BuildStructuredPredicateFor([]string{"annotation:owner=dev"}, s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	matches, err = predicate(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(matches, jc.IsFalse)
+}
+
 func (s *statusUnitTestSuite) TestWorkloadVersionSimple(c *gc.C) {
 	application := s.Factory.MakeApplication(c, nil)
 	unit1 := addUnitWithVersion(c, application, "voltron")
@@ -303,7 +434,7 @@ func (s *statusUpgradeUnitSuite) SetUpTest(c *gc.C) {
 		EnvironManager: true,
 	}
 	var err error
-	s.charmrevisionupdater, err = charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser)
+	s.charmrevisionupdater, err = charmrevisionupdater.NewCharmRevisionUpdaterAPI(s.State, s.resources, s.authoriser, clock.WallClock)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -6,6 +6,7 @@ package common
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
@@ -20,6 +21,7 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	ModelConfig() (*config.Config, error)
 }
 
 // APIAddresser implements the APIAddresses method
@@ -59,9 +61,14 @@ func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
 	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
 }
 
-// APIAddresses returns the list of addresses used to connect to the API.
+// APIAddresses returns the list of addresses used to connect to the API,
+// ordered according to the model's address preference.
 func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
-	addrs, err := apiAddresses(api.getter)
+	cfg, err := api.getter.ModelConfig()
+	if err != nil {
+		return params.StringsResult{}, err
+	}
+	addrs, err := apiAddressesWithPreference(api.getter, cfg.AddressPreference())
 	if err != nil {
 		return params.StringsResult{}, err
 	}
@@ -71,13 +78,17 @@ func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
 }
 
 func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
+	return apiAddressesWithPreference(getter, network.PreferDualStack)
+}
+
+func apiAddressesWithPreference(getter APIHostPortsGetter, pref network.AddressPreference) ([]string, error) {
 	apiHostPorts, err := getter.APIHostPorts()
 	if err != nil {
 		return nil, err
 	}
 	var addrs = make([]string, 0, len(apiHostPorts))
 	for _, hostPorts := range apiHostPorts {
-		ordered := network.PrioritizeInternalHostPorts(hostPorts, false)
+		ordered := network.PrioritizeInternalHostPortsWithPreference(hostPorts, false, pref)
 		for _, addr := range ordered {
 			if addr != "" {
 				addrs = append(addrs, addr)
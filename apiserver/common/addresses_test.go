@@ -8,8 +8,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type stateAddresserSuite struct {
@@ -88,6 +90,22 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAPIAddressesPreferringIPv6(c *gc.C) {
+	ctlr1, err := network.ParseHostPorts("10.0.2.1:17070", "[fc00::1]:17070", "[2001:db8::1]:17070")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.hostPorts = [][]network.HostPort{ctlr1}
+	s.fake.attrs = map[string]interface{}{"address-preference": "ipv6"}
+
+	result, err := s.addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Result, gc.DeepEquals, []string{
+		"[fc00::1]:17070",
+		"10.0.2.1:17070",
+		"[2001:db8::1]:17070",
+	})
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -102,6 +120,7 @@ var _ common.AddressAndCertGetter = fakeAddresses{}
 
 type fakeAddresses struct {
 	hostPorts [][]network.HostPort
+	attrs     map[string]interface{}
 }
 
 func (fakeAddresses) Addresses() ([]string, error) {
@@ -127,3 +146,7 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (f fakeAddresses) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(f.attrs))
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ConfigHistoryResult converts the recorded history of changes made to
+// application or model settings into its API representation.
+func ConfigHistoryResult(history []state.SettingsRevision) params.ConfigHistoryResult {
+	result := params.ConfigHistoryResult{
+		History: make([]params.ConfigRevision, len(history)),
+	}
+	for i, rev := range history {
+		changes := make([]params.ConfigChange, len(rev.Changes))
+		for j, change := range rev.Changes {
+			changes[j] = params.ConfigChange{
+				Key:      change.Key,
+				Type:     configChangeType(change.Type),
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			}
+		}
+		result.History[i] = params.ConfigRevision{
+			Revision: rev.Revision,
+			User:     rev.User,
+			Time:     rev.Time,
+			Changes:  changes,
+		}
+	}
+	return result
+}
+
+func configChangeType(t int) string {
+	switch t {
+	case state.ItemAdded:
+		return params.ConfigAdded
+	case state.ItemDeleted:
+		return params.ConfigDeleted
+	}
+	return params.ConfigModified
+}
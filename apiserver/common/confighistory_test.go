@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type configHistorySuite struct{}
+
+var _ = gc.Suite(&configHistorySuite{})
+
+func (*configHistorySuite) TestConfigHistoryResult(c *gc.C) {
+	now := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	result := common.ConfigHistoryResult([]state.SettingsRevision{{
+		Revision: 2,
+		User:     "mary",
+		Time:     now,
+		Changes: []state.ItemChange{
+			{Type: state.ItemDeleted, Key: "outlook", OldValue: "good"},
+			{Type: state.ItemModified, Key: "title", OldValue: "foo", NewValue: "bar"},
+		},
+		Settings: map[string]interface{}{"title": "bar"},
+	}, {
+		Revision: 1,
+		Time:     now.Add(-time.Hour),
+		Changes: []state.ItemChange{
+			{Type: state.ItemAdded, Key: "title", NewValue: "foo"},
+		},
+	}})
+	c.Assert(result, jc.DeepEquals, params.ConfigHistoryResult{
+		History: []params.ConfigRevision{{
+			Revision: 2,
+			User:     "mary",
+			Time:     now,
+			Changes: []params.ConfigChange{
+				{Key: "outlook", Type: params.ConfigDeleted, OldValue: "good"},
+				{Key: "title", Type: params.ConfigModified, OldValue: "foo", NewValue: "bar"},
+			},
+		}, {
+			Revision: 1,
+			Time:     now.Add(-time.Hour),
+			Changes: []params.ConfigChange{
+				{Key: "title", Type: params.ConfigAdded, NewValue: "foo"},
+			},
+		}},
+	})
+}
@@ -87,6 +87,20 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoPersistentDeviceNa
 	})
 }
 
+func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoBlockDeviceSize(c *gc.C) {
+	// The size reported is that of the block device as seen
+	// on the machine, which reflects any resizing.
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.blockDevices[0].Size = 2048
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: filepath.FromSlash("/dev/sda"),
+		Size:     2048,
+	})
+}
+
 func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMissingBlockDevice(c *gc.C) {
 	// If the block device has not shown up yet,
 	// then we should get a NotProvisioned error.
@@ -19,7 +19,8 @@ import (
 
 func init() {
 	// Version 0 is no longer supported.
-	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPIV3)
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -115,6 +116,35 @@ func NewFirewallerAPI(
 	}, nil
 }
 
+// FirewallerAPIV3 provides the Firewaller API facade, version 3, which
+// lacks the methods added in version 4.
+type FirewallerAPIV3 struct {
+	*FirewallerAPI
+}
+
+// NewFirewallerAPIV3 creates a new server-side Firewaller API facade,
+// version 3.
+func NewFirewallerAPIV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FirewallerAPIV3, error) {
+	api, err := NewFirewallerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV3{api}, nil
+}
+
+// Taking two arguments keeps the methods below off the RPC facade,
+// and with them the version 4 methods they hide.
+
+// GetExposedIngressCIDRs isn't on the version 3 API.
+func (*FirewallerAPIV3) GetExposedIngressCIDRs(_, _ struct{}) {}
+
+// GetExposedEndpoints isn't on the version 3 API.
+func (*FirewallerAPIV3) GetExposedEndpoints(_, _ struct{}) {}
+
 // WatchOpenedPorts returns a new StringsWatcher for each given
 // environment tag.
 func (f *FirewallerAPI) WatchOpenedPorts(args params.Entities) (params.StringsWatchResults, error) {
@@ -197,6 +227,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			portRangeEndpoints := ports.AllPortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -205,11 +236,14 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 
 			for _, portRange := range portRanges {
 				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
-				result.Results[i].Ports = append(result.Results[i].Ports,
-					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
-					})
+				for _, endpoint := range portRangeEndpoints[portRange] {
+					result.Results[i].Ports = append(result.Results[i].Ports,
+						params.MachinePortRange{
+							UnitTag:   unitTag,
+							PortRange: params.FromNetworkPortRange(portRange),
+							Endpoint:  endpoint,
+						})
+				}
 			}
 		}
 	}
@@ -287,6 +321,58 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedIngressCIDRs returns the source CIDRs from which the
+// opened ports of each given application may be accessed when the
+// application is exposed.
+func (f *FirewallerAPI) GetExposedIngressCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = service.ExposedIngressCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GetExposedEndpoints returns the names of the endpoints whose opened
+// ports are exposed for each given application. An empty result means
+// the ports opened for all endpoints are exposed.
+func (f *FirewallerAPI) GetExposedEndpoints(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = service.ExposedEndpoints()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
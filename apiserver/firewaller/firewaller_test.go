@@ -77,6 +77,93 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposedIngressCIDRs(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("public", "", []string{"192.168.1.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetExposedTo([]string{"10.0.0.0/8"}, []string{"public"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetExposedIngressCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Exposing without restrictions allows access from anywhere.
+	err = s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.firewaller.GetExposedIngressCIDRs(params.Entities{
+		Entities: []params.Entity{{Tag: s.service.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{Result: []string{"0.0.0.0/0"}}},
+	})
+}
+
+func (s *firewallerSuite) TestGetExposedEndpoints(c *gc.C) {
+	err := s.service.SetExposedEndpoints([]string{"url"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := s.firewaller.GetExposedEndpoints(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"url"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestGetMachinePortsWithEndpoints(c *gc.C) {
+	err := s.units[0].OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenEndpointPorts("admin-api", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.firewaller.GetMachinePorts(params.MachinePortsParams{
+		Params: []params.MachinePorts{{MachineTag: s.machines[0].Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit0Tag := s.units[0].Tag().String()
+	c.Assert(result, jc.DeepEquals, params.MachinePortsResults{
+		Results: []params.MachinePortsResult{{Ports: []params.MachinePortRange{
+			{UnitTag: unit0Tag, Endpoint: "admin-api", PortRange: params.PortRange{
+				FromPort: 80, ToPort: 80, Protocol: "tcp",
+			}},
+			{UnitTag: unit0Tag, Endpoint: "url", PortRange: params.PortRange{
+				FromPort: 80, ToPort: 80, Protocol: "tcp",
+			}},
+			{UnitTag: unit0Tag, PortRange: params.PortRange{
+				FromPort: 443, ToPort: 443, Protocol: "tcp",
+			}},
+		}}},
+	})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
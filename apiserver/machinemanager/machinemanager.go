@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -18,7 +19,8 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPI)
+	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPIV2)
+	common.RegisterStandardFacade("MachineManager", 3, NewMachineManagerAPI)
 }
 
 // MachineManagerAPI provides access to the MachineManager API facade.
@@ -51,6 +53,35 @@ func NewMachineManagerAPI(
 	}, nil
 }
 
+// MachineManagerAPIV2 provides access to version 2 of the MachineManager
+// API facade, which lacks the series upgrade methods.
+type MachineManagerAPIV2 struct {
+	*MachineManagerAPI
+}
+
+// NewMachineManagerAPIV2 creates a new server-side version 2
+// MachineManager API facade.
+func NewMachineManagerAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*MachineManagerAPIV2, error) {
+	api, err := NewMachineManagerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachineManagerAPIV2{api}, nil
+}
+
+// Methods with two arguments are not served over RPC, so the methods
+// below keep the version 3 methods off version 2.
+
+// PrepareUpgradeSeries isn't on the version 2 API.
+func (*MachineManagerAPIV2) PrepareUpgradeSeries(_, _ struct{}) {}
+
+// CompleteUpgradeSeries isn't on the version 2 API.
+func (*MachineManagerAPIV2) CompleteUpgradeSeries(_, _ struct{}) {}
+
 // AddMachines adds new machines with the supplied parameters.
 func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMachinesResults, error) {
 	results := params.AddMachinesResults{
@@ -78,6 +109,65 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// PrepareUpgradeSeries records that the operating systems of the
+// given machines are about to be upgraded to new series, pausing the
+// agents of the units on them.
+func (mm *MachineManagerAPI) PrepareUpgradeSeries(args params.UpgradeSeriesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := mm.updateMachine(arg.Entity.Tag, func(m Machine) error {
+			return m.PrepareUpgradeSeries(arg.Series, arg.Force)
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// CompleteUpgradeSeries records that the operating systems of the
+// given machines have been upgraded to the series they were prepared
+// for, so that their agents can be reconfigured and resumed.
+func (mm *MachineManagerAPI) CompleteUpgradeSeries(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		err := mm.updateMachine(entity.Tag, Machine.CompleteUpgradeSeries)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) checkCanWrite() error {
+	canWrite, err := mm.authorizer.HasPermission(description.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return mm.check.ChangeAllowed()
+}
+
+func (mm *MachineManagerAPI) updateMachine(tag string, update func(Machine) error) error {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return update(m)
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
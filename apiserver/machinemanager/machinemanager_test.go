@@ -5,6 +5,7 @@ package machinemanager_test
 
 import (
 	"errors"
+	"fmt"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -118,10 +119,63 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestPrepareUpgradeSeries(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.PrepareUpgradeSeries(params.UpgradeSeriesArgs{
+		Args: []params.UpgradeSeriesArg{{
+			Entity: params.Entity{Tag: "machine-0"},
+			Series: "xenial",
+			Force:  true,
+		}, {
+			Entity: params.Entity{Tag: "unit-mysql-0"},
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"0"})
+	c.Assert(s.st.machine.calls, jc.DeepEquals, []string{"PrepareUpgradeSeries xenial true"})
+}
+
+func (s *MachineManagerSuite) TestCompleteUpgradeSeries(c *gc.C) {
+	s.st.machine = &mockMachine{err: errors.New("machine not prepared")}
+	results, err := s.api.CompleteUpgradeSeries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: "machine not prepared"}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1"})
+	c.Assert(s.st.machine.calls, jc.DeepEquals, []string{"CompleteUpgradeSeries"})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.api.CompleteUpgradeSeries(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockState struct {
-	calls    int
-	machines []state.MachineTemplate
-	err      error
+	calls      int
+	machines   []state.MachineTemplate
+	err        error
+	machine    *mockMachine
+	machineIds []string
+}
+
+func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
+	st.machineIds = append(st.machineIds, id)
+	return st.machine, nil
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -155,6 +209,21 @@ func (st *mockState) AddMachineInsideMachine(template state.MachineTemplate, par
 	panic("not implemented")
 }
 
+type mockMachine struct {
+	calls []string
+	err   error
+}
+
+func (m *mockMachine) PrepareUpgradeSeries(toSeries string, force bool) error {
+	m.calls = append(m.calls, fmt.Sprintf("PrepareUpgradeSeries %s %v", toSeries, force))
+	return m.err
+}
+
+func (m *mockMachine) CompleteUpgradeSeries() error {
+	m.calls = append(m.calls, "CompleteUpgradeSeries")
+	return m.err
+}
+
 type mockBlock struct {
 	state.Block
 }
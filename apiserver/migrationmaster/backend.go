@@ -6,7 +6,7 @@ package migrationmaster
 import (
 	"gopkg.in/juju/names.v2"
 
-	"github.com/juju/juju/migration"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/state"
 	"github.com/juju/version"
 )
@@ -22,5 +22,8 @@ type Backend interface {
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
 
-	migration.StateExporter
+	// ExportForMigration generates an abstract representation of
+	// the model, with secret config values encrypted with the given
+	// key.
+	ExportForMigration(secretKey string) (description.Model, error)
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -173,10 +174,16 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 }
 
 // Export serializes the model associated with the API connection.
+// Secret config values are encrypted with a new key, returned with the
+// model, for the target controller to decrypt them with.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
 
-	model, err := api.backend.Export()
+	secretKey, err := state.NewSecretConfigKey()
+	if err != nil {
+		return serialized, err
+	}
+	model, err := api.backend.ExportForMigration(secretKey)
 	if err != nil {
 		return serialized, err
 	}
@@ -188,6 +195,7 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Bytes = bytes
 	serialized.Charms = getUsedCharms(model)
 	serialized.Tools = getUsedTools(model)
+	serialized.SecretKey = secretKey
 	return serialized, nil
 }
 
@@ -216,6 +216,10 @@ func (s *Suite) TestExport(c *gc.C) {
 	c.Assert(serialized.Tools, gc.DeepEquals, []params.SerializedModelTools{
 		{tools, "/tools/" + tools},
 	})
+	c.Assert(serialized.SecretKey, gc.Not(gc.Equals), "")
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"ExportForMigration", []interface{}{serialized.SecretKey}},
+	})
 }
 
 func (s *Suite) TestReap(c *gc.C) {
@@ -359,8 +363,8 @@ func (b *stubBackend) RemoveExportingModelDocs() error {
 	return b.removeErr
 }
 
-func (b *stubBackend) Export() (description.Model, error) {
-	b.stub.AddCall("Export")
+func (b *stubBackend) ExportForMigration(secretKey string) (description.Model, error) {
+	b.stub.AddCall("ExportForMigration", secretKey)
 	return b.model, nil
 }
 
@@ -79,7 +79,7 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
-	_, st, err := migration.ImportModel(api.state, serialized.Bytes)
+	_, st, err := migration.ImportModel(api.state, serialized.Bytes, serialized.SecretKey)
 	if err != nil {
 		return err
 	}
@@ -17,7 +17,9 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
-	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
+	UpdateModelConfigAs(string, map[string]interface{}, []string, state.ValidateConfigFunc) error
+	ModelConfigHistory() ([]state.SettingsRevision, error)
+	RollbackModelConfig(user string, revision int) error
 }
 
 type stateShim struct {
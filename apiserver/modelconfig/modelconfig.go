@@ -15,13 +15,22 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("ModelConfig", 1, newFacade)
+	common.RegisterStandardFacade("ModelConfig", 1, newFacadeV1)
+	common.RegisterStandardFacade("ModelConfig", 2, newFacade)
 }
 
 func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelConfigAPI, error) {
 	return NewModelConfigAPI(NewStateBackend(st), auth)
 }
 
+func newFacadeV1(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV1, error) {
+	api, err := newFacade(st, resources, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelConfigAPIV1{api}, nil
+}
+
 // ModelConfigAPI is the endpoint which implements the model config facade.
 type ModelConfigAPI struct {
 	backend Backend
@@ -42,6 +51,21 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 	return client, nil
 }
 
+// ModelConfigAPIV1 is the endpoint which implements version 1 of the
+// model config facade, which lacks the methods added in version 2.
+type ModelConfigAPIV1 struct {
+	*ModelConfigAPI
+}
+
+// Methods with two arguments are not served over RPC, so the methods
+// below keep the version 2 methods off version 1.
+
+// ModelConfigHistory isn't on the version 1 API.
+func (*ModelConfigAPIV1) ModelConfigHistory(_, _ struct{}) {}
+
+// ModelRollback isn't on the version 1 API.
+func (*ModelConfigAPIV1) ModelRollback(_, _ struct{}) {}
+
 func (c *ModelConfigAPI) checkCanWrite() error {
 	canWrite, err := c.auth.HasPermission(description.WriteAccess, c.backend.ModelTag())
 	if err != nil {
@@ -115,7 +139,7 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 	}
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion)
+	return c.backend.UpdateModelConfigAs(c.userName(), attrs, nil, checkAgentVersion)
 }
 
 // ModelUnset implements the server-side part of the
@@ -127,5 +151,36 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys, nil)
+	return c.backend.UpdateModelConfigAs(c.userName(), nil, args.Keys, nil)
+}
+
+// ModelConfigHistory returns the recorded history of changes made to
+// the model configuration, most recent first.
+func (c *ModelConfigAPI) ModelConfigHistory() (params.ConfigHistoryResult, error) {
+	if err := c.checkCanWrite(); err != nil {
+		return params.ConfigHistoryResult{}, errors.Trace(err)
+	}
+	history, err := c.backend.ModelConfigHistory()
+	if err != nil {
+		return params.ConfigHistoryResult{}, errors.Trace(err)
+	}
+	return common.ConfigHistoryResult(history), nil
+}
+
+// ModelRollback restores the model configuration to that recorded at
+// the given revision of its history.
+func (c *ModelConfigAPI) ModelRollback(args params.ModelRollback) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.RollbackModelConfig(c.userName(), args.Revision)
+}
+
+// userName returns the name of the authenticated user, for recording
+// in the settings history.
+func (c *ModelConfigAPI) userName() string {
+	return c.auth.GetAuthTag().Id()
 }
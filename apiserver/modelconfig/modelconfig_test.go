@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -79,6 +81,7 @@ func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertConfigValue(c, "some-key", "value")
 	s.assertConfigValue(c, "other-key", "other value")
+	c.Assert(s.backend.user, gc.Equals, s.authorizer.Tag.Id())
 }
 
 func (s *modelconfigSuite) blockAllChanges(c *gc.C, msg string) {
@@ -127,7 +130,7 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil, nil)
+	err := s.backend.UpdateModelConfigAs("", map[string]interface{}{"abc": 123}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.ModelUnset{[]string{"abc"}}
@@ -137,7 +140,7 @@ func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestBlockModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil, nil)
+	err := s.backend.UpdateModelConfigAs("", map[string]interface{}{"abc": 123}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockAllChanges(c, "TestBlockModelUnset")
 
@@ -153,18 +156,65 @@ func (s *modelconfigSuite) TestModelUnsetMissing(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	now := time.Now().UTC()
+	s.backend.history = []state.SettingsRevision{{
+		Revision: 1,
+		User:     "bruce",
+		Time:     now,
+		Changes:  []state.ItemChange{{Type: state.ItemAdded, Key: "ftp-proxy", NewValue: "http://proxy"}},
+	}}
+	result, err := s.api.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConfigHistoryResult{
+		History: []params.ConfigRevision{{
+			Revision: 1,
+			User:     "bruce",
+			Time:     now,
+			Changes: []params.ConfigChange{{
+				Key:      "ftp-proxy",
+				Type:     params.ConfigAdded,
+				NewValue: "http://proxy",
+			}},
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestModelRollback(c *gc.C) {
+	s.backend.history = []state.SettingsRevision{{
+		Revision: 1,
+		Settings: map[string]interface{}{"ftp-proxy": "http://old-proxy"},
+	}}
+	err := s.api.ModelRollback(params.ModelRollback{Revision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "ftp-proxy", "http://old-proxy")
+	c.Assert(s.backend.user, gc.Equals, s.authorizer.Tag.Id())
+
+	err = s.api.ModelRollback(params.ModelRollback{Revision: 42})
+	c.Assert(err, gc.ErrorMatches, "settings revision 42 not found")
+}
+
+func (s *modelconfigSuite) TestBlockChangesModelRollback(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockChangesModelRollback")
+	err := s.api.ModelRollback(params.ModelRollback{Revision: 1})
+	s.assertBlocked(c, err, "TestBlockChangesModelRollback")
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg     config.ConfigValues
+	old     *config.Config
+	b       state.BlockType
+	msg     string
+	user    string
+	history []state.SettingsRevision
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
 	return m.cfg, nil
 }
 
-func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []string, validate state.ValidateConfigFunc) error {
+func (m *mockBackend) UpdateModelConfigAs(user string, update map[string]interface{}, remove []string, validate state.ValidateConfigFunc) error {
+	m.user = user
 	if validate != nil {
 		err := validate(update, remove, m.old)
 		if err != nil {
@@ -180,6 +230,25 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ModelConfigHistory() ([]state.SettingsRevision, error) {
+	return m.history, nil
+}
+
+func (m *mockBackend) RollbackModelConfig(user string, revision int) error {
+	m.user = user
+	for _, rev := range m.history {
+		if rev.Revision != revision {
+			continue
+		}
+		m.cfg = make(config.ConfigValues)
+		for k, v := range rev.Settings {
+			m.cfg[k] = config.ConfigValue{v, "model"}
+		}
+		return nil
+	}
+	return errors.NotFoundf("settings revision %d", revision)
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
@@ -319,16 +319,21 @@ type mockState struct {
 	cfgDefaults     config.ModelDefaultAttributes
 	blockMsg        string
 	block           state.BlockType
+	applications    map[string]interface{}
 }
 
 type fakeModelDescription struct {
 	description.Model `yaml:"-"`
 
-	UUID string `yaml:"model-uuid"`
+	UUID         string                 `yaml:"model-uuid"`
+	Applications map[string]interface{} `yaml:"applications,omitempty"`
 }
 
 func (st *mockState) Export() (description.Model, error) {
-	return &fakeModelDescription{UUID: st.modelUUID}, nil
+	return &fakeModelDescription{
+		UUID:         st.modelUUID,
+		Applications: st.applications,
+	}, nil
 }
 
 func (st *mockState) ModelUUID() string {
@@ -368,7 +368,29 @@ func (mm *ModelManagerAPI) dumpModel(args params.Entity) (map[string]interface{}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return out.(map[string]interface{}), nil
+	dumped := out.(map[string]interface{})
+	redactSecretSettings(dumped)
+	return dumped, nil
+}
+
+// redactSecretSettings replaces the values of the secret settings of
+// the applications in a dumped model. The export already redacts the
+// values it holds encrypted; this also covers any secret value that
+// has not yet been encrypted.
+func redactSecretSettings(dumped map[string]interface{}) {
+	applications, _ := dumped["applications"].(map[string]interface{})
+	all, _ := applications["applications"].([]interface{})
+	for _, item := range all {
+		application, _ := item.(map[string]interface{})
+		settings, _ := application["settings"].(map[string]interface{})
+		secrets, _ := application["secret-config-keys"].([]interface{})
+		for _, secret := range secrets {
+			name, _ := secret.(string)
+			if _, ok := settings[name]; ok {
+				settings[name] = state.RedactedConfigValue
+			}
+		}
+	}
 }
 
 func (mm *ModelManagerAPI) dumpModelDB(args params.Entity) (map[string]interface{}, error) {
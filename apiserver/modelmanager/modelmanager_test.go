@@ -490,6 +490,43 @@ func (s *modelManagerSuite) TestDumpModel(c *gc.C) {
 	})
 }
 
+func (s *modelManagerSuite) TestDumpModelRedactsSecrets(c *gc.C) {
+	s.st.applications = map[string]interface{}{
+		"version": 1,
+		"applications": []interface{}{
+			map[string]interface{}{
+				"name": "mysql",
+				"settings": map[string]interface{}{
+					"user":     "root",
+					"password": "sekrit",
+				},
+				"secret-config-keys": []interface{}{"password"},
+			},
+		},
+	}
+	results := s.api.DumpModels(params.Entities{[]params.Entity{{
+		Tag: s.st.ModelTag().String(),
+	}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Result, jc.DeepEquals, map[string]interface{}{
+		"model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"applications": map[string]interface{}{
+			"version": 1,
+			"applications": []interface{}{
+				map[string]interface{}{
+					"name": "mysql",
+					"settings": map[string]interface{}{
+						"user":     "root",
+						"password": "<redacted>",
+					},
+					"secret-config-keys": []interface{}{"password"},
+				},
+			},
+		},
+	})
+}
+
 func (s *modelManagerSuite) TestDumpModelMissingModel(c *gc.C) {
 	s.st.SetErrors(errors.NotFoundf("boom"))
 	tag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f000")
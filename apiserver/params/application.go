@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "github.com/juju/juju/constraints"
+
+// UnitInfoResults holds the results of a UnitsInfo call.
+type UnitInfoResults struct {
+	Results []UnitInfoResult `json:"results"`
+}
+
+// UnitInfoResult holds the details of a single unit, or an error
+// if they could not be retrieved.
+type UnitInfoResult struct {
+	Result *UnitInfo `json:"result,omitempty"`
+	Error  *Error    `json:"error,omitempty"`
+}
+
+// UnitInfo holds the details of a unit, including the relation
+// settings it can see.
+type UnitInfo struct {
+	Tag             string                 `json:"tag"`
+	Charm           string                 `json:"charm"`
+	Life            string                 `json:"life"`
+	Machine         string                 `json:"machine,omitempty"`
+	PublicAddress   string                 `json:"public-address,omitempty"`
+	PrivateAddress  string                 `json:"private-address,omitempty"`
+	OpenedPorts     []string               `json:"opened-ports,omitempty"`
+	Leader          bool                   `json:"leader"`
+	WorkloadVersion string                 `json:"workload-version,omitempty"`
+	LeaderSettings  map[string]string      `json:"leader-settings,omitempty"`
+	RelationData    []EndpointRelationData `json:"relation-data,omitempty"`
+}
+
+// EndpointRelationData holds the settings of a relation as seen by
+// one of its units.
+type EndpointRelationData struct {
+	RelationId      int                               `json:"relation-id"`
+	Endpoint        string                            `json:"endpoint"`
+	RelatedEndpoint string                            `json:"related-endpoint"`
+	UnitData        map[string]interface{}            `json:"unit-data"`
+	RemoteUnitData  map[string]map[string]interface{} `json:"remote-unit-data"`
+}
+
+// ApplicationInfoResults holds the results of an ApplicationsInfo call.
+type ApplicationInfoResults struct {
+	Results []ApplicationInfoResult `json:"results"`
+}
+
+// ApplicationInfoResult holds the details of a single application, or
+// an error if they could not be retrieved.
+type ApplicationInfoResult struct {
+	Result *ApplicationInfo `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// ApplicationInfo holds the details of an application.
+type ApplicationInfo struct {
+	Tag              string                        `json:"tag"`
+	Charm            string                        `json:"charm"`
+	Channel          string                        `json:"channel,omitempty"`
+	Series           string                        `json:"series"`
+	Life             string                        `json:"life"`
+	Principal        bool                          `json:"principal"`
+	Exposed          bool                          `json:"exposed"`
+	MinUnits         int                           `json:"min-units,omitempty"`
+	Constraints      constraints.Value             `json:"constraints"`
+	EndpointBindings map[string]string             `json:"endpoint-bindings,omitempty"`
+	Config           map[string]interface{}        `json:"config,omitempty"`
+	Storage          map[string]StorageConstraints `json:"storage,omitempty"`
+	Resources        []ApplicationResourceInfo     `json:"resources,omitempty"`
+	Units            []ApplicationUnitInfo         `json:"units,omitempty"`
+	Relations        map[string][]string           `json:"relations,omitempty"`
+}
+
+// ApplicationResourceInfo holds a summary of a resource in use by
+// an application.
+type ApplicationResourceInfo struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Origin   string `json:"origin"`
+	Revision int    `json:"revision"`
+}
+
+// ApplicationUnitInfo holds a summary of a unit of an application.
+type ApplicationUnitInfo struct {
+	Name           string `json:"name"`
+	Machine        string `json:"machine,omitempty"`
+	WorkloadStatus string `json:"workload-status"`
+	AgentStatus    string `json:"agent-status"`
+	Message        string `json:"message,omitempty"`
+}
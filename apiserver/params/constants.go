@@ -31,4 +31,14 @@ const (
 	ResolvedNoHooks    ResolvedMode = "no-hooks"
 )
 
+// UpgradeSeriesStatus describes the progress of an upgrade of the
+// series of a machine, and of the units running on it.
+type UpgradeSeriesStatus string
+
+const (
+	UpgradeSeriesNotStarted UpgradeSeriesStatus = ""
+	UpgradeSeriesPrepared   UpgradeSeriesStatus = "prepared"
+	UpgradeSeriesCompleted  UpgradeSeriesStatus = "completed"
+)
+
 const MachineNonceHeader = "X-Juju-Nonce"
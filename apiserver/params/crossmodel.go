@@ -0,0 +1,189 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// RemoteEndpoint describes an endpoint of an offered or remote
+// application.
+type RemoteEndpoint struct {
+	Name      string              `json:"name"`
+	Role      charm.RelationRole  `json:"role"`
+	Interface string              `json:"interface"`
+	Limit     int                 `json:"limit"`
+	Scope     charm.RelationScope `json:"scope"`
+}
+
+// AddApplicationOffers holds the application offers to add.
+type AddApplicationOffers struct {
+	Offers []AddApplicationOffer `json:"offers"`
+}
+
+// AddApplicationOffer describes the application endpoints to offer
+// for consumption by other models.
+type AddApplicationOffer struct {
+	ModelTag               string            `json:"model-tag"`
+	OfferName              string            `json:"offer-name"`
+	ApplicationName        string            `json:"application-name"`
+	ApplicationDescription string            `json:"application-description"`
+	Endpoints              map[string]string `json:"endpoints"`
+}
+
+// ApplicationOffer describes an offered application.
+type ApplicationOffer struct {
+	OfferURL               string           `json:"offer-url"`
+	OfferName              string           `json:"offer-name"`
+	ApplicationName        string           `json:"application-name"`
+	ApplicationDescription string           `json:"application-description"`
+	Endpoints              []RemoteEndpoint `json:"endpoints"`
+}
+
+// ListApplicationOffersResult holds the offers of a model.
+type ListApplicationOffersResult struct {
+	Offers []ApplicationOffer `json:"offers,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ListApplicationOffersResults holds the offers of several models.
+type ListApplicationOffersResults struct {
+	Results []ListApplicationOffersResult `json:"results"`
+}
+
+// ConsumeApplicationArgs holds the offers to consume.
+type ConsumeApplicationArgs struct {
+	Args []ConsumeApplicationArg `json:"args"`
+}
+
+// ConsumeApplicationArg holds the URL of an offer to consume, and the
+// name to give the remote application in the consuming model. If the
+// alias is empty, the offer name is used.
+type ConsumeApplicationArg struct {
+	OfferURL         string `json:"offer-url"`
+	ApplicationAlias string `json:"application-alias,omitempty"`
+}
+
+// ConsumeApplicationResult holds the name of the remote application
+// added by consuming an offer.
+type ConsumeApplicationResult struct {
+	LocalName string `json:"local-name,omitempty"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// ConsumeApplicationResults holds the results of consuming offers.
+type ConsumeApplicationResults struct {
+	Results []ConsumeApplicationResult `json:"results"`
+}
+
+// RemoteApplicationStatus holds status info about a remote application.
+type RemoteApplicationStatus struct {
+	Err       error               `json:"err,omitempty"`
+	OfferURL  string              `json:"offer-url"`
+	OfferName string              `json:"offer-name"`
+	Endpoints []RemoteEndpoint    `json:"endpoints"`
+	Life      string              `json:"life"`
+	Relations map[string][]string `json:"relations"`
+	Status    DetailedStatus      `json:"status"`
+}
+
+// RemoteApplication describes a remote application standing, in the
+// model it was added to, for an application in another model.
+type RemoteApplication struct {
+	Name            string `json:"name"`
+	Life            string `json:"life"`
+	OfferURL        string `json:"offer-url,omitempty"`
+	SourceModelUUID string `json:"source-model-uuid"`
+	IsConsumerProxy bool   `json:"is-consumer-proxy"`
+}
+
+// RemoteApplicationResult holds a remote application or an error.
+type RemoteApplicationResult struct {
+	Result *RemoteApplication `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// RemoteApplicationResults holds the results of calls returning remote
+// applications.
+type RemoteApplicationResults struct {
+	Results []RemoteApplicationResult `json:"results"`
+}
+
+// RemoteRelation describes a relation between a local application and
+// a consumed remote application, together with its counterpart in the
+// offering model.
+type RemoteRelation struct {
+	Key                     string `json:"key"`
+	Life                    string `json:"life"`
+	ApplicationName         string `json:"application-name"`
+	RemoteApplicationName   string `json:"remote-application-name"`
+	OfferingModelUUID       string `json:"offering-model-uuid"`
+	OfferingRelationKey     string `json:"offering-relation-key"`
+	OfferingApplicationName string `json:"offering-application-name"`
+}
+
+// RemoteRelationResult holds a remote relation or an error.
+type RemoteRelationResult struct {
+	Result *RemoteRelation `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// RemoteRelationResults holds the results of calls returning remote
+// relations.
+type RemoteRelationResults struct {
+	Results []RemoteRelationResult `json:"results"`
+}
+
+// RemoteRelationUnitsArg identifies the units of an application in a
+// relation of a model on the controller.
+type RemoteRelationUnitsArg struct {
+	ModelUUID       string `json:"model-uuid"`
+	RelationKey     string `json:"relation-key"`
+	ApplicationName string `json:"application-name"`
+}
+
+// RemoteRelationUnitsArgs holds the arguments for watching relation
+// units across models.
+type RemoteRelationUnitsArgs struct {
+	Args []RemoteRelationUnitsArg `json:"args"`
+}
+
+// RemoteRelationArg identifies a relation of a model on the
+// controller.
+type RemoteRelationArg struct {
+	ModelUUID   string `json:"model-uuid"`
+	RelationKey string `json:"relation-key"`
+}
+
+// RemoteRelationArgs holds a set of relations of models on the
+// controller.
+type RemoteRelationArgs struct {
+	Args []RemoteRelationArg `json:"args"`
+}
+
+// RemoteRelationChange describes units of an application in a relation
+// in one model to be mirrored as remote units of the counterpart
+// relation in another model.
+type RemoteRelationChange struct {
+	// Source identifies the units whose membership and settings
+	// are mirrored.
+	Source RemoteRelationUnitsArg `json:"source"`
+
+	// TargetModelUUID and TargetRelationKey identify the relation
+	// the units are mirrored into.
+	TargetModelUUID   string `json:"target-model-uuid"`
+	TargetRelationKey string `json:"target-relation-key"`
+
+	// ChangedUnits are the units that have entered scope or whose
+	// settings have changed.
+	ChangedUnits []string `json:"changed-units,omitempty"`
+
+	// DepartedUnits are the units that have left scope.
+	DepartedUnits []string `json:"departed-units,omitempty"`
+}
+
+// RemoteRelationChanges holds a set of relation changes to mirror.
+type RemoteRelationChanges struct {
+	Changes []RemoteRelationChange `json:"changes"`
+}
@@ -262,6 +262,20 @@ type RelationUnits struct {
 	RelationUnits []RelationUnit `json:"relation-units"`
 }
 
+// RelationUnitSettled holds a relation tag, a unit tag and whether the
+// unit has run all the relation hooks due so far.
+type RelationUnitSettled struct {
+	Relation string `json:"relation"`
+	Unit     string `json:"unit"`
+	Settled  bool   `json:"settled"`
+}
+
+// RelationUnitsSettled holds the parameters for recording the relation
+// hook progress of units.
+type RelationUnitsSettled struct {
+	RelationUnits []RelationUnitSettled `json:"relation-units"`
+}
+
 // RelationIds holds multiple relation ids.
 type RelationIds struct {
 	RelationIds []int `json:"relation-ids"`
@@ -66,11 +66,13 @@ type SetMigrationStatusMessageArgs struct {
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model. It
-// also contains lists of the charms and tools used in the model.
+// also contains lists of the charms and tools used in the model, and
+// the key with which its secret config values are encrypted.
 type SerializedModel struct {
-	Bytes  []byte                 `json:"bytes"`
-	Charms []string               `json:"charms"`
-	Tools  []SerializedModelTools `json:"tools"`
+	Bytes     []byte                 `json:"bytes"`
+	Charms    []string               `json:"charms"`
+	Tools     []SerializedModelTools `json:"tools"`
+	SecretKey string                 `json:"secret-key,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
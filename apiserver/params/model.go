@@ -54,6 +54,12 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ModelRollback contains the arguments for ModelRollback client API
+// call.
+type ModelRollback struct {
+	Revision int `json:"revision"`
+}
+
 // SetModelDefaults contains the arguments for SetModelDefaults
 // client API call.
 type SetModelDefaults struct {
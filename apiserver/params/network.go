@@ -232,12 +232,15 @@ type EntitiesPorts struct {
 	Entities []EntityPort `json:"entities"`
 }
 
-// EntityPortRange holds an entity's tag, a protocol and a port range.
+// EntityPortRange holds an entity's tag, a protocol and a port range,
+// and the endpoint the port range applies to; an empty endpoint means
+// all endpoints.
 type EntityPortRange struct {
 	Tag      string `json:"tag"`
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -371,6 +374,44 @@ type UnitNetworkConfig struct {
 	BindingName string `json:"binding-name"`
 }
 
+// NetworkInfoParams holds the unit and the endpoint binding names
+// for which network info is requested from Uniter.NetworkInfo().
+type NetworkInfoParams struct {
+	Unit     string   `json:"unit"`
+	Bindings []string `json:"bindings"`
+}
+
+// InterfaceAddress holds an address and the CIDR of the subnet
+// it belongs to.
+type InterfaceAddress struct {
+	Address string `json:"value"`
+	CIDR    string `json:"cidr"`
+}
+
+// NetworkInfo holds the addresses of a single network device
+// a binding is bound to.
+type NetworkInfo struct {
+	MACAddress    string             `json:"mac-address"`
+	InterfaceName string             `json:"interface-name"`
+	Addresses     []InterfaceAddress `json:"addresses"`
+}
+
+// NetworkInfoResult holds the network info for a single binding:
+// the addresses it is bound to, the addresses other units should use
+// to reach it, and the subnets its outgoing traffic comes from.
+type NetworkInfoResult struct {
+	Error            *Error        `json:"error,omitempty"`
+	Info             []NetworkInfo `json:"network-info,omitempty"`
+	IngressAddresses []string      `json:"ingress-addresses,omitempty"`
+	EgressSubnets    []string      `json:"egress-subnets,omitempty"`
+}
+
+// NetworkInfoResults holds the network info results keyed
+// by binding name.
+type NetworkInfoResults struct {
+	Results map[string]NetworkInfoResult `json:"results"`
+}
+
 // MachineAddresses holds an machine tag and addresses.
 type MachineAddresses struct {
 	Tag       string    `json:"tag"`
@@ -404,11 +445,13 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags, and the endpoint it was opened
+// for; an empty endpoint means all endpoints.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoint    string    `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -129,6 +129,33 @@ type DestroyRelation struct {
 	Endpoints []string `json:"endpoints"`
 }
 
+// RelationSuspendedArg holds the parameters for suspending or resuming
+// a relation.
+type RelationSuspendedArg struct {
+	RelationId int    `json:"relation-id"`
+	Suspended  bool   `json:"suspended"`
+	Message    string `json:"message,omitempty"`
+}
+
+// RelationSuspendedArgs holds the parameters for making the
+// SetRelationsSuspended call.
+type RelationSuspendedArgs struct {
+	Args []RelationSuspendedArg `json:"args"`
+}
+
+// ApplicationEndpointBindings holds the new endpoint bindings for
+// an application.
+type ApplicationEndpointBindings struct {
+	ApplicationTag string            `json:"application-tag"`
+	Bindings       map[string]string `json:"bindings"`
+}
+
+// ApplicationsEndpointBindings holds the parameters for making the
+// SetEndpointBindings call.
+type ApplicationsEndpointBindings struct {
+	Args []ApplicationEndpointBindings `json:"args"`
+}
+
 // AddCharm holds the arguments for making an AddCharm API call.
 type AddCharm struct {
 	URL     string `json:"url"`
@@ -223,6 +250,7 @@ type ApplicationDeploy struct {
 	Constraints      constraints.Value              `json:"constraints"`
 	Placement        []*instance.Placement          `json:"placement,omitempty"`
 	Storage          map[string]storage.Constraints `json:"storage,omitempty"`
+	AttachStorage    []string                       `json:"attach-storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
 }
@@ -255,11 +283,32 @@ type ApplicationSetCharm struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resource-ids,omitempty"`
+	// BatchSize, when non-zero, limits the number of units that
+	// upgrade to the new charm at the same time.
+	BatchSize int `json:"batch-size,omitempty"`
+	// WaitForActive holds back the next batch of units until the
+	// workload status of the upgraded units returns to active.
+	WaitForActive bool `json:"wait-for-active,omitempty"`
+	// PauseOnError pauses a rolling upgrade when an upgraded unit
+	// goes into an error state.
+	PauseOnError bool `json:"pause-on-error,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ToCIDRs, if non-empty, restricts access to the application's
+	// opened ports to the specified source CIDRs.
+	ToCIDRs []string `json:"to-cidrs,omitempty"`
+
+	// ToSpaces, if non-empty, restricts access to the application's
+	// opened ports to the subnets in the specified spaces.
+	ToSpaces []string `json:"to-spaces,omitempty"`
+
+	// Endpoints, if non-empty, restricts the exposed ports to those
+	// opened for the specified endpoints or for all endpoints.
+	Endpoints []string `json:"endpoints,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -277,10 +326,125 @@ type ApplicationUnset struct {
 	Options         []string `json:"options"`
 }
 
+// ApplicationConfigRollback holds the parameters for making the
+// application RollbackConfig call.
+type ApplicationConfigRollback struct {
+	ApplicationName string `json:"application"`
+	Revision        int    `json:"revision"`
+}
+
+// ApplicationSecretConfigKeys holds the parameters for making the
+// application SetSecretConfigKeys call.
+type ApplicationSecretConfigKeys struct {
+	ApplicationName string   `json:"application"`
+	Keys            []string `json:"keys"`
+}
+
+// ApplicationAutoUpgrade holds the parameters for making the
+// application SetAutoUpgradePolicy call.
+type ApplicationAutoUpgrade struct {
+	ApplicationName string `json:"application"`
+	// Mode is one of "off", "patch-channel" or "channel".
+	Mode string `json:"mode"`
+	// MaintenanceWindow, if set, restricts automatic upgrades to a
+	// daily UTC window of the form "HH:MM-HH:MM".
+	MaintenanceWindow string `json:"maintenance-window,omitempty"`
+}
+
+// ApplicationSetSeries holds the parameters for making the
+// application SetSeries call.
+type ApplicationSetSeries struct {
+	ApplicationName string `json:"application"`
+	Series          string `json:"series"`
+	// CharmURL, if set, names the charm to switch the application to
+	// for the new series. A charm store charm is added to the model
+	// if it is not already there.
+	CharmURL string `json:"charm-url,omitempty"`
+	// Force allows a series that the charm does not declare support
+	// for.
+	Force bool `json:"force,omitempty"`
+}
+
+// UpgradeSeriesArg holds the parameters for preparing a machine for
+// an upgrade of its series.
+type UpgradeSeriesArg struct {
+	Entity Entity `json:"entity"`
+	Series string `json:"series"`
+	Force  bool   `json:"force,omitempty"`
+}
+
+// UpgradeSeriesArgs holds the parameters for making the
+// MachineManager PrepareUpgradeSeries call.
+type UpgradeSeriesArgs struct {
+	Args []UpgradeSeriesArg `json:"args"`
+}
+
+// UpgradeSeriesInfo describes the progress of a series upgrade of a
+// machine.
+type UpgradeSeriesInfo struct {
+	// Status is one of UpgradeSeriesNotStarted,
+	// UpgradeSeriesPrepared or UpgradeSeriesCompleted.
+	Status UpgradeSeriesStatus `json:"status"`
+	// Series is the series the machine runs.
+	Series string `json:"series"`
+	// TargetSeries is the series the machine is being upgraded to,
+	// while it is prepared for the upgrade.
+	TargetSeries string `json:"target-series,omitempty"`
+	// Units holds the names of the units on the machine.
+	Units []string `json:"units,omitempty"`
+}
+
+// UpgradeSeriesInfoResult holds the result of an UpgradeSeriesInfo
+// call for a single machine.
+type UpgradeSeriesInfoResult struct {
+	Result *UpgradeSeriesInfo `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// UpgradeSeriesInfoResults holds the results of an
+// UpgradeSeriesInfo call.
+type UpgradeSeriesInfoResults struct {
+	Results []UpgradeSeriesInfoResult `json:"results"`
+}
+
+// Config change types reported in a ConfigChange.
+const (
+	ConfigAdded    = "added"
+	ConfigModified = "modified"
+	ConfigDeleted  = "deleted"
+)
+
+// ConfigChange describes a change made to a single configuration key.
+type ConfigChange struct {
+	Key      string      `json:"key"`
+	Type     string      `json:"type"`
+	OldValue interface{} `json:"old-value,omitempty"`
+	NewValue interface{} `json:"new-value,omitempty"`
+}
+
+// ConfigRevision describes a change made to application or model
+// configuration, as recorded in its history.
+type ConfigRevision struct {
+	Revision int            `json:"revision"`
+	User     string         `json:"user,omitempty"`
+	Time     time.Time      `json:"time"`
+	Changes  []ConfigChange `json:"changes"`
+}
+
+// ConfigHistoryResult holds the recorded history of changes made to
+// application or model configuration, most recent first.
+type ConfigHistoryResult struct {
+	History []ConfigRevision `json:"history"`
+}
+
 // ApplicationGet holds parameters for making the Get or
 // GetCharmURL calls.
 type ApplicationGet struct {
 	ApplicationName string `json:"application"`
+
+	// ShowSecrets requests that the values of secret settings be
+	// revealed, which only model administrators may do.
+	ShowSecrets bool `json:"show-secrets,omitempty"`
 }
 
 // ApplicationGetResults holds results of the application Get call.
@@ -362,6 +526,7 @@ type AddApplicationUnits struct {
 	ApplicationName string                `json:"application"`
 	NumUnits        int                   `json:"num-units"`
 	Placement       []*instance.Placement `json:"placement"`
+	AttachStorage   []string              `json:"attach-storage,omitempty"`
 }
 
 // DestroyApplicationUnits holds parameters for the DestroyUnits call.
@@ -488,6 +653,10 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretConfigKey is the key with which secret config values
+	// are encrypted. It is kept out of the database that holds
+	// the values.
+	SecretConfigKey string `json:"secret-config-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
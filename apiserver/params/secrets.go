@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// CreateSecretArgs holds the arguments for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the arguments for creating a secret owned by
+// the application of the given unit.
+type CreateSecretArg struct {
+	UnitTag        string            `json:"unit-tag"`
+	Label          string            `json:"label,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	RotateInterval time.Duration     `json:"rotate-interval,omitempty"`
+	Data           map[string]string `json:"data"`
+}
+
+// UpdateSecretArgs holds the arguments for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the arguments for adding a new revision to a
+// secret owned by the application of the given unit.
+type UpdateSecretArg struct {
+	UnitTag string            `json:"unit-tag"`
+	ID      string            `json:"id"`
+	Data    map[string]string `json:"data"`
+}
+
+// GetSecretArgs holds the arguments for reading secrets.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// GetSecretArg identifies a revision of a secret to be read on behalf
+// of the given unit. A revision of zero means the latest revision.
+type GetSecretArg struct {
+	UnitTag  string `json:"unit-tag"`
+	ID       string `json:"id"`
+	Revision int    `json:"revision,omitempty"`
+}
+
+// SecretValueResults holds the results of reading secrets.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the content of a secret, or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantSecretArgs holds the arguments for granting or revoking access
+// to secrets.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// GrantSecretArg identifies a secret owned by the application of the
+// given unit, and the relation whose other application is to be granted
+// access to it, or have its access revoked.
+type GrantSecretArg struct {
+	UnitTag     string `json:"unit-tag"`
+	ID          string `json:"id"`
+	RelationTag string `json:"relation-tag"`
+}
+
+// SecretRevision identifies a revision of a secret.
+type SecretRevision struct {
+	ID       string `json:"id"`
+	Revision int    `json:"revision"`
+}
+
+// SecretRevisionsResults holds the results of a SecretRevisions call.
+type SecretRevisionsResults struct {
+	Results []SecretRevisionsResult `json:"results"`
+}
+
+// SecretRevisionsResult holds the latest revisions of the secrets a
+// unit's application has been granted access to and, if the unit is
+// the leader, of the secrets its application owns that are due to be
+// rotated.
+type SecretRevisionsResult struct {
+	Granted  []SecretRevision `json:"granted,omitempty"`
+	ToRotate []SecretRevision `json:"to-rotate,omitempty"`
+	Error    *Error           `json:"error,omitempty"`
+}
+
+// ListSecretsArgs holds the arguments for listing secrets. An empty
+// owner lists the secrets of all applications.
+type ListSecretsArgs struct {
+	Owner string `json:"owner,omitempty"`
+}
+
+// SecretIDs holds the IDs of secrets.
+type SecretIDs struct {
+	IDs []string `json:"ids"`
+}
+
+// ListSecretResults holds the metadata of a set of secrets.
+type ListSecretResults struct {
+	Results []SecretMetadata `json:"results"`
+}
+
+// SecretMetadataResults holds the results of a ShowSecrets call.
+type SecretMetadataResults struct {
+	Results []SecretMetadataResult `json:"results"`
+}
+
+// SecretMetadataResult holds the metadata of a secret, or an error.
+type SecretMetadataResult struct {
+	Result *SecretMetadata `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// SecretMetadata describes a secret, without its content.
+type SecretMetadata struct {
+	ID             string        `json:"id"`
+	Owner          string        `json:"owner"`
+	Label          string        `json:"label,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	Revision       int           `json:"revision"`
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `json:"next-rotate-time,omitempty"`
+	CreateTime     time.Time     `json:"create-time"`
+	UpdateTime     time.Time     `json:"update-time"`
+	Grants         []SecretGrant `json:"grants,omitempty"`
+}
+
+// SecretGrant describes the access to a secret granted to an
+// application through a relation.
+type SecretGrant struct {
+	Application string `json:"application"`
+	Relation    string `json:"relation"`
+}
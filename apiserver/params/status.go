@@ -15,16 +15,21 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// IncludeRelationUnits, if true, requests that the status of
+	// each unit participating in a relation be reported.
+	IncludeRelationUnits bool `json:"include-relation-units,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
 
 // FullStatus holds information about the status of a juju model.
 type FullStatus struct {
-	Model        ModelStatusInfo              `json:"model"`
-	Machines     map[string]MachineStatus     `json:"machines"`
-	Applications map[string]ApplicationStatus `json:"applications"`
-	Relations    []RelationStatus             `json:"relations"`
+	Model              ModelStatusInfo                    `json:"model"`
+	Machines           map[string]MachineStatus           `json:"machines"`
+	Applications       map[string]ApplicationStatus       `json:"applications"`
+	RemoteApplications map[string]RemoteApplicationStatus `json:"remote-applications"`
+	Relations          []RelationStatus                   `json:"relations"`
 }
 
 // ModelStatusInfo holds status information about the model itself.
@@ -98,8 +103,52 @@ type RelationStatus struct {
 	Interface string           `json:"interface"`
 	Scope     string           `json:"scope"`
 	Endpoints []EndpointStatus `json:"endpoints"`
+
+	// Suspended and SuspendedReason record whether the relation has
+	// been suspended, and why.
+	Suspended       bool   `json:"suspended,omitempty"`
+	SuspendedReason string `json:"suspended-reason,omitempty"`
+
+	// Life and Units are only populated when relation units
+	// were requested in StatusParams.
+	Life  string               `json:"life,omitempty"`
+	Units []RelationUnitStatus `json:"units,omitempty"`
+}
+
+// RelationUnitStatus holds status info about a unit's participation
+// in a relation.
+type RelationUnitStatus struct {
+	UnitName string `json:"unit"`
+	Endpoint string `json:"endpoint"`
+	// Status is one of the RelationUnit* status values.
+	Status string `json:"status"`
+	Info   string `json:"info,omitempty"`
 }
 
+// The possible values of RelationUnitStatus.Status.
+const (
+	// RelationUnitJoined indicates that the unit has entered the
+	// relation's scope and run the relation hooks due so far.
+	RelationUnitJoined = "joined"
+
+	// RelationUnitPending indicates that the unit has not yet
+	// entered the relation's scope, or still has relation-joined
+	// or relation-changed hooks to run.
+	RelationUnitPending = "pending"
+
+	// RelationUnitExecuting indicates that the unit is running a
+	// hook for the relation.
+	RelationUnitExecuting = "executing"
+
+	// RelationUnitDeparting indicates that the unit is in the
+	// process of leaving the relation's scope.
+	RelationUnitDeparting = "departing"
+
+	// RelationUnitError indicates that a hook for the relation
+	// failed on the unit.
+	RelationUnitError = "error"
+)
+
 // EndpointStatus holds status info about a single endpoint
 type EndpointStatus struct {
 	ApplicationName string `json:"application"`
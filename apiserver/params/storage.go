@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,7 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+	Size     uint64      `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -210,6 +215,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -270,6 +276,62 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeSnapshotIds holds a set of volume snapshot IDs.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the ID of the snapshot in the model.
+	Id string `json:"id"`
+
+	// VolumeId is the provider-allocated ID of the volume to snapshot.
+	VolumeId string `json:"volume-id"`
+
+	// Volume holds the parameters of the volume to snapshot.
+	Volume VolumeParams `json:"volume"`
+}
+
+// VolumeSnapshotParamsResult holds the parameters for taking a
+// volume snapshot, or an error.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds the parameters for taking
+// multiple volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot that has been taken.
+type VolumeSnapshotInfo struct {
+	Id         string `json:"id"`
+	SnapshotId string `json:"snapshot-id"`
+	Size       uint64 `json:"size"`
+}
+
+// VolumeSnapshotInfos holds information about multiple volume snapshots.
+type VolumeSnapshotInfos struct {
+	Snapshots []VolumeSnapshotInfo `json:"snapshots"`
+}
+
+// VolumeSnapshotStatus holds the status to set for a volume snapshot.
+type VolumeSnapshotStatus struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	Info   string `json:"info"`
+}
+
+// VolumeSnapshotStatuses holds the statuses to set for multiple
+// volume snapshots.
+type VolumeSnapshotStatuses struct {
+	Statuses []VolumeSnapshotStatus `json:"statuses"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -476,6 +538,12 @@ type StoragePool struct {
 	Attrs map[string]interface{} `json:"attrs"`
 }
 
+// StoragePoolDeleteArg holds the name of a storage pool to delete.
+type StoragePoolDeleteArg struct {
+	// Name is the pool's name.
+	Name string `json:"name"`
+}
+
 // StoragePoolFilter holds a filter for matching storage pools.
 type StoragePoolFilter struct {
 	// Names are pool's names to filter on.
@@ -668,3 +736,134 @@ type StorageAddParams struct {
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// ResizeStorage holds the parameters for resizing storage instances.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for resizing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to resize.
+	Tag string `json:"tag"`
+
+	// Size is the new size of the storage instance in MiB. Storage
+	// instances can be grown, but not shrunk.
+	Size uint64 `json:"size"`
+}
+
+// RemoveStorage holds the parameters for removing storage from the model.
+type RemoveStorage struct {
+	Storage []RemoveStorageInstance `json:"storage"`
+}
+
+// RemoveStorageInstance holds the parameters for removing a storage
+// instance from the model.
+type RemoveStorageInstance struct {
+	// Tag is the tag of the storage instance to remove.
+	Tag string `json:"tag"`
+
+	// Release, if true, causes the storage instance to be removed
+	// from the model while the volume or filesystem backing it is
+	// retained. Otherwise the backing volume or filesystem will be
+	// destroyed along with the storage instance.
+	Release bool `json:"release,omitempty"`
+}
+
+// VolumeSnapshotFilter holds a filter for the volume snapshot list
+// API call.
+type VolumeSnapshotFilter struct {
+	// StorageTags are storage instance tags to filter on.
+	StorageTags []string `json:"storage-tags,omitempty"`
+}
+
+// VolumeSnapshotFilters holds a collection of volume snapshot filters.
+type VolumeSnapshotFilters struct {
+	Filters []VolumeSnapshotFilter `json:"filters,omitempty"`
+}
+
+// VolumeSnapshotDetails describes a volume snapshot in the model for
+// the purpose of storage CLI commands.
+type VolumeSnapshotDetails struct {
+	// Id is the ID of the snapshot in the model.
+	Id string `json:"id"`
+
+	// StorageTag is the tag of the storage instance whose volume
+	// was snapshotted, if any.
+	StorageTag string `json:"storage-tag,omitempty"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// SnapshotId is the provider-allocated ID of the snapshot,
+	// if it has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Size is the size of the snapshot in MiB, if it has been taken.
+	Size uint64 `json:"size,omitempty"`
+
+	// Created is the time at which the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// Status contains the status of the snapshot.
+	Status EntityStatus `json:"status"`
+}
+
+// VolumeSnapshotDetailsListResult holds a collection of volume
+// snapshot details.
+type VolumeSnapshotDetailsListResult struct {
+	Result []VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsListResults holds a collection of collections
+// of volume snapshot details.
+type VolumeSnapshotDetailsListResults struct {
+	Results []VolumeSnapshotDetailsListResult `json:"results,omitempty"`
+}
+
+// BulkImportStorageParams contains the parameters for importing a
+// collection of storage entities.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageParams contains the parameters for importing a storage
+// entity.
+type ImportStorageParams struct {
+	// Kind is the kind of the storage entity to import.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool into which the storage
+	// entity will be imported.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's unique ID for the
+	// storage entity, e.g. the EBS volume ID.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the storage to assign to the
+	// entity.
+	StorageName string `json:"storage-name"`
+}
+
+// ImportStorageResults contains the results of importing a collection
+// of storage entities.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// ImportStorageResult contains the result of importing a storage entity.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageDetails contains the details of an imported storage
+// entity.
+type ImportStorageDetails struct {
+	// StorageTag contains the string representation of the storage
+	// tag assigned to the imported storage entity.
+	StorageTag string `json:"storage-tag"`
+}
@@ -0,0 +1,543 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides the API server facade used by the
+// remote relations worker to mirror relations with remote applications
+// into the models offering them.
+package remoterelations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("RemoteRelations", 1, NewAPI)
+}
+
+// API implements the RemoteRelations facade.
+type API struct {
+	st        *state.State
+	resources facade.Resources
+}
+
+// NewAPI returns a new RemoteRelations API facade.
+func NewAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:        st,
+		resources: resources,
+	}, nil
+}
+
+// WatchRemoteApplications returns a strings watcher that notifies of
+// the addition, removal, and lifecycle changes of remote applications
+// in the model.
+func (api *API) WatchRemoteApplications() (params.StringsWatchResult, error) {
+	w := api.st.WatchRemoteApplications()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
+// RemoteApplications returns the given remote applications of the
+// model.
+func (api *API) RemoteApplications(args params.Entities) (params.RemoteApplicationResults, error) {
+	result := params.RemoteApplicationResults{
+		Results: make([]params.RemoteApplicationResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		app, err := api.remoteApplication(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = &params.RemoteApplication{
+			Name:            app.Name(),
+			Life:            app.Life().String(),
+			OfferURL:        app.URL(),
+			SourceModelUUID: app.SourceModel().Id(),
+			IsConsumerProxy: app.IsConsumerProxy(),
+		}
+	}
+	return result, nil
+}
+
+// WatchRemoteApplicationRelations returns a strings watcher for each
+// of the given remote applications, notifying of changes to the
+// lifecycles of the relations they take part in.
+func (api *API) WatchRemoteApplicationRelations(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		app, err := api.remoteApplication(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := app.WatchRelations()
+		changes, ok := <-w.Changes()
+		if !ok {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		result.Results[i].StringsWatcherId = api.resources.Register(w)
+		result.Results[i].Changes = changes
+	}
+	return result, nil
+}
+
+func (api *API) remoteApplication(tag string) (*state.RemoteApplication, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.st.RemoteApplication(appTag.Id())
+}
+
+// ExportRelations ensures that each of the given relations between a
+// local application and a consumed remote application has a
+// counterpart in the model offering the remote application, relating
+// the offered application to a proxy for the local application. The
+// details of both relations are returned.
+//
+// Relations that are no longer alive are not exported; their details
+// are returned with the life of the relation.
+func (api *API) ExportRelations(args params.Entities) (params.RemoteRelationResults, error) {
+	result := params.RemoteRelationResults{
+		Results: make([]params.RemoteRelationResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		remoteRelation, err := api.exportRelation(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = remoteRelation
+	}
+	return result, nil
+}
+
+func (api *API) exportRelation(tag string) (*params.RemoteRelation, error) {
+	relTag, err := names.ParseRelationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rel, err := api.st.KeyRelation(relTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var localEp, remoteEp *state.Endpoint
+	var remoteApp *state.RemoteApplication
+	for _, ep := range rel.Endpoints() {
+		ep := ep
+		app, err := api.st.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			localEp = &ep
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		remoteEp, remoteApp = &ep, app
+	}
+	if remoteApp == nil || localEp == nil {
+		return nil, errors.NotValidf("relation %q without a remote application", rel)
+	}
+	if remoteApp.IsConsumerProxy() {
+		return nil, errors.NotSupportedf("exporting relation %q with consumer %q", rel, remoteApp.Name())
+	}
+
+	offeringSt, err := api.st.ForModel(remoteApp.SourceModel())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer offeringSt.Close()
+	offer, err := offeringSt.ApplicationOffer(remoteApp.OfferName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offeredEpName, ok := offer.Endpoints[remoteEp.Name]
+	if !ok {
+		return nil, errors.NotFoundf("endpoint %q of offer %q", remoteEp.Name, offer.OfferName)
+	}
+	result := &params.RemoteRelation{
+		Key:                     rel.String(),
+		Life:                    rel.Life().String(),
+		ApplicationName:         localEp.ApplicationName,
+		RemoteApplicationName:   remoteApp.Name(),
+		OfferingModelUUID:       offeringSt.ModelUUID(),
+		OfferingApplicationName: offer.ApplicationName,
+	}
+	offeredApp, err := offeringSt.Application(offer.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offeredEp, err := offeredApp.Endpoint(offeredEpName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Relations that are going away are reported with their existing
+	// counterparts, if any, so that those can be destroyed too.
+	alive := rel.Life() == state.Alive
+	proxy, err := api.consumerProxy(offeringSt, localEp.ApplicationName, alive)
+	if errors.IsNotFound(err) && !alive {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	proxyEp, err := proxy.Endpoint(localEp.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offeringRel, err := offeringSt.EndpointsRelation(offeredEp, proxyEp)
+	if errors.IsNotFound(err) {
+		if !alive {
+			return result, nil
+		}
+		offeringRel, err = offeringSt.AddRelation(offeredEp, proxyEp)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot relate %q to offered application %q", localEp.ApplicationName, offer.ApplicationName)
+	}
+	result.OfferingRelationKey = offeringRel.String()
+	return result, nil
+}
+
+// consumerProxy returns the remote application standing, in the
+// offering model, for the named application of the API's model. If
+// create is true, the remote application is added if needed.
+func (api *API) consumerProxy(offeringSt *state.State, appName string, create bool) (*state.RemoteApplication, error) {
+	proxyName := consumerProxyName(api.st.ModelUUID(), appName)
+	proxy, err := offeringSt.RemoteApplication(proxyName)
+	if err == nil || !errors.IsNotFound(err) || !create {
+		return proxy, err
+	}
+	app, err := api.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps, err := app.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var endpoints []charm.Relation
+	for _, ep := range eps {
+		if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+			continue
+		}
+		endpoints = append(endpoints, ep.Relation)
+	}
+	proxy, err = offeringSt.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:            proxyName,
+		OfferName:       appName,
+		SourceModel:     api.st.ModelTag(),
+		Endpoints:       endpoints,
+		IsConsumerProxy: true,
+	})
+	if errors.IsAlreadyExists(errors.Cause(err)) {
+		return offeringSt.RemoteApplication(proxyName)
+	}
+	return proxy, errors.Trace(err)
+}
+
+// consumerProxyName returns the name of the remote application
+// standing for the named application of the given model in the models
+// offering applications it consumes.
+func consumerProxyName(modelUUID, appName string) string {
+	hash := sha256.Sum256([]byte(modelUUID + "/" + appName))
+	return "remote-" + hex.EncodeToString(hash[:16])
+}
+
+// DestroyRelations destroys the given relations of models offering
+// applications consumed by the API's model, once their counterparts in
+// the API's model are no longer alive. The proxies for the consuming
+// applications are destroyed with their last relation.
+func (api *API) DestroyRelations(args params.RemoteRelationArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.destroyRelation(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) destroyRelation(arg params.RemoteRelationArg) error {
+	if arg.ModelUUID == api.st.ModelUUID() {
+		return common.ErrPerm
+	}
+	offeringSt, closer, err := api.modelState(arg.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+	rel, err := offeringSt.KeyRelation(arg.RelationKey)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ep := range rel.Endpoints() {
+		proxy, err := offeringSt.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !proxy.IsConsumerProxy() || proxy.SourceModel() != api.st.ModelTag() {
+			return common.ErrPerm
+		}
+		rels, err := proxy.Relations()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(rels) == 1 {
+			return errors.Trace(proxy.Destroy())
+		}
+		return errors.Trace(rel.Destroy())
+	}
+	return common.ErrPerm
+}
+
+// WatchRelationUnits returns a relation units watcher for each of the
+// given applications' units in relations of the API's model, or of the
+// models offering applications it consumes.
+func (api *API) WatchRelationUnits(args params.RemoteRelationUnitsArgs) (params.RelationUnitsWatchResults, error) {
+	result := params.RelationUnitsWatchResults{
+		Results: make([]params.RelationUnitsWatchResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		w, err := api.watchRelationUnits(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		changes, ok := <-w.Changes()
+		if !ok {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		result.Results[i].RelationUnitsWatcherId = api.resources.Register(w)
+		result.Results[i].Changes = changes
+	}
+	return result, nil
+}
+
+func (api *API) watchRelationUnits(arg params.RemoteRelationUnitsArg) (state.RelationUnitsWatcher, error) {
+	st, closer, err := api.modelState(arg.ModelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rel, err := st.KeyRelation(arg.RelationKey)
+	if err != nil {
+		closer()
+		return nil, errors.Trace(err)
+	}
+	w, err := rel.WatchApplicationUnits(arg.ApplicationName)
+	if err != nil {
+		closer()
+		return nil, errors.Trace(err)
+	}
+	return &relationUnitsWatcher{w, closer}, nil
+}
+
+// relationUnitsWatcher releases the state of the model it watches
+// when stopped.
+type relationUnitsWatcher struct {
+	state.RelationUnitsWatcher
+	closer func()
+}
+
+// Stop is part of the state.Watcher interface.
+func (w *relationUnitsWatcher) Stop() error {
+	err := w.RelationUnitsWatcher.Stop()
+	w.closer()
+	return err
+}
+
+// PublishRelationChanges mirrors units of applications in relations,
+// together with their settings, as remote units of the counterpart
+// relations in other models.
+func (api *API) PublishRelationChanges(args params.RemoteRelationChanges) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, change := range args.Changes {
+		err := api.publishRelationChange(change)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) publishRelationChange(change params.RemoteRelationChange) error {
+	sourceSt, closeSource, err := api.modelState(change.Source.ModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeSource()
+	targetSt, closeTarget, err := api.modelState(change.TargetModelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeTarget()
+
+	targetRel, err := targetSt.KeyRelation(change.TargetRelationKey)
+	if errors.IsNotFound(err) && len(change.ChangedUnits) == 0 {
+		// The relation was removed once all its units departed.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	targetApp, err := remoteApplicationName(targetSt, targetRel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, unitName := range change.DepartedUnits {
+		ru, err := targetRel.RemoteUnit(mirroredUnitName(unitName, targetApp))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(change.ChangedUnits) == 0 {
+		return nil
+	}
+	sourceRel, err := sourceSt.KeyRelation(change.Source.RelationKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := sourceRel.Endpoint(change.Source.ApplicationName); err != nil {
+		return errors.Trace(err)
+	}
+	for _, unitName := range change.ChangedUnits {
+		if app, err := names.UnitApplication(unitName); err != nil {
+			return errors.Trace(err)
+		} else if app != change.Source.ApplicationName {
+			return errors.NotValidf("unit %q of application %q", unitName, change.Source.ApplicationName)
+		}
+		unit, err := sourceSt.Unit(unitName)
+		if errors.IsNotFound(err) {
+			// The unit's departure will follow.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		sourceRU, err := sourceRel.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sourceSettings, err := sourceRU.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ru, err := targetRel.RemoteUnit(mirroredUnitName(unitName, targetApp))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := replaceRemoteUnitSettings(ru, sourceSettings.Map()); err != nil {
+			return errors.Annotatef(err, "cannot publish settings of unit %q", unitName)
+		}
+	}
+	return nil
+}
+
+// replaceRemoteUnitSettings enters the remote unit into the scope of
+// its relation with the given settings, or replaces its settings if it
+// is already in scope.
+func replaceRemoteUnitSettings(ru *state.RelationUnit, settings map[string]interface{}) error {
+	inScope, err := ru.InScope()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !inScope {
+		err := ru.EnterScope(settings)
+		if err == state.ErrCannotEnterScope {
+			// The relation is going away.
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	current, err := ru.Settings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, key := range current.Keys() {
+		if _, ok := settings[key]; !ok {
+			current.Delete(key)
+		}
+	}
+	current.Update(settings)
+	_, err = current.Write()
+	return errors.Trace(err)
+}
+
+// remoteApplicationName returns the name of the remote application
+// taking part in the given relation.
+func remoteApplicationName(st *state.State, rel *state.Relation) (string, error) {
+	for _, ep := range rel.Endpoints() {
+		_, err := st.RemoteApplication(ep.ApplicationName)
+		if err == nil {
+			return ep.ApplicationName, nil
+		} else if !errors.IsNotFound(err) {
+			return "", errors.Trace(err)
+		}
+	}
+	return "", errors.NotValidf("relation %q without a remote application", rel)
+}
+
+// mirroredUnitName returns the name of the remote unit of the given
+// application standing for the named unit.
+func mirroredUnitName(unitName, appName string) string {
+	return appName + unitName[strings.Index(unitName, "/"):]
+}
+
+// modelState returns the state of the model with the given UUID, which
+// must be the API's model or a model offering applications it
+// consumes, and a function releasing it.
+func (api *API) modelState(modelUUID string) (*state.State, func(), error) {
+	if modelUUID == api.st.ModelUUID() {
+		return api.st, func() {}, nil
+	}
+	apps, err := api.st.AllRemoteApplications()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, app := range apps {
+		if app.IsConsumerProxy() || app.SourceModel().Id() != modelUUID {
+			continue
+		}
+		st, err := api.st.ForModel(app.SourceModel())
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return st, func() { st.Close() }, nil
+	}
+	return nil, nil, common.ErrPerm
+}
@@ -0,0 +1,292 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/remoterelations"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type remoteRelationsSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *remoterelations.API
+
+	offeringSt *state.State
+	wordpress  *state.Application
+	relation   *state.Relation
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:            names.NewMachineTag("0"),
+		EnvironManager: true,
+	}
+	var err error
+	s.api, err = remoterelations.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Offer mysql from the "prod" model...
+	s.offeringSt = s.Factory.MakeModel(c, &factory.ModelParams{Name: "prod"})
+	s.AddCleanup(func(*gc.C) { s.offeringSt.Close() })
+	f := factory.NewFactory(s.offeringSt)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	_, err = s.offeringSt.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// ...and relate wordpress to it in the controller model.
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "hosted-mysql",
+		OfferName:   "hosted-mysql",
+		URL:         "admin/prod.hosted-mysql",
+		SourceModel: s.offeringSt.ModelTag(),
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "database",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.wordpress = s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	eps, err := s.State.InferEndpoints("wordpress", "hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) TestNewAPIRequiresModelManager(c *gc.C) {
+	_, err := remoterelations.NewAPI(s.State, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *remoteRelationsSuite) TestWatchRemoteApplications(c *gc.C) {
+	result, err := s.api.WatchRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Changes, jc.DeepEquals, []string{"hosted-mysql"})
+	c.Check(s.resources.Get(result.StringsWatcherId), gc.NotNil)
+}
+
+func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
+	results, err := s.api.RemoteApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-hosted-mysql"},
+			{Tag: "application-unknown"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Result, jc.DeepEquals, &params.RemoteApplication{
+		Name:            "hosted-mysql",
+		Life:            "alive",
+		OfferURL:        "admin/prod.hosted-mysql",
+		SourceModelUUID: s.offeringSt.ModelUUID(),
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `remote application "unknown" not found`)
+}
+
+func (s *remoteRelationsSuite) exportRelation(c *gc.C) *params.RemoteRelation {
+	results, err := s.api.ExportRelations(params.Entities{
+		Entities: []params.Entity{{Tag: s.relation.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return results.Results[0].Result
+}
+
+func (s *remoteRelationsSuite) TestExportRelations(c *gc.C) {
+	remoteRelation := s.exportRelation(c)
+	c.Check(remoteRelation.Key, gc.Equals, "wordpress:db hosted-mysql:database")
+	c.Check(remoteRelation.Life, gc.Equals, "alive")
+	c.Check(remoteRelation.ApplicationName, gc.Equals, "wordpress")
+	c.Check(remoteRelation.RemoteApplicationName, gc.Equals, "hosted-mysql")
+	c.Check(remoteRelation.OfferingModelUUID, gc.Equals, s.offeringSt.ModelUUID())
+	c.Check(remoteRelation.OfferingApplicationName, gc.Equals, "mysql")
+
+	offeringRel, err := s.offeringSt.KeyRelation(remoteRelation.OfferingRelationKey)
+	c.Assert(err, jc.ErrorIsNil)
+	proxyName := s.remoteApplicationName(c, offeringRel)
+	proxy, err := s.offeringSt.RemoteApplication(proxyName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(proxy.IsConsumerProxy(), jc.IsTrue)
+	c.Check(proxy.SourceModel(), gc.Equals, s.State.ModelTag())
+
+	// Exporting again reuses the proxy and the relation.
+	again := s.exportRelation(c)
+	c.Check(again, jc.DeepEquals, remoteRelation)
+	apps, err := s.offeringSt.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(apps, gc.HasLen, 1)
+}
+
+func (s *remoteRelationsSuite) remoteApplicationName(c *gc.C, rel *state.Relation) string {
+	for _, ep := range rel.Endpoints() {
+		if ep.ApplicationName != "mysql" {
+			return ep.ApplicationName
+		}
+	}
+	c.Fatalf("no remote application in relation %q", rel)
+	return ""
+}
+
+func (s *remoteRelationsSuite) TestPublishRelationChanges(c *gc.C) {
+	remoteRelation := s.exportRelation(c)
+	offeringRel, err := s.offeringSt.KeyRelation(remoteRelation.OfferingRelationKey)
+	c.Assert(err, jc.ErrorIsNil)
+	proxyName := s.remoteApplicationName(c, offeringRel)
+
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := s.relation.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"user": "wp", "database": "blog"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	change := params.RemoteRelationChange{
+		Source: params.RemoteRelationUnitsArg{
+			ModelUUID:       s.State.ModelUUID(),
+			RelationKey:     remoteRelation.Key,
+			ApplicationName: "wordpress",
+		},
+		TargetModelUUID:   s.offeringSt.ModelUUID(),
+		TargetRelationKey: remoteRelation.OfferingRelationKey,
+		ChangedUnits:      []string{"wordpress/0"},
+	}
+	s.publish(c, change)
+	mirrored, err := offeringRel.RemoteUnit(proxyName + "/0")
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := mirrored.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(inScope, jc.IsTrue)
+	s.checkSettings(c, mirrored, map[string]interface{}{"user": "wp", "database": "blog"})
+
+	// Settings changes replace the mirrored settings.
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Delete("database")
+	settings.Set("user", "wordpress")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	s.publish(c, change)
+	s.checkSettings(c, mirrored, map[string]interface{}{"user": "wordpress"})
+
+	change.ChangedUnits = nil
+	change.DepartedUnits = []string{"wordpress/0"}
+	s.publish(c, change)
+	inScope, err = mirrored.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(inScope, jc.IsFalse)
+}
+
+func (s *remoteRelationsSuite) publish(c *gc.C, change params.RemoteRelationChange) {
+	results, err := s.api.PublishRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{change},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) checkSettings(c *gc.C, ru *state.RelationUnit, expected map[string]interface{}) {
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings.Map(), jc.DeepEquals, expected)
+}
+
+func (s *remoteRelationsSuite) TestPublishRelationChangesUnknownModel(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	results, err := s.api.PublishRelationChanges(params.RemoteRelationChanges{
+		Changes: []params.RemoteRelationChange{{
+			Source: params.RemoteRelationUnitsArg{
+				ModelUUID:       otherSt.ModelUUID(),
+				RelationKey:     "wordpress:db mysql:server",
+				ApplicationName: "wordpress",
+			},
+			TargetModelUUID:   s.State.ModelUUID(),
+			TargetRelationKey: s.relation.String(),
+			ChangedUnits:      []string{"wordpress/0"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *remoteRelationsSuite) TestWatchRelationUnits(c *gc.C) {
+	remoteRelation := s.exportRelation(c)
+	results, err := s.api.WatchRelationUnits(params.RemoteRelationUnitsArgs{
+		Args: []params.RemoteRelationUnitsArg{{
+			ModelUUID:       s.offeringSt.ModelUUID(),
+			RelationKey:     remoteRelation.OfferingRelationKey,
+			ApplicationName: "mysql",
+		}, {
+			ModelUUID:       s.State.ModelUUID(),
+			RelationKey:     remoteRelation.Key,
+			ApplicationName: "wordpress",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	for _, result := range results.Results {
+		c.Check(result.Error, gc.IsNil)
+		c.Check(result.Changes.Changed, gc.HasLen, 0)
+		c.Check(s.resources.Get(result.RelationUnitsWatcherId), gc.NotNil)
+	}
+}
+
+func (s *remoteRelationsSuite) TestDestroyRelations(c *gc.C) {
+	remoteRelation := s.exportRelation(c)
+	offeringRel, err := s.offeringSt.KeyRelation(remoteRelation.OfferingRelationKey)
+	c.Assert(err, jc.ErrorIsNil)
+	proxyName := s.remoteApplicationName(c, offeringRel)
+
+	results, err := s.api.DestroyRelations(params.RemoteRelationArgs{
+		Args: []params.RemoteRelationArg{{
+			ModelUUID:   s.offeringSt.ModelUUID(),
+			RelationKey: remoteRelation.OfferingRelationKey,
+		}, {
+			ModelUUID:   s.State.ModelUUID(),
+			RelationKey: remoteRelation.Key,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+
+	_, err = s.offeringSt.KeyRelation(remoteRelation.OfferingRelationKey)
+	c.Check(err, gc.ErrorMatches, `relation ".*" not found`)
+	_, err = s.offeringSt.RemoteApplication(proxyName)
+	c.Check(err, gc.ErrorMatches, `remote application ".*" not found`)
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the API server facade for inspecting the
+// metadata of the secrets owned by applications in a model. The content
+// of secrets is never exposed through this facade.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Secrets", 1, NewAPI)
+}
+
+// API implements the Secrets facade.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new Secrets API facade.
+func NewAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanAdmin() error {
+	ok, err := api.authorizer.HasPermission(description.AdminAccess, api.st.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the metadata of the secrets in the model, or of
+// those owned by the given application.
+func (api *API) ListSecrets(args params.ListSecretsArgs) (params.ListSecretResults, error) {
+	var result params.ListSecretResults
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	secrets, err := api.st.AllSecrets()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = []params.SecretMetadata{}
+	for _, secret := range secrets {
+		if args.Owner != "" && secret.Owner != args.Owner {
+			continue
+		}
+		result.Results = append(result.Results, secretMetadata(secret, nil))
+	}
+	return result, nil
+}
+
+// ShowSecrets returns the metadata, including the grants of access,
+// of the secrets with the given IDs.
+func (api *API) ShowSecrets(args params.SecretIDs) (params.SecretMetadataResults, error) {
+	result := params.SecretMetadataResults{
+		Results: make([]params.SecretMetadataResult, len(args.IDs)),
+	}
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, id := range args.IDs {
+		metadata, err := api.showSecret(id)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = metadata
+	}
+	return result, nil
+}
+
+func (api *API) showSecret(id string) (*params.SecretMetadata, error) {
+	secret, err := api.st.Secret(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	grants, err := api.st.SecretGrants(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	metadata := secretMetadata(secret, grants)
+	return &metadata, nil
+}
+
+func secretMetadata(secret *state.Secret, grants []state.SecretGrant) params.SecretMetadata {
+	metadata := params.SecretMetadata{
+		ID:             secret.ID,
+		Owner:          secret.Owner,
+		Label:          secret.Label,
+		Description:    secret.Description,
+		Revision:       secret.Revision,
+		RotateInterval: secret.RotateInterval,
+		CreateTime:     secret.CreateTime,
+		UpdateTime:     secret.UpdateTime,
+	}
+	if !secret.NextRotateTime.IsZero() {
+		next := secret.NextRotateTime
+		metadata.NextRotateTime = &next
+	}
+	for _, grant := range grants {
+		metadata.Grants = append(metadata.Grants, params.SecretGrant{
+			Application: grant.Application,
+			Relation:    grant.RelationKey,
+		})
+	}
+	return metadata
+}
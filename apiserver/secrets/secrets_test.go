@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type secretsSuite struct {
+	jujutesting.JujuConnSuite
+
+	api      *secrets.API
+	secret   *state.Secret
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	var err error
+	s.api, err = secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.relation = s.Factory.MakeRelation(c, nil)
+	s.secret, err = s.State.AddSecret(state.AddSecretArgs{
+		Owner:          "mysql",
+		Label:          "db",
+		Description:    "database password",
+		RotateInterval: time.Hour,
+		Data:           map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestListSecretsRequiresAdmin(c *gc.C) {
+	api, err := secrets.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("fred"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.ShowSecrets(params.SecretIDs{IDs: []string{s.secret.ID}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	result, err := s.api.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	metadata := result.Results[0]
+	c.Check(metadata.ID, gc.Equals, s.secret.ID)
+	c.Check(metadata.Owner, gc.Equals, "mysql")
+	c.Check(metadata.Label, gc.Equals, "db")
+	c.Check(metadata.Description, gc.Equals, "database password")
+	c.Check(metadata.Revision, gc.Equals, 1)
+	c.Check(metadata.RotateInterval, gc.Equals, time.Hour)
+	c.Check(metadata.NextRotateTime, gc.NotNil)
+
+	result, err = s.api.ListSecrets(params.ListSecretsArgs{Owner: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+}
+
+func (s *secretsSuite) TestShowSecrets(c *gc.C) {
+	err := s.State.GrantSecret(s.secret.ID, s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.ShowSecrets(params.SecretIDs{IDs: []string{s.secret.ID, "secret:99"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Result.ID, gc.Equals, s.secret.ID)
+	c.Check(result.Results[0].Result.Grants, jc.DeepEquals, []params.SecretGrant{{
+		Application: "wordpress",
+		Relation:    s.relation.String(),
+	}})
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
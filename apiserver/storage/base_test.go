@@ -84,6 +84,17 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	attachStorageCall                       = "attachStorage"
+	detachStorageCall                       = "detachStorage"
+	destroyStorageInstanceCall              = "destroyStorageInstance"
+	releaseStorageInstanceCall              = "releaseStorageInstance"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	snapshotStorageInstanceCall             = "snapshotStorageInstance"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	storageInstanceVolumeSnapshotsCall      = "storageInstanceVolumeSnapshots"
+	restoreVolumeSnapshotCall               = "restoreVolumeSnapshot"
+	addExistingFilesystemCall               = "addExistingFilesystem"
+	storagePoolInUseCall                    = "storagePoolInUse"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 )
@@ -224,6 +235,50 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			return nil
+		},
+		detachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			return nil
+		},
+		destroyStorageInstance: func(tag names.StorageTag) error {
+			s.calls = append(s.calls, destroyStorageInstanceCall)
+			return nil
+		},
+		releaseStorageInstance: func(tag names.StorageTag) error {
+			s.calls = append(s.calls, releaseStorageInstanceCall)
+			return nil
+		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.calls = append(s.calls, resizeStorageInstanceCall)
+			return nil
+		},
+		snapshotStorageInstance: func(tag names.StorageTag) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, snapshotStorageInstanceCall)
+			return &mockVolumeSnapshot{id: "0", volume: s.volumeTag, storage: &s.storageTag}, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, allVolumeSnapshotsCall)
+			return nil, nil
+		},
+		storageInstanceVolumeSnapshots: func(tag names.StorageTag) ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, storageInstanceVolumeSnapshotsCall)
+			return nil, nil
+		},
+		restoreVolumeSnapshot: func(id string) (names.StorageTag, error) {
+			s.calls = append(s.calls, restoreVolumeSnapshotCall)
+			return names.NewStorageTag("data/1"), nil
+		},
+		addExistingFilesystem: func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error) {
+			s.calls = append(s.calls, addExistingFilesystemCall)
+			return names.NewStorageTag("data/1"), nil
+		},
+		storagePoolInUse: func(name string) (bool, error) {
+			s.calls = append(s.calls, storagePoolInUseCall)
+			return false, nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -269,6 +324,22 @@ func (s *baseStorageSuite) constructPoolManager() *mockPoolManager {
 			s.pools[name] = pool
 			return pool, err
 		},
+		updatePool: func(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+			pool, ok := s.pools[name]
+			if !ok {
+				return nil, errors.NotFoundf("mock pool manager: update pool %v", name)
+			}
+			newAttrs := pool.Attrs()
+			if newAttrs == nil {
+				newAttrs = make(map[string]interface{})
+			}
+			for k, v := range attrs {
+				newAttrs[k] = v
+			}
+			pool, err := jujustorage.NewConfig(name, pool.Provider(), newAttrs)
+			s.pools[name] = pool
+			return pool, err
+		},
 		deletePool: func(name string) error {
 			delete(s.pools, name)
 			return nil
@@ -4,18 +4,23 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type mockPoolManager struct {
 	getPool    func(name string) (*jujustorage.Config, error)
 	createPool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
+	updatePool func(name string, attrs map[string]interface{}) (*jujustorage.Config, error)
 	deletePool func(name string) error
 	listPools  func() ([]*jujustorage.Config, error)
 }
@@ -28,6 +33,10 @@ func (m *mockPoolManager) Create(name string, providerType jujustorage.ProviderT
 	return m.createPool(name, providerType, attrs)
 }
 
+func (m *mockPoolManager) Update(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+	return m.updatePool(name, attrs)
+}
+
 func (m *mockPoolManager) Delete(name string) error {
 	return m.deletePool(name)
 }
@@ -60,6 +69,17 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	destroyStorageInstance              func(names.StorageTag) error
+	releaseStorageInstance              func(names.StorageTag) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	snapshotStorageInstance             func(names.StorageTag) (state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	storageInstanceVolumeSnapshots      func(names.StorageTag) ([]state.VolumeSnapshot, error)
+	restoreVolumeSnapshot               func(string) (names.StorageTag, error)
+	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	storagePoolInUse                    func(name string) (bool, error)
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -120,6 +140,14 @@ func (st *mockState) ModelTag() names.ModelTag {
 	return st.modelTag
 }
 
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (st *mockState) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
 func (st *mockState) AllVolumes() ([]state.Volume, error) {
 	return st.allVolumes()
 }
@@ -156,6 +184,54 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) AttachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.attachStorage(s, u)
+}
+
+func (st *mockState) DetachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.detachStorage(s, u)
+}
+
+func (st *mockState) DestroyStorageInstance(s names.StorageTag) error {
+	return st.destroyStorageInstance(s)
+}
+
+func (st *mockState) ReleaseStorageInstance(s names.StorageTag) error {
+	return st.releaseStorageInstance(s)
+}
+
+func (st *mockState) ResizeStorageInstance(s names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(s, size)
+}
+
+func (st *mockState) SnapshotStorageInstance(s names.StorageTag) (state.VolumeSnapshot, error) {
+	return st.snapshotStorageInstance(s)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockState) StorageInstanceVolumeSnapshots(s names.StorageTag) ([]state.VolumeSnapshot, error) {
+	return st.storageInstanceVolumeSnapshots(s)
+}
+
+func (st *mockState) RestoreVolumeSnapshot(id string) (names.StorageTag, error) {
+	return st.restoreVolumeSnapshot(id)
+}
+
+func (st *mockState) AddExistingFilesystem(
+	info state.FilesystemInfo,
+	backingVolume *state.VolumeInfo,
+	storageName string,
+) (names.StorageTag, error) {
+	return st.addExistingFilesystem(info, backingVolume, storageName)
+}
+
+func (st *mockState) StoragePoolInUse(name string) (bool, error) {
+	return st.storagePoolInUse(name)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -212,6 +288,48 @@ func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.StatusAttached}, nil
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id      string
+	volume  names.VolumeTag
+	storage *names.StorageTag
+	info    *state.VolumeSnapshotInfo
+	created time.Time
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) StorageInstance() (names.StorageTag, error) {
+	if m.storage != nil {
+		return *m.storage, nil
+	}
+	return names.StorageTag{}, errors.NewNotAssigned(nil, "error from mock")
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+}
+
+func (m *mockVolumeSnapshot) Status() (status.StatusInfo, error) {
+	if m.info != nil {
+		return status.StatusInfo{Status: status.StatusAvailable}, nil
+	}
+	return status.StatusInfo{Status: status.StatusPending}, nil
+}
+
 type mockFilesystem struct {
 	state.Filesystem
 	tag     names.FilesystemTag
@@ -282,8 +400,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -308,7 +426,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
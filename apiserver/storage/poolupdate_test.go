@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) TestUpdatePool(c *gc.C) {
+	const pname = "pname"
+	_, err := s.poolManager.Create(pname, provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	expected, _ := jujustorage.NewConfig(pname, provider.LoopProviderType, map[string]interface{}{
+		"foo": "baz",
+		"qux": "quux",
+	})
+
+	err = s.api.UpdatePool(params.StoragePool{
+		Name:  pname,
+		Attrs: map[string]interface{}{"foo": "baz", "qux": "quux"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools, err := s.poolManager.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 1)
+	c.Assert(pools[0], gc.DeepEquals, expected)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolError(c *gc.C) {
+	msg := "as expected"
+	s.baseStorageSuite.poolManager.updatePool = func(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+		return nil, errors.New(msg)
+	}
+
+	err := s.api.UpdatePool(params.StoragePool{})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
+}
+
+type poolRemoveSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolRemoveSuite{})
+
+func (s *poolRemoveSuite) TestRemovePool(c *gc.C) {
+	const pname = "pname"
+	_, err := s.poolManager.Create(pname, provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.api.RemovePool(params.StoragePoolDeleteArg{Name: pname})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCalls(c, []string{storagePoolInUseCall})
+
+	pools, err := s.poolManager.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 0)
+}
+
+func (s *poolRemoveSuite) TestRemovePoolNotFound(c *gc.C) {
+	err := s.api.RemovePool(params.StoragePoolDeleteArg{Name: "pname"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.assertCalls(c, nil)
+}
+
+func (s *poolRemoveSuite) TestRemovePoolInUse(c *gc.C) {
+	const pname = "pname"
+	_, err := s.poolManager.Create(pname, provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.state.storagePoolInUse = func(name string) (bool, error) {
+		c.Assert(name, gc.Equals, pname)
+		return true, nil
+	}
+
+	err = s.api.RemovePool(params.StoragePoolDeleteArg{Name: pname})
+	c.Assert(err, gc.ErrorMatches, `storage pool "pname" in use`)
+
+	pools, err := s.poolManager.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 1)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/poolmanager"
@@ -21,7 +22,20 @@ import (
 // *trivially* correct, you would be Doing It Wrong.
 
 func init() {
-	common.RegisterStandardFacade("Storage", 3, newAPI)
+	common.RegisterStandardFacade("Storage", 3, newAPIV3)
+	common.RegisterStandardFacade("Storage", 4, newAPI)
+}
+
+func newAPIV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV3, error) {
+	api, err := newAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV3{api}, nil
 }
 
 func newAPI(
@@ -84,6 +98,12 @@ type storageAccess interface {
 	// ModelTag is required for model permission checking.
 	ModelTag() names.ModelTag
 
+	// ModelConfig is required for storage import functionality.
+	ModelConfig() (*config.Config, error)
+
+	// ControllerTag is required for storage import functionality.
+	ControllerTag() names.ControllerTag
+
 	// AllVolumes is required for volume functionality.
 	AllVolumes() ([]state.Volume, error)
 
@@ -111,6 +131,44 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
+	// DestroyStorageInstance is required for storage remove functionality.
+	DestroyStorageInstance(names.StorageTag) error
+
+	// ReleaseStorageInstance is required for storage remove functionality.
+	ReleaseStorageInstance(names.StorageTag) error
+
+	// ResizeStorageInstance is required for storage resize functionality.
+	ResizeStorageInstance(names.StorageTag, uint64) error
+
+	// SnapshotStorageInstance is required for storage snapshot
+	// functionality.
+	SnapshotStorageInstance(names.StorageTag) (state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for storage snapshot list
+	// functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// StorageInstanceVolumeSnapshots is required for storage snapshot
+	// list functionality.
+	StorageInstanceVolumeSnapshots(names.StorageTag) ([]state.VolumeSnapshot, error)
+
+	// RestoreVolumeSnapshot is required for storage restore
+	// functionality.
+	RestoreVolumeSnapshot(string) (names.StorageTag, error)
+
+	// AddExistingFilesystem is required for storage import
+	// functionality.
+	AddExistingFilesystem(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+
+	// StoragePoolInUse is required for pool remove functionality.
+	StoragePoolInUse(name string) (bool, error)
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
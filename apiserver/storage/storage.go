@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -49,6 +50,46 @@ func NewAPI(
 		authorizer:  authorizer,
 	}, nil
 }
+
+// APIV3 provides the storage API facade, version 3, which lacks the
+// methods added in version 4.
+type APIV3 struct {
+	*API
+}
+
+// Methods taking two arguments are not exposed over RPC; those below
+// hide the methods added in version 4.
+
+// Attach isn't on the version 3 API.
+func (*APIV3) Attach(_, _ struct{}) {}
+
+// Detach isn't on the version 3 API.
+func (*APIV3) Detach(_, _ struct{}) {}
+
+// Remove isn't on the version 3 API.
+func (*APIV3) Remove(_, _ struct{}) {}
+
+// UpdatePool isn't on the version 3 API.
+func (*APIV3) UpdatePool(_, _ struct{}) {}
+
+// RemovePool isn't on the version 3 API.
+func (*APIV3) RemovePool(_, _ struct{}) {}
+
+// Resize isn't on the version 3 API.
+func (*APIV3) Resize(_, _ struct{}) {}
+
+// CreateSnapshots isn't on the version 3 API.
+func (*APIV3) CreateSnapshots(_, _ struct{}) {}
+
+// ListSnapshots isn't on the version 3 API.
+func (*APIV3) ListSnapshots(_, _ struct{}) {}
+
+// RestoreSnapshots isn't on the version 3 API.
+func (*APIV3) RestoreSnapshots(_, _ struct{}) {}
+
+// Import isn't on the version 3 API.
+func (*APIV3) Import(_, _ struct{}) {}
+
 func (api *API) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(description.ReadAccess, api.storage.ModelTag())
 	if err != nil {
@@ -195,9 +236,13 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
@@ -361,6 +406,37 @@ func (a *API) CreatePool(p params.StoragePool) error {
 	return err
 }
 
+// UpdatePool updates the attributes of an existing pool. Attributes
+// with empty values are removed from the pool's configuration. The
+// updated configuration applies only to storage subsequently
+// provisioned from the pool.
+func (a *API) UpdatePool(p params.StoragePool) error {
+	if err := a.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := a.poolManager.Update(p.Name, p.Attrs)
+	return err
+}
+
+// RemovePool removes the named pool. A pool may not be removed while
+// any volumes or filesystems have been, or are to be, created from it.
+func (a *API) RemovePool(p params.StoragePoolDeleteArg) error {
+	if err := a.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := a.poolManager.Get(p.Name); err != nil {
+		return errors.Trace(err)
+	}
+	inUse, err := a.storage.StoragePoolInUse(p.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if inUse {
+		return errors.Errorf("storage pool %q in use", p.Name)
+	}
+	return a.poolManager.Delete(p.Name)
+}
+
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
@@ -706,3 +782,399 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// Attach attaches existing, detached storage instances to units.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := a.storage.AttachStorage(storageTag, unitTag); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Detach detaches storage instances from units, retaining the storage
+// so that it may later be attached to another unit. If a unit tag is
+// not specified, the storage is detached from all units it is attached
+// to. A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if err := a.detachStorage(id); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *API) detachStorage(id params.StorageAttachmentId) error {
+	storageTag, err := names.ParseStorageTag(id.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if id.UnitTag != "" {
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.DetachStorage(storageTag, unitTag)
+	}
+	attachments, err := a.storage.StorageAttachments(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(attachments) == 0 {
+		return errors.Errorf("storage %s is not attached", storageTag.Id())
+	}
+	for _, attachment := range attachments {
+		if err := a.storage.DetachStorage(storageTag, attachment.Unit()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Remove removes storage instances from the model. Depending on the
+// parameters, the volume or filesystem backing each storage instance
+// is either destroyed or released, i.e. retained but no longer
+// associated with the storage. A "REMOVE" block can block this
+// operation.
+func (a *API) Remove(args params.RemoveStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		remove := a.storage.DestroyStorageInstance
+		if arg.Release {
+			remove = a.storage.ReleaseStorageInstance
+		}
+		if err := remove(tag); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Resize grows the volumes backing storage instances to the requested
+// sizes. Once the storage provider has grown a volume, the units that
+// the storage is attached to are notified with the "storage-resized"
+// hook. A "CHANGE" block can block this operation.
+func (a *API) Resize(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := a.storage.ResizeStorageInstance(tag, arg.Size); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// CreateSnapshots requests point-in-time snapshots of the volumes
+// backing the specified storage instances, returning the IDs of the
+// new snapshots. The snapshots are taken asynchronously by the storage
+// provisioner. A "CHANGE" block can block this operation.
+func (a *API) CreateSnapshots(args params.Entities) (params.StringResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.StringResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		snapshot, err := a.storage.SnapshotStorageInstance(tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Result = snapshot.Id()
+	}
+	return params.StringResults{Results: result}, nil
+}
+
+// ListSnapshots lists volume snapshots with the given filters. Each
+// filter produces an independent list of snapshots, or an error if the
+// filter is invalid or the snapshots could not be listed.
+func (a *API) ListSnapshots(filters params.VolumeSnapshotFilters) (params.VolumeSnapshotDetailsListResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotDetailsListResults{}, errors.Trace(err)
+	}
+	results := params.VolumeSnapshotDetailsListResults{
+		Results: make([]params.VolumeSnapshotDetailsListResult, len(filters.Filters)),
+	}
+	for i, filter := range filters.Filters {
+		snapshots, err := filterVolumeSnapshots(a.storage, filter)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		details := make([]params.VolumeSnapshotDetails, len(snapshots))
+		for j, snapshot := range snapshots {
+			snapshotDetails, err := createVolumeSnapshotDetails(snapshot)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				break
+			}
+			details[j] = snapshotDetails
+		}
+		if results.Results[i].Error == nil {
+			results.Results[i].Result = details
+		}
+	}
+	return results, nil
+}
+
+func filterVolumeSnapshots(st storageAccess, f params.VolumeSnapshotFilter) ([]state.VolumeSnapshot, error) {
+	if len(f.StorageTags) == 0 {
+		return st.AllVolumeSnapshots()
+	}
+	var snapshots []state.VolumeSnapshot
+	for _, tagString := range f.StorageTags {
+		tag, err := names.ParseStorageTag(tagString)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageSnapshots, err := st.StorageInstanceVolumeSnapshots(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots = append(snapshots, storageSnapshots...)
+	}
+	return snapshots, nil
+}
+
+func createVolumeSnapshotDetails(snapshot state.VolumeSnapshot) (params.VolumeSnapshotDetails, error) {
+	details := params.VolumeSnapshotDetails{
+		Id:        snapshot.Id(),
+		VolumeTag: snapshot.Volume().String(),
+		Created:   snapshot.Created(),
+	}
+	if storageTag, err := snapshot.StorageInstance(); err == nil {
+		details.StorageTag = storageTag.String()
+	}
+	if info, err := snapshot.Info(); err == nil {
+		details.SnapshotId = info.SnapshotId
+		details.Size = info.Size
+	}
+	status, err := snapshot.Status()
+	if err != nil {
+		return params.VolumeSnapshotDetails{}, errors.Trace(err)
+	}
+	details.Status = common.EntityStatusFromState(status)
+	return details, nil
+}
+
+// RestoreSnapshots creates new, detached storage instances backed by
+// volumes restored from the specified snapshots, returning the tags of
+// the new storage instances. The storage instances may then be
+// attached to units. A "CHANGE" block can block this operation.
+func (a *API) RestoreSnapshots(args params.VolumeSnapshotIds) (params.StringResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.StringResult, len(args.Ids))
+	for i, id := range args.Ids {
+		storageTag, err := a.storage.RestoreVolumeSnapshot(id)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Result = storageTag.String()
+	}
+	return params.StringResults{Results: result}, nil
+}
+
+// Import imports existing storage into the model. Each storage entity
+// is validated with the storage provider, tagged, and recorded in the
+// model as a detached storage instance that may later be attached to
+// a unit. A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	cfg, err := a.storage.ModelConfig()
+	if err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+	resourceTags := tags.ResourceTags(
+		a.storage.ModelTag(),
+		a.storage.ControllerTag(),
+		cfg,
+	)
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		details, err := a.importStorage(arg, resourceTags)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *API) importStorage(
+	arg params.ImportStorageParams,
+	resourceTags map[string]string,
+) (*params.ImportStorageDetails, error) {
+	if arg.Kind != params.StorageKindFilesystem {
+		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
+	}
+	cfg, err := a.poolConfig(arg.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Scope() == storage.ScopeMachine {
+		return nil, errors.NotSupportedf("importing machine-scoped storage")
+	}
+
+	var storageTag names.StorageTag
+	if provider.Supports(storage.StorageKindFilesystem) {
+		source, err := provider.FilesystemSource(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		importer, ok := source.(storage.FilesystemImporter)
+		if !ok {
+			return nil, errors.NotSupportedf(
+				"importing filesystem with storage provider %q",
+				cfg.Provider(),
+			)
+		}
+		info, err := importer.ImportFilesystem(arg.ProviderId, resourceTags)
+		if err != nil {
+			return nil, errors.Annotate(err, "importing filesystem")
+		}
+		storageTag, err = a.storage.AddExistingFilesystem(state.FilesystemInfo{
+			Size:         info.Size,
+			Pool:         arg.Pool,
+			FilesystemId: info.FilesystemId,
+		}, nil, arg.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		source, err := provider.VolumeSource(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		importer, ok := source.(storage.VolumeImporter)
+		if !ok {
+			return nil, errors.NotSupportedf(
+				"importing volume with storage provider %q",
+				cfg.Provider(),
+			)
+		}
+		info, err := importer.ImportVolume(arg.ProviderId, resourceTags)
+		if err != nil {
+			return nil, errors.Annotate(err, "importing volume")
+		}
+		storageTag, err = a.storage.AddExistingFilesystem(state.FilesystemInfo{
+			Size: info.Size,
+			Pool: arg.Pool,
+		}, &state.VolumeInfo{
+			HardwareId: info.HardwareId,
+			Size:       info.Size,
+			Pool:       arg.Pool,
+			VolumeId:   info.VolumeId,
+			Persistent: info.Persistent,
+		}, arg.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// poolConfig returns the storage configuration for the named pool.
+// If there is no pool with the given name, the name is interpreted
+// as a storage provider type, with an empty configuration.
+func (a *API) poolConfig(poolName string) (*storage.Config, error) {
+	cfg, err := a.poolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		providerType := storage.ProviderType(poolName)
+		if _, err1 := a.registry.StorageProvider(providerType); err1 != nil {
+			return nil, errors.Trace(err)
+		}
+		return storage.NewConfig(poolName, providerType, map[string]interface{}{})
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	var attached []string
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		attached = append(attached, storage.Id()+":"+unit.Id())
+		if unit.Id() == "mysql/2" {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{
+			{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+			{StorageTag: "storage-data-0", UnitTag: "unit-mysql-2"},
+			{StorageTag: "volume-0", UnitTag: "unit-mysql-1"},
+			{StorageTag: "storage-data-0", UnitTag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: `"application-mysql" is not a valid unit tag`}},
+	})
+	c.Assert(attached, jc.DeepEquals, []string{"data/0:mysql/1", "data/0:mysql/2"})
+	s.assertCalls(c, []string{getBlockForTypeCall, attachStorageCall, attachStorageCall})
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{
+			{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+		},
+	})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{
+			{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+			// No unit specified: detach from all attached units.
+			{StorageTag: s.storageTag.String()},
+			{StorageTag: "storage-data-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{}, {},
+		{Error: &params.Error{Message: `storage data/1 not found`, Code: params.CodeNotFound}},
+	})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0:mysql/1", "data/0:mysql/0"})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		detachStorageCall,
+		storageInstanceAttachmentsCall, detachStorageCall,
+		storageInstanceAttachmentsCall,
+	})
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{StorageTag: "storage-data-0"}},
+	})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
+
+func (s *storageAttachSuite) TestRemove(c *gc.C) {
+	results, err := s.api.Remove(params.RemoveStorage{
+		Storage: []params.RemoveStorageInstance{
+			{Tag: "storage-data-0"},
+			{Tag: "storage-data-1", Release: true},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{}, {},
+		{Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall, getBlockForTypeCall,
+		destroyStorageInstanceCall,
+		releaseStorageInstanceCall,
+	})
+}
+
+func (s *storageAttachSuite) TestRemoveBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemoveBlocked")
+	_, err := s.api.Remove(params.RemoveStorage{
+		Storage: []params.RemoveStorageInstance{{Tag: "storage-data-0"}},
+	})
+	s.assertBlocked(c, err, "TestRemoveBlocked")
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+)
+
+type storageImportSuite struct {
+	baseStorageSuite
+	volumeSource *dummy.VolumeSource
+}
+
+var _ = gc.Suite(&storageImportSuite{})
+
+func (s *storageImportSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummy.VolumeSource{
+		ImportVolumeFunc: func(volumeId string, tags map[string]string) (jujustorage.VolumeInfo, error) {
+			if volumeId == "vol-missing" {
+				return jujustorage.VolumeInfo{}, errors.NotFoundf("volume %q", volumeId)
+			}
+			return jujustorage.VolumeInfo{
+				VolumeId:   volumeId,
+				Size:       1024,
+				Persistent: true,
+			}, nil
+		},
+	}
+	s.registry.Providers["block"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		SupportsFunc: func(kind jujustorage.StorageKind) bool {
+			return kind == jujustorage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["machine"] = &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+	pool, err := jujustorage.NewConfig("fast", "block", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["fast"] = pool
+}
+
+func (s *storageImportSuite) TestImport(c *gc.C) {
+	var added []state.VolumeInfo
+	s.state.addExistingFilesystem = func(
+		info state.FilesystemInfo,
+		backingVolume *state.VolumeInfo,
+		storageName string,
+	) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingFilesystemCall)
+		c.Assert(info, jc.DeepEquals, state.FilesystemInfo{Size: 1024, Pool: "fast"})
+		c.Assert(backingVolume, gc.NotNil)
+		c.Assert(storageName, gc.Equals, "data")
+		added = append(added, *backingVolume)
+		return names.NewStorageTag("data/1"), nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "fast",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}, {
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "fast",
+			ProviderId:  "vol-missing",
+			StorageName: "data",
+		}, {
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "machine",
+			ProviderId:  "foo",
+			StorageName: "data",
+		}, {
+			Kind:        params.StorageKindBlock,
+			Pool:        "fast",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}, {
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "nonexistent",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ImportStorageResult{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-data-1"},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `importing volume: volume "vol-missing" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "importing machine-scoped storage not supported")
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `storage kind "block" not supported`)
+	c.Assert(results.Results[4].Error, gc.ErrorMatches, `mock pool manager: get pool nonexistent not found`)
+	c.Assert(added, jc.DeepEquals, []state.VolumeInfo{{
+		Size:       1024,
+		Pool:       "fast",
+		VolumeId:   "vol-123",
+		Persistent: true,
+	}})
+	s.assertCalls(c, []string{getBlockForTypeCall, addExistingFilesystemCall})
+	s.volumeSource.CheckCallNames(c, "ImportVolume", "ImportVolume")
+}
+
+func (s *storageImportSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestImportBlocked")
+	_, err := s.api.Import(params.BulkImportStorageParams{
+		Storage: []params.ImportStorageParams{{
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "fast",
+			ProviderId:  "vol-123",
+			StorageName: "data",
+		}},
+	})
+	s.assertBlocked(c, err, "TestImportBlocked")
+}
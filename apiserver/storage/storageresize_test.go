@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) TestResize(c *gc.C) {
+	var resized []string
+	s.state.resizeStorageInstance = func(tag names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageInstanceCall)
+		if tag.Id() == "data/1" {
+			return errors.NotSupportedf("resizing provisioned filesystem without a backing volume")
+		}
+		resized = append(resized, tag.Id())
+		c.Assert(size, gc.Equals, uint64(20480))
+		return nil
+	}
+	results, err := s.api.Resize(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{
+			{Tag: "storage-data-0", Size: 20480},
+			{Tag: "storage-data-1", Size: 20480},
+			{Tag: "unit-mysql-0", Size: 20480},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Message: `resizing provisioned filesystem without a backing volume not supported`,
+			Code:    params.CodeNotSupported,
+		}},
+		{Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`}},
+	})
+	c.Assert(resized, jc.DeepEquals, []string{"data/0"})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		resizeStorageInstanceCall,
+		resizeStorageInstanceCall,
+	})
+}
+
+func (s *storageResizeSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.api.Resize(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{Tag: "storage-data-0", Size: 20480}},
+	})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) TestCreateSnapshots(c *gc.C) {
+	s.state.snapshotStorageInstance = func(tag names.StorageTag) (state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, snapshotStorageInstanceCall)
+		if tag.Id() == "data/1" {
+			return nil, errors.NotSupportedf("snapshotting storage without a backing volume")
+		}
+		return &mockVolumeSnapshot{id: "0", volume: s.volumeTag, storage: &tag}, nil
+	}
+	results, err := s.api.CreateSnapshots(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "storage-data-0"},
+			{Tag: "storage-data-1"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "0"},
+		{Error: &params.Error{
+			Message: "snapshotting storage without a backing volume not supported",
+			Code:    params.CodeNotSupported,
+		}},
+		{Error: &params.Error{Message: `"unit-mysql-0" is not a valid storage tag`}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		snapshotStorageInstanceCall,
+		snapshotStorageInstanceCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: "storage-data-0"}},
+	})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestListSnapshots(c *gc.C) {
+	created := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshots := []state.VolumeSnapshot{
+		&mockVolumeSnapshot{
+			id:      "0",
+			volume:  s.volumeTag,
+			storage: &s.storageTag,
+			created: created,
+			info:    &state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024},
+		},
+		&mockVolumeSnapshot{
+			id:      "1",
+			volume:  s.volumeTag,
+			storage: &s.storageTag,
+			created: created,
+		},
+	}
+	s.state.allVolumeSnapshots = func() ([]state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, allVolumeSnapshotsCall)
+		return snapshots, nil
+	}
+	s.state.storageInstanceVolumeSnapshots = func(tag names.StorageTag) ([]state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, storageInstanceVolumeSnapshotsCall)
+		c.Assert(tag, gc.Equals, s.storageTag)
+		return snapshots[:1], nil
+	}
+
+	results, err := s.api.ListSnapshots(params.VolumeSnapshotFilters{
+		Filters: []params.VolumeSnapshotFilter{
+			{},
+			{StorageTags: []string{"storage-data-0"}},
+			{StorageTags: []string{"volume-0"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	expected := []params.VolumeSnapshotDetails{{
+		Id:         "0",
+		StorageTag: "storage-data-0",
+		VolumeTag:  "volume-22",
+		SnapshotId: "snap-0",
+		Size:       1024,
+		Created:    created,
+		Status:     params.EntityStatus{Status: status.StatusAvailable},
+	}, {
+		Id:         "1",
+		StorageTag: "storage-data-0",
+		VolumeTag:  "volume-22",
+		Created:    created,
+		Status:     params.EntityStatus{Status: status.StatusPending},
+	}}
+	c.Assert(results.Results[0], jc.DeepEquals, params.VolumeSnapshotDetailsListResult{
+		Result: expected,
+	})
+	c.Assert(results.Results[1], jc.DeepEquals, params.VolumeSnapshotDetailsListResult{
+		Result: expected[:1],
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+	s.assertCalls(c, []string{
+		allVolumeSnapshotsCall,
+		storageInstanceVolumeSnapshotsCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestRestoreSnapshots(c *gc.C) {
+	s.state.restoreVolumeSnapshot = func(id string) (names.StorageTag, error) {
+		s.calls = append(s.calls, restoreVolumeSnapshotCall)
+		if id == "1" {
+			return names.StorageTag{}, errors.New("snapshot is not available")
+		}
+		return names.NewStorageTag("data/1"), nil
+	}
+	results, err := s.api.RestoreSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"0", "1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "storage-data-1"},
+		{Error: &params.Error{Message: "snapshot is not available"}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		restoreVolumeSnapshotCall,
+		restoreVolumeSnapshotCall,
+	})
+}
+
+func (s *storageSnapshotSuite) TestRestoreSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestRestoreSnapshotsBlocked")
+	_, err := s.api.RestoreSnapshots(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	s.assertBlocked(c, err, "TestRestoreSnapshotsBlocked")
+}
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/poolmanager"
 )
 
@@ -23,7 +26,16 @@ import (
 // *trivially* correct, you would be Doing It Wrong.
 
 func init() {
-	common.RegisterStandardFacade("StorageProvisioner", 3, newStorageProvisionerAPI)
+	common.RegisterStandardFacade("StorageProvisioner", 3, newStorageProvisionerAPIV3)
+	common.RegisterStandardFacade("StorageProvisioner", 4, newStorageProvisionerAPI)
+}
+
+func newStorageProvisionerAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIV3, error) {
+	api, err := newStorageProvisionerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageProvisionerAPIV3{api}, nil
 }
 
 func newStorageProvisionerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPI, error) {
@@ -55,6 +67,10 @@ type Backend interface {
 	WatchEnvironVolumeAttachments() state.StringsWatcher
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeSnapshots() state.StringsWatcher
+	WatchMachineVolumeSnapshots(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
@@ -65,6 +81,7 @@ type Backend interface {
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.MachineTag, names.FilesystemTag) error
@@ -75,6 +92,8 @@ type Backend interface {
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSnapshotInfo(string, state.VolumeSnapshotInfo) error
+	SetVolumeSnapshotStatus(string, status.Status, string, map[string]interface{}, *time.Time) error
 }
 
 type stateShim struct {
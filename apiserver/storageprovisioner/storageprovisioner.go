@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 )
@@ -173,6 +176,33 @@ func NewStorageProvisionerAPI(
 	}, nil
 }
 
+// StorageProvisionerAPIV3 provides the StorageProvisioner API facade,
+// version 3, which lacks the methods added in version 4.
+type StorageProvisionerAPIV3 struct {
+	*StorageProvisionerAPI
+}
+
+// The methods below take two arguments, which keeps them, and the
+// version 4 methods they hide, out of the version 3 facade.
+
+// WatchVolumeResizes isn't on the version 3 API.
+func (*StorageProvisionerAPIV3) WatchVolumeResizes(_, _ struct{}) {}
+
+// VolumeResizeParams isn't on the version 3 API.
+func (*StorageProvisionerAPIV3) VolumeResizeParams(_, _ struct{}) {}
+
+// WatchVolumeSnapshots isn't on the version 3 API.
+func (*StorageProvisionerAPIV3) WatchVolumeSnapshots(_, _ struct{}) {}
+
+// VolumeSnapshotParams isn't on the version 3 API.
+func (*StorageProvisionerAPIV3) VolumeSnapshotParams(_, _ struct{}) {}
+
+// SetVolumeSnapshotInfo isn't on the version 3 API.
+func (*StorageProvisionerAPIV3) SetVolumeSnapshotInfo(_, _ struct{}) {}
+
+// SetVolumeSnapshotStatus isn't on the version 3 API.
+func (*StorageProvisionerAPIV3) SetVolumeSnapshotStatus(_, _ struct{}) {}
+
 // WatchBlockDevices watches for changes to the specified machines' block devices.
 func (s *StorageProvisionerAPI) WatchBlockDevices(args params.Entities) (params.NotifyWatchResults, error) {
 	canAccess, err := s.getBlockDevicesAuthFunc()
@@ -260,6 +290,19 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchModelFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that pending resizes
+// may be processed.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchVolumeSnapshots watches for changes to snapshots of volumes
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -622,6 +665,158 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags, with the size set to the requested size.
+// If a volume has no pending resize, a NotFound error is returned
+// for that volume.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeParamsResults{}, err
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeParamsResults{}, err
+	}
+	results := params.VolumeParamsResults{
+		Results: make([]params.VolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if err != nil {
+			// The volume may have been removed since the
+			// watcher reported it; report NotFound so the
+			// storage provisioner can ignore it.
+			return params.VolumeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok {
+			return params.VolumeParams{}, errors.NotFoundf("pending resize for volume %q", tag.Id())
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			volume.StorageInstance,
+			s.st.StorageInstance,
+		)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
+		volumeParams, err := storagecommon.VolumeParams(
+			volume, storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(),
+			modelCfg, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
+		volumeParams.Size = size
+		return volumeParams, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeParamsResult
+		volumeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = volumeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs. If a snapshot has already been
+// taken, a NotFound error is returned for that snapshot.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.volumeSnapshot(id, canAccess)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if _, err := snapshot.Info(); err == nil {
+			return params.VolumeSnapshotParams{}, errors.NotFoundf("pending snapshot %q", id)
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volume, err := s.st.Volume(snapshot.Volume())
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			volume.StorageInstance,
+			s.st.StorageInstance,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volumeParams, err := storagecommon.VolumeParams(
+			volume, storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(),
+			modelCfg, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return params.VolumeSnapshotParams{
+			Id:       id,
+			VolumeId: volumeInfo.VolumeId,
+			Volume:   volumeParams,
+		}, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// volumeSnapshot returns the volume snapshot with the specified ID,
+// if the snapshotted volume may be accessed by the authenticated
+// entity.
+func (s *StorageProvisionerAPI) volumeSnapshot(id string, canAccess common.AuthFunc) (state.VolumeSnapshot, error) {
+	snapshot, err := s.st.VolumeSnapshot(id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, err
+	}
+	if !canAccess(snapshot.Volume()) {
+		return nil, common.ErrPerm
+	}
+	return snapshot, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -956,6 +1151,55 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 	return results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotInfo(args params.VolumeSnapshotInfos) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.VolumeSnapshotInfo) error {
+		if _, err := s.volumeSnapshot(arg.Id, canAccess); err != nil {
+			return err
+		}
+		return s.st.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.SnapshotId,
+			Size:       arg.Size,
+		})
+	}
+	for i, arg := range args.Snapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotStatus sets the status of volume snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotStatus(args params.VolumeSnapshotStatuses) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Statuses)),
+	}
+	one := func(arg params.VolumeSnapshotStatus) error {
+		if _, err := s.volumeSnapshot(arg.Id, canAccess); err != nil {
+			return err
+		}
+		now := time.Now()
+		return s.st.SetVolumeSnapshotStatus(arg.Id, status.Status(arg.Status), arg.Info, nil, &now)
+	}
+	for i, arg := range args.Statuses {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (params.ErrorResults, error) {
 	canAccessFilesystem, err := s.getStorageEntityAuthFunc()
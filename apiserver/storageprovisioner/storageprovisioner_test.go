@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/testing"
@@ -180,8 +181,8 @@ func (s *provisionerSuite) TestVolumesMachine(c *gc.C) {
 					Persistent: true,
 				},
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -201,7 +202,7 @@ func (s *provisionerSuite) TestVolumesEnviron(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.VolumeResults{
 		Results: []params.VolumeResult{
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: common.ServerError(errors.NotProvisionedf(`volume "1"`))},
 			{Result: params.Volume{
 				VolumeTag: "volume-2",
@@ -211,7 +212,7 @@ func (s *provisionerSuite) TestVolumesEnviron(c *gc.C) {
 					Size:       4096,
 				},
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -237,7 +238,7 @@ func (s *provisionerSuite) TestFilesystems(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, params.FilesystemResults{
 		Results: []params.FilesystemResult{
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: common.ServerError(errors.NotProvisionedf(`filesystem "1"`))},
 			{Result: params.Filesystem{
 				FilesystemTag: "filesystem-2",
@@ -246,7 +247,7 @@ func (s *provisionerSuite) TestFilesystems(c *gc.C) {
 					Size:         4096,
 				},
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -288,7 +289,7 @@ func (s *provisionerSuite) TestVolumeAttachments(c *gc.C) {
 				Code:    params.CodeNotProvisioned,
 				Message: `volume attachment "2" on "0" not provisioned`,
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -334,7 +335,7 @@ func (s *provisionerSuite) TestFilesystemAttachments(c *gc.C) {
 				Code:    params.CodeNotProvisioned,
 				Message: `filesystem attachment "2" on "0" not provisioned`,
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -398,7 +399,7 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 					ReadOnly:   true,
 				},
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -409,6 +410,153 @@ func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 0)
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeParamsResults{
+		Results: []params.VolumeParamsResult{
+			{Result: params.VolumeParams{
+				VolumeTag: "volume-2",
+				Size:      8192,
+				Provider:  "environscoped",
+				Tags: map[string]string{
+					tags.JujuController: testing.ControllerTag.Id(),
+					tags.JujuModel:      testing.ModelTag.Id(),
+				},
+			}},
+			{Error: &params.Error{Message: `pending resize for volume "0/0" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: `volume "42" not found`, Code: "not found"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) setupVolumeSnapshot(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	sCons := map[string]state.StorageConstraints{
+		"data": {Pool: "environscoped", Size: 1024, Count: 1},
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, sCons)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(names.NewVolumeTag("0"), state.VolumeInfo{
+		VolumeId: "vol-0",
+		Size:     1024,
+		Pool:     "environscoped",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.SnapshotStorageInstance(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumeSnapshot(c)
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0", "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:       "0",
+				VolumeId: "vol-0",
+				Volume: params.VolumeParams{
+					VolumeTag: "volume-0",
+					Size:      1024,
+					Provider:  "environscoped",
+					Tags: map[string]string{
+						tags.JujuController:      testing.ControllerTag.Id(),
+						tags.JujuModel:           testing.ModelTag.Id(),
+						tags.JujuStorageInstance: "data/0",
+						tags.JujuStorageOwner:    "storage-block/0",
+					},
+				},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumeSnapshot(c)
+	results, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshotInfos{
+		Snapshots: []params.VolumeSnapshotInfo{
+			{Id: "0", SnapshotId: "snap-0", Size: 1024},
+			{Id: "42", SnapshotId: "snap-42", Size: 1024},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024})
+
+	// Once the snapshot has been taken, there
+	// are no parameters to take it again.
+	paramsResults, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{Ids: []string{"0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paramsResults.Results[0].Error, jc.DeepEquals, &params.Error{
+		Message: `pending snapshot "0" not found`, Code: "not found",
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotStatus(c *gc.C) {
+	s.setupVolumeSnapshot(c)
+	results, err := s.api.SetVolumeSnapshotStatus(params.VolumeSnapshotStatuses{
+		Statuses: []params.VolumeSnapshotStatus{
+			{Id: "0", Status: "error", Info: "boom"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	statusInfo, err := s.State.VolumeSnapshotStatus("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+	c.Assert(statusInfo.Message, gc.Equals, "boom")
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshot(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -435,7 +583,7 @@ func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 					tags.JujuModel:      testing.ModelTag.Id(),
 				},
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -507,7 +655,7 @@ func (s *provisionerSuite) TestVolumeAttachmentParams(c *gc.C) {
 				VolumeTag:  "volume-4",
 				Provider:   "environscoped",
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -570,7 +718,7 @@ func (s *provisionerSuite) TestFilesystemAttachmentParams(c *gc.C) {
 				FilesystemTag: "filesystem-3",
 				Provider:      "environscoped",
 			}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -618,7 +766,7 @@ func (s *provisionerSuite) TestSetVolumeAttachmentInfo(c *gc.C) {
 			{},
 			{Error: &params.Error{Message: `cannot set info for volume attachment 1:0: volume "1" not provisioned`, Code: "not provisioned"}},
 			{Error: &params.Error{Message: `cannot set info for volume attachment 4:2: machine 2 not provisioned`, Code: "not provisioned"}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -666,7 +814,7 @@ func (s *provisionerSuite) TestSetFilesystemAttachmentInfo(c *gc.C) {
 			{},
 			{Error: &params.Error{Message: `cannot set info for filesystem attachment 1:0: filesystem "1" not provisioned`, Code: "not provisioned"}},
 			{Error: &params.Error{Message: `cannot set info for filesystem attachment 3:2: machine 2 not provisioned`, Code: "not provisioned"}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -711,6 +859,39 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	err = s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("2")
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
@@ -1003,7 +1184,7 @@ func (s *provisionerSuite) TestAttachmentLife(c *gc.C) {
 			{Life: params.Alive},
 			{Life: params.Alive},
 			{Life: params.Alive},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -1041,12 +1222,12 @@ func (s *provisionerSuite) TestRemoveVolumesEnvironManager(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, params.ErrorResults{
 		Results: []params.ErrorResult{
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: nil},
 			{Error: &params.Error{Message: "removing volume 2: volume is not dead"}},
 			{Error: nil},
 			{Error: &params.Error{Message: `"volume-invalid" is not a valid volume tag`}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -1069,12 +1250,12 @@ func (s *provisionerSuite) TestRemoveFilesystemsEnvironManager(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, params.ErrorResults{
 		Results: []params.ErrorResult{
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: nil},
 			{Error: &params.Error{Message: "removing filesystem 2: filesystem is not dead"}},
 			{Error: nil},
 			{Error: &params.Error{Message: `"filesystem-invalid" is not a valid filesystem tag`}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -1100,9 +1281,9 @@ func (s *provisionerSuite) TestRemoveVolumesMachineAgent(c *gc.C) {
 		Results: []params.ErrorResult{
 			{Error: nil},
 			{Error: nil},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: &params.Error{Message: `"volume-invalid" is not a valid volume tag`}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -1128,9 +1309,9 @@ func (s *provisionerSuite) TestRemoveFilesystemsMachineAgent(c *gc.C) {
 		Results: []params.ErrorResult{
 			{Error: nil},
 			{Error: nil},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: &params.Error{Message: `"filesystem-invalid" is not a valid filesystem tag`}},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 		},
 	})
 }
@@ -1162,7 +1343,7 @@ func (s *provisionerSuite) TestRemoveVolumeAttachments(c *gc.C) {
 		Results: []params.ErrorResult{
 			{Error: &params.Error{Message: "removing attachment of volume 0/0 from machine 0: volume attachment is not dying"}},
 			{Error: nil},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: &params.Error{Message: `removing attachment of volume 42 from machine 0: volume "42" on machine "0" not found`, Code: "not found"}},
 		},
 	})
@@ -1195,7 +1376,7 @@ func (s *provisionerSuite) TestRemoveFilesystemAttachments(c *gc.C) {
 		Results: []params.ErrorResult{
 			{Error: &params.Error{Message: "removing attachment of filesystem 0/0 from machine 0: filesystem attachment is not dying"}},
 			{Error: nil},
-			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: apiservertesting.ErrUnauthorized},
 			{Error: &params.Error{Message: `removing attachment of filesystem 42 from machine 0: filesystem "42" on machine "0" not found`, Code: "not found"}},
 		},
 	})
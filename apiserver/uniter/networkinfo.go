@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// NetworkInfo returns the network info for the given unit and each of the
+// requested endpoint bindings: the device addresses the binding is bound to,
+// the addresses other units should use to reach it and the subnets its
+// outgoing traffic originates from.
+func (u *UniterAPIV3) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+	unitTag, err := names.ParseUnitTag(args.Unit)
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+	if !canAccess(unitTag) {
+		return params.NetworkInfoResults{}, common.ErrPerm
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+
+	result := params.NetworkInfoResults{
+		Results: make(map[string]params.NetworkInfoResult),
+	}
+	for _, binding := range args.Bindings {
+		info, err := u.getOneNetworkInfo(unit, binding)
+		if err != nil {
+			info = params.NetworkInfoResult{Error: common.ServerError(err)}
+		}
+		result.Results[binding] = info
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) getOneNetworkInfo(unit *state.Unit, bindingName string) (params.NetworkInfoResult, error) {
+	if bindingName == "" {
+		return params.NetworkInfoResult{}, errors.Errorf("binding name cannot be empty")
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return params.NetworkInfoResult{}, errors.Trace(err)
+	}
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return params.NetworkInfoResult{}, errors.Trace(err)
+	}
+	boundSpace, known := bindings[bindingName]
+	if !known {
+		return params.NetworkInfoResult{}, errors.Errorf("binding name %q not defined by the unit's charm", bindingName)
+	}
+	machineID, err := unit.AssignedMachineId()
+	if err != nil {
+		return params.NetworkInfoResult{}, errors.Trace(err)
+	}
+	machine, err := u.st.Machine(machineID)
+	if err != nil {
+		return params.NetworkInfoResult{}, errors.Trace(err)
+	}
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return params.NetworkInfoResult{}, errors.Trace(err)
+	}
+
+	deviceAddresses, err := machine.AllAddresses()
+	if err != nil {
+		return params.NetworkInfoResult{}, errors.Annotate(err, "cannot get devices addresses")
+	}
+
+	// Select the device addresses the binding is bound to. Endpoints not
+	// explicitly bound to a space use the machine's preferred private
+	// address.
+	var privateAddress network.Address
+	if boundSpace == "" {
+		privateAddress, err = machine.PrivateAddress()
+		if err != nil {
+			return params.NetworkInfoResult{}, errors.Annotatef(err, "getting machine %q preferred private address", machineID)
+		}
+	}
+	var boundAddresses []*state.Address
+	for _, addr := range deviceAddresses {
+		if boundSpace == "" {
+			if addr.Value() == privateAddress.Value {
+				boundAddresses = append(boundAddresses, addr)
+			}
+			continue
+		}
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			logger.Debugf("skipping %s: not linked to a known subnet (%v)", addr, err)
+			continue
+		} else if err != nil {
+			return params.NetworkInfoResult{}, errors.Annotatef(err, "cannot get subnet for address %q", addr)
+		}
+		if subnet.SpaceName() == boundSpace {
+			boundAddresses = append(boundAddresses, addr)
+		}
+	}
+
+	var result params.NetworkInfoResult
+	var bindValues []network.Address
+	if boundSpace == "" && len(boundAddresses) == 0 && privateAddress.Value != "" {
+		// The preferred private address is not known to belong to any
+		// device (e.g. on providers not reporting link-layer devices).
+		result.Info = []params.NetworkInfo{{
+			Addresses: []params.InterfaceAddress{{Address: privateAddress.Value}},
+		}}
+		bindValues = append(bindValues, privateAddress)
+	}
+	for _, addr := range boundAddresses {
+		device, err := addr.Device()
+		if err != nil {
+			return params.NetworkInfoResult{}, errors.Trace(err)
+		}
+		result.Info = appendDeviceAddress(result.Info, device, addr)
+		bindValues = append(bindValues, network.NewAddress(addr.Value()))
+	}
+	bindValues = network.OrderAddressesByPreference(bindValues, cfg.AddressPreference())
+
+	// Behind NAT or a floating IP, the public address of the machine is
+	// not configured on any of its devices; other units need to use it
+	// to reach endpoints not bound to a specific space.
+	if boundSpace == "" {
+		publicAddress, err := machine.PublicAddress()
+		if err == nil && publicAddress.Scope == network.ScopePublic && !isDeviceAddress(deviceAddresses, publicAddress.Value) {
+			result.IngressAddresses = append(result.IngressAddresses, publicAddress.Value)
+		}
+	}
+	for _, addr := range bindValues {
+		result.IngressAddresses = append(result.IngressAddresses, addr.Value)
+	}
+
+	result.EgressSubnets = cfg.EgressSubnets()
+	if len(result.EgressSubnets) == 0 && len(result.IngressAddresses) > 0 {
+		if cidr := hostCIDR(result.IngressAddresses[0]); cidr != "" {
+			result.EgressSubnets = []string{cidr}
+		}
+	}
+	return result, nil
+}
+
+// appendDeviceAddress adds the given address to the info of its device,
+// adding the device to info if needed.
+func appendDeviceAddress(info []params.NetworkInfo, device *state.LinkLayerDevice, addr *state.Address) []params.NetworkInfo {
+	ifaceAddr := params.InterfaceAddress{
+		Address: addr.Value(),
+		CIDR:    addr.SubnetCIDR(),
+	}
+	for i := range info {
+		if info[i].InterfaceName == device.Name() {
+			info[i].Addresses = append(info[i].Addresses, ifaceAddr)
+			return info
+		}
+	}
+	return append(info, params.NetworkInfo{
+		MACAddress:    device.MACAddress(),
+		InterfaceName: device.Name(),
+		Addresses:     []params.InterfaceAddress{ifaceAddr},
+	})
+}
+
+func isDeviceAddress(deviceAddresses []*state.Address, value string) bool {
+	for _, addr := range deviceAddresses {
+		if addr.Value() == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hostCIDR returns the single host CIDR for the given address, or ""
+// when it is not an IP address.
+func hostCIDR(address string) string {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return address + "/32"
+	}
+	return address + "/128"
+}
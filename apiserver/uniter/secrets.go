@@ -0,0 +1,313 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// CreateSecrets creates secrets owned by the applications of the given
+// units, and returns their IDs. Only leader units may create secrets.
+func (u *UniterAPIV3) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		id, err := u.createOneSecret(canAccess, arg)
+		result.Results[i].Result = id
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) createOneSecret(canAccess common.AuthFunc, arg params.CreateSecretArg) (string, error) {
+	appName, err := u.leaderApplication(canAccess, arg.UnitTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	secret, err := u.st.AddSecret(state.AddSecretArgs{
+		Owner:          appName,
+		Label:          arg.Label,
+		Description:    arg.Description,
+		RotateInterval: arg.RotateInterval,
+		Data:           arg.Data,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return secret.ID, nil
+}
+
+// UpdateSecrets adds new revisions to secrets owned by the applications
+// of the given units. Only leader units may update secrets.
+func (u *UniterAPIV3) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := u.updateOneSecret(canAccess, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) updateOneSecret(canAccess common.AuthFunc, arg params.UpdateSecretArg) error {
+	appName, err := u.leaderApplication(canAccess, arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := u.checkSecretOwner(arg.ID, appName); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = u.st.UpdateSecret(arg.ID, arg.Data)
+	return errors.Trace(err)
+}
+
+// SecretValues returns the content of the given revisions of secrets.
+// A unit may read the secrets owned by its application, and those its
+// application has been granted access to.
+func (u *UniterAPIV3) SecretValues(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretValueResults{}, err
+	}
+	for i, arg := range args.Args {
+		data, err := u.getOneSecretValue(canAccess, arg)
+		result.Results[i].Data = data
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) getOneSecretValue(canAccess common.AuthFunc, arg params.GetSecretArg) (map[string]string, error) {
+	tag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ok, err := u.st.CanReadSecret(arg.ID, appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	return u.st.SecretValue(arg.ID, arg.Revision)
+}
+
+// GrantSecrets allows the applications at the other ends of the given
+// relations to read secrets owned by the applications of the given
+// units. Only leader units may grant access to secrets.
+func (u *UniterAPIV3) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, func(id string, rel *state.Relation, application string) error {
+		return u.st.GrantSecret(id, rel, application)
+	})
+}
+
+// RevokeSecrets removes the access to secrets granted to the
+// applications at the other ends of the given relations. Only leader
+// units may revoke access to secrets.
+func (u *UniterAPIV3) RevokeSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, func(id string, rel *state.Relation, application string) error {
+		return u.st.RevokeSecret(id, rel, application)
+	})
+}
+
+func (u *UniterAPIV3) changeSecretGrants(
+	args params.GrantSecretArgs,
+	change func(id string, rel *state.Relation, application string) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := u.changeOneSecretGrant(canAccess, arg, change)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) changeOneSecretGrant(
+	canAccess common.AuthFunc,
+	arg params.GrantSecretArg,
+	change func(id string, rel *state.Relation, application string) error,
+) error {
+	appName, err := u.leaderApplication(canAccess, arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := u.checkSecretOwner(arg.ID, appName); err != nil {
+		return errors.Trace(err)
+	}
+	relTag, err := names.ParseRelationTag(arg.RelationTag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	rel, err := u.st.KeyRelation(relTag.Id())
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	related, err := rel.RelatedEndpoints(appName)
+	if err != nil {
+		return common.ErrPerm
+	}
+	return change(arg.ID, rel, related[0].ApplicationName)
+}
+
+// leaderApplication returns the name of the application of the given
+// unit, if the unit may be accessed and is the application's leader.
+func (u *UniterAPIV3) leaderApplication(canAccess common.AuthFunc, unitTag string) (string, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil || !canAccess(tag) {
+		return "", common.ErrPerm
+	}
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	token := u.st.LeadershipChecker().LeadershipCheck(appName, tag.Id())
+	if err := token.Check(nil); err != nil {
+		return "", errors.Trace(err)
+	}
+	return appName, nil
+}
+
+// checkSecretOwner returns an error if the given secret is not owned
+// by the named application.
+func (u *UniterAPIV3) checkSecretOwner(id, appName string) error {
+	secret, err := u.st.Secret(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Owner != appName {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// WatchSecrets returns a NotifyWatcher for each given unit, which
+// notifies when secrets are added, updated, granted or revoked.
+func (u *UniterAPIV3) WatchSecrets(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchSecrets()
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) watchSecrets() (string, error) {
+	watch := common.NewMultiNotifyWatcher(u.st.WatchSecrets(), u.st.WatchSecretGrants())
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// SecretRevisions returns, for each given unit, the latest revisions
+// of the secrets its application has been granted access to and, if
+// the unit is the leader, the secrets its application owns that are
+// due to be rotated.
+func (u *UniterAPIV3) SecretRevisions(args params.Entities) (params.SecretRevisionsResults, error) {
+	result := params.SecretRevisionsResults{
+		Results: make([]params.SecretRevisionsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretRevisionsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		one, err := u.oneSecretRevisions(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i] = one
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) oneSecretRevisions(canAccess common.AuthFunc, unitTag string) (params.SecretRevisionsResult, error) {
+	var result params.SecretRevisionsResult
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil || !canAccess(tag) {
+		return result, common.ErrPerm
+	}
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	granted, err := u.st.GrantedSecrets(appName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Granted = secretRevisions(granted)
+	token := u.st.LeadershipChecker().LeadershipCheck(appName, tag.Id())
+	if token.Check(nil) != nil {
+		return result, nil
+	}
+	toRotate, err := u.st.SecretsToRotate(appName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.ToRotate = secretRevisions(toRotate)
+	return result, nil
+}
+
+func secretRevisions(secrets []*state.Secret) []params.SecretRevision {
+	if len(secrets) == 0 {
+		return nil
+	}
+	result := make([]params.SecretRevision, len(secrets))
+	for i, secret := range secrets {
+		result[i] = params.SecretRevision{
+			ID:       secret.ID,
+			Revision: secret.Revision,
+		}
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) claimWordpressLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) createWordpressSecret(c *gc.C) string {
+	result, err := s.uniter.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag: "unit-wordpress-0",
+			Label:   "db",
+			Data:    map[string]string{"password": "sekrit"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	return result.Results[0].Result
+}
+
+func (s *uniterSuite) TestCreateSecretsNotLeader(c *gc.C) {
+	result, err := s.uniter.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag: "unit-wordpress-0",
+			Data:    map[string]string{"password": "sekrit"},
+		}, {
+			UnitTag: "unit-mysql-0",
+			Data:    map[string]string{"password": "sekrit"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, ".* is not leader of .*")
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *uniterSuite) TestCreateUpdateAndReadSecrets(c *gc.C) {
+	s.claimWordpressLeadership(c)
+	id := s.createWordpressSecret(c)
+	c.Assert(id, gc.Equals, "secret:1")
+
+	errResults, err := s.uniter.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag: "unit-wordpress-0",
+			ID:      id,
+			Data:    map[string]string{"password": "changed"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.OneError(), jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{
+			UnitTag: "unit-wordpress-0",
+			ID:      id,
+		}, {
+			UnitTag:  "unit-wordpress-0",
+			ID:       id,
+			Revision: 1,
+		}, {
+			UnitTag: "unit-wordpress-0",
+			ID:      "secret:42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{{
+			Data: map[string]string{"password": "changed"},
+		}, {
+			Data: map[string]string{"password": "sekrit"},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `secret "secret:42" not found`,
+			},
+		}},
+	})
+}
+
+func (s *uniterSuite) TestGrantAndRevokeSecrets(c *gc.C) {
+	s.claimWordpressLeadership(c)
+	id := s.createWordpressSecret(c)
+	rel := s.addRelation(c, "wordpress", "mysql")
+
+	errResults, err := s.uniter.GrantSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			UnitTag:     "unit-wordpress-0",
+			ID:          id,
+			RelationTag: rel.Tag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.OneError(), jc.ErrorIsNil)
+	grants, err := s.State.SecretGrants(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.SecretGrant{{
+		Application: "mysql",
+		RelationKey: rel.String(),
+	}})
+
+	errResults, err = s.uniter.RevokeSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			UnitTag:     "unit-wordpress-0",
+			ID:          id,
+			RelationTag: rel.Tag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.OneError(), jc.ErrorIsNil)
+	grants, err = s.State.SecretGrants(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestSecretValuesNotGranted(c *gc.C) {
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: "mysql",
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{
+			UnitTag: "unit-wordpress-0",
+			ID:      secret.ID,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *uniterSuite) TestSecretRevisions(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: "mysql",
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.GrantSecret(secret.ID, rel, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UpdateSecret(secret.ID, map[string]string{"password": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretRevisions(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-wordpress-0"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretRevisionsResults{
+		Results: []params.SecretRevisionsResult{{
+			Granted: []params.SecretRevision{{ID: secret.ID, Revision: 2}},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeUnauthorized,
+				Message: "permission denied",
+			},
+		}},
+	})
+}
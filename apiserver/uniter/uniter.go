@@ -7,6 +7,7 @@ package uniter
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -29,6 +30,7 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -53,8 +55,8 @@ type UniterAPIV3 struct {
 	StorageAPI
 }
 
-// NewUniterAPIV4 creates a new instance of the Uniter API, version 3.
-func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV3, error) {
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV3, error) {
 	if !authorizer.AuthUnitAgent() {
 		return nil, common.ErrPerm
 	}
@@ -140,6 +142,60 @@ func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer faca
 	}, nil
 }
 
+// UniterAPIV4 implements the API version 4, which lacks the methods
+// added in version 5.
+type UniterAPIV4 struct {
+	*UniterAPIV3
+}
+
+// NewUniterAPIV4 creates a new instance of the Uniter API, version 4.
+func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV4, error) {
+	api, err := NewUniterAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV4{api}, nil
+}
+
+// The version 5 methods are hidden from version 4 by the methods
+// below, which take two arguments and so are not exposed over RPC.
+
+// NetworkInfo isn't on the version 4 API.
+func (*UniterAPIV4) NetworkInfo(_, _ struct{}) {}
+
+// CreateSecrets isn't on the version 4 API.
+func (*UniterAPIV4) CreateSecrets(_, _ struct{}) {}
+
+// UpdateSecrets isn't on the version 4 API.
+func (*UniterAPIV4) UpdateSecrets(_, _ struct{}) {}
+
+// SecretValues isn't on the version 4 API.
+func (*UniterAPIV4) SecretValues(_, _ struct{}) {}
+
+// GrantSecrets isn't on the version 4 API.
+func (*UniterAPIV4) GrantSecrets(_, _ struct{}) {}
+
+// RevokeSecrets isn't on the version 4 API.
+func (*UniterAPIV4) RevokeSecrets(_, _ struct{}) {}
+
+// WatchSecrets isn't on the version 4 API.
+func (*UniterAPIV4) WatchSecrets(_, _ struct{}) {}
+
+// SecretRevisions isn't on the version 4 API.
+func (*UniterAPIV4) SecretRevisions(_, _ struct{}) {}
+
+// TargetCharmURL isn't on the version 4 API.
+func (*UniterAPIV4) TargetCharmURL(_, _ struct{}) {}
+
+// UpgradeSeriesStatus isn't on the version 4 API.
+func (*UniterAPIV4) UpgradeSeriesStatus(_, _ struct{}) {}
+
+// FinishUpgradeSeries isn't on the version 4 API.
+func (*UniterAPIV4) FinishUpgradeSeries(_, _ struct{}) {}
+
+// SetRelationsSettled isn't on the version 4 API.
+func (*UniterAPIV4) SetRelationsSettled(_, _ struct{}) {}
+
 // AllMachinePorts returns all opened port ranges for each given
 // machine (on all networks).
 func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
@@ -217,6 +273,7 @@ func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag s
 		// AllPortRanges gives a map, but apis require a stable order
 		// for results, so sort the port ranges.
 		portRangesToUnits := ports.AllPortRanges()
+		portRangesToEndpoints := ports.AllPortRangeEndpoints()
 		portRanges := make([]network.PortRange, 0, len(portRangesToUnits))
 		for portRange := range portRangesToUnits {
 			portRanges = append(portRanges, portRange)
@@ -224,10 +281,13 @@ func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag s
 		network.SortPortRanges(portRanges)
 		for _, portRange := range portRanges {
 			unitName := portRangesToUnits[portRange]
-			resultPorts = append(resultPorts, params.MachinePortRange{
-				UnitTag:   names.NewUnitTag(unitName).String(),
-				PortRange: params.FromNetworkPortRange(portRange),
-			})
+			for _, endpoint := range portRangesToEndpoints[portRange] {
+				resultPorts = append(resultPorts, params.MachinePortRange{
+					UnitTag:   names.NewUnitTag(unitName).String(),
+					PortRange: params.FromNetworkPortRange(portRange),
+					Endpoint:  endpoint,
+				})
+			}
 		}
 	}
 	return params.MachinePortsResult{
@@ -407,6 +467,64 @@ func (u *UniterAPIV3) ClearResolved(args params.Entities) (params.ErrorResults,
 	return result, nil
 }
 
+// UpgradeSeriesStatus returns, for each given unit, the progress of
+// the unit through the series upgrade of its machine.
+func (u *UniterAPIV3) UpgradeSeriesStatus(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				result.Results[i].Result = string(unit.UpgradeSeriesStatus())
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// FinishUpgradeSeries records, for each given unit, that the unit has
+// run its post-series-upgrade hook.
+func (u *UniterAPIV3) FinishUpgradeSeries(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.FinishUpgradeSeries()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetPrincipal returns the result of calling PrincipalName() and
 // converting it to a tag, on each given unit.
 func (u *UniterAPIV3) GetPrincipal(args params.Entities) (params.StringBoolResults, error) {
@@ -612,6 +730,43 @@ func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults,
 	return result, nil
 }
 
+// TargetCharmURL returns, for each given unit, the URL of the charm the
+// unit should be running, and whether the upgrade to it is forced. This
+// is the charm of the unit's application, unless the unit is held at
+// its current charm during a rolling charm upgrade.
+func (u *UniterAPIV3) TargetCharmURL(args params.Entities) (params.StringBoolResults, error) {
+	result := params.StringBoolResults{
+		Results: make([]params.StringBoolResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringBoolResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				var curl *charm.URL
+				var force bool
+				curl, force, err = unit.TargetCharmURL()
+				if err == nil && curl != nil {
+					result.Results[i].Result = curl.String()
+					result.Results[i].Ok = force
+				}
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not know.
 func (u *UniterAPIV3) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -735,7 +890,7 @@ func (u *UniterAPIV3) OpenPorts(args params.EntitiesPortRanges) (params.ErrorRes
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenEndpointPorts(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -764,7 +919,7 @@ func (u *UniterAPIV3) ClosePorts(args params.EntitiesPortRanges) (params.ErrorRe
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.ClosePorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.CloseEndpointPorts(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1053,6 +1208,7 @@ func (u *UniterAPIV3) EnterScope(args params.RelationUnits) (params.ErrorResults
 			settings := map[string]interface{}{
 				"private-address": privateAddress.Value,
 			}
+			u.addIngressSettings(settings, tag, relUnit.Endpoint().Name)
 			err = relUnit.EnterScope(settings)
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1060,6 +1216,28 @@ func (u *UniterAPIV3) EnterScope(args params.RelationUnits) (params.ErrorResults
 	return result, nil
 }
 
+// addIngressSettings publishes the unit's ingress address and egress
+// subnets for the given endpoint into the relation settings. Failing
+// to work them out is not fatal, as the private address is always set.
+func (u *UniterAPIV3) addIngressSettings(settings map[string]interface{}, tag names.UnitTag, endpoint string) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		logger.Warningf("cannot get unit %q: %v", tag.Id(), err)
+		return
+	}
+	info, err := u.getOneNetworkInfo(unit, endpoint)
+	if err != nil {
+		logger.Warningf("cannot get network info for %q endpoint %q: %v", tag.Id(), endpoint, err)
+		return
+	}
+	if len(info.IngressAddresses) > 0 {
+		settings["ingress-address"] = info.IngressAddresses[0]
+	}
+	if len(info.EgressSubnets) > 0 {
+		settings["egress-subnets"] = strings.Join(info.EgressSubnets, ",")
+	}
+}
+
 // LeaveScope signals each unit has left its scope in the relation,
 // for all of the given relation/unit pairs. See also
 // state.RelationUnit.LeaveScope().
@@ -1086,6 +1264,32 @@ func (u *UniterAPIV3) LeaveScope(args params.RelationUnits) (params.ErrorResults
 	return result, nil
 }
 
+// SetRelationsSettled records, for each given relation/unit pair,
+// whether the unit has run all the relation hooks due so far. See also
+// state.RelationUnit.SetSettled().
+func (u *UniterAPIV3) SetRelationsSettled(args params.RelationUnitsSettled) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			err = relUnit.SetSettled(arg.Settled)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // ReadSettings returns the local settings of each given set of
 // relation/unit.
 func (u *UniterAPIV3) ReadSettings(args params.RelationUnits) (params.SettingsResults, error) {
@@ -1213,7 +1417,8 @@ func (u *UniterAPIV3) WatchRelationUnits(args params.RelationUnits) (params.Rela
 }
 
 // WatchUnitAddresses returns a NotifyWatcher for observing changes
-// to each unit's addresses.
+// to each unit's addresses, including changes to the endpoint bindings
+// of the unit's application.
 func (u *UniterAPIV3) WatchUnitAddresses(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -1399,7 +1604,17 @@ func (u *UniterAPIV3) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	watch := machine.WatchAddresses()
+	application, err := unit.Application()
+	if err != nil {
+		return "", err
+	}
+	// Changes to the application's endpoint bindings change the
+	// addresses the unit's endpoints are bound to, so they are
+	// reported along with changes to the machine's addresses.
+	watch := common.NewMultiNotifyWatcher(
+		machine.WatchAddresses(),
+		application.WatchEndpointBindings(),
+	)
 	// Consume the initial event. Technically, API
 	// calls to Watch 'transmit' the initial event
 	// in the Watch response. But NotifyWatchers
@@ -1652,6 +1867,7 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		machineID, addresses, unit.Name(), service.Name(), bindings,
 	)
 
+	var boundAddresses []network.Address
 	for _, addr := range addresses {
 		subnet, err := addr.Subnet()
 		if errors.IsNotFound(err) {
@@ -1666,10 +1882,18 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 			continue
 		}
 		logger.Debugf("endpoint %q bound to space %q has address %q", bindingName, boundSpace, addr)
+		boundAddresses = append(boundAddresses, network.NewAddress(addr.Value()))
+	}
 
+	// List the addresses of the preferred IP address family first.
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, addr := range network.OrderAddressesByPreference(boundAddresses, cfg.AddressPreference()) {
 		// TODO(dimitern): Fill in the rest later (see linked LKK card above).
 		results = append(results, params.NetworkConfig{
-			Address: addr.Value(),
+			Address: addr.Value,
 		})
 	}
 
@@ -114,7 +114,7 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	uniterAPIV3, err := uniter.NewUniterAPIV4(
+	uniterAPIV3, err := uniter.NewUniterAPIV5(
 		s.State,
 		s.resources,
 		s.authorizer,
@@ -126,7 +126,7 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
 	anAuthorizer := s.authorizer
 	anAuthorizer.Tag = names.NewMachineTag("9")
-	_, err := uniter.NewUniterAPIV4(s.State, s.resources, anAuthorizer)
+	_, err := uniter.NewUniterAPIV5(s.State, s.resources, anAuthorizer)
 	c.Assert(err, gc.NotNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
@@ -571,6 +571,64 @@ func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
 }
 
+func (s *uniterSuite) upgradeSeries(c *gc.C) {
+	trustyCharm := s.Factory.MakeCharm(c, &jujuFactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:trusty/wordpress-3",
+	})
+	err := s.wordpress.SetSeries(state.SetSeriesConfig{Series: "trusty", Charm: trustyCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine0.PrepareUpgradeSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestUpgradeSeriesStatus(c *gc.C) {
+	s.upgradeSeries(c)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.UpgradeSeriesStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "prepared"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestFinishUpgradeSeries(c *gc.C) {
+	s.upgradeSeries(c)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.FinishUpgradeSeries(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{&params.Error{Message: `cannot finish series upgrade of unit "wordpress/0": upgrade not completed`}},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machine0.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.FinishUpgradeSeries(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.UpgradeSeriesStatus(), gc.Equals, state.UpgradeSeriesNotStarted)
+}
+
 func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Add a subordinate to wordpressUnit.
 	_, _, subordinate := s.addRelatedService(c, "wordpress", "logging", s.wordpressUnit)
@@ -600,7 +658,7 @@ func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Now try as subordinate's agent.
 	subAuthorizer := s.authorizer
 	subAuthorizer.Tag = subordinate.Tag()
-	subUniter, err := uniter.NewUniterAPIV4(s.State, s.resources, subAuthorizer)
+	subUniter, err := uniter.NewUniterAPIV5(s.State, s.resources, subAuthorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err = subUniter.GetPrincipal(args)
@@ -750,6 +808,45 @@ func (s *uniterSuite) TestCharmURL(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestTargetCharmURL(c *gc.C) {
+	newCharm := s.Factory.MakeCharm(c, &jujuFactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.TargetCharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: s.wpCharm.String()},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// During a rolling upgrade the unit is held at its charm.
+	err = s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:      newCharm,
+		ForceUnits: true,
+		Rollout:    &state.CharmRolloutParams{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.TargetCharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], gc.DeepEquals, params.StringBoolResult{Result: s.wpCharm.String()})
+
+	// Once released, it follows the application.
+	err = s.wordpress.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.TargetCharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1], gc.DeepEquals, params.StringBoolResult{Result: newCharm.String(), Ok: true})
+}
+
 func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	_, ok := s.wordpressUnit.CharmURL()
 	c.Assert(ok, jc.IsFalse)
@@ -1216,7 +1313,7 @@ func (s *uniterSuite) TestActionsWrongUnit(c *gc.C) {
 	mysqlUnitAuthorizer := apiservertesting.FakeAuthorizer{
 		Tag: s.mysqlUnit.Tag(),
 	}
-	mysqlUnitFacade, err := uniter.NewUniterAPIV4(s.State, s.resources, mysqlUnitAuthorizer)
+	mysqlUnitFacade, err := uniter.NewUniterAPIV5(s.State, s.resources, mysqlUnitAuthorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
@@ -1508,6 +1605,8 @@ func (s *uniterSuite) TestEnterScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
 		"private-address": "1.2.3.4",
+		"ingress-address": "1.2.3.4",
+		"egress-subnets":  "1.2.3.4/32",
 	})
 }
 
@@ -1560,6 +1659,38 @@ func (s *uniterSuite) TestLeaveScope(c *gc.C) {
 	c.Assert(readSettings, gc.DeepEquals, settings)
 }
 
+func (s *uniterSuite) TestSetRelationsSettled(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettled{RelationUnits: []params.RelationUnitSettled{
+		{Relation: "relation-42", Unit: "unit-foo-0", Settled: true},
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0", Settled: true},
+		{Relation: "relation-42", Unit: "unit-wordpress-0", Settled: true},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0", Settled: true},
+		{Relation: rel.Tag().String(), Unit: "application-wordpress", Settled: true},
+		{Relation: "foo", Unit: "bar", Settled: true},
+	}}
+	result, err := s.uniter.SetRelationsSettled(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+	settled, err := relUnit.Settled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settled, jc.IsTrue)
+}
+
 func (s *uniterSuite) TestJoinedRelations(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -2282,7 +2413,7 @@ func (s *unitMetricBatchesSuite) SetUpTest(c *gc.C) {
 		Tag: s.meteredUnit.Tag(),
 	}
 	var err error
-	s.uniter, err = uniter.NewUniterAPIV4(
+	s.uniter, err = uniter.NewUniterAPIV5(
 		s.State,
 		s.resources,
 		meteredAuthorizer,
@@ -2552,7 +2683,7 @@ func (s *uniterNetworkConfigSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *sta
 	}
 
 	var err error
-	s.base.uniter, err = uniter.NewUniterAPIV4(
+	s.base.uniter, err = uniter.NewUniterAPIV5(
 		s.base.State,
 		s.base.resources,
 		s.base.authorizer,
@@ -2656,3 +2787,121 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpoint(c
 		},
 	})
 }
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoPermissions(c *gc.C) {
+	_, err := s.base.uniter.NetworkInfo(params.NetworkInfoParams{
+		Unit:     "unit-mysql-0",
+		Bindings: []string{"server"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	result, err := s.base.uniter.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.wordpressUnit.Tag().String(),
+		Bindings: []string{"", "unknown"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"":        {Error: apiservertesting.ServerError(`binding name cannot be empty`)},
+			"unknown": {Error: apiservertesting.ServerError(`binding name "unknown" not defined by the unit's charm`)},
+		},
+	})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoForExplicitlyBoundEndpoint(c *gc.C) {
+	result, err := s.base.uniter.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.wordpressUnit.Tag().String(),
+		Bindings: []string{"db", "admin-api"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"db": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.10", CIDR: "10.0.0.0/24"}},
+				}, {
+					InterfaceName: "eth1.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.11", CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{"10.0.0.10", "10.0.0.11"},
+				EgressSubnets:    []string{"10.0.0.10/32"},
+			},
+			"admin-api": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0",
+					Addresses:     []params.InterfaceAddress{{Address: "8.8.8.10", CIDR: "8.8.0.0/16"}},
+				}, {
+					InterfaceName: "eth1",
+					Addresses:     []params.InterfaceAddress{{Address: "8.8.4.10", CIDR: "8.8.0.0/16"}},
+				}},
+				IngressAddresses: []string{"8.8.8.10", "8.8.4.10"},
+				EgressSubnets:    []string{"8.8.8.10/32"},
+			},
+		},
+	})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoUsesEgressSubnetsFromModelConfig(c *gc.C) {
+	err := s.base.State.UpdateModelConfig(map[string]interface{}{
+		"egress-subnets": "192.168.0.0/16,10.1.0.0/16",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.base.uniter.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.wordpressUnit.Tag().String(),
+		Bindings: []string{"db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results["db"].Error, gc.IsNil)
+	c.Assert(result.Results["db"].IngressAddresses, jc.DeepEquals, []string{"10.0.0.10", "10.0.0.11"})
+	c.Assert(result.Results["db"].EgressSubnets, jc.DeepEquals, []string{"192.168.0.0/16", "10.1.0.0/16"})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoForImplicitlyBoundEndpoint(c *gc.C) {
+	s.setupUniterAPIForUnit(c, s.base.mysqlUnit)
+
+	privateAddress, err := s.base.machine1.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(privateAddress.Value, gc.Equals, "10.0.0.20")
+
+	result, err := s.base.uniter.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.mysqlUnit.Tag().String(),
+		Bindings: []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"server": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0.100",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.20", CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{"10.0.0.20"},
+				EgressSubnets:    []string{"10.0.0.20/32"},
+			},
+		},
+	})
+}
+
+func (s *uniterNetworkConfigSuite) TestNetworkInfoBehindNAT(c *gc.C) {
+	s.setupUniterAPIForUnit(c, s.base.mysqlUnit)
+
+	// The public address is a floating IP, not configured on any of the
+	// machine's devices.
+	err := s.base.machine1.SetProviderAddresses(
+		network.NewAddress("10.0.0.20"),
+		network.NewAddress("54.1.2.3"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.base.uniter.NetworkInfo(params.NetworkInfoParams{
+		Unit:     s.base.mysqlUnit.Tag().String(),
+		Bindings: []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	info := result.Results["server"]
+	c.Assert(info.Error, gc.IsNil)
+	c.Assert(info.IngressAddresses, jc.DeepEquals, []string{"54.1.2.3", "10.0.0.20"})
+	c.Assert(info.EgressSubnets, jc.DeepEquals, []string{"54.1.2.3/32"})
+}
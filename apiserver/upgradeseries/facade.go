@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the API facade used by the
+// upgradeseries worker.
+package upgradeseries
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("UpgradeSeries", 1, newFacade)
+}
+
+// Backend defines the State API used by the upgradeseries facade.
+type Backend interface {
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the machine methods used by the upgradeseries
+// facade.
+type Machine interface {
+	Series() string
+	UpgradeSeriesStatus() (state.UpgradeSeriesStatus, string)
+	FinishUpgradeSeries() error
+	UnitNames() ([]string, error)
+	Watch() state.NotifyWatcher
+}
+
+// Facade implements the API required by the upgradeseries worker.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+	canAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the upgradeseries worker.
+func New(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+		canAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// WatchUpgradeSeries returns a watcher that fires when the given
+// machines change, so that their agents notice series upgrades.
+func (f *Facade) WatchUpgradeSeries(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := f.withMachine(entity.Tag, func(m Machine) error {
+			watch := m.Watch()
+			// Consume the initial event; it is transmitted in the
+			// response to the Watch call.
+			if _, ok := <-watch.Changes(); !ok {
+				return watcher.EnsureErr(watch)
+			}
+			results.Results[i].NotifyWatcherId = f.resources.Register(watch)
+			return nil
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// UpgradeSeriesInfo returns the progress of the series upgrade of each
+// given machine, its series and the series it is being upgraded to, and
+// the units running on it.
+func (f *Facade) UpgradeSeriesInfo(args params.Entities) (params.UpgradeSeriesInfoResults, error) {
+	results := params.UpgradeSeriesInfoResults{
+		Results: make([]params.UpgradeSeriesInfoResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := f.withMachine(entity.Tag, func(m Machine) error {
+			units, err := m.UnitNames()
+			if err != nil {
+				return err
+			}
+			status, target := m.UpgradeSeriesStatus()
+			results.Results[i].Result = &params.UpgradeSeriesInfo{
+				Status:       params.UpgradeSeriesStatus(status),
+				Series:       m.Series(),
+				TargetSeries: target,
+				Units:        units,
+			}
+			return nil
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// FinishUpgradeSeries records, for each given machine, that its agent
+// has seen its series upgrade completed.
+func (f *Facade) FinishUpgradeSeries(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := f.withMachine(entity.Tag, Machine.FinishUpgradeSeries)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (f *Facade) withMachine(tag string, fn func(Machine) error) error {
+	canAccess, err := f.canAccess()
+	if err != nil {
+		return err
+	}
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil || !canAccess(machineTag) {
+		return common.ErrPerm
+	}
+	m, err := f.backend.Machine(machineTag.Id())
+	if err != nil {
+		return err
+	}
+	return fn(m)
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/upgradeseries"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *upgradeseries.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		machine: &mockMachine{
+			series: "trusty",
+			status: state.UpgradeSeriesPrepared,
+			target: "xenial",
+			units:  []string{"mysql/0", "nrpe/0"},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("1")}
+	facade, err := upgradeseries.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewNotMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := upgradeseries.New(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestWatchUpgradeSeries(c *gc.C) {
+	results, err := s.facade.WatchUpgradeSeries(entities("machine-0", "machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+	s.backend.stub.CheckCall(c, 0, "Machine", "1")
+}
+
+func (s *facadeSuite) TestUpgradeSeriesInfo(c *gc.C) {
+	results, err := s.facade.UpgradeSeriesInfo(entities("machine-0", "machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpgradeSeriesInfoResults{
+		Results: []params.UpgradeSeriesInfoResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.UpgradeSeriesInfo{
+				Status:       params.UpgradeSeriesPrepared,
+				Series:       "trusty",
+				TargetSeries: "xenial",
+				Units:        []string{"mysql/0", "nrpe/0"},
+			}},
+		},
+	})
+}
+
+func (s *facadeSuite) TestFinishUpgradeSeries(c *gc.C) {
+	s.backend.machine.finishErr = errors.New("upgrade not completed")
+	results, err := s.facade.FinishUpgradeSeries(entities("machine-0", "machine-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: "upgrade not completed"}},
+		},
+	})
+}
+
+func entities(tags ...string) params.Entities {
+	var result params.Entities
+	for _, tag := range tags {
+		result.Entities = append(result.Entities, params.Entity{Tag: tag})
+	}
+	return result
+}
+
+type mockBackend struct {
+	stub    jujutesting.Stub
+	machine *mockMachine
+}
+
+func (b *mockBackend) Machine(id string) (upgradeseries.Machine, error) {
+	b.stub.AddCall("Machine", id)
+	return b.machine, nil
+}
+
+type mockMachine struct {
+	series    string
+	status    state.UpgradeSeriesStatus
+	target    string
+	units     []string
+	finishErr error
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
+func (m *mockMachine) UpgradeSeriesStatus() (state.UpgradeSeriesStatus, string) {
+	return m.status, m.target
+}
+
+func (m *mockMachine) FinishUpgradeSeries() error {
+	return m.finishErr
+}
+
+func (m *mockMachine) UnitNames() ([]string, error) {
+	return m.units, nil
+}
+
+func (m *mockMachine) Watch() state.NotifyWatcher {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return &mockWatcher{changes: changes}
+}
+
+// mockWatcher implements state.NotifyWatcher for the tests' convenience.
+type mockWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+}
+
+func (w *mockWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockWatcher) Stop() error {
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	facade, err := New(backendShim{st}, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return facade, nil
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+func (b backendShim) Machine(id string) (Machine, error) {
+	m, err := b.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineShim{m}, nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+// UnitNames returns the names of the units on the machine.
+func (m machineShim) UnitNames() ([]string, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(units))
+	for i, unit := range units {
+		names[i] = unit.Name()
+	}
+	return names, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const annotateDoc = `
+Sets one or more annotations on an entity. Annotations are free-form
+key=value pairs which Juju stores but does not interpret; they can be
+used to record information such as an owner or a purpose, and can be
+used to filter the output of "juju status" with annotation:key=value.
+` + entityDoc + `
+Examples:
+    juju annotate mysql owner=dba-team
+    juju annotate mysql/0 note="do not reboot"
+    juju annotate 3 rack=r12 row=b
+    juju annotate model purpose=staging
+
+See also:
+    remove-annotation
+    show-annotations
+    status
+`
+
+// NewAnnotateCommand returns a command which sets annotations
+// on an entity.
+func NewAnnotateCommand() cmd.Command {
+	return modelcmd.Wrap(&annotateCommand{})
+}
+
+// annotateCommand sets annotations on an entity.
+type annotateCommand struct {
+	annotationsCommandBase
+	entity      string
+	annotations map[string]string
+}
+
+// Info implements Command.Info.
+func (c *annotateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "annotate",
+		Args:    "<entity> <key>=<value> ...",
+		Purpose: "Sets annotations on an application, unit, machine or model.",
+		Doc:     annotateDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *annotateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity specified")
+	}
+	c.entity = args[0]
+	if len(args) == 1 {
+		return errors.New("no annotations specified")
+	}
+	annotations, err := keyvalues.Parse(args[1:], false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.annotations = annotations
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *annotateCommand) Run(ctx *cmd.Context) error {
+	tag, err := c.entityTag(c.entity)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.Set(map[string]map[string]string{
+		tag.String(): c.annotations,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return block.ProcessBlockedError(combineErrors(results), block.BlockChange)
+}
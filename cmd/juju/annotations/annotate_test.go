@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/annotations"
+	"github.com/juju/juju/testing"
+)
+
+type AnnotateSuite struct {
+	annotationsSuite
+}
+
+var _ = gc.Suite(&AnnotateSuite{})
+
+func (s *AnnotateSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, annotations.NewAnnotateCommandForTest(s.fake, s.store), args...)
+	return err
+}
+
+func (s *AnnotateSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no entity specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no annotations specified",
+	}, {
+		args: []string{"mysql", "owner"},
+		err:  `expected "key=value", got "owner"`,
+	}, {
+		args: []string{"mysql", "owner="},
+		err:  `expected "key=value", got "owner="`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AnnotateSuite) TestAnnotate(c *gc.C) {
+	for i, test := range []struct {
+		entity string
+		tag    string
+	}{
+		{"mysql", "application-mysql"},
+		{"mysql/0", "unit-mysql-0"},
+		{"3", "machine-3"},
+		{"0/lxd/1", "machine-0-lxd-1"},
+		{"unit-mysql-1", "unit-mysql-1"},
+		{"model", testing.ModelTag.String()},
+	} {
+		c.Logf("test %d: %s", i, test.entity)
+		s.fake.ResetCalls()
+		err := s.run(c, test.entity, "owner=dba", "rack=r12")
+		c.Assert(err, jc.ErrorIsNil)
+		s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+			{"Set", []interface{}{map[string]map[string]string{
+				test.tag: {"owner": "dba", "rack": "r12"},
+			}}},
+			{"Close", nil},
+		})
+	}
+}
+
+func (s *AnnotateSuite) TestAnnotateInvalidEntity(c *gc.C) {
+	err := s.run(c, "not/an/entity", "owner=dba")
+	c.Assert(err, gc.ErrorMatches, `entity "not/an/entity" not valid`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *AnnotateSuite) TestAnnotateError(c *gc.C) {
+	s.fake.setResults = []params.ErrorResult{{
+		Error: &params.Error{Message: `application "mysql" not found`},
+	}}
+	err := s.run(c, "mysql", "owner=dba")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
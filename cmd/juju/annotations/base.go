@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package annotations provides the commands used to set, remove and
+// show annotations on applications, units, machines and models.
+package annotations
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/annotations"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// modelEntity is the name used on the command line to refer
+// to the current model.
+const modelEntity = "model"
+
+const entityDoc = `
+The entity may be an application name, a unit name, a machine id, or
+"model" to refer to the current model. Entity tags such as
+"application-mysql" may also be used.
+`
+
+// annotationsAPI defines the API methods used by the annotation
+// commands.
+type annotationsAPI interface {
+	Close() error
+	Get(tags []string) ([]params.AnnotationsGetResult, error)
+	Set(annotations map[string]map[string]string) ([]params.ErrorResult, error)
+}
+
+// annotationsCommandBase is the base type for the annotation commands.
+type annotationsCommandBase struct {
+	modelcmd.ModelCommandBase
+	api annotationsAPI
+}
+
+func (c *annotationsCommandBase) getAPI() (annotationsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
+// entityTag returns the tag of the entity named on the command line.
+func (c *annotationsCommandBase) entityTag(entity string) (names.Tag, error) {
+	if entity == modelEntity {
+		return c.modelTag()
+	}
+	if tag, err := names.ParseTag(entity); err == nil {
+		switch tag.Kind() {
+		case names.ApplicationTagKind, names.UnitTagKind, names.MachineTagKind, names.ModelTagKind:
+			return tag, nil
+		}
+	}
+	switch {
+	case names.IsValidMachine(entity):
+		return names.NewMachineTag(entity), nil
+	case names.IsValidUnit(entity):
+		return names.NewUnitTag(entity), nil
+	case names.IsValidApplication(entity):
+		return names.NewApplicationTag(entity), nil
+	}
+	return nil, errors.NotValidf("entity %q", entity)
+}
+
+// modelTag returns the tag of the model the command operates on.
+func (c *annotationsCommandBase) modelTag() (names.Tag, error) {
+	details, err := c.ClientStore().ModelByName(c.ControllerName(), c.ModelName())
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model details")
+	}
+	return names.NewModelTag(details.ModelUUID), nil
+}
+
+// combineErrors returns a single error combining any errors
+// in the given results.
+func combineErrors(results []params.ErrorResult) error {
+	return params.ErrorResults{Results: results}.Combine()
+}
@@ -0,0 +1,38 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewAnnotateCommandForTest returns an annotateCommand with the api
+// and store provided as specified.
+func NewAnnotateCommandForTest(api annotationsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &annotateCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveAnnotationCommandForTest returns a removeAnnotationCommand
+// with the api and store provided as specified.
+func NewRemoveAnnotationCommandForTest(api annotationsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeAnnotationCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowAnnotationsCommandForTest returns a showAnnotationsCommand
+// with the api and store provided as specified.
+func NewShowAnnotationsCommandForTest(api annotationsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showAnnotationsCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations_test
+
+import (
+	stdtesting "testing"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+func TestAll(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type annotationsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeAnnotationsAPI
+	store *jujuclienttesting.MemStore
+}
+
+func (s *annotationsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeAnnotationsAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin@local/mymodel"
+}
+
+type fakeAnnotationsAPI struct {
+	gitjujutesting.Stub
+	getResults []params.AnnotationsGetResult
+	setResults []params.ErrorResult
+}
+
+func (f *fakeAnnotationsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAnnotationsAPI) Get(tags []string) ([]params.AnnotationsGetResult, error) {
+	f.MethodCall(f, "Get", tags)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.getResults, nil
+}
+
+func (f *fakeAnnotationsAPI) Set(annotations map[string]map[string]string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "Set", annotations)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.setResults, nil
+}
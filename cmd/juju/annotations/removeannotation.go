@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeAnnotationDoc = `
+Removes one or more annotations from an entity. Removing an annotation
+which is not set is not an error.
+` + entityDoc + `
+Examples:
+    juju remove-annotation mysql owner
+    juju remove-annotation model purpose
+
+See also:
+    annotate
+    show-annotations
+`
+
+// NewRemoveAnnotationCommand returns a command which removes
+// annotations from an entity.
+func NewRemoveAnnotationCommand() cmd.Command {
+	return modelcmd.Wrap(&removeAnnotationCommand{})
+}
+
+// removeAnnotationCommand removes annotations from an entity.
+type removeAnnotationCommand struct {
+	annotationsCommandBase
+	entity string
+	keys   []string
+}
+
+// Info implements Command.Info.
+func (c *removeAnnotationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-annotation",
+		Args:    "<entity> <key> ...",
+		Purpose: "Removes annotations from an application, unit, machine or model.",
+		Doc:     removeAnnotationDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeAnnotationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity specified")
+	}
+	c.entity = args[0]
+	if len(args) == 1 {
+		return errors.New("no annotation keys specified")
+	}
+	c.keys = args[1:]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeAnnotationCommand) Run(ctx *cmd.Context) error {
+	tag, err := c.entityTag(c.entity)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Setting an annotation to the empty string removes it.
+	annotations := make(map[string]string)
+	for _, key := range c.keys {
+		annotations[key] = ""
+	}
+	results, err := client.Set(map[string]map[string]string{
+		tag.String(): annotations,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return block.ProcessBlockedError(combineErrors(results), block.BlockChange)
+}
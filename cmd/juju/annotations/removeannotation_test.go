@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/annotations"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveAnnotationSuite struct {
+	annotationsSuite
+}
+
+var _ = gc.Suite(&RemoveAnnotationSuite{})
+
+func (s *RemoveAnnotationSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, annotations.NewRemoveAnnotationCommandForTest(s.fake, s.store), args...)
+	return err
+}
+
+func (s *RemoveAnnotationSuite) TestInitErrors(c *gc.C) {
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no entity specified")
+	err = s.run(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "no annotation keys specified")
+}
+
+func (s *RemoveAnnotationSuite) TestRemoveAnnotation(c *gc.C) {
+	err := s.run(c, "mysql/0", "owner", "rack")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Set", []interface{}{map[string]map[string]string{
+			"unit-mysql-0": {"owner": "", "rack": ""},
+		}}},
+		{"Close", nil},
+	})
+}
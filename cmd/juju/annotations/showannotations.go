@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showAnnotationsDoc = `
+Shows the annotations set on one or more entities, keyed by the entity
+names given on the command line.
+` + entityDoc + `
+The default output format is yaml; json is also available.
+
+Examples:
+    juju show-annotations mysql
+    juju show-annotations mysql/0 3 model --format json
+
+See also:
+    annotate
+    remove-annotation
+`
+
+// NewShowAnnotationsCommand returns a command which shows the
+// annotations on entities.
+func NewShowAnnotationsCommand() cmd.Command {
+	return modelcmd.Wrap(&showAnnotationsCommand{})
+}
+
+// showAnnotationsCommand shows the annotations on entities.
+type showAnnotationsCommand struct {
+	annotationsCommandBase
+	out      cmd.Output
+	entities []string
+}
+
+// Info implements Command.Info.
+func (c *showAnnotationsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-annotations",
+		Args:    "<entity> ...",
+		Purpose: "Shows the annotations on applications, units, machines or models.",
+		Doc:     showAnnotationsDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showAnnotationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.annotationsCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *showAnnotationsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity specified")
+	}
+	c.entities = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showAnnotationsCommand) Run(ctx *cmd.Context) error {
+	tags := make([]string, len(c.entities))
+	for i, entity := range c.entities {
+		tag, err := c.entityTag(entity)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tags[i] = tag.String()
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.Get(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != len(tags) {
+		return errors.Errorf("expected %d results, got %d", len(tags), len(results))
+	}
+	var errs []params.ErrorResult
+	output := make(map[string]map[string]string)
+	for i, result := range results {
+		if result.Error.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		annotations := result.Annotations
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		output[c.entities[i]] = annotations
+	}
+	if err := combineErrors(errs); err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
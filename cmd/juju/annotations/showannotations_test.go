@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/annotations"
+	"github.com/juju/juju/testing"
+)
+
+type ShowAnnotationsSuite struct {
+	annotationsSuite
+}
+
+var _ = gc.Suite(&ShowAnnotationsSuite{})
+
+func (s *ShowAnnotationsSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, annotations.NewShowAnnotationsCommandForTest(s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no entity specified")
+}
+
+func (s *ShowAnnotationsSuite) TestShowAnnotations(c *gc.C) {
+	s.fake.getResults = []params.AnnotationsGetResult{{
+		EntityTag:   "application-mysql",
+		Annotations: map[string]string{"owner": "dba", "rack": "r12"},
+	}, {
+		EntityTag: testing.ModelTag.String(),
+	}}
+	ctx, err := testing.RunCommand(c, annotations.NewShowAnnotationsCommandForTest(s.fake, s.store), "mysql", "model")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Get", []interface{}{[]string{"application-mysql", testing.ModelTag.String()}}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+model: {}
+mysql:
+  owner: dba
+  rack: r12
+`[1:])
+}
+
+func (s *ShowAnnotationsSuite) TestShowAnnotationsJSON(c *gc.C) {
+	s.fake.getResults = []params.AnnotationsGetResult{{
+		EntityTag:   "machine-3",
+		Annotations: map[string]string{"rack": "r12"},
+	}}
+	ctx, err := testing.RunCommand(c, annotations.NewShowAnnotationsCommandForTest(s.fake, s.store), "3", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `{"3":{"rack":"r12"}}`+"\n")
+}
+
+func (s *ShowAnnotationsSuite) TestShowAnnotationsError(c *gc.C) {
+	s.fake.getResults = []params.AnnotationsGetResult{{
+		EntityTag: "application-mysql",
+		Error: params.ErrorResult{
+			Error: &params.Error{Message: `application "mysql" not found`},
+		},
+	}}
+	_, err := testing.RunCommand(c, annotations.NewShowAnnotationsCommandForTest(s.fake, s.store), "mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
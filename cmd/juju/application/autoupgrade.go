@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetAutoUpgradeSummary = `
+Sets whether an application's charm is upgraded automatically.`[1:]
+
+var usageSetAutoUpgradeDetails = `
+The controller regularly checks the charm store for newer revisions of
+deployed charms. With auto-upgrade enabled, an application is upgraded,
+as if by upgrade-charm, whenever a newer revision appears in the
+channel it tracks. Each automatic upgrade is recorded in the
+application's status history.
+
+The mode is one of:
+
+    off            never upgrade automatically (the default)
+    patch-channel  upgrade only to revisions that keep every config key
+                   with its type, and every endpoint with its interface
+                   and role
+    channel        upgrade to any newer revision in the channel
+
+Only charms from the charm store are upgraded automatically. The
+--window option restricts automatic upgrades to a daily maintenance
+window, given in UTC as HH:MM-HH:MM; a window may run past midnight.
+
+Examples:
+    juju set-auto-upgrade mysql channel
+    juju set-auto-upgrade wordpress patch-channel --window 22:00-02:00
+    juju set-auto-upgrade wordpress off
+
+See also:
+    upgrade-charm`[1:]
+
+// NewSetAutoUpgradeCommand returns a command to set the automatic
+// charm upgrade policy of an application.
+func NewSetAutoUpgradeCommand() cmd.Command {
+	return modelcmd.Wrap(&setAutoUpgradeCommand{})
+}
+
+// setAutoUpgradeCommand sets the automatic charm upgrade policy of an
+// application.
+type setAutoUpgradeCommand struct {
+	modelcmd.ModelCommandBase
+	api setAutoUpgradeAPI
+
+	ApplicationName string
+	Mode            string
+	Window          string
+}
+
+// setAutoUpgradeAPI defines the API methods used by the
+// set-auto-upgrade command.
+type setAutoUpgradeAPI interface {
+	Close() error
+	SetAutoUpgradePolicy(application, mode, window string) error
+}
+
+// Info implements Command.Info.
+func (c *setAutoUpgradeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-auto-upgrade",
+		Args:    "<application> <off|patch-channel|channel>",
+		Purpose: usageSetAutoUpgradeSummary,
+		Doc:     usageSetAutoUpgradeDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setAutoUpgradeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Window, "window", "", "Daily UTC maintenance window for automatic upgrades, as HH:MM-HH:MM")
+}
+
+// Init implements Command.Init.
+func (c *setAutoUpgradeCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no auto-upgrade mode specified")
+	case 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	switch args[1] {
+	case "off":
+		if c.Window != "" {
+			return errors.New("--window cannot be used when turning auto-upgrade off")
+		}
+	case "patch-channel", "channel":
+	default:
+		return errors.NotValidf("auto-upgrade mode %q", args[1])
+	}
+	c.Mode = args[1]
+	return nil
+}
+
+func (c *setAutoUpgradeCommand) getAPI() (setAutoUpgradeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *setAutoUpgradeCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetAutoUpgradePolicy(c.ApplicationName, c.Mode, c.Window)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
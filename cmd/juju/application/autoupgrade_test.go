@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type SetAutoUpgradeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetAutoUpgradeAPI
+}
+
+var _ = gc.Suite(&SetAutoUpgradeSuite{})
+
+type fakeSetAutoUpgradeAPI struct {
+	application string
+	mode        string
+	window      string
+	err         error
+}
+
+func (f *fakeSetAutoUpgradeAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSetAutoUpgradeAPI) SetAutoUpgradePolicy(application, mode, window string) error {
+	f.application = application
+	f.mode = mode
+	f.window = window
+	return f.err
+}
+
+func (s *SetAutoUpgradeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetAutoUpgradeAPI{}
+}
+
+func (s *SetAutoUpgradeSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no auto-upgrade mode specified",
+	}, {
+		args: []string{"mysql", "channel", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql/0", "channel"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "always"},
+		err:  `auto-upgrade mode "always" not valid`,
+	}, {
+		args: []string{"mysql", "off", "--window", "01:00-02:00"},
+		err:  "--window cannot be used when turning auto-upgrade off",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := testing.RunCommand(c, application.NewSetAutoUpgradeCommandForTest(s.fake), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SetAutoUpgradeSuite) TestSetAutoUpgrade(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetAutoUpgradeCommandForTest(s.fake), "mysql", "patch-channel", "--window", "22:00-02:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.application, gc.Equals, "mysql")
+	c.Check(s.fake.mode, gc.Equals, "patch-channel")
+	c.Check(s.fake.window, gc.Equals, "22:00-02:00")
+}
+
+func (s *SetAutoUpgradeSuite) TestSetAutoUpgradeError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewSetAutoUpgradeCommandForTest(s.fake), "mysql", "channel")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
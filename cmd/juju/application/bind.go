@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces an application's endpoints are bound to.`[1:]
+
+var usageBindDetails = `
+Endpoint bindings are normally set when an application is deployed, with
+deploy --bind. The bind command changes the bindings of a deployed
+application. Each binding is given as endpoint=space; endpoints that are
+not mentioned keep their existing bindings.
+
+Every machine hosting a unit of the application must already have an
+address in each of the spaces being bound to. Once the bindings are
+changed, the application's units run their config-changed hook, so that
+network-get reports the addresses in the new spaces.
+
+Examples:
+    juju bind mysql server=db
+    juju bind wordpress db=internal website=public
+
+See also:
+    deploy
+    spaces`[1:]
+
+// NewBindCommand returns a command to change the endpoint bindings of
+// an application.
+func NewBindCommand() cmd.Command {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+// bindCommand changes the endpoint bindings of an application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	api bindAPI
+
+	ApplicationName string
+	Bindings        map[string]string
+}
+
+// bindAPI defines the API methods used by the bind command.
+type bindAPI interface {
+	Close() error
+	Bind(application string, bindings map[string]string) error
+}
+
+// Info implements Command.Info.
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application> <endpoint>=<space> [...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	c.Bindings = make(map[string]string)
+	for _, arg := range args[1:] {
+		parts := strings.Split(arg, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("binding %q not valid, expected <endpoint>=<space>", arg)
+		}
+		if !names.IsValidSpace(parts[1]) {
+			return errors.NotValidf("space name %q", parts[1])
+		}
+		if _, ok := c.Bindings[parts[0]]; ok {
+			return errors.Errorf("endpoint %q bound more than once", parts[0])
+		}
+		c.Bindings[parts[0]] = parts[1]
+	}
+	return nil
+}
+
+func (c *bindCommand) getAPI() (bindAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *bindCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.Bind(c.ApplicationName, c.Bindings)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeBindAPI
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+type fakeBindAPI struct {
+	application string
+	bindings    map[string]string
+	err         error
+}
+
+func (f *fakeBindAPI) Close() error {
+	return nil
+}
+
+func (f *fakeBindAPI) Bind(application string, bindings map[string]string) error {
+	f.application = application
+	f.bindings = bindings
+	return f.err
+}
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeBindAPI{}
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql/0", "server=db"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql", "db"},
+		err:  `binding "db" not valid, expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "=db"},
+		err:  `binding "=db" not valid, expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "server=db=x"},
+		err:  `binding "server=db=x" not valid, expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "server=%%"},
+		err:  `space name "%%" not valid`,
+	}, {
+		args: []string{"mysql", "server=db", "server=public"},
+		err:  `endpoint "server" bound more than once`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "server=db", "cluster=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.application, gc.Equals, "mysql")
+	c.Check(s.fake.bindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"cluster": "internal",
+	})
+}
+
+func (s *BindSuite) TestBindError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "server=db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
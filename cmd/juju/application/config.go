@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/utils/keyvalues"
@@ -33,6 +34,21 @@ Output includes the name of the charm used to deploy the application and a
 listing of the application-specific configuration settings.
 See ` + "`juju status`" + ` for application names.
 
+Changes made to the configuration are recorded, along with who made them
+and when. The --history option displays the recent changes, most recent
+first, and --rollback restores the configuration recorded at the given
+revision of the history.
+
+The values of secret settings, such as passwords, are stored encrypted
+and are shown as "<redacted>". The encryption key is kept in the
+controller database, so this hides secret values from status, bundles
+and logs, but not from anyone with a copy of that database. Settings
+whose names suggest they hold credentials are treated as secret until
+--secret-keys is used; --secret-keys names exactly the settings that
+are secret, replacing any chosen before (an empty list leaves none
+secret). Model administrators may see the values of secret settings
+with --show-secrets.
+
 Examples:
     juju config apache2
     juju config --format=json apache2
@@ -41,6 +57,10 @@ Examples:
     juju config apache2 --file path/to/config.yaml
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
+    juju config mysql --history
+    juju config mysql --rollback 3
+    juju config mysql --secret-keys root-password,backup-key
+    juju config mysql --show-secrets
 
 See also:
     deploy
@@ -65,26 +85,58 @@ type configCommand struct {
 	action          func(configCommandAPI, *cmd.Context) error // get, set, or reset action set in  Init
 	applicationName string
 	configFile      cmd.FileVar
+	history         bool
 	keys            []string
 	reset           bool
+	rollback        int
+	secretKeys      secretKeysValue
+	showSecrets     bool
 	useFile         bool
 	values          attributes
 }
 
+// secretKeysValue holds the config keys given to --secret-keys, and
+// whether the flag was given at all.
+type secretKeysValue struct {
+	set  bool
+	keys []string
+}
+
+// Set implements gnuflag.Value.
+func (v *secretKeysValue) Set(s string) error {
+	v.set = true
+	v.keys = nil
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			v.keys = append(v.keys, key)
+		}
+	}
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v *secretKeysValue) String() string {
+	return strings.Join(v.keys, ",")
+}
+
 // configCommandAPI is an interface to allow passing in a fake implementation under test.
 type configCommandAPI interface {
 	Close() error
 	Update(args params.ApplicationUpdate) error
 	Get(application string) (*params.ApplicationGetResults, error)
+	GetWithSecrets(application string) (*params.ApplicationGetResults, error)
 	Set(application string, options map[string]string) error
 	Unset(application string, options []string) error
+	ConfigHistory(application string, showSecrets bool) ([]params.ConfigRevision, error)
+	RollbackConfig(application string, revision int) error
+	SetSecretConfigKeys(application string, keys []string) error
 }
 
 // Info is part of the cmd.Command interface.
 func (c *configCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "config",
-		Args:    "<application name> [[--reset] <attribute-key>][=<value>] ...]",
+		Args:    "<application name> [[--reset] <attribute-key>][=<value>] ...] | --history | --rollback <revision> | --secret-keys <key>[,...]",
 		Purpose: configSummary,
 		Doc:     configDetails,
 	}
@@ -96,6 +148,10 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.BoolVar(&c.reset, "reset", false, "Reset the provided keys to be empty")
+	f.BoolVar(&c.history, "history", false, "Display the recorded configuration changes")
+	f.IntVar(&c.rollback, "rollback", 0, "Restore the configuration recorded at the given revision")
+	f.Var(&c.secretKeys, "secret-keys", "Comma-separated configuration keys whose values are secret")
+	f.BoolVar(&c.showSecrets, "show-secrets", false, "Display the values of secret configuration settings")
 }
 
 // getAPI either uses the fake API set at test time or that is nil, gets a real
@@ -118,6 +174,12 @@ func (c *configCommand) Init(args []string) error {
 		return errors.New("no application name specified")
 	}
 	c.applicationName = args[0]
+	if c.secretKeys.set {
+		return c.parseSecretKeys(args[1:])
+	}
+	if c.history || c.rollback != 0 {
+		return c.parseHistory(args[1:])
+	}
 	if c.reset {
 		c.action = c.resetConfig
 		return c.parseReset(args[1:])
@@ -142,6 +204,36 @@ func (c *configCommand) parseReset(args []string) error {
 	return nil
 }
 
+// parseHistory parses the command line args when the --history or
+// --rollback flag is supplied.
+func (c *configCommand) parseHistory(args []string) error {
+	if c.history && c.rollback != 0 {
+		return errors.New("cannot specify --history and --rollback simultaneously")
+	}
+	if c.reset || c.configFile.Path != "" || len(args) > 0 {
+		return errors.New("cannot specify --history or --rollback with other configuration options")
+	}
+	if c.rollback < 0 {
+		return errors.NotValidf("revision %d", c.rollback)
+	}
+	if c.history {
+		c.action = c.getHistory
+	} else {
+		c.action = c.rollbackConfig
+	}
+	return nil
+}
+
+// parseSecretKeys parses the command line args when the --secret-keys
+// flag is supplied.
+func (c *configCommand) parseSecretKeys(args []string) error {
+	if c.history || c.rollback != 0 || c.reset || c.configFile.Path != "" || c.showSecrets || len(args) > 0 {
+		return errors.New("cannot specify --secret-keys with other configuration options")
+	}
+	c.action = c.setSecretKeys
+	return nil
+}
+
 // parseSet parses the command line args when --file is set or if the
 // positional args are key=value pairs.
 func (c *configCommand) parseSet(args []string, file bool) error {
@@ -279,7 +371,11 @@ func (c *configCommand) setConfigFromFile(client configCommandAPI, ctx *cmd.Cont
 
 // getConfig is the run action to return one or all configuration values.
 func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) error {
-	results, err := client.Get(c.applicationName)
+	get := client.Get
+	if c.showSecrets {
+		get = client.GetWithSecrets
+	}
+	results, err := get(c.applicationName)
 	if err != nil {
 		return err
 	}
@@ -306,6 +402,22 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return c.out.Write(ctx, resultsMap)
 }
 
+// getHistory is the run action to display the recorded configuration
+// changes.
+func (c *configCommand) getHistory(client configCommandAPI, ctx *cmd.Context) error {
+	history, err := client.ConfigHistory(c.applicationName, c.showSecrets)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, common.FormatConfigHistory(history))
+}
+
+// rollbackConfig is the run action to restore the configuration recorded
+// at a previous revision.
+func (c *configCommand) rollbackConfig(client configCommandAPI, ctx *cmd.Context) error {
+	return block.ProcessBlockedError(client.RollbackConfig(c.applicationName, c.rollback), block.BlockChange)
+}
+
 // readValue reads the value of an option out of the named file.
 // An empty content is valid, like in parsing the options. The upper
 // size is 5M.
@@ -324,3 +436,8 @@ func readValue(ctx *cmd.Context, filename string) (string, error) {
 	}
 	return string(content), nil
 }
+
+// setSecretKeys is the run action to mark configuration keys as secret.
+func (c *configCommand) setSecretKeys(client configCommandAPI, ctx *cmd.Context) error {
+	return block.ProcessBlockedError(client.SetSecretConfigKeys(c.applicationName, c.secretKeys.keys), block.BlockChange)
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -16,6 +17,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -286,6 +288,139 @@ func (s *configCommandSuite) TestBlockSetConfig(c *gc.C) {
 	c.Check(stripped, gc.Matches, ".*TestBlockSetConfig.*")
 }
 
+func (s *configCommandSuite) TestHistoryCommandInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"app", "--history", "--rollback", "2"},
+		err:  "cannot specify --history and --rollback simultaneously",
+	}, {
+		args: []string{"app", "--history", "key"},
+		err:  "cannot specify --history or --rollback with other configuration options",
+	}, {
+		args: []string{"app", "--rollback", "2", "--reset", "key"},
+		err:  "cannot specify --history or --rollback with other configuration options",
+	}, {
+		args: []string{"app", "--rollback", "-1"},
+		err:  "revision -1 not valid",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := coretesting.InitCommand(application.NewConfigCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *configCommandSuite) TestGetHistory(c *gc.C) {
+	s.fake.history = []params.ConfigRevision{{
+		Revision: 2,
+		User:     "mary",
+		Time:     time.Date(2017, 3, 1, 12, 30, 0, 0, time.UTC),
+		Changes: []params.ConfigChange{
+			{Key: "title", Type: params.ConfigModified, OldValue: "foo", NewValue: "bar"},
+		},
+	}, {
+		Revision: 1,
+		User:     "bob",
+		Time:     time.Date(2017, 3, 1, 11, 0, 0, 0, time.UTC),
+		Changes: []params.ConfigChange{
+			{Key: "title", Type: params.ConfigAdded, NewValue: "foo"},
+		},
+	}}
+	ctx, err := coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+- revision: 2
+  user: mary
+  time: 2017-03-01 12:30:00Z
+  changes:
+    title:
+      change: modified
+      old: foo
+      new: bar
+- revision: 1
+  user: bob
+  time: 2017-03-01 11:00:00Z
+  changes:
+    title:
+      change: added
+      new: foo
+`[1:])
+}
+
+func (s *configCommandSuite) TestRollbackConfig(c *gc.C) {
+	_, err := coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--rollback", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.rollback, gc.Equals, 3)
+}
+
+func (s *configCommandSuite) TestBlockRollbackConfig(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockRollbackConfig")
+	ctx := coretesting.ContextForDir(c, s.dir)
+	code := cmd.Main(application.NewConfigCommandForTest(s.fake), ctx, []string{"dummy-application", "--rollback", "3"})
+	c.Check(code, gc.Equals, 1)
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockRollbackConfig.*")
+}
+
+func (s *configCommandSuite) TestGetConfigKeySecret(c *gc.C) {
+	s.fake.secrets = []string{"title"}
+	ctx, err := coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "title")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "<redacted>\n")
+
+	ctx, err = coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "title", "--show-secrets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "Nearly There\n")
+}
+
+func (s *configCommandSuite) TestGetHistoryShowSecrets(c *gc.C) {
+	_, err := coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--history", "--show-secrets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.revealed, jc.IsTrue)
+}
+
+func (s *configCommandSuite) TestSecretKeysCommandInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"app", "--secret-keys", "password", "key"},
+		err:  "cannot specify --secret-keys with other configuration options",
+	}, {
+		args: []string{"app", "--secret-keys", "password", "--history"},
+		err:  "cannot specify --secret-keys with other configuration options",
+	}, {
+		args: []string{"app", "--secret-keys", "password", "--reset", "key"},
+		err:  "cannot specify --secret-keys with other configuration options",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := coretesting.InitCommand(application.NewConfigCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *configCommandSuite) TestSetSecretKeys(c *gc.C) {
+	_, err := coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--secret-keys", "title, username")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.secrets, jc.DeepEquals, []string{"title", "username"})
+
+	_, err = coretesting.RunCommand(c, application.NewConfigCommandForTest(s.fake), "dummy-application", "--secret-keys", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.secrets, gc.HasLen, 0)
+}
+
+func (s *configCommandSuite) TestBlockSetSecretKeys(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockSetSecretKeys")
+	ctx := coretesting.ContextForDir(c, s.dir)
+	code := cmd.Main(application.NewConfigCommandForTest(s.fake), ctx, []string{"dummy-application", "--secret-keys", "title"})
+	c.Check(code, gc.Equals, 1)
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockSetSecretKeys.*")
+}
+
 // assertSetSuccess sets configuration options and checks the expected settings.
 func (s *configCommandSuite) assertSetSuccess(c *gc.C, dir string, args []string, expect map[string]interface{}) {
 	ctx := coretesting.ContextForDir(c, dir)
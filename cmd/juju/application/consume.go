@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+var usageConsumeSummary = `
+Adds a remote application to the model.`[1:]
+
+var usageConsumeDetails = `
+Adds a remote application to the model, standing for an application
+offered by another model on the same controller. The remote application
+is named after the offer unless an alias is given, and can then be
+related to applications in the model like any other application.
+
+The offer URL has the form
+
+    [<model owner>/]<model name>.<offer name>
+
+where the model owner defaults to the current user.
+
+Examples:
+    juju consume prod.hosted-mysql
+    juju consume admin/prod.hosted-mysql mysql
+    juju add-relation wordpress mysql
+
+See also:
+    offer
+    add-relation
+    remove-application`[1:]
+
+// NewConsumeCommand returns a command to add remote applications to
+// the model.
+func NewConsumeCommand() cmd.Command {
+	return modelcmd.Wrap(&consumeCommand{})
+}
+
+// consumeCommand adds remote applications to the model.
+type consumeCommand struct {
+	modelcmd.ModelCommandBase
+	api consumeAPI
+
+	OfferURL         string
+	ApplicationAlias string
+}
+
+// consumeAPI defines the API methods used by the consume command.
+type consumeAPI interface {
+	Close() error
+	Consume(offerURL, alias string) (string, error)
+}
+
+// Info implements Command.Info.
+func (c *consumeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "consume",
+		Args:    "<offer url> [application alias]",
+		Purpose: usageConsumeSummary,
+		Doc:     usageConsumeDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *consumeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer URL specified")
+	}
+	if _, err := crossmodel.ParseApplicationURL(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.OfferURL = args[0]
+	if len(args) == 1 {
+		return nil
+	}
+	if !names.IsValidApplication(args[1]) {
+		return errors.NotValidf("application alias %q", args[1])
+	}
+	c.ApplicationAlias = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *consumeCommand) getAPI() (consumeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	localName, err := client.Consume(c.OfferURL, c.ApplicationAlias)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintf(ctx.Stderr, "Added %q as %q\n", c.OfferURL, localName)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type ConsumeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeConsumeAPI
+}
+
+var _ = gc.Suite(&ConsumeSuite{})
+
+type fakeConsumeAPI struct {
+	offerURL string
+	alias    string
+	err      error
+}
+
+func (f *fakeConsumeAPI) Close() error {
+	return nil
+}
+
+func (f *fakeConsumeAPI) Consume(offerURL, alias string) (string, error) {
+	f.offerURL = offerURL
+	f.alias = alias
+	if f.err != nil {
+		return "", f.err
+	}
+	if alias != "" {
+		return alias, nil
+	}
+	return "hosted-mysql", nil
+}
+
+func (s *ConsumeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeConsumeAPI{}
+}
+
+func (s *ConsumeSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no offer URL specified",
+	}, {
+		args: []string{"hosted-mysql"},
+		err:  `application URL "hosted-mysql" without offer name not valid`,
+	}, {
+		args: []string{"prod.hosted-mysql", "my_db"},
+		err:  `application alias "my_db" not valid`,
+	}, {
+		args: []string{"prod.hosted-mysql", "db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := testing.RunCommand(c, application.NewConsumeCommandForTest(s.fake), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ConsumeSuite) TestConsume(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewConsumeCommandForTest(s.fake), "admin/prod.hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.offerURL, gc.Equals, "admin/prod.hosted-mysql")
+	c.Check(s.fake.alias, gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, `Added "admin/prod.hosted-mysql" as "hosted-mysql"`+"\n")
+}
+
+func (s *ConsumeSuite) TestConsumeWithAlias(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewConsumeCommandForTest(s.fake), "prod.hosted-mysql", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.alias, gc.Equals, "db")
+	c.Check(testing.Stderr(ctx), gc.Equals, `Added "prod.hosted-mysql" as "db"`+"\n")
+}
+
+func (s *ConsumeSuite) TestConsumeError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewConsumeCommandForTest(s.fake), "prod.hosted-mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -33,7 +33,6 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
-	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
 )
@@ -50,7 +49,7 @@ type CharmAdder interface {
 type ApplicationAPI interface {
 	AddMachines(machineParams []apiparams.AddMachineParams) ([]apiparams.AddMachinesResult, error)
 	AddRelation(endpoints ...string) (*apiparams.AddRelationResults, error)
-	AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error)
+	AddUnits(application.AddUnitsParams) ([]string, error)
 	Expose(application string) error
 	GetCharmURL(serviceName string) (*charm.URL, error)
 	SetAnnotation(annotations map[string]map[string]string) ([]apiparams.ErrorResult, error)
@@ -378,6 +377,10 @@ Examples:
     (deploy 2 units to machines that are part of the 'dmz' space but not of the
     'cmd' or the 'database' spaces)
 
+    juju deploy postgresql --attach-storage pgdata/0
+    (deploy a single unit, attaching the existing detached storage pgdata/0
+    in place of the storage that would otherwise be created)
+
 See also:
     spaces
     constraints
@@ -423,7 +426,7 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
-	charmOnlyFlags        = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
+	charmOnlyFlags        = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource", "attach-storage"}
 	bundleOnlyFlags       = []string{}
 	modelCommandBaseFlags = []string{"B", "no-browser-login"}
 )
@@ -533,6 +536,9 @@ func (c *DeployCommand) deployCharm(
 		} else {
 			return errors.New("cannot use --num-units or --to with subordinate application")
 		}
+		if len(c.AttachStorage) > 0 {
+			return errors.New("cannot use --attach-storage with subordinate application")
+		}
 	}
 	serviceName := c.ApplicationName
 	if serviceName == "" {
@@ -599,6 +605,7 @@ func (c *DeployCommand) deployCharm(
 		ConfigYAML:       string(configYAML),
 		Placement:        c.Placement,
 		Storage:          c.Storage,
+		AttachStorage:    c.AttachStorage,
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
 	}))
@@ -341,6 +341,34 @@ func (s *DeploySuite) TestStorage(c *gc.C) {
 	})
 }
 
+func (s *DeploySuite) TestAttachStorage(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "environscoped-block"},
+		&state.VolumeInfo{VolumeId: "vol-123", Size: 1024},
+		"data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "storage-filesystem")
+	err = runDeploy(c, ch,
+		"--storage", "data=environscoped-block,1G",
+		"--attach-storage", storageTag.Id(),
+		"--series", "trusty",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachments, err := s.State.UnitStorageAttachments(names.NewUnitTag("storage-filesystem/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+}
+
+func (s *DeploySuite) TestAttachStorageSubordinate(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "logging")
+	err := runDeploy(c, ch, "--attach-storage", "data/0", "--series", "quantal")
+	c.Assert(err, gc.ErrorMatches, "cannot use --attach-storage with subordinate application")
+}
+
 func (s *DeploySuite) TestPlacement(c *gc.C) {
 	ch := testcharms.Repo.ClonedDirPath(s.CharmsPath, "dummy")
 	// Add a machine that will be ignored due to placement directive.
@@ -1372,7 +1400,10 @@ func (s *DeployUnitTestSuite) TestDeployBundle_OutputsCorrectMessage(c *gc.C) {
 		false,
 		0,
 	)
-	fakeAPI.Call("AddUnits", "mysql", 1, []*instance.Placement(nil)).Returns([]string{"mysql/0"}, error(nil))
+	fakeAPI.Call("AddUnits", application.AddUnitsParams{
+		ApplicationName: "mysql",
+		NumUnits:        1,
+	}).Returns([]string{"mysql/0"}, error(nil))
 
 	wordpressURL := charm.MustParseURL("cs:wordpress")
 	withCharmRepoResolvable(fakeAPI, wordpressURL, cfg)
@@ -1385,7 +1416,10 @@ func (s *DeployUnitTestSuite) TestDeployBundle_OutputsCorrectMessage(c *gc.C) {
 		false,
 		0,
 	)
-	fakeAPI.Call("AddUnits", "wordpress", 1, []*instance.Placement(nil)).Returns([]string{"wordpress/0"}, error(nil))
+	fakeAPI.Call("AddUnits", application.AddUnitsParams{
+		ApplicationName: "wordpress",
+		NumUnits:        1,
+	}).Returns([]string{"wordpress/0"}, error(nil))
 
 	fakeAPI.Call("AddRelation", "wordpress:db", "mysql:server").Returns(
 		&params.AddRelationResults{},
@@ -1517,8 +1551,8 @@ func (f *fakeDeployAPI) AddRelation(endpoints ...string) (*params.AddRelationRes
 	return results[0].(*params.AddRelationResults), typeAssertError(results[1])
 }
 
-func (f *fakeDeployAPI) AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error) {
-	results := f.MethodCall(f, "AddUnits", application, numUnits, placement)
+func (f *fakeDeployAPI) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	results := f.MethodCall(f, "AddUnits", args)
 	return results[0].([]string), typeAssertError(results[1])
 }
 
@@ -25,6 +25,20 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewShowUnitCommandForTest returns a ShowUnitCommand with the api provided as specified.
+func NewShowUnitCommandForTest(api showUnitAPI) cmd.Command {
+	return modelcmd.Wrap(&showUnitCommand{
+		api: api,
+	})
+}
+
+// NewShowApplicationCommandForTest returns a ShowApplicationCommand with the api provided as specified.
+func NewShowApplicationCommandForTest(api showApplicationAPI) cmd.Command {
+	return modelcmd.Wrap(&showApplicationCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -37,3 +51,54 @@ func PatchNewCharmStoreClient(s Patcher, url string) {
 		})
 	})
 }
+
+// NewOfferCommandForTest returns an OfferCommand with the api provided as specified.
+func NewOfferCommandForTest(api offerAPI) cmd.Command {
+	return modelcmd.Wrap(&offerCommand{
+		api: api,
+	})
+}
+
+// NewConsumeCommandForTest returns a ConsumeCommand with the api provided as specified.
+func NewConsumeCommandForTest(api consumeAPI) cmd.Command {
+	return modelcmd.Wrap(&consumeCommand{
+		api: api,
+	})
+}
+
+// NewSuspendRelationCommandForTest returns a SuspendRelationCommand with the api provided as specified.
+func NewSuspendRelationCommandForTest(api setRelationSuspendedAPI) cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{
+		api: api,
+	})
+}
+
+// NewResumeRelationCommandForTest returns a ResumeRelationCommand with the api provided as specified.
+func NewResumeRelationCommandForTest(api setRelationSuspendedAPI) cmd.Command {
+	return modelcmd.Wrap(&resumeRelationCommand{
+		api: api,
+	})
+}
+
+// NewBindCommandForTest returns a BindCommand with the api provided as specified.
+func NewBindCommandForTest(api bindAPI) cmd.Command {
+	return modelcmd.Wrap(&bindCommand{
+		api: api,
+	})
+}
+
+// NewSetAutoUpgradeCommandForTest returns a SetAutoUpgradeCommand with
+// the api provided as specified.
+func NewSetAutoUpgradeCommandForTest(api setAutoUpgradeAPI) cmd.Command {
+	return modelcmd.Wrap(&setAutoUpgradeCommand{
+		api: api,
+	})
+}
+
+// NewSetSeriesCommandForTest returns a SetSeriesCommand with the api
+// provided as specified.
+func NewSetSeriesCommandForTest(api setSeriesAPI) cmd.Command {
+	return modelcmd.Wrap(&setSeriesCommand{
+		api: api,
+	})
+}
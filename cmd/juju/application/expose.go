@@ -4,8 +4,12 @@
 package application
 
 import (
+	"net"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +23,20 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default, the application's opened ports are made accessible from
+any source address. Access may instead be restricted to a set of source
+CIDRs with --to-cidrs, and/or to the subnets of a set of spaces with
+--to-spaces. Running expose again replaces any previous restrictions.
+
+Charms may open ports for specific endpoints. With --endpoints, only the
+ports opened for the given endpoints, and the ports opened for all
+endpoints, are made accessible.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose wordpress --to-spaces public
+    juju expose wordpress --endpoints website
 
 See also: 
     unexpose`[1:]
@@ -34,6 +50,9 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToCIDRs         []string
+	ToSpaces        []string
+	Endpoints       []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +64,36 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to-cidrs", "Comma-separated source CIDRs allowed to access the application")
+	f.Var(cmd.NewStringsValue(nil, &c.ToSpaces), "to-spaces", "Comma-separated spaces whose subnets may access the application")
+	f.Var(cmd.NewStringsValue(nil, &c.Endpoints), "endpoints", "Comma-separated endpoints whose opened ports are exposed")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range c.ToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid CIDR %q", cidr)
+		}
+	}
+	for _, space := range c.ToSpaces {
+		if !names.IsValidSpace(space) {
+			return errors.Errorf("invalid space name %q", space)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, cidrs, spaces []string) error
+	ExposeEndpoints(serviceName string, endpoints, cidrs, spaces []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +113,6 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	err = client.ExposeEndpoints(c.ApplicationName, c.Endpoints, c.ToCIDRs, c.ToSpaces)
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
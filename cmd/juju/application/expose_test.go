@@ -59,6 +59,48 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeToCIDRsAndSpaces(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("public", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name",
+		"--to-cidrs", "10.0.0.0/8,192.168.1.0/24",
+		"--to-spaces", "public",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	svc, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(svc.ExposedSpaces(), jc.DeepEquals, []string{"public"})
+}
+
+func (s *ExposeSuite) TestExposeEndpoints(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "wordpress")
+	err := runDeploy(c, ch, "wp", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "wp", "--endpoints", "url,admin-api")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "wp")
+	svc, err := s.State.Application("wp")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.ExposedEndpoints(), jc.DeepEquals, []string{"url", "admin-api"})
+
+	err = runExpose(c, "wp", "--endpoints", "missing")
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "wp": endpoint "missing" not found`)
+}
+
+func (s *ExposeSuite) TestExposeInvalidArgs(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `invalid CIDR "10.0.0.0"`)
+	err = runExpose(c, "some-application-name", "--to-spaces", "Public!")
+	c.Assert(err, gc.ErrorMatches, `invalid space name "Public!"`)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
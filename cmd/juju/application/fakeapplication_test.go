@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -18,6 +19,10 @@ type fakeApplicationAPI struct {
 	charmName string
 	values    map[string]interface{}
 	config    string
+	history   []params.ConfigRevision
+	rollback  int
+	secrets   []string
+	revealed  bool
 	err       error
 }
 
@@ -43,13 +48,21 @@ func (f *fakeApplicationAPI) Get(application string) (*params.ApplicationGetResu
 		return nil, errors.NotFoundf("application %q", application)
 	}
 
+	secrets := set.NewStrings(f.secrets...)
 	configInfo := make(map[string]interface{})
 	for k, v := range f.values {
-		configInfo[k] = map[string]interface{}{
+		info := map[string]interface{}{
 			"description": fmt.Sprintf("Specifies %s", k),
 			"type":        fmt.Sprintf("%T", v),
 			"value":       v,
 		}
+		if secrets.Contains(k) {
+			info["secret"] = true
+			if !f.revealed {
+				info["value"] = "<redacted>"
+			}
+		}
+		configInfo[k] = info
 	}
 
 	return &params.ApplicationGetResults{
@@ -59,6 +72,11 @@ func (f *fakeApplicationAPI) Get(application string) (*params.ApplicationGetResu
 	}, nil
 }
 
+func (f *fakeApplicationAPI) GetWithSecrets(application string) (*params.ApplicationGetResults, error) {
+	f.revealed = true
+	return f.Get(application)
+}
+
 func (f *fakeApplicationAPI) Set(application string, options map[string]string) error {
 	if f.err != nil {
 		return f.err
@@ -100,3 +118,39 @@ func (f *fakeApplicationAPI) Unset(application string, options []string) error {
 
 	return nil
 }
+
+func (f *fakeApplicationAPI) ConfigHistory(application string, showSecrets bool) ([]params.ConfigRevision, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if application != f.name {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	f.revealed = showSecrets
+	return f.history, nil
+}
+
+func (f *fakeApplicationAPI) RollbackConfig(application string, revision int) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if application != f.name {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.rollback = revision
+	return nil
+}
+
+func (f *fakeApplicationAPI) SetSecretConfigKeys(application string, keys []string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if application != f.name {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.secrets = keys
+	return nil
+}
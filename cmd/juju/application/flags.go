@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
 )
@@ -103,3 +104,27 @@ func (m stringMap) String() string {
 	}
 	return strings.Join(pairs, ";")
 }
+
+// attachStorageFlag is a type that deserializes a CLI string using
+// gnuflag's Value semantics. It expects a comma-separated list of
+// storage IDs, and supports multiple copies of the flag adding more
+// IDs.
+type attachStorageFlag struct {
+	storageIDs *[]string
+}
+
+// Set implements gnuflag.Value's Set method.
+func (f attachStorageFlag) Set(s string) error {
+	for _, id := range strings.Split(s, ",") {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+		*f.storageIDs = append(*f.storageIDs, id)
+	}
+	return nil
+}
+
+// String implements gnuflag.Value's String method.
+func (f attachStorageFlag) String() string {
+	return strings.Join(*f.storageIDs, ",")
+}
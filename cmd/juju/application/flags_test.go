@@ -47,3 +47,22 @@ func (FlagSuite) TestStringMapDupVal(c *gc.C) {
 	err = sm.Set("bar=someothervalue")
 	c.Assert(err, gc.ErrorMatches, ".*duplicate.*bar.*")
 }
+
+func (FlagSuite) TestAttachStorageFlag(c *gc.C) {
+	var ids []string
+	f := attachStorageFlag{&ids}
+	err := f.Set("foo/0,bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = f.Set("baz/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"foo/0", "bar/1", "baz/2"})
+	c.Assert(f.String(), gc.Equals, "foo/0,bar/1,baz/2")
+}
+
+func (FlagSuite) TestAttachStorageFlagInvalid(c *gc.C) {
+	var ids []string
+	f := attachStorageFlag{&ids}
+	err := f.Set("foo/0,bar")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `storage ID "bar" not valid`)
+}
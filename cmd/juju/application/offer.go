@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageOfferSummary = `
+Offers application endpoints for use in other models.`[1:]
+
+var usageOfferDetails = `
+Makes the given endpoints of an application available for consumption by
+other models on the same controller. The offer is named after the
+application unless an offer name is given; other models refer to it by
+the URL
+
+    [<model owner>/]<model name>.<offer name>
+
+Only endpoints with a provides or requires role and a global scope can be
+offered.
+
+Examples:
+    juju offer mysql:db
+    juju offer mysql:db,admin hosted-mysql
+    juju offer mysql:db --description "Shared production database"
+
+See also:
+    consume
+    add-relation`[1:]
+
+// NewOfferCommand returns a command to offer application endpoints.
+func NewOfferCommand() cmd.Command {
+	return modelcmd.Wrap(&offerCommand{})
+}
+
+// offerCommand offers application endpoints for consumption by other
+// models.
+type offerCommand struct {
+	modelcmd.ModelCommandBase
+	api offerAPI
+
+	Application string
+	Endpoints   []string
+	OfferName   string
+	Description string
+}
+
+// offerAPI defines the API methods used by the offer command.
+type offerAPI interface {
+	Close() error
+	Offer(application string, endpoints map[string]string, offerName, description string) error
+}
+
+// Info implements Command.Info.
+func (c *offerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer",
+		Args:    "<application name>:<endpoint name>[,...] [offer name]",
+		Purpose: usageOfferSummary,
+		Doc:     usageOfferDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Description, "description", "", "Description of the offered application shown to consumers")
+}
+
+// Init implements Command.Init.
+func (c *offerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application endpoints specified")
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("endpoints must be specified as <application name>:<endpoint name>[,...], got %q", args[0])
+	}
+	if !names.IsValidApplication(parts[0]) {
+		return errors.NotValidf("application name %q", parts[0])
+	}
+	c.Application = parts[0]
+	for _, endpoint := range strings.Split(parts[1], ",") {
+		if endpoint == "" {
+			return errors.Errorf("empty endpoint name in %q", args[0])
+		}
+		c.Endpoints = append(c.Endpoints, endpoint)
+	}
+	if len(args) == 1 {
+		return nil
+	}
+	if !names.IsValidApplication(args[1]) {
+		return errors.NotValidf("offer name %q", args[1])
+	}
+	c.OfferName = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *offerCommand) getAPI() (offerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *offerCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	endpoints := make(map[string]string)
+	for _, endpoint := range c.Endpoints {
+		endpoints[endpoint] = endpoint
+	}
+	offerName := c.OfferName
+	if offerName == "" {
+		offerName = c.Application
+	}
+	err = client.Offer(c.Application, endpoints, offerName, c.Description)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintf(ctx.Stderr, "Application %q endpoints %v available at %q\n", c.Application, c.Endpoints, c.ModelName()+"."+offerName)
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type OfferSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeOfferAPI
+}
+
+var _ = gc.Suite(&OfferSuite{})
+
+type fakeOfferAPI struct {
+	application string
+	endpoints   map[string]string
+	offerName   string
+	description string
+	err         error
+}
+
+func (f *fakeOfferAPI) Close() error {
+	return nil
+}
+
+func (f *fakeOfferAPI) Offer(application string, endpoints map[string]string, offerName, description string) error {
+	f.application = application
+	f.endpoints = endpoints
+	f.offerName = offerName
+	f.description = description
+	return f.err
+}
+
+func (s *OfferSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeOfferAPI{}
+}
+
+func (s *OfferSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application endpoints specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `endpoints must be specified as <application name>:<endpoint name>\[,...\], got "mysql"`,
+	}, {
+		args: []string{"mysql/0:db"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql:db,"},
+		err:  `empty endpoint name in "mysql:db,"`,
+	}, {
+		args: []string{"mysql:db", "hosted_mysql"},
+		err:  `offer name "hosted_mysql" not valid`,
+	}, {
+		args: []string{"mysql:db", "hosted-mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := testing.RunCommand(c, application.NewOfferCommandForTest(s.fake), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *OfferSuite) TestOffer(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewOfferCommandForTest(s.fake), "mysql:db,admin", "hosted-mysql", "--description", "a database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.application, gc.Equals, "mysql")
+	c.Check(s.fake.endpoints, jc.DeepEquals, map[string]string{"db": "db", "admin": "admin"})
+	c.Check(s.fake.offerName, gc.Equals, "hosted-mysql")
+	c.Check(s.fake.description, gc.Equals, "a database")
+}
+
+func (s *OfferSuite) TestOfferDefaultName(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewOfferCommandForTest(s.fake), "mysql:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.offerName, gc.Equals, "mysql")
+}
+
+func (s *OfferSuite) TestOfferError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewOfferCommandForTest(s.fake), "mysql:db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageResumeRelationSummary = `
+Resumes a suspended relation between two applications.`[1:]
+
+var usageResumeRelationDetails = `
+The units of the applications rejoin the relation, running
+relation-joined and relation-changed hooks, with the relation settings
+they had when the relation was suspended.
+
+Examples:
+    juju resume-relation 123
+
+See also:
+    add-relation
+    remove-relation
+    suspend-relation`[1:]
+
+// NewResumeRelationCommand returns a command to resume a relation.
+func NewResumeRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&resumeRelationCommand{})
+}
+
+// resumeRelationCommand resumes a suspended relation.
+type resumeRelationCommand struct {
+	modelcmd.ModelCommandBase
+	api setRelationSuspendedAPI
+
+	RelationId int
+}
+
+// Info implements Command.Info.
+func (c *resumeRelationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-relation",
+		Args:    "<relation-id>",
+		Purpose: usageResumeRelationSummary,
+		Doc:     usageResumeRelationDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *resumeRelationCommand) Init(args []string) (err error) {
+	c.RelationId, err = parseRelationId(args)
+	return err
+}
+
+func (c *resumeRelationCommand) getAPI() (setRelationSuspendedAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *resumeRelationCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetRelationSuspended(c.RelationId, false, "")
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type ResumeRelationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetRelationSuspendedAPI
+}
+
+var _ = gc.Suite(&ResumeRelationSuite{})
+
+func (s *ResumeRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetRelationSuspendedAPI{relationId: -1, suspended: true}
+}
+
+func (s *ResumeRelationSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewResumeRelationCommandForTest(s.fake))
+	c.Check(err, gc.ErrorMatches, "no relation id specified")
+	_, err = testing.RunCommand(c, application.NewResumeRelationCommandForTest(s.fake), "mysql")
+	c.Check(err, gc.ErrorMatches, `relation id "mysql" not valid`)
+}
+
+func (s *ResumeRelationSuite) TestResumeRelation(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewResumeRelationCommandForTest(s.fake), "123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.relationId, gc.Equals, 123)
+	c.Check(s.fake.suspended, jc.IsFalse)
+}
+
+func (s *ResumeRelationSuite) TestResumeRelationError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewResumeRelationCommandForTest(s.fake), "123")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetSeriesSummary = `
+Sets the series of an application.`[1:]
+
+var usageSetSeriesDetails = `
+Changes the series used for new units of the application, and the
+series of its charm URL. Existing units keep running the series of
+their machines; use upgrade-series to upgrade those in place.
+
+The new series must be supported by the application's charm. A charm
+written for a single series must be replaced with the same charm for
+the new series, given with --charm; charm store charms are added to
+the model as needed. The --force option allows a series the charm does
+not declare support for, but never one of a different operating
+system.
+
+Examples:
+    juju set-series mysql xenial
+    juju set-series wordpress xenial --charm cs:xenial/wordpress-5
+
+See also:
+    upgrade-series
+    upgrade-charm`[1:]
+
+// NewSetSeriesCommand returns a command to set the series of an
+// application.
+func NewSetSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&setSeriesCommand{})
+}
+
+// setSeriesCommand sets the series of an application.
+type setSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api setSeriesAPI
+
+	ApplicationName string
+	Series          string
+	CharmURL        string
+	Force           bool
+}
+
+// setSeriesAPI defines the API methods used by the set-series command.
+type setSeriesAPI interface {
+	Close() error
+	SetSeries(application, series, charmURL string, force bool) error
+}
+
+// Info implements Command.Info.
+func (c *setSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-series",
+		Args:    "<application> <series>",
+		Purpose: usageSetSeriesSummary,
+		Doc:     usageSetSeriesDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.CharmURL, "charm", "", "Replace the application's charm with this one, written for the new series")
+	f.BoolVar(&c.Force, "force", false, "Allow a series not supported by the charm")
+}
+
+// Init implements Command.Init.
+func (c *setSeriesCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no series specified")
+	case 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	c.Series = args[1]
+	if c.CharmURL != "" {
+		curl, err := charm.ParseURL(c.CharmURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if curl.Series != "" && curl.Series != c.Series {
+			return errors.Errorf("charm %q is not for series %q", c.CharmURL, c.Series)
+		}
+	}
+	return nil
+}
+
+func (c *setSeriesCommand) getAPI() (setSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *setSeriesCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetSeries(c.ApplicationName, c.Series, c.CharmURL, c.Force)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
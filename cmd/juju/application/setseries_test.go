@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type SetSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetSeriesAPI
+}
+
+var _ = gc.Suite(&SetSeriesSuite{})
+
+type fakeSetSeriesAPI struct {
+	application string
+	series      string
+	charmURL    string
+	force       bool
+	err         error
+}
+
+func (f *fakeSetSeriesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSetSeriesAPI) SetSeries(application, series, charmURL string, force bool) error {
+	f.application = application
+	f.series = series
+	f.charmURL = charmURL
+	f.force = force
+	return f.err
+}
+
+func (s *SetSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetSeriesAPI{}
+}
+
+func (s *SetSeriesSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no series specified",
+	}, {
+		args: []string{"mysql", "xenial", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql/0", "xenial"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "xenial", "--charm", "cs:trusty/mysql-3"},
+		err:  `charm "cs:trusty/mysql-3" is not for series "xenial"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := testing.RunCommand(c, application.NewSetSeriesCommandForTest(s.fake), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SetSeriesSuite) TestSetSeries(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetSeriesCommandForTest(s.fake), "mysql", "xenial", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.application, gc.Equals, "mysql")
+	c.Check(s.fake.series, gc.Equals, "xenial")
+	c.Check(s.fake.charmURL, gc.Equals, "")
+	c.Check(s.fake.force, jc.IsTrue)
+}
+
+func (s *SetSeriesSuite) TestSetSeriesWithCharm(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSetSeriesCommandForTest(s.fake), "mysql", "xenial", "--charm", "cs:xenial/mysql-5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.series, gc.Equals, "xenial")
+	c.Check(s.fake.charmURL, gc.Equals, "cs:xenial/mysql-5")
+	c.Check(s.fake.force, jc.IsFalse)
+}
+
+func (s *SetSeriesSuite) TestSetSeriesError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewSetSeriesCommandForTest(s.fake), "mysql", "xenial")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,298 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/status"
+)
+
+const showApplicationDoc = `
+The command takes one or more application names as arguments and shows
+everything about each application: its charm URL and channel, series,
+endpoint bindings, whether it is exposed, its constraints, configuration
+that differs from the charm's defaults, resources, storage directives,
+minimum number of units, its units with their statuses, and its relations.
+
+The default output format is yaml; json and tabular are also available.
+
+Examples:
+    juju show-application mysql
+    juju show-application mysql wordpress --format tabular
+
+See also:
+    show-status
+    show-unit
+    config
+`
+
+// NewShowApplicationCommand returns a command that displays
+// application details.
+func NewShowApplicationCommand() cmd.Command {
+	return modelcmd.Wrap(&showApplicationCommand{})
+}
+
+// showApplicationCommand displays application details.
+type showApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api showApplicationAPI
+
+	applications []names.ApplicationTag
+}
+
+// showApplicationAPI defines the API methods used by the
+// show-application command.
+type showApplicationAPI interface {
+	Close() error
+	ApplicationsInfo([]names.ApplicationTag) ([]params.ApplicationInfoResult, error)
+}
+
+// Info implements Command.Info.
+func (c *showApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-application",
+		Args:    "<application name> [...]",
+		Purpose: "Displays information about an application.",
+		Doc:     showApplicationDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatApplicationInfoTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	for _, arg := range args {
+		if !names.IsValidApplication(arg) {
+			return errors.NotValidf("application name %q", arg)
+		}
+		c.applications = append(c.applications, names.NewApplicationTag(arg))
+	}
+	return nil
+}
+
+func (c *showApplicationCommand) getAPI() (showApplicationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.ApplicationsInfo(c.applications)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var errs params.ErrorResults
+	output := make(map[string]applicationInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{result.Error})
+			continue
+		}
+		output[c.applications[i].Id()] = formatApplicationInfo(*result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+	return c.out.Write(ctx, output)
+}
+
+// applicationInfo is the output format of a single application.
+type applicationInfo struct {
+	Charm            string                            `yaml:"charm" json:"charm"`
+	Channel          string                            `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Series           string                            `yaml:"series" json:"series"`
+	Life             string                            `yaml:"life" json:"life"`
+	Principal        bool                              `yaml:"principal" json:"principal"`
+	Exposed          bool                              `yaml:"exposed" json:"exposed"`
+	MinUnits         int                               `yaml:"min-units,omitempty" json:"min-units,omitempty"`
+	Constraints      string                            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	EndpointBindings map[string]string                 `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	Config           map[string]interface{}            `yaml:"config,omitempty" json:"config,omitempty"`
+	Storage          map[string]storageDirective       `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Resources        map[string]applicationResource    `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Units            map[string]applicationUnitSummary `yaml:"units,omitempty" json:"units,omitempty"`
+	Relations        map[string][]string               `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+type storageDirective struct {
+	Pool  string `yaml:"pool" json:"pool"`
+	Size  uint64 `yaml:"size" json:"size"`
+	Count uint64 `yaml:"count" json:"count"`
+}
+
+type applicationResource struct {
+	Type     string `yaml:"type" json:"type"`
+	Origin   string `yaml:"origin" json:"origin"`
+	Revision int    `yaml:"revision" json:"revision"`
+}
+
+type applicationUnitSummary struct {
+	Machine        string `yaml:"machine,omitempty" json:"machine,omitempty"`
+	WorkloadStatus string `yaml:"workload-status" json:"workload-status"`
+	AgentStatus    string `yaml:"agent-status" json:"agent-status"`
+	Message        string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func formatApplicationInfo(info params.ApplicationInfo) applicationInfo {
+	out := applicationInfo{
+		Charm:            info.Charm,
+		Channel:          info.Channel,
+		Series:           info.Series,
+		Life:             info.Life,
+		Principal:        info.Principal,
+		Exposed:          info.Exposed,
+		MinUnits:         info.MinUnits,
+		Constraints:      info.Constraints.String(),
+		EndpointBindings: info.EndpointBindings,
+		Config:           info.Config,
+		Relations:        info.Relations,
+	}
+	if len(info.Storage) > 0 {
+		out.Storage = make(map[string]storageDirective)
+		for name, cons := range info.Storage {
+			directive := storageDirective{Pool: cons.Pool}
+			if cons.Size != nil {
+				directive.Size = *cons.Size
+			}
+			if cons.Count != nil {
+				directive.Count = *cons.Count
+			}
+			out.Storage[name] = directive
+		}
+	}
+	if len(info.Resources) > 0 {
+		out.Resources = make(map[string]applicationResource)
+		for _, res := range info.Resources {
+			out.Resources[res.Name] = applicationResource{
+				Type:     res.Type,
+				Origin:   res.Origin,
+				Revision: res.Revision,
+			}
+		}
+	}
+	if len(info.Units) > 0 {
+		out.Units = make(map[string]applicationUnitSummary)
+		for _, u := range info.Units {
+			out.Units[u.Name] = applicationUnitSummary{
+				Machine:        u.Machine,
+				WorkloadStatus: u.WorkloadStatus,
+				AgentStatus:    u.AgentStatus,
+				Message:        u.Message,
+			}
+		}
+	}
+	return out
+}
+
+// formatApplicationInfoTabular writes a tabular summary of each
+// application, followed by its units and relations.
+func formatApplicationInfoTabular(writer io.Writer, value interface{}) error {
+	applications, ok := value.(map[string]applicationInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", applications, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	appNames := make([]string, 0, len(applications))
+	for name := range applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+
+	for i, name := range appNames {
+		app := applications[name]
+		if i > 0 {
+			w.Println()
+		}
+		w.Println("APP", "CHARM", "CHANNEL", "SERIES", "EXPOSED", "MIN-UNITS", "CONSTRAINTS")
+		w.Println(name, app.Charm, app.Channel, app.Series, app.Exposed, app.MinUnits, app.Constraints)
+
+		if len(app.Units) > 0 {
+			w.Println()
+			w.Println("UNIT", "WORKLOAD", "AGENT", "MACHINE", "MESSAGE")
+			unitNames := make([]string, 0, len(app.Units))
+			for unitName := range app.Units {
+				unitNames = append(unitNames, unitName)
+			}
+			for _, unitName := range utils.SortStringsNaturally(unitNames) {
+				u := app.Units[unitName]
+				w.Print(unitName)
+				w.PrintStatus(status.Status(u.WorkloadStatus))
+				w.PrintStatus(status.Status(u.AgentStatus))
+				w.Println(u.Machine, u.Message)
+			}
+		}
+
+		if len(app.Relations) > 0 {
+			w.Println()
+			w.Println("ENDPOINT", "RELATED-APPLICATIONS")
+			for _, endpoint := range sortedKeys(app.Relations) {
+				w.Println(endpoint, strings.Join(app.Relations[endpoint], ","))
+			}
+		}
+
+		if len(app.Config) > 0 {
+			w.Println()
+			w.Println("CONFIG", "VALUE")
+			keys := make([]string, 0, len(app.Config))
+			for key := range app.Config {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				w.Println(key, fmt.Sprint(app.Config[key]))
+			}
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
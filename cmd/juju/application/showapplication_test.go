@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type ShowApplicationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeShowApplicationAPI
+}
+
+var _ = gc.Suite(&ShowApplicationSuite{})
+
+type fakeShowApplicationAPI struct {
+	applications []names.ApplicationTag
+	results      []params.ApplicationInfoResult
+}
+
+func (f *fakeShowApplicationAPI) Close() error {
+	return nil
+}
+
+func (f *fakeShowApplicationAPI) ApplicationsInfo(applications []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+	f.applications = applications
+	return f.results, nil
+}
+
+func (s *ShowApplicationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	size, count := uint64(1024), uint64(1)
+	s.fake = &fakeShowApplicationAPI{
+		results: []params.ApplicationInfoResult{{
+			Result: &params.ApplicationInfo{
+				Tag:              "application-mysql",
+				Charm:            "cs:trusty/mysql-42",
+				Channel:          "stable",
+				Series:           "trusty",
+				Life:             "alive",
+				Principal:        true,
+				MinUnits:         2,
+				Constraints:      constraints.MustParse("mem=4G"),
+				EndpointBindings: map[string]string{"server": "db"},
+				Config:           map[string]interface{}{"dataset-size": "80%"},
+				Storage: map[string]params.StorageConstraints{
+					"data": {Pool: "ebs", Size: &size, Count: &count},
+				},
+				Units: []params.ApplicationUnitInfo{{
+					Name:           "mysql/0",
+					Machine:        "0",
+					WorkloadStatus: "active",
+					AgentStatus:    "idle",
+					Message:        "ready",
+				}, {
+					Name:           "mysql/1",
+					Machine:        "1",
+					WorkloadStatus: "maintenance",
+					AgentStatus:    "executing",
+					Message:        "installing",
+				}},
+				Relations: map[string][]string{
+					"cluster": {"mysql"},
+					"server":  {"wordpress"},
+				},
+			},
+		}},
+	}
+}
+
+func (s *ShowApplicationSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewShowApplicationCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	_, err = testing.RunCommand(c, application.NewShowApplicationCommandForTest(s.fake), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *ShowApplicationSuite) TestShowApplicationYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewShowApplicationCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.applications, jc.DeepEquals, []names.ApplicationTag{names.NewApplicationTag("mysql")})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+mysql:
+  charm: cs:trusty/mysql-42
+  channel: stable
+  series: trusty
+  life: alive
+  principal: true
+  exposed: false
+  min-units: 2
+  constraints: mem=4096M
+  endpoint-bindings:
+    server: db
+  config:
+    dataset-size: 80%
+  storage:
+    data:
+      pool: ebs
+      size: 1024
+      count: 1
+  units:
+    mysql/0:
+      machine: "0"
+      workload-status: active
+      agent-status: idle
+      message: ready
+    mysql/1:
+      machine: "1"
+      workload-status: maintenance
+      agent-status: executing
+      message: installing
+  relations:
+    cluster:
+    - mysql
+    server:
+    - wordpress
+`[1:])
+}
+
+func (s *ShowApplicationSuite) TestShowApplicationTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, application.NewShowApplicationCommandForTest(s.fake), "mysql", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+APP    CHARM               CHANNEL  SERIES  EXPOSED  MIN-UNITS  CONSTRAINTS
+mysql  cs:trusty/mysql-42  stable   trusty  false    2          mem=4096M
+
+UNIT     WORKLOAD     AGENT      MACHINE  MESSAGE
+mysql/0  active       idle       0        ready
+mysql/1  maintenance  executing  1        installing
+
+ENDPOINT  RELATED-APPLICATIONS
+cluster   mysql
+server    wordpress
+
+CONFIG        VALUE
+dataset-size  80%
+`[1:])
+}
+
+func (s *ShowApplicationSuite) TestShowApplicationError(c *gc.C) {
+	s.fake.results = []params.ApplicationInfoResult{{
+		Error: &params.Error{Message: `application "mysql" not found`},
+	}}
+	_, err := testing.RunCommand(c, application.NewShowApplicationCommandForTest(s.fake), "mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
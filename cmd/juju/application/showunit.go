@@ -0,0 +1,174 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showUnitDoc = `
+The command takes one or more unit names as arguments and shows the
+unit's charm, machine, addresses, opened ports, whether it is the
+application leader, and the workload version it reports.
+
+For each relation the unit has joined, the settings the unit has set in
+the relation are shown, together with the settings of each remote unit
+as seen by this unit. The leader settings of the unit's application are
+also shown.
+
+The default output format is yaml; json is also available.
+
+Examples:
+    juju show-unit mysql/0
+    juju show-unit wordpress/1 mysql/0 --format json
+
+See also:
+    show-status
+    show-application
+`
+
+// NewShowUnitCommand returns a command that displays unit details,
+// including the relation data visible to each unit.
+func NewShowUnitCommand() cmd.Command {
+	return modelcmd.Wrap(&showUnitCommand{})
+}
+
+// showUnitCommand displays unit details.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api showUnitAPI
+
+	units []names.UnitTag
+}
+
+// showUnitAPI defines the API methods used by the show-unit command.
+type showUnitAPI interface {
+	Close() error
+	UnitsInfo([]names.UnitTag) ([]params.UnitInfoResult, error)
+}
+
+// Info implements Command.Info.
+func (c *showUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name> [...]",
+		Purpose: "Displays information about a unit.",
+		Doc:     showUnitDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit name specified")
+	}
+	for _, arg := range args {
+		if !names.IsValidUnit(arg) {
+			return errors.NotValidf("unit name %q", arg)
+		}
+		c.units = append(c.units, names.NewUnitTag(arg))
+	}
+	return nil
+}
+
+func (c *showUnitCommand) getAPI() (showUnitAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.UnitsInfo(c.units)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var errs params.ErrorResults
+	output := make(map[string]unitInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{result.Error})
+			continue
+		}
+		output[c.units[i].Id()] = formatUnitInfo(*result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+	return c.out.Write(ctx, output)
+}
+
+// unitInfo is the output format of a single unit.
+type unitInfo struct {
+	Charm           string            `yaml:"charm" json:"charm"`
+	Life            string            `yaml:"life" json:"life"`
+	Machine         string            `yaml:"machine,omitempty" json:"machine,omitempty"`
+	PublicAddress   string            `yaml:"public-address,omitempty" json:"public-address,omitempty"`
+	PrivateAddress  string            `yaml:"private-address,omitempty" json:"private-address,omitempty"`
+	OpenedPorts     []string          `yaml:"opened-ports,omitempty" json:"opened-ports,omitempty"`
+	Leader          bool              `yaml:"leader" json:"leader"`
+	WorkloadVersion string            `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	LeaderSettings  map[string]string `yaml:"application-leader-settings,omitempty" json:"application-leader-settings,omitempty"`
+	RelationInfo    []relationInfo    `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+}
+
+// relationInfo is the output format of the relation settings
+// visible to a unit.
+type relationInfo struct {
+	RelationId      int                               `yaml:"relation-id" json:"relation-id"`
+	Endpoint        string                            `yaml:"endpoint" json:"endpoint"`
+	RelatedEndpoint string                            `yaml:"related-endpoint" json:"related-endpoint"`
+	UnitData        map[string]interface{}            `yaml:"unit-data" json:"unit-data"`
+	RelatedUnits    map[string]map[string]interface{} `yaml:"related-units,omitempty" json:"related-units,omitempty"`
+}
+
+func formatUnitInfo(info params.UnitInfo) unitInfo {
+	out := unitInfo{
+		Charm:           info.Charm,
+		Life:            info.Life,
+		Machine:         info.Machine,
+		PublicAddress:   info.PublicAddress,
+		PrivateAddress:  info.PrivateAddress,
+		OpenedPorts:     info.OpenedPorts,
+		Leader:          info.Leader,
+		WorkloadVersion: info.WorkloadVersion,
+		LeaderSettings:  info.LeaderSettings,
+	}
+	for _, rd := range info.RelationData {
+		out.RelationInfo = append(out.RelationInfo, relationInfo{
+			RelationId:      rd.RelationId,
+			Endpoint:        rd.Endpoint,
+			RelatedEndpoint: rd.RelatedEndpoint,
+			UnitData:        rd.UnitData,
+			RelatedUnits:    rd.RemoteUnitData,
+		})
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type ShowUnitSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeShowUnitAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+type fakeShowUnitAPI struct {
+	units   []names.UnitTag
+	results []params.UnitInfoResult
+}
+
+func (f *fakeShowUnitAPI) Close() error {
+	return nil
+}
+
+func (f *fakeShowUnitAPI) UnitsInfo(units []names.UnitTag) ([]params.UnitInfoResult, error) {
+	f.units = units
+	return f.results, nil
+}
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeShowUnitAPI{}
+}
+
+func (s *ShowUnitSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewShowUnitCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "no unit name specified")
+	_, err = testing.RunCommand(c, application.NewShowUnitCommandForTest(s.fake), "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *ShowUnitSuite) TestShowUnit(c *gc.C) {
+	s.fake.results = []params.UnitInfoResult{{
+		Result: &params.UnitInfo{
+			Tag:            "unit-wordpress-0",
+			Charm:          "cs:trusty/wordpress-3",
+			Life:           "alive",
+			Machine:        "1",
+			PrivateAddress: "10.0.0.1",
+			OpenedPorts:    []string{"80/tcp"},
+			Leader:         true,
+			LeaderSettings: map[string]string{"secret": "s3kr1t"},
+			RelationData: []params.EndpointRelationData{{
+				RelationId:      2,
+				Endpoint:        "db",
+				RelatedEndpoint: "mysql:server",
+				UnitData:        map[string]interface{}{"private-address": "10.0.0.1"},
+				RemoteUnitData: map[string]map[string]interface{}{
+					"mysql/0": {"host": "10.0.0.2"},
+				},
+			}},
+		},
+	}}
+	ctx, err := testing.RunCommand(c, application.NewShowUnitCommandForTest(s.fake), "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.units, jc.DeepEquals, []names.UnitTag{names.NewUnitTag("wordpress/0")})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+wordpress/0:
+  charm: cs:trusty/wordpress-3
+  life: alive
+  machine: "1"
+  private-address: 10.0.0.1
+  opened-ports:
+  - 80/tcp
+  leader: true
+  application-leader-settings:
+    secret: s3kr1t
+  relation-info:
+  - relation-id: 2
+    endpoint: db
+    related-endpoint: mysql:server
+    unit-data:
+      private-address: 10.0.0.1
+    related-units:
+      mysql/0:
+        host: 10.0.0.2
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowUnitError(c *gc.C) {
+	s.fake.results = []params.UnitInfoResult{{
+		Error: common.ServerError(common.ErrPerm),
+	}}
+	_, err := testing.RunCommand(c, application.NewShowUnitCommandForTest(s.fake), "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSuspendRelationSummary = `
+Suspends a relation between two applications.`[1:]
+
+var usageSuspendRelationDetails = `
+A suspended relation is retained, along with the relation settings of its
+units, but its units depart from it; relation-departed hooks are run as if
+the units had left the relation. The relation remains suspended, and is
+shown as such by juju status, until it is resumed with resume-relation.
+
+The relation is identified by its id, as shown by juju status --relations.
+
+Examples:
+    juju suspend-relation 123
+    juju suspend-relation 123 --message "reason for suspending"
+
+See also:
+    add-relation
+    remove-relation
+    resume-relation`[1:]
+
+// NewSuspendRelationCommand returns a command to suspend a relation.
+func NewSuspendRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{})
+}
+
+// suspendRelationCommand suspends a relation without removing it.
+type suspendRelationCommand struct {
+	modelcmd.ModelCommandBase
+	api setRelationSuspendedAPI
+
+	RelationId int
+	Message    string
+}
+
+// setRelationSuspendedAPI defines the API methods used by the suspend
+// and resume relation commands.
+type setRelationSuspendedAPI interface {
+	Close() error
+	SetRelationSuspended(relationId int, suspended bool, message string) error
+}
+
+// Info implements Command.Info.
+func (c *suspendRelationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "suspend-relation",
+		Args:    "<relation-id>",
+		Purpose: usageSuspendRelationSummary,
+		Doc:     usageSuspendRelationDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *suspendRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Message, "message", "", "reason for suspension")
+}
+
+// Init implements Command.Init.
+func (c *suspendRelationCommand) Init(args []string) (err error) {
+	c.RelationId, err = parseRelationId(args)
+	return err
+}
+
+// parseRelationId returns the relation id given as the only argument.
+func parseRelationId(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, errors.New("no relation id specified")
+	}
+	relationId, err := strconv.Atoi(args[0])
+	if err != nil || relationId < 0 {
+		return 0, errors.NotValidf("relation id %q", args[0])
+	}
+	return relationId, cmd.CheckEmpty(args[1:])
+}
+
+func (c *suspendRelationCommand) getAPI() (setRelationSuspendedAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *suspendRelationCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetRelationSuspended(c.RelationId, true, c.Message)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
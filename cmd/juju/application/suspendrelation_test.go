@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type SuspendRelationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSetRelationSuspendedAPI
+}
+
+var _ = gc.Suite(&SuspendRelationSuite{})
+
+type fakeSetRelationSuspendedAPI struct {
+	relationId int
+	suspended  bool
+	message    string
+	err        error
+}
+
+func (f *fakeSetRelationSuspendedAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSetRelationSuspendedAPI) SetRelationSuspended(relationId int, suspended bool, message string) error {
+	f.relationId = relationId
+	f.suspended = suspended
+	f.message = message
+	return f.err
+}
+
+func (s *SuspendRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSetRelationSuspendedAPI{relationId: -1}
+}
+
+func (s *SuspendRelationSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no relation id specified",
+	}, {
+		args: []string{"wordpress"},
+		err:  `relation id "wordpress" not valid`,
+	}, {
+		args: []string{"-1"},
+		err:  `relation id "-1" not valid`,
+	}, {
+		args: []string{"123", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := testing.RunCommand(c, application.NewSuspendRelationCommandForTest(s.fake), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SuspendRelationSuite) TestSuspendRelation(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSuspendRelationCommandForTest(s.fake), "123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.relationId, gc.Equals, 123)
+	c.Check(s.fake.suspended, jc.IsTrue)
+	c.Check(s.fake.message, gc.Equals, "")
+}
+
+func (s *SuspendRelationSuite) TestSuspendRelationWithMessage(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewSuspendRelationCommandForTest(s.fake), "123", "--message", "misbehaving")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.relationId, gc.Equals, 123)
+	c.Check(s.fake.suspended, jc.IsTrue)
+	c.Check(s.fake.message, gc.Equals, "misbehaving")
+}
+
+func (s *SuspendRelationSuite) TestSuspendRelationError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, application.NewSuspendRelationCommandForTest(s.fake), "123")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/charms"
@@ -50,6 +51,21 @@ type upgradeCharmCommand struct {
 	// Channel holds the charmstore channel to use when obtaining
 	// the charm to be upgraded to.
 	Channel csclientparams.Channel
+
+	// BatchSize, when non-zero, limits the number of units that
+	// upgrade to the new charm at the same time.
+	BatchSize     int
+	WaitForActive bool
+	PauseOnError  bool
+
+	// Resume and Abort act on a rolling upgrade that is already
+	// in progress, rather than starting a new upgrade.
+	Resume bool
+	Abort  bool
+
+	// DryRun reports what the upgrade would change without
+	// performing it.
+	DryRun bool
 }
 
 const upgradeCharmDoc = `
@@ -111,6 +127,31 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+By default all units of the application are upgraded at once. The --batch-size
+flag performs a rolling upgrade instead: only that many units run the
+upgrade-charm operation at a time, and the next units start once a unit
+finishes. With --wait-for-active, a unit is only considered finished once its
+workload status returns to active. With --pause-on-error, the upgrade is paused
+when an upgrading unit goes into an error state. Units added while the upgrade
+is in progress use the new charm straight away.
+
+  juju upgrade-charm mysql --batch-size 2 --wait-for-active --pause-on-error
+
+A paused upgrade is continued with --resume. A rolling upgrade can be aborted
+with --abort, which returns the application and all of its units, including
+those already upgraded, to the previous charm.
+
+  juju upgrade-charm mysql --resume
+  juju upgrade-charm mysql --abort
+
+The --dry-run flag shows what the upgrade would change without performing it:
+added and removed config keys and changed defaults, added and removed
+endpoints and the relations that would break, added and removed resources and
+storage, and whether the new charm requires a different series. The charm is
+not added to the model.
+
+  juju upgrade-charm mysql --dry-run
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -131,6 +172,12 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CharmPath, "path", "", "Upgrade to a charm located at path")
 	f.IntVar(&c.Revision, "revision", -1, "Explicit revision of current charm")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Number of units to upgrade at a time")
+	f.BoolVar(&c.WaitForActive, "wait-for-active", false, "Wait for upgraded units to become active before upgrading more")
+	f.BoolVar(&c.PauseOnError, "pause-on-error", false, "Pause the rolling upgrade when a unit goes into an error state")
+	f.BoolVar(&c.Resume, "resume", false, "Resume a paused rolling upgrade")
+	f.BoolVar(&c.Abort, "abort", false, "Abort a rolling upgrade and return to the previous charm")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what the upgrade would change without performing it")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -154,6 +201,29 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.Resume || c.Abort {
+		return c.checkRolloutControl()
+	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must be a positive number")
+	}
+	if c.BatchSize == 0 && (c.WaitForActive || c.PauseOnError) {
+		return errors.Errorf("--wait-for-active and --pause-on-error require --batch-size")
+	}
+	return nil
+}
+
+// checkRolloutControl checks that --resume and --abort are used on
+// their own.
+func (c *upgradeCharmCommand) checkRolloutControl() error {
+	if c.Resume && c.Abort {
+		return errors.Errorf("--resume and --abort are mutually exclusive")
+	}
+	if c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1 ||
+		c.Channel != "" || c.ForceUnits || c.ForceSeries || len(c.Resources) > 0 ||
+		c.BatchSize != 0 || c.WaitForActive || c.PauseOnError || c.DryRun {
+		return errors.Errorf("--resume and --abort cannot be combined with other flags")
+	}
 	return nil
 }
 
@@ -188,6 +258,15 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 	defer serviceClient.Close()
 
+	if c.Resume {
+		err := serviceClient.ResumeCharmUpgrade(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.Abort {
+		err := serviceClient.AbortCharmUpgrade(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+
 	oldURL, err := serviceClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
 		return err
@@ -231,6 +310,10 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		conf,
 	).(*charmrepo.CharmStore)
 
+	if c.DryRun {
+		return c.dryRun(ctx, apiRoot, charmRepo, conf, oldURL, newRef)
+	}
+
 	// TODO(katco): This anonymous adapter should go away in favor of
 	// a comprehensive API passed into the upgrade-charm command.
 	charmstoreAdapter := &struct {
@@ -262,11 +345,62 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceSeries:     c.ForceSeries,
 		ForceUnits:      c.ForceUnits,
 		ResourceIDs:     ids,
+		BatchSize:       c.BatchSize,
+		WaitForActive:   c.WaitForActive,
+		PauseOnError:    c.PauseOnError,
 	}
 
 	return block.ProcessBlockedError(serviceClient.SetCharm(cfg), block.BlockChange)
 }
 
+// dryRun reports what upgrading the application to the charm referred
+// to by charmRef would change, without adding the charm to the model.
+func (c *upgradeCharmCommand) dryRun(
+	ctx *cmd.Context,
+	apiRoot api.Connection,
+	charmRepo *charmrepo.CharmStore,
+	conf *config.Config,
+	oldURL *charm.URL,
+	charmRef string,
+) error {
+	target, err := c.resolveUpgradeTarget(charmRepo, conf, oldURL, charmRef)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newURL, newCharm := target.url, target.local
+	if newCharm != nil {
+		// Local charms are given their revision when added to the
+		// model, so it is not known yet.
+		newURL = newURL.WithRevision(-1)
+	} else if newCharm, err = charmRepo.Get(target.url); err != nil {
+		return errors.Trace(err)
+	}
+	oldCharm, err := charms.NewClient(apiRoot).CharmInfo(oldURL.String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	status, err := apiRoot.Client().Status([]string{c.ApplicationName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appStatus, ok := status.Applications[c.ApplicationName]
+	if !ok {
+		return errors.NotFoundf("application %q", c.ApplicationName)
+	}
+	report := newUpgradeReport(upgradeReportParams{
+		Application: c.ApplicationName,
+		Series:      appStatus.Series,
+		Relations:   appStatus.Relations,
+		OldURL:      oldURL,
+		OldMeta:     oldCharm.Meta,
+		OldConfig:   oldCharm.Config,
+		NewURL:      newURL,
+		NewMeta:     newCharm.Meta(),
+		NewConfig:   newCharm.Config(),
+	})
+	return report.write(ctx.Stdout)
+}
+
 // upgradeResources pushes metadata up to the server for each resource defined
 // in the new charm's metadata and returns a map of resource names to pending
 // IDs to include in the upgrage-charm call.
@@ -378,43 +512,80 @@ func (c *upgradeCharmCommand) addCharm(
 	charmAdder CharmAdder,
 ) (charmstore.CharmID, *macaroon.Macaroon, error) {
 	var id charmstore.CharmID
+	target, err := c.resolveUpgradeTarget(charmRepo, config, oldURL, charmRef)
+	if err != nil {
+		return id, nil, err
+	}
+	if target.local != nil {
+		addedURL, err := charmAdder.AddLocalCharm(target.url, target.local)
+		id.URL = addedURL
+		return id, nil, err
+	}
+	id.Channel = target.channel
+	curl, csMac, err := addCharmFromURL(charmAdder, target.url, target.channel)
+	if err != nil {
+		return id, nil, errors.Trace(err)
+	}
+	id.URL = curl
+	return id, csMac, nil
+}
+
+// upgradeTarget identifies the charm an application is to be
+// upgraded to.
+type upgradeTarget struct {
+	url     *charm.URL
+	channel csclientparams.Channel
+	// local holds the charm when it was read from a path.
+	local charm.Charm
+}
+
+// resolveUpgradeTarget works out which charm the supplied reference,
+// either a path or a charm store URL, refers to, and checks that the
+// application can be upgraded to it. It does not add the charm to
+// the model.
+func (c *upgradeCharmCommand) resolveUpgradeTarget(
+	charmRepo *charmrepo.CharmStore,
+	config *config.Config,
+	oldURL *charm.URL,
+	charmRef string,
+) (upgradeTarget, error) {
+	var target upgradeTarget
 	// Charm may have been supplied via a path reference.
 	ch, newURL, err := charmrepo.NewCharmAtPathForceSeries(charmRef, oldURL.Series, c.ForceSeries)
 	if err == nil {
 		_, newName := filepath.Split(charmRef)
 		if newName != oldURL.Name {
-			return id, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
+			return target, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
 		}
-		addedURL, err := charmAdder.AddLocalCharm(newURL, ch)
-		id.URL = addedURL
-		return id, nil, err
+		target.url = newURL
+		target.local = ch
+		return target, nil
 	}
 	if _, ok := err.(*charmrepo.NotFoundError); ok {
-		return id, nil, errors.Errorf("no charm found at %q", charmRef)
+		return target, errors.Errorf("no charm found at %q", charmRef)
 	}
 	// If we get a "not exists" or invalid path error then we attempt to interpret
 	// the supplied charm reference as a URL below, otherwise we return the error.
 	if err != os.ErrNotExist && !charmrepo.IsInvalidPathError(err) {
-		return id, nil, err
+		return target, err
 	}
 
 	refURL, err := charm.ParseURL(charmRef)
 	if err != nil {
-		return id, nil, errors.Trace(err)
+		return target, errors.Trace(err)
 	}
 
 	// Charm has been supplied as a URL so we resolve and deploy using the store.
 	newURL, channel, supportedSeries, err := resolveCharm(charmRepo.ResolveWithChannel, config, refURL)
 	if err != nil {
-		return id, nil, errors.Trace(err)
+		return target, errors.Trace(err)
 	}
-	id.Channel = channel
 	if !c.ForceSeries && oldURL.Series != "" && newURL.Series == "" && !isSeriesSupported(oldURL.Series, supportedSeries) {
 		series := []string{"no series"}
 		if len(supportedSeries) > 0 {
 			series = supportedSeries
 		}
-		return id, nil, errors.Errorf(
+		return target, errors.Errorf(
 			"cannot upgrade from single series %q charm to a charm supporting %q. Use --force-series to override.",
 			oldURL.Series, series,
 		)
@@ -423,18 +594,14 @@ func (c *upgradeCharmCommand) addCharm(
 	// or Revision flags, discover the latest.
 	if *newURL == *oldURL {
 		if refURL.Revision != -1 {
-			return id, nil, errors.Errorf("already running specified charm %q", newURL)
+			return target, errors.Errorf("already running specified charm %q", newURL)
 		}
 		// No point in trying to upgrade a charm store charm when
 		// we just determined that's the latest revision
 		// available.
-		return id, nil, errors.Errorf("already running latest charm %q", newURL)
-	}
-
-	curl, csMac, err := addCharmFromURL(charmAdder, newURL, channel)
-	if err != nil {
-		return id, nil, errors.Trace(err)
+		return target, errors.Errorf("already running latest charm %q", newURL)
 	}
-	id.URL = curl
-	return id, csMac, nil
+	target.url = newURL
+	target.channel = channel
+	return target, nil
 }
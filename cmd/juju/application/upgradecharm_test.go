@@ -128,6 +128,30 @@ func (s *UpgradeCharmErrorsSuite) TestInvalidRevision(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid value "blah" for flag --revision: strconv.ParseInt: parsing "blah": invalid syntax`)
 }
 
+func (s *UpgradeCharmErrorsSuite) TestRollingFlagsNeedBatchSize(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--wait-for-active")
+	c.Assert(err, gc.ErrorMatches, "--wait-for-active and --pause-on-error require --batch-size")
+	err = runUpgradeCharm(c, "riak", "--pause-on-error")
+	c.Assert(err, gc.ErrorMatches, "--wait-for-active and --pause-on-error require --batch-size")
+	err = runUpgradeCharm(c, "riak", "--batch-size=-1")
+	c.Assert(err, gc.ErrorMatches, "--batch-size must be a positive number")
+}
+
+func (s *UpgradeCharmErrorsSuite) TestResumeAndAbortFlags(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--resume", "--abort")
+	c.Assert(err, gc.ErrorMatches, "--resume and --abort are mutually exclusive")
+	err = runUpgradeCharm(c, "riak", "--resume", "--batch-size=2")
+	c.Assert(err, gc.ErrorMatches, "--resume and --abort cannot be combined with other flags")
+	err = runUpgradeCharm(c, "riak", "--abort", "--path=foo")
+	c.Assert(err, gc.ErrorMatches, "--resume and --abort cannot be combined with other flags")
+}
+
+func (s *UpgradeCharmErrorsSuite) TestAbortWithoutRollingUpgrade(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--abort")
+	c.Assert(err, gc.ErrorMatches, `rolling charm upgrade of application "riak" not found`)
+}
+
 type BaseUpgradeCharmSuite struct{}
 
 type UpgradeCharmSuccessSuite struct {
@@ -279,6 +303,83 @@ func (s *UpgradeCharmSuccessSuite) TestBlockForcedUnitsUpgrade(c *gc.C) {
 	s.assertLocalRevision(c, 7, s.path)
 }
 
+func (s *UpgradeCharmSuccessSuite) TestRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--path", s.path, "--batch-size", "1", "--wait-for-active")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, s.riak, 8, false)
+	rollout, err := s.riak.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.BatchSize, gc.Equals, 1)
+	c.Assert(rollout.WaitForActive, jc.IsTrue)
+	c.Assert(rollout.PauseOnError, jc.IsFalse)
+	c.Assert(rollout.PreviousCharmURL.Revision, gc.Equals, 7)
+}
+
+func (s *UpgradeCharmSuccessSuite) TestAbortRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--path", s.path, "--batch-size", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, s.riak, 8, false)
+
+	err = runUpgradeCharm(c, "riak", "--abort")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, s.riak, 7, false)
+	_, err = s.riak.CharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeCharmSuccessSuite) TestResumeRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--path", s.path, "--batch-size", "1", "--pause-on-error")
+	c.Assert(err, jc.ErrorIsNil)
+	err = runUpgradeCharm(c, "riak", "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err := s.riak.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Paused, jc.IsFalse)
+}
+
+func (s *UpgradeCharmSuccessSuite) TestDryRun(c *gc.C) {
+	myriakPath := testcharms.Repo.ClonedDirPath(c.MkDir(), "riak")
+	err := ioutil.WriteFile(path.Join(myriakPath, "metadata.yaml"), []byte(`
+name: riak
+summary: "K/V storage engine"
+description: "Scalable K/V Store in Erlang with Clocks :-)"
+provides:
+  endpoint:
+    interface: http
+peers:
+  ring:
+    interface: riak
+storage:
+  data:
+    type: filesystem
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(myriakPath, "config.yaml"), []byte(`
+options:
+  ring-size:
+    type: int
+    default: 64
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := testing.RunCommand(c, NewUpgradeCharmCommand(), "riak", "--path", myriakPath, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+Upgrading "riak" from local:quantal/riak-7 to local:quantal/riak:
+New config keys:
+  ring-size
+Removed endpoints:
+  admin
+New storage:
+  data
+`[1:])
+
+	// Nothing has changed in the model.
+	s.assertUpgraded(c, s.riak, 7, false)
+	_, err = s.State.Charm(charm.MustParseURL("local:quantal/riak-8"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *UpgradeCharmSuccessSuite) TestCharmPath(c *gc.C) {
 	myriakPath := testcharms.Repo.ClonedDirPath(c.MkDir(), "riak")
 
@@ -0,0 +1,224 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// upgradeReport describes what would change if an application were
+// upgraded from one charm to another.
+type upgradeReport struct {
+	Application string
+	OldURL      *charm.URL
+	NewURL      *charm.URL
+
+	ConfigAdded          []string
+	ConfigRemoved        []string
+	ConfigTypeChanged    []string
+	ConfigDefaultChanged []string
+
+	EndpointsAdded   []string
+	EndpointsRemoved []string
+	// BrokenRelations holds, for each endpoint that is in use and would
+	// be removed or change interface, the applications related over it.
+	BrokenRelations map[string][]string
+
+	ResourcesAdded   []string
+	ResourcesRemoved []string
+	StorageAdded     []string
+	StorageRemoved   []string
+
+	// Series holds the series the application currently runs, and
+	// SeriesChange, when set, explains why the new charm would
+	// require a different one.
+	Series       string
+	SeriesChange string
+}
+
+// upgradeReportParams holds what is needed to build an upgradeReport.
+type upgradeReportParams struct {
+	Application string
+	Series      string
+	// Relations maps each endpoint of the application to the
+	// applications related over it.
+	Relations map[string][]string
+
+	OldURL    *charm.URL
+	OldMeta   *charm.Meta
+	OldConfig *charm.Config
+
+	NewURL    *charm.URL
+	NewMeta   *charm.Meta
+	NewConfig *charm.Config
+}
+
+// newUpgradeReport compares the deployed charm of an application with
+// the charm it would be upgraded to.
+func newUpgradeReport(p upgradeReportParams) *upgradeReport {
+	report := &upgradeReport{
+		Application:     p.Application,
+		OldURL:          p.OldURL,
+		NewURL:          p.NewURL,
+		Series:          p.Series,
+		BrokenRelations: make(map[string][]string),
+	}
+
+	oldOptions := configOptions(p.OldConfig)
+	newOptions := configOptions(p.NewConfig)
+	for name, newOption := range newOptions {
+		oldOption, ok := oldOptions[name]
+		switch {
+		case !ok:
+			report.ConfigAdded = append(report.ConfigAdded, name)
+		case oldOption.Type != newOption.Type:
+			report.ConfigTypeChanged = append(report.ConfigTypeChanged, fmt.Sprintf(
+				"%s: %s -> %s", name, oldOption.Type, newOption.Type,
+			))
+		case !reflect.DeepEqual(oldOption.Default, newOption.Default):
+			report.ConfigDefaultChanged = append(report.ConfigDefaultChanged, fmt.Sprintf(
+				"%s: %s -> %s", name, formatDefault(oldOption.Default), formatDefault(newOption.Default),
+			))
+		}
+	}
+	for name := range oldOptions {
+		if _, ok := newOptions[name]; !ok {
+			report.ConfigRemoved = append(report.ConfigRemoved, name)
+		}
+	}
+
+	oldRelations := p.OldMeta.CombinedRelations()
+	newRelations := p.NewMeta.CombinedRelations()
+	for name := range newRelations {
+		if _, ok := oldRelations[name]; !ok {
+			report.EndpointsAdded = append(report.EndpointsAdded, name)
+		}
+	}
+	for name, oldRelation := range oldRelations {
+		newRelation, ok := newRelations[name]
+		if !ok {
+			report.EndpointsRemoved = append(report.EndpointsRemoved, name)
+		}
+		related := p.Relations[name]
+		if len(related) == 0 {
+			continue
+		}
+		if !ok || newRelation.Interface != oldRelation.Interface || newRelation.Role != oldRelation.Role {
+			report.BrokenRelations[name] = append([]string(nil), related...)
+		}
+	}
+
+	for name := range p.NewMeta.Resources {
+		if _, ok := p.OldMeta.Resources[name]; !ok {
+			report.ResourcesAdded = append(report.ResourcesAdded, name)
+		}
+	}
+	for name := range p.OldMeta.Resources {
+		if _, ok := p.NewMeta.Resources[name]; !ok {
+			report.ResourcesRemoved = append(report.ResourcesRemoved, name)
+		}
+	}
+	for name := range p.NewMeta.Storage {
+		if _, ok := p.OldMeta.Storage[name]; !ok {
+			report.StorageAdded = append(report.StorageAdded, name)
+		}
+	}
+	for name := range p.OldMeta.Storage {
+		if _, ok := p.NewMeta.Storage[name]; !ok {
+			report.StorageRemoved = append(report.StorageRemoved, name)
+		}
+	}
+
+	switch {
+	case p.NewURL.Series != "" && p.NewURL.Series != p.Series:
+		report.SeriesChange = fmt.Sprintf("new charm is for series %q", p.NewURL.Series)
+	case p.NewURL.Series == "" && len(p.NewMeta.Series) > 0 && !isSeriesSupported(p.Series, p.NewMeta.Series):
+		report.SeriesChange = fmt.Sprintf(
+			"new charm supports series %s", strings.Join(p.NewMeta.Series, ", "),
+		)
+	}
+
+	for _, values := range [][]string{
+		report.ConfigAdded, report.ConfigRemoved,
+		report.ConfigTypeChanged, report.ConfigDefaultChanged,
+		report.EndpointsAdded, report.EndpointsRemoved,
+		report.ResourcesAdded, report.ResourcesRemoved,
+		report.StorageAdded, report.StorageRemoved,
+	} {
+		sort.Strings(values)
+	}
+	return report
+}
+
+func configOptions(config *charm.Config) map[string]charm.Option {
+	if config == nil {
+		return nil
+	}
+	return config.Options
+}
+
+func formatDefault(value interface{}) string {
+	if value == nil {
+		return "(none)"
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// write writes a human readable form of the report to w.
+func (r *upgradeReport) write(w io.Writer) error {
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+	section := func(title string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		add("%s:", title)
+		for _, value := range values {
+			add("  %s", value)
+		}
+	}
+
+	add("Upgrading %q from %s to %s:", r.Application, r.OldURL, r.NewURL)
+	section("New config keys", r.ConfigAdded)
+	section("Removed config keys", r.ConfigRemoved)
+	section("Config keys changing type", r.ConfigTypeChanged)
+	section("Config keys changing default", r.ConfigDefaultChanged)
+	section("New endpoints", r.EndpointsAdded)
+	section("Removed endpoints", r.EndpointsRemoved)
+	if len(r.BrokenRelations) > 0 {
+		endpoints := make([]string, 0, len(r.BrokenRelations))
+		for endpoint := range r.BrokenRelations {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		var broken []string
+		for _, endpoint := range endpoints {
+			related := append([]string(nil), r.BrokenRelations[endpoint]...)
+			sort.Strings(related)
+			broken = append(broken, fmt.Sprintf("%s: %s", endpoint, strings.Join(related, ", ")))
+		}
+		section("Relations that would break", broken)
+	}
+	section("New resources", r.ResourcesAdded)
+	section("Removed resources", r.ResourcesRemoved)
+	section("New storage", r.StorageAdded)
+	section("Removed storage", r.StorageRemoved)
+	if r.SeriesChange != "" {
+		add("Series change required:")
+		add("  application runs %q; %s", r.Series, r.SeriesChange)
+	}
+	if len(lines) == 1 {
+		add("No changes to config, endpoints, resources, storage or series.")
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
@@ -0,0 +1,175 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"strings"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+type UpgradeReportSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&UpgradeReportSuite{})
+
+func readMeta(c *gc.C, yaml string) *charm.Meta {
+	meta, err := charm.ReadMeta(strings.NewReader(yaml))
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func readConfig(c *gc.C, yaml string) *charm.Config {
+	config, err := charm.ReadConfig(strings.NewReader(yaml))
+	c.Assert(err, jc.ErrorIsNil)
+	return config
+}
+
+func (s *UpgradeReportSuite) params(c *gc.C) upgradeReportParams {
+	return upgradeReportParams{
+		Application: "mysql",
+		Series:      "trusty",
+		Relations: map[string][]string{
+			"db":      {"wordpress", "mediawiki"},
+			"cluster": {"mysql"},
+		},
+		OldURL: charm.MustParseURL("cs:trusty/mysql-1"),
+		OldMeta: readMeta(c, `
+name: mysql
+summary: s
+description: d
+provides:
+  db:
+    interface: mysql
+  metrics:
+    interface: prometheus
+peers:
+  cluster:
+    interface: mysql-ha
+resources:
+  tools:
+    type: file
+    filename: tools.tgz
+`),
+		OldConfig: readConfig(c, `
+options:
+  port:
+    type: int
+    default: 3306
+  flavour:
+    type: string
+    default: classic
+  legacy:
+    type: boolean
+`),
+		NewURL: charm.MustParseURL("cs:mysql-2"),
+		NewMeta: readMeta(c, `
+name: mysql
+summary: s
+description: d
+series: [xenial, bionic]
+provides:
+  db:
+    interface: mysql-root
+  monitoring:
+    interface: nrpe
+peers:
+  cluster:
+    interface: mysql-ha
+resources:
+  plugins:
+    type: file
+    filename: plugins.tgz
+storage:
+  data:
+    type: filesystem
+`),
+		NewConfig: readConfig(c, `
+options:
+  port:
+    type: string
+    default: "3306"
+  flavour:
+    type: string
+    default: percona
+  tuning:
+    type: string
+`),
+	}
+}
+
+func (s *UpgradeReportSuite) TestNewUpgradeReport(c *gc.C) {
+	report := newUpgradeReport(s.params(c))
+	c.Check(report.ConfigAdded, jc.DeepEquals, []string{"tuning"})
+	c.Check(report.ConfigRemoved, jc.DeepEquals, []string{"legacy"})
+	c.Check(report.ConfigTypeChanged, jc.DeepEquals, []string{"port: int -> string"})
+	c.Check(report.ConfigDefaultChanged, jc.DeepEquals, []string{`flavour: "classic" -> "percona"`})
+	c.Check(report.EndpointsAdded, jc.DeepEquals, []string{"monitoring"})
+	c.Check(report.EndpointsRemoved, jc.DeepEquals, []string{"metrics"})
+	c.Check(report.BrokenRelations, jc.DeepEquals, map[string][]string{
+		"db": {"wordpress", "mediawiki"},
+	})
+	c.Check(report.ResourcesAdded, jc.DeepEquals, []string{"plugins"})
+	c.Check(report.ResourcesRemoved, jc.DeepEquals, []string{"tools"})
+	c.Check(report.StorageAdded, jc.DeepEquals, []string{"data"})
+	c.Check(report.StorageRemoved, gc.HasLen, 0)
+	c.Check(report.SeriesChange, gc.Equals, "new charm supports series xenial, bionic")
+}
+
+func (s *UpgradeReportSuite) TestSingleSeriesChange(c *gc.C) {
+	p := s.params(c)
+	p.NewURL = charm.MustParseURL("cs:xenial/mysql-2")
+	report := newUpgradeReport(p)
+	c.Check(report.SeriesChange, gc.Equals, `new charm is for series "xenial"`)
+}
+
+func (s *UpgradeReportSuite) TestWrite(c *gc.C) {
+	var buf bytes.Buffer
+	err := newUpgradeReport(s.params(c)).write(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+Upgrading "mysql" from cs:trusty/mysql-1 to cs:mysql-2:
+New config keys:
+  tuning
+Removed config keys:
+  legacy
+Config keys changing type:
+  port: int -> string
+Config keys changing default:
+  flavour: "classic" -> "percona"
+New endpoints:
+  monitoring
+Removed endpoints:
+  metrics
+Relations that would break:
+  db: mediawiki, wordpress
+New resources:
+  plugins
+Removed resources:
+  tools
+New storage:
+  data
+Series change required:
+  application runs "trusty"; new charm supports series xenial, bionic
+`[1:])
+}
+
+func (s *UpgradeReportSuite) TestWriteNoChanges(c *gc.C) {
+	p := s.params(c)
+	p.NewMeta = p.OldMeta
+	p.NewConfig = p.OldConfig
+	p.NewURL = charm.MustParseURL("cs:trusty/mysql-2")
+	var buf bytes.Buffer
+	err := newUpgradeReport(p).write(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+Upgrading "mysql" from cs:trusty/mysql-1 to cs:trusty/mysql-2:
+No changes to config, endpoints, resources, storage or series.
+`[1:])
+}
@@ -18,6 +18,7 @@ import (
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/cmd/juju/annotations"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/cmd/juju/block"
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -299,6 +301,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewUpgradeSeriesCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -335,6 +338,26 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
+	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewShowUnitCommand())
+	r.Register(application.NewSuspendRelationCommand())
+	r.Register(application.NewResumeRelationCommand())
+	r.Register(application.NewBindCommand())
+	r.Register(application.NewSetAutoUpgradeCommand())
+	r.Register(application.NewSetSeriesCommand())
+
+	// Cross-model relations
+	r.Register(application.NewOfferCommand())
+	r.Register(application.NewConsumeCommand())
+
+	// Annotation commands
+	r.Register(annotations.NewAnnotateCommand())
+	r.Register(annotations.NewRemoveAnnotationCommand())
+	r.Register(annotations.NewShowAnnotationsCommand())
+
+	// Secret commands
+	r.Register(secrets.NewListSecretsCommand())
+	r.Register(secrets.NewShowSecretCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -343,10 +366,20 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage storage
 	r.Register(storage.NewAddCommand())
+	r.Register(storage.NewAttachStorageCommand())
+	r.Register(storage.NewDetachStorageCommand())
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewPoolUpdateCommand())
+	r.Register(storage.NewPoolRemoveCommand())
 	r.Register(storage.NewShowCommand())
+	r.Register(storage.NewRemoveStorageCommand())
+	r.Register(storage.NewResizeStorageCommand())
+	r.Register(storage.NewSnapshotStorageCommand())
+	r.Register(storage.NewListSnapshotsCommand())
+	r.Register(storage.NewRestoreStorageCommand())
+	r.Register(storage.NewImportFilesystemCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
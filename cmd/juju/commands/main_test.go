@@ -400,8 +400,11 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"annotate",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budgets",
 	"cached-images",
@@ -410,6 +413,7 @@ var commandNames = []string{
 	"clouds",
 	"config",
 	"collect-metrics",
+	"consume",
 	"controllers",
 	"create-backup",
 	"create-budget",
@@ -421,6 +425,7 @@ var commandNames = []string{
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
+	"detach-storage",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -437,6 +442,7 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"import-filesystem",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -451,11 +457,13 @@ var commandNames = []string{
 	"list-machines",
 	"list-models",
 	"list-plans",
+	"list-secrets",
 	"list-shares",
 	"list-ssh-keys",
 	"list-spaces",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -465,9 +473,11 @@ var commandNames = []string{
 	"model-config",
 	"model-defaults",
 	"models",
+	"offer",
 	"plans",
 	"register",
 	"relate", //alias for add-relation
+	"remove-annotation",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -476,14 +486,21 @@ var commandNames = []string{
 	"remove-machine",
 	"remove-relation",
 	"remove-ssh-key",
+	"remove-storage",
+	"remove-storage-pool",
 	"remove-unit",
+	"resize-storage",
 	"resolved",
 	"restore-backup",
+	"restore-storage",
+	"resume-relation",
 	"retry-provisioning",
 	"revoke",
 	"run",
 	"run-action",
 	"scp",
+	"secrets",
+	"set-auto-upgrade",
 	"set-budget",
 	"set-constraints",
 	"set-default-credential",
@@ -491,26 +508,34 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-series",
 	"shares",
 	"show-action-output",
 	"show-action-status",
+	"show-annotations",
+	"show-application",
 	"show-backup",
 	"show-budget",
 	"show-cloud",
 	"show-controller",
 	"show-machine",
 	"show-model",
+	"show-secret",
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"ssh-keys",
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
+	"suspend-relation",
 	"switch",
 	"sync-tools",
 	"unexpose",
@@ -518,9 +543,11 @@ var commandNames = []string{
 	"upload-backup",
 	"unregister",
 	"update-clouds",
+	"update-storage-pool",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-series",
 	"users",
 	"version",
 	"whoami",
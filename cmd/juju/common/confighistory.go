@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ConfigRevision is the serialisation-friendly form of a recorded change
+// to application or model configuration.
+type ConfigRevision struct {
+	Revision int                     `yaml:"revision" json:"revision"`
+	User     string                  `yaml:"user,omitempty" json:"user,omitempty"`
+	Time     string                  `yaml:"time" json:"time"`
+	Changes  map[string]ConfigChange `yaml:"changes" json:"changes"`
+}
+
+// ConfigChange is the serialisation-friendly form of a change made to
+// a single configuration key.
+type ConfigChange struct {
+	Change string      `yaml:"change" json:"change"`
+	Old    interface{} `yaml:"old,omitempty" json:"old,omitempty"`
+	New    interface{} `yaml:"new,omitempty" json:"new,omitempty"`
+}
+
+// FormatConfigHistory converts the configuration history returned by
+// the API into its serialisation-friendly form.
+func FormatConfigHistory(history []params.ConfigRevision) []ConfigRevision {
+	result := make([]ConfigRevision, len(history))
+	for i, rev := range history {
+		changes := make(map[string]ConfigChange)
+		for _, change := range rev.Changes {
+			changes[change.Key] = ConfigChange{
+				Change: change.Type,
+				Old:    change.OldValue,
+				New:    change.NewValue,
+			}
+		}
+		result[i] = ConfigRevision{
+			Revision: rev.Revision,
+			User:     rev.User,
+			Time:     FormatTime(&rev.Time, true),
+			Changes:  changes,
+		}
+	}
+	return result
+}
+
+// FormatConfigHistoryTabular writes a tabular summary of configuration
+// history, with one line per changed key.
+func FormatConfigHistoryTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.([]ConfigRevision)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("REVISION", "TIME", "USER", "KEY", "CHANGE", "OLD", "NEW")
+	for _, rev := range history {
+		var keys []string
+		for key := range rev.Changes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			change := rev.Changes[key]
+			oldValue, err := formatConfigValue(change.Old)
+			if err != nil {
+				return errors.Annotatef(err, "formatting value for %q", key)
+			}
+			newValue, err := formatConfigValue(change.New)
+			if err != nil {
+				return errors.Annotatef(err, "formatting value for %q", key)
+			}
+			w.Println(rev.Revision, rev.Time, rev.User, key, change.Change, oldValue, newValue)
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
+func formatConfigValue(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	out := &bytes.Buffer{}
+	if err := cmd.FormatYaml(out, value); err != nil {
+		return "", err
+	}
+	// Some values have a newline appended which makes the output messy.
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"bytes"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type ConfigHistorySuite struct{}
+
+var _ = gc.Suite(&ConfigHistorySuite{})
+
+var testConfigHistory = []params.ConfigRevision{{
+	Revision: 2,
+	User:     "mary",
+	Time:     time.Date(2017, 3, 1, 12, 30, 0, 0, time.UTC),
+	Changes: []params.ConfigChange{
+		{Key: "outlook", Type: params.ConfigDeleted, OldValue: "good"},
+		{Key: "title", Type: params.ConfigModified, OldValue: "foo", NewValue: "bar"},
+	},
+}, {
+	Revision: 1,
+	User:     "bob",
+	Time:     time.Date(2017, 3, 1, 11, 0, 0, 0, time.UTC),
+	Changes: []params.ConfigChange{
+		{Key: "title", Type: params.ConfigAdded, NewValue: "foo"},
+	},
+}}
+
+func (s *ConfigHistorySuite) TestFormatConfigHistory(c *gc.C) {
+	history := common.FormatConfigHistory(testConfigHistory)
+	c.Assert(history, jc.DeepEquals, []common.ConfigRevision{{
+		Revision: 2,
+		User:     "mary",
+		Time:     "2017-03-01 12:30:00Z",
+		Changes: map[string]common.ConfigChange{
+			"outlook": {Change: "deleted", Old: "good"},
+			"title":   {Change: "modified", Old: "foo", New: "bar"},
+		},
+	}, {
+		Revision: 1,
+		User:     "bob",
+		Time:     "2017-03-01 11:00:00Z",
+		Changes: map[string]common.ConfigChange{
+			"title": {Change: "added", New: "foo"},
+		},
+	}})
+}
+
+func (s *ConfigHistorySuite) TestFormatConfigHistoryTabular(c *gc.C) {
+	var out bytes.Buffer
+	err := common.FormatConfigHistoryTabular(&out, common.FormatConfigHistory(testConfigHistory))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, ""+
+		"REVISION  TIME                  USER  KEY      CHANGE    OLD   NEW\n"+
+		"2         2017-03-01 12:30:00Z  mary  outlook  deleted   good  \n"+
+		"2         2017-03-01 12:30:00Z  mary  title    modified  foo   bar\n"+
+		"1         2017-03-01 11:00:00Z  bob   title    added           foo\n")
+}
+
+func (s *ConfigHistorySuite) TestFormatConfigHistoryTabularWrongType(c *gc.C) {
+	var out bytes.Buffer
+	err := common.FormatConfigHistoryTabular(&out, "foo")
+	c.Assert(err, gc.ErrorMatches, `expected value of type \[\]common.ConfigRevision, got string`)
+}
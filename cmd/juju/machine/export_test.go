@@ -55,3 +55,16 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+type UpgradeSeriesCommand struct {
+	*upgradeSeriesCommand
+}
+
+// NewUpgradeSeriesCommandForTest returns an UpgradeSeriesCommand with
+// the api provided as specified.
+func NewUpgradeSeriesCommandForTest(api UpgradeSeriesAPI) (cmd.Command, *UpgradeSeriesCommand) {
+	cmd := &upgradeSeriesCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &UpgradeSeriesCommand{cmd}
+}
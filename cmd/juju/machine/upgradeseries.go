@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	upgradeSeriesPrepare  = "prepare"
+	upgradeSeriesComplete = "complete"
+)
+
+// NewUpgradeSeriesCommand returns a command used to upgrade the
+// series of a machine in place.
+func NewUpgradeSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{})
+}
+
+// upgradeSeriesCommand drives the in-place upgrade of the operating
+// system series of a machine.
+type upgradeSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api       UpgradeSeriesAPI
+	MachineId string
+	Command   string
+	Series    string
+	Force     bool
+}
+
+const upgradeSeriesDoc = `
+Upgrading the series of a machine in place is done in two steps,
+around the operating system upgrade itself.
+
+"prepare" records the series the machine is to be upgraded to and
+pauses the unit agents on the machine, so that no hooks run while the
+operating system changes under them. The machine agent then replaces
+the service definitions of the agents on the machine with those for
+the init system of the new series, so that the agents start again when
+the machine reboots into it. The new series must be supported by the
+charms of all units on the machine; the '--force' option skips that
+check.
+
+Once the operating system has been upgraded (for example with
+do-release-upgrade) and the machine rebooted, "complete" updates the
+series of the machine and its units, and each unit runs its
+post-series-upgrade hook.
+
+Examples:
+
+    juju upgrade-series 3 prepare xenial
+    juju upgrade-series 3 complete
+
+See also:
+    set-series
+`
+
+// Info implements Command.Info.
+func (c *upgradeSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrade-series",
+		Args:    "<machine> prepare <series> | <machine> complete",
+		Purpose: "Upgrades the series of a machine in place.",
+		Doc:     upgradeSeriesDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *upgradeSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Force, "force", false, "Allow a series not supported by the charms of the units on the machine")
+}
+
+// Init implements Command.Init.
+func (c *upgradeSeriesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machine specified")
+	}
+	if !names.IsValidMachine(args[0]) {
+		return errors.Errorf("invalid machine id %q", args[0])
+	}
+	c.MachineId = args[0]
+	if len(args) == 1 {
+		return errors.Errorf("expected %q or %q", upgradeSeriesPrepare, upgradeSeriesComplete)
+	}
+	c.Command = args[1]
+	switch c.Command {
+	case upgradeSeriesPrepare:
+		if len(args) == 2 {
+			return errors.Errorf("no series specified")
+		}
+		c.Series = args[2]
+		return cmd.CheckEmpty(args[3:])
+	case upgradeSeriesComplete:
+		if c.Force {
+			return errors.Errorf("--force can only be used with %q", upgradeSeriesPrepare)
+		}
+		return cmd.CheckEmpty(args[2:])
+	}
+	return errors.Errorf("expected %q or %q, got %q", upgradeSeriesPrepare, upgradeSeriesComplete, c.Command)
+}
+
+// UpgradeSeriesAPI defines the API methods used by the upgrade-series
+// command.
+type UpgradeSeriesAPI interface {
+	PrepareUpgradeSeries(machine, series string, force bool) error
+	CompleteUpgradeSeries(machine string) error
+	Close() error
+}
+
+func (c *upgradeSeriesCommand) getUpgradeSeriesAPI() (UpgradeSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getUpgradeSeriesAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if c.Command == upgradeSeriesPrepare {
+		err = client.PrepareUpgradeSeries(c.MachineId, c.Series, c.Force)
+		if err == nil {
+			ctx.Infof("machine %s prepared for upgrade to %s; upgrade its operating system and reboot it, then run\n"+
+				"    juju upgrade-series %s complete", c.MachineId, c.Series, c.MachineId)
+		}
+	} else {
+		err = client.CompleteUpgradeSeries(c.MachineId)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeUpgradeSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeUpgradeSeriesAPI{}
+}
+
+func (s *UpgradeSeriesSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	upgradeSeries, _ := machine.NewUpgradeSeriesCommandForTest(s.fake)
+	return testing.RunCommand(c, upgradeSeries, args...)
+}
+
+func (s *UpgradeSeriesSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		command     string
+		series      string
+		force       bool
+		errorString string
+	}{{
+		errorString: "no machine specified",
+	}, {
+		args:        []string{"lxd", "complete"},
+		errorString: `invalid machine id "lxd"`,
+	}, {
+		args:        []string{"1"},
+		errorString: `expected "prepare" or "complete"`,
+	}, {
+		args:        []string{"1", "start"},
+		errorString: `expected "prepare" or "complete", got "start"`,
+	}, {
+		args:        []string{"1", "prepare"},
+		errorString: "no series specified",
+	}, {
+		args:        []string{"1", "prepare", "xenial", "extra"},
+		errorString: `unrecognized args: \["extra"\]`,
+	}, {
+		args:        []string{"1", "complete", "--force"},
+		errorString: `--force can only be used with "prepare"`,
+	}, {
+		args:    []string{"1", "prepare", "xenial", "--force"},
+		command: "prepare",
+		series:  "xenial",
+		force:   true,
+	}, {
+		args:    []string{"1", "complete"},
+		command: "complete",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		wrapped, upgradeSeries := machine.NewUpgradeSeriesCommandForTest(s.fake)
+		err := testing.InitCommand(wrapped, test.args)
+		if test.errorString != "" {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(upgradeSeries.MachineId, gc.Equals, "1")
+		c.Check(upgradeSeries.Command, gc.Equals, test.command)
+		c.Check(upgradeSeries.Series, gc.Equals, test.series)
+		c.Check(upgradeSeries.Force, gc.Equals, test.force)
+	}
+}
+
+func (s *UpgradeSeriesSuite) TestPrepare(c *gc.C) {
+	ctx, err := s.run(c, "3", "prepare", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.prepared, gc.DeepEquals, []string{"3", "xenial"})
+	c.Check(s.fake.completed, gc.Equals, "")
+	c.Check(testing.Stderr(ctx), jc.Contains, "juju upgrade-series 3 complete")
+}
+
+func (s *UpgradeSeriesSuite) TestComplete(c *gc.C) {
+	_, err := s.run(c, "3", "complete")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.prepared, gc.IsNil)
+	c.Check(s.fake.completed, gc.Equals, "3")
+}
+
+func (s *UpgradeSeriesSuite) TestError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := s.run(c, "3", "complete")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeUpgradeSeriesAPI struct {
+	prepared  []string
+	force     bool
+	completed string
+	err       error
+}
+
+func (f *fakeUpgradeSeriesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUpgradeSeriesAPI) PrepareUpgradeSeries(machine, series string, force bool) error {
+	f.prepared = []string{machine, series}
+	f.force = force
+	return f.err
+}
+
+func (f *fakeUpgradeSeriesAPI) CompleteUpgradeSeries(machine string) error {
+	f.completed = machine
+	return f.err
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/juju/utils/keyvalues"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/environs/config"
@@ -30,12 +32,19 @@ Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys.
 
+Changes made to the configuration are recorded, along with who made them and
+when. The --history option displays the recent changes, most recent first, and
+--rollback restores the configuration recorded at the given revision of the
+history. The agent version is never rolled back.
+
 Examples
     juju model-config default-series
     juju model-config -m mycontroller:mymodel
     juju model-config ftp-proxy=10.0.0.1:8000
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --history
+    juju model-config --rollback 3
 
 See also:
     models
@@ -57,10 +66,12 @@ type configCommand struct {
 	modelcmd.ModelCommandBase
 	out cmd.Output
 
-	action func(*cmd.Context) error // The action which we want to handle, set in cmd.Init.
-	keys   []string
-	reset  bool // Flag denoting whether we are resetting the keys provided.
-	values attributes
+	action   func(*cmd.Context) error // The action which we want to handle, set in cmd.Init.
+	keys     []string
+	reset    bool // Flag denoting whether we are resetting the keys provided.
+	history  bool // Flag denoting whether we are displaying the config history.
+	rollback int  // The config history revision to restore, if non-zero.
+	values   attributes
 }
 
 // Info implements part of the cmd.Command interface.
@@ -83,10 +94,30 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.BoolVar(&c.reset, "reset", false, "Reset the provided keys to be empty")
+	f.BoolVar(&c.history, "history", false, "Display the recorded configuration changes")
+	f.IntVar(&c.rollback, "rollback", 0, "Restore the configuration recorded at the given revision")
 }
 
 // Init implements part of the cmd.Command interface.
 func (c *configCommand) Init(args []string) error {
+	if c.history || c.rollback != 0 {
+		if c.history && c.rollback != 0 {
+			return errors.New("cannot specify --history and --rollback simultaneously")
+		}
+		if c.reset || len(args) > 0 {
+			return errors.New("cannot specify --history or --rollback with other configuration options")
+		}
+		if c.rollback < 0 {
+			return errors.NotValidf("revision %d", c.rollback)
+		}
+		if c.history {
+			c.action = c.getHistory
+		} else {
+			c.action = c.rollbackConfig
+		}
+		return nil
+	}
+
 	if c.reset {
 		// We're doing resetConfig.
 		if len(args) == 0 {
@@ -140,6 +171,8 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ModelConfigHistory() ([]params.ConfigRevision, error)
+	ModelRollback(revision int) error
 }
 
 // isModelAttribute returns if the supplied attribute is a valid model
@@ -254,6 +287,25 @@ func (c *configCommand) getConfig(ctx *cmd.Context) error {
 	return c.out.Write(ctx, attrs)
 }
 
+// getHistory writes the recorded configuration changes to the cmd.Context.
+func (c *configCommand) getHistory(ctx *cmd.Context) error {
+	history, err := c.api.ModelConfigHistory()
+	if err != nil {
+		return err
+	}
+	formatted := common.FormatConfigHistory(history)
+	if c.out.Name() == "tabular" {
+		return common.FormatConfigHistoryTabular(ctx.Stdout, formatted)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// rollbackConfig restores the configuration recorded at a previous revision.
+func (c *configCommand) rollbackConfig(ctx *cmd.Context) error {
+	// ctx unused in this method.
+	return block.ProcessBlockedError(c.api.ModelRollback(c.rollback), block.BlockChange)
+}
+
 // formatConfigTabular writes a tabular summary of config information.
 func formatConfigTabular(writer io.Writer, value interface{}) error {
 	configValues, ok := value.(config.ConfigValues)
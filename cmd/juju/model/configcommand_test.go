@@ -3,11 +3,14 @@
 package model_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -63,6 +66,27 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			// 7
 			args:       []string{"one", "two"},
 			errorMatch: "can only retrieve a single value, or all values",
+		}, {
+			// Test history and rollback
+			// 8
+			args:   []string{"--history"},
+			nilErr: true,
+		}, {
+			// 9
+			args:   []string{"--rollback", "2"},
+			nilErr: true,
+		}, {
+			// 10
+			args:       []string{"--history", "--rollback", "2"},
+			errorMatch: "cannot specify --history and --rollback simultaneously",
+		}, {
+			// 11
+			args:       []string{"--history", "one"},
+			errorMatch: "cannot specify --history or --rollback with other configuration options",
+		}, {
+			// 12
+			args:       []string{"--rollback", "-2"},
+			errorMatch: "revision -2 not valid",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -191,3 +215,35 @@ func (s *ConfigCommandSuite) TestResetBlockedError(c *gc.C) {
 	// msg is logged
 	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
 }
+
+func (s *ConfigCommandSuite) TestHistoryTabular(c *gc.C) {
+	s.fake.history = []params.ConfigRevision{{
+		Revision: 1,
+		User:     "bob",
+		Time:     time.Date(2017, 3, 1, 11, 0, 0, 0, time.UTC),
+		Changes: []params.ConfigChange{
+			{Key: "special", Type: params.ConfigModified, OldValue: "value", NewValue: "special value"},
+		},
+	}}
+	context, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	output := testing.Stdout(context)
+	expected := "" +
+		"REVISION  TIME                  USER  KEY      CHANGE    OLD    NEW\n" +
+		"1         2017-03-01 11:00:00Z  bob   special  modified  value  special value\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestRollback(c *gc.C) {
+	_, err := s.run(c, "--rollback", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.rollback, gc.Equals, 2)
+}
+
+func (s *ConfigCommandSuite) TestRollbackBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--rollback", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
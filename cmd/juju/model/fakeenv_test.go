@@ -6,6 +6,7 @@ package model_test
 import (
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
@@ -39,6 +40,8 @@ type fakeEnvAPI struct {
 	defaults      config.ConfigValues
 	err           error
 	keys          []string
+	history       []params.ConfigRevision
+	rollback      int
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -67,6 +70,15 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ModelConfigHistory() ([]params.ConfigRevision, error) {
+	return f.history, f.err
+}
+
+func (f *fakeEnvAPI) ModelRollback(revision int) error {
+	f.rollback = revision
+	return f.err
+}
+
 // ModelDefaults related fake environment for testing.
 
 type fakeModelDefaultEnvSuite struct {
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the commands used to list and inspect the
+// metadata of the secrets owned by applications. The content of
+// secrets is only ever available to the units of the applications
+// granted access to them.
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// secretsAPI defines the API methods used by the secrets commands.
+type secretsAPI interface {
+	Close() error
+	ListSecrets(owner string) ([]params.SecretMetadata, error)
+	ShowSecret(id string) (*params.SecretMetadata, error)
+}
+
+// secretsCommandBase is the base type for the secrets commands.
+type secretsCommandBase struct {
+	modelcmd.ModelCommandBase
+	api secretsAPI
+}
+
+func (c *secretsCommandBase) getAPI() (secretsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewClient(root), nil
+}
+
+// SecretInfo holds the metadata of a secret for output.
+type SecretInfo struct {
+	ID             string      `yaml:"id" json:"id"`
+	Owner          string      `yaml:"owner" json:"owner"`
+	Label          string      `yaml:"label,omitempty" json:"label,omitempty"`
+	Description    string      `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision       int         `yaml:"revision" json:"revision"`
+	RotateInterval string      `yaml:"rotate-interval,omitempty" json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time  `yaml:"next-rotate-time,omitempty" json:"next-rotate-time,omitempty"`
+	Created        time.Time   `yaml:"created" json:"created"`
+	Updated        time.Time   `yaml:"updated" json:"updated"`
+	Grants         []GrantInfo `yaml:"grants,omitempty" json:"grants,omitempty"`
+}
+
+// GrantInfo holds the details of an access grant to a secret for
+// output.
+type GrantInfo struct {
+	Application string `yaml:"application" json:"application"`
+	Relation    string `yaml:"relation" json:"relation"`
+}
+
+func formatSecretInfo(metadata params.SecretMetadata) SecretInfo {
+	info := SecretInfo{
+		ID:             metadata.ID,
+		Owner:          metadata.Owner,
+		Label:          metadata.Label,
+		Description:    metadata.Description,
+		Revision:       metadata.Revision,
+		NextRotateTime: metadata.NextRotateTime,
+		Created:        metadata.CreateTime,
+		Updated:        metadata.UpdateTime,
+	}
+	if metadata.RotateInterval > 0 {
+		info.RotateInterval = metadata.RotateInterval.String()
+	}
+	for _, grant := range metadata.Grants {
+		info.Grants = append(info.Grants, GrantInfo{
+			Application: grant.Application,
+			Relation:    grant.Relation,
+		})
+	}
+	return info
+}
@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewListSecretsCommandForTest returns a listSecretsCommand with the
+// api and store provided as specified.
+func NewListSecretsCommandForTest(api secretsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listSecretsCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowSecretCommandForTest returns a showSecretCommand with the api
+// and store provided as specified.
+func NewShowSecretCommandForTest(api secretsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showSecretCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listSecretsDoc = `
+Lists the secrets owned by the applications in the model. Only the
+metadata of the secrets is shown; their content is only available to
+the units of the applications granted access to them.
+
+Examples:
+    juju secrets
+    juju secrets --owner mysql --format yaml
+
+See also:
+    show-secret
+`
+
+// NewListSecretsCommand returns a command which lists the secrets in
+// the model.
+func NewListSecretsCommand() cmd.Command {
+	return modelcmd.Wrap(&listSecretsCommand{})
+}
+
+// listSecretsCommand lists the secrets in the model.
+type listSecretsCommand struct {
+	secretsCommandBase
+	out   cmd.Output
+	owner string
+}
+
+// Info implements Command.Info.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets in the model.",
+		Doc:     listSecretsDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "Only list the secrets owned by this application")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	secrets, err := client.ListSecrets(c.owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output := make([]SecretInfo, len(secrets))
+	for i, metadata := range secrets {
+		output[i] = formatSecretInfo(metadata)
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatSecretsTabular writes a tabular summary of secrets.
+func formatSecretsTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]SecretInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	if len(secrets) == 0 {
+		fmt.Fprintln(writer, "No secrets in the model.")
+		return nil
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("ID", "OWNER", "REVISION", "ROTATE", "LABEL")
+	for _, info := range secrets {
+		rotate := info.RotateInterval
+		if rotate == "" {
+			rotate = "-"
+		}
+		w.Println(info.ID, info.Owner, info.Revision, rotate, info.Label)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"errors"
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ListSecretsSuite struct {
+	secretsSuite
+}
+
+var _ = gc.Suite(&ListSecretsSuite{})
+
+func (s *ListSecretsSuite) SetUpTest(c *gc.C) {
+	s.secretsSuite.SetUpTest(c)
+	created := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	s.fake.secrets = []params.SecretMetadata{{
+		ID:             "secret:1",
+		Owner:          "mysql",
+		Label:          "db",
+		Revision:       2,
+		RotateInterval: time.Hour,
+		CreateTime:     created,
+		UpdateTime:     created,
+	}, {
+		ID:         "secret:2",
+		Owner:      "wordpress",
+		Label:      "cache",
+		Revision:   1,
+		CreateTime: created,
+		UpdateTime: created,
+	}}
+}
+
+func (s *ListSecretsSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, secrets.NewListSecretsCommandForTest(s.fake, s.store), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ListSecretsSuite) TestListSecretsTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, secrets.NewListSecretsCommandForTest(s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ListSecrets", []interface{}{""}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+ID        OWNER      REVISION  ROTATE  LABEL
+secret:1  mysql      2         1h0m0s  db
+secret:2  wordpress  1         -       cache
+`[1:])
+}
+
+func (s *ListSecretsSuite) TestListSecretsOwnerYAML(c *gc.C) {
+	s.fake.secrets = s.fake.secrets[:1]
+	ctx, err := testing.RunCommand(c, secrets.NewListSecretsCommandForTest(s.fake, s.store), "--owner", "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "ListSecrets", "mysql")
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- id: secret:1
+  owner: mysql
+  label: db
+  revision: 2
+  rotate-interval: 1h0m0s
+  created: 2017-03-01T10:00:00Z
+  updated: 2017-03-01T10:00:00Z
+`[1:])
+}
+
+func (s *ListSecretsSuite) TestListSecretsNone(c *gc.C) {
+	s.fake.secrets = nil
+	ctx, err := testing.RunCommand(c, secrets.NewListSecretsCommandForTest(s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No secrets in the model.\n")
+}
+
+func (s *ListSecretsSuite) TestListSecretsError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, secrets.NewListSecretsCommandForTest(s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
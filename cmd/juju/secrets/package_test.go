@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	stdtesting "testing"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+func TestAll(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type secretsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeSecretsAPI
+	store *jujuclienttesting.MemStore
+}
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSecretsAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin@local/mymodel"
+}
+
+type fakeSecretsAPI struct {
+	gitjujutesting.Stub
+	secrets []params.SecretMetadata
+}
+
+func (f *fakeSecretsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSecretsAPI) ListSecrets(owner string) ([]params.SecretMetadata, error) {
+	f.MethodCall(f, "ListSecrets", owner)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.secrets, nil
+}
+
+func (f *fakeSecretsAPI) ShowSecret(id string) (*params.SecretMetadata, error) {
+	f.MethodCall(f, "ShowSecret", id)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return &f.secrets[0], nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showSecretDoc = `
+Shows the metadata of a secret, including the applications granted
+access to it and the relations the grants are scoped to. The content of
+the secret is not shown.
+
+The default output format is yaml; json is also available.
+
+Examples:
+    juju show-secret secret:1
+
+See also:
+    secrets
+`
+
+// NewShowSecretCommand returns a command which shows the metadata of
+// a secret.
+func NewShowSecretCommand() cmd.Command {
+	return modelcmd.Wrap(&showSecretCommand{})
+}
+
+// showSecretCommand shows the metadata of a secret.
+type showSecretCommand struct {
+	secretsCommandBase
+	out cmd.Output
+	id  string
+}
+
+// Info implements Command.Info.
+func (c *showSecretCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-secret",
+		Args:    "<secret id>",
+		Purpose: "Shows the metadata of a secret.",
+		Doc:     showSecretDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showSecretCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *showSecretCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *showSecretCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	metadata, err := client.ShowSecret(c.id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatSecretInfo(*metadata))
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ShowSecretSuite struct {
+	secretsSuite
+}
+
+var _ = gc.Suite(&ShowSecretSuite{})
+
+func (s *ShowSecretSuite) TestInitErrors(c *gc.C) {
+	_, err := testing.RunCommand(c, secrets.NewShowSecretCommandForTest(s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no secret id specified")
+	_, err = testing.RunCommand(c, secrets.NewShowSecretCommandForTest(s.fake, s.store), "secret:1", "secret:2")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["secret:2"\]`)
+}
+
+func (s *ShowSecretSuite) TestShowSecret(c *gc.C) {
+	created := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	s.fake.secrets = []params.SecretMetadata{{
+		ID:          "secret:1",
+		Owner:       "mysql",
+		Description: "database password",
+		Revision:    1,
+		CreateTime:  created,
+		UpdateTime:  created,
+		Grants: []params.SecretGrant{{
+			Application: "wordpress",
+			Relation:    "wordpress:db mysql:server",
+		}},
+	}}
+	ctx, err := testing.RunCommand(c, secrets.NewShowSecretCommandForTest(s.fake, s.store), "secret:1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ShowSecret", []interface{}{"secret:1"}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+id: secret:1
+owner: mysql
+description: database password
+revision: 1
+created: 2017-03-01T10:00:00Z
+updated: 2017-03-01T10:00:00Z
+grants:
+- application: wordpress
+  relation: wordpress:db mysql:server
+`[1:])
+}
@@ -14,9 +14,28 @@ import (
 )
 
 type formattedStatus struct {
-	Model        modelStatus                  `json:"model"`
-	Machines     map[string]machineStatus     `json:"machines"`
-	Applications map[string]applicationStatus `json:"applications"`
+	Model              modelStatus                        `json:"model"`
+	Machines           map[string]machineStatus           `json:"machines"`
+	Applications       map[string]applicationStatus       `json:"applications"`
+	RemoteApplications map[string]remoteApplicationStatus `json:"remote-applications,omitempty" yaml:"remote-applications,omitempty"`
+	Relations          map[string]relationStatus          `json:"relations,omitempty" yaml:"relations,omitempty"`
+}
+
+type relationStatus struct {
+	Id              int                           `json:"id" yaml:"id"`
+	Interface       string                        `json:"interface" yaml:"interface"`
+	Scope           string                        `json:"scope" yaml:"scope"`
+	Life            string                        `json:"life" yaml:"life"`
+	Suspended       bool                          `json:"suspended,omitempty" yaml:"suspended,omitempty"`
+	SuspendedReason string                        `json:"suspended-reason,omitempty" yaml:"suspended-reason,omitempty"`
+	Endpoints       []string                      `json:"endpoints" yaml:"endpoints"`
+	Units           map[string]relationUnitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+}
+
+type relationUnitStatus struct {
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	Status   string `json:"status" yaml:"status"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
 type formattedMachineStatus struct {
@@ -70,21 +89,22 @@ func (s machineStatus) MarshalYAML() (interface{}, error) {
 }
 
 type applicationStatus struct {
-	Err           error                 `json:"-" yaml:",omitempty"`
-	Charm         string                `json:"charm" yaml:"charm"`
-	Series        string                `json:"series"`
-	OS            string                `json:"os"`
-	CharmOrigin   string                `json:"charm-origin" yaml:"charm-origin"`
-	CharmName     string                `json:"charm-name" yaml:"charm-name"`
-	CharmRev      int                   `json:"charm-rev" yaml:"charm-rev"`
-	CanUpgradeTo  string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
-	Exposed       bool                  `json:"exposed" yaml:"exposed"`
-	Life          string                `json:"life,omitempty" yaml:"life,omitempty"`
-	StatusInfo    statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
-	Relations     map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
-	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
-	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
-	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
+	Err                error                 `json:"-" yaml:",omitempty"`
+	Charm              string                `json:"charm" yaml:"charm"`
+	Series             string                `json:"series"`
+	OS                 string                `json:"os"`
+	CharmOrigin        string                `json:"charm-origin" yaml:"charm-origin"`
+	CharmName          string                `json:"charm-name" yaml:"charm-name"`
+	CharmRev           int                   `json:"charm-rev" yaml:"charm-rev"`
+	CanUpgradeTo       string                `json:"can-upgrade-to,omitempty" yaml:"can-upgrade-to,omitempty"`
+	Exposed            bool                  `json:"exposed" yaml:"exposed"`
+	Life               string                `json:"life,omitempty" yaml:"life,omitempty"`
+	StatusInfo         statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
+	Relations          map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
+	SuspendedRelations map[string][]string   `json:"suspended-relations,omitempty" yaml:"suspended-relations,omitempty"`
+	SubordinateTo      []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
+	Units              map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
+	Version            string                `json:"version,omitempty" yaml:"version,omitempty"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -103,6 +123,32 @@ func (s applicationStatus) MarshalYAML() (interface{}, error) {
 	return applicationStatusNoMarshal(s), nil
 }
 
+type remoteApplicationStatus struct {
+	Err        error               `json:"-" yaml:",omitempty"`
+	OfferURL   string              `json:"url" yaml:"url"`
+	OfferName  string              `json:"offer" yaml:"offer"`
+	Endpoints  map[string]string   `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	Life       string              `json:"life,omitempty" yaml:"life,omitempty"`
+	StatusInfo statusInfoContents  `json:"application-status,omitempty" yaml:"application-status"`
+	Relations  map[string][]string `json:"relations,omitempty" yaml:"relations,omitempty"`
+}
+
+type remoteApplicationStatusNoMarshal remoteApplicationStatus
+
+func (s remoteApplicationStatus) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatus{s.Err.Error()})
+	}
+	return json.Marshal(remoteApplicationStatusNoMarshal(s))
+}
+
+func (s remoteApplicationStatus) MarshalYAML() (interface{}, error) {
+	if s.Err != nil {
+		return errorStatus{s.Err.Error()}, nil
+	}
+	return remoteApplicationStatusNoMarshal(s), nil
+}
+
 type meterStatus struct {
 	Color   string `json:"color,omitempty" yaml:"color,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
@@ -64,6 +64,50 @@ func (sf *statusFormatter) format() formattedStatus {
 	for sn, s := range sf.status.Applications {
 		out.Applications[sn] = sf.formatApplication(sn, s)
 	}
+	if len(sf.status.RemoteApplications) > 0 {
+		out.RemoteApplications = make(map[string]remoteApplicationStatus)
+		for name, app := range sf.status.RemoteApplications {
+			out.RemoteApplications[name] = sf.formatRemoteApplication(app)
+		}
+	}
+	return out
+}
+
+// formatWithRelations formats the status as format does, and also
+// includes a relation-centric view of the relations and the status
+// of their units.
+func (sf *statusFormatter) formatWithRelations() formattedStatus {
+	out := sf.format()
+	if sf.status == nil {
+		return out
+	}
+	out.Relations = make(map[string]relationStatus)
+	for _, r := range sf.status.Relations {
+		out.Relations[r.Key] = sf.formatRelation(r)
+	}
+	return out
+}
+
+func (sf *statusFormatter) formatRelation(relation params.RelationStatus) relationStatus {
+	out := relationStatus{
+		Id:              relation.Id,
+		Interface:       relation.Interface,
+		Scope:           relation.Scope,
+		Life:            relation.Life,
+		Suspended:       relation.Suspended,
+		SuspendedReason: relation.SuspendedReason,
+		Units:           make(map[string]relationUnitStatus),
+	}
+	for _, ep := range relation.Endpoints {
+		out.Endpoints = append(out.Endpoints, ep.String())
+	}
+	for _, u := range relation.Units {
+		out.Units[u.UnitName] = relationUnitStatus{
+			Endpoint: u.Endpoint,
+			Status:   u.Status,
+			Message:  u.Info,
+		}
+	}
 	return out
 }
 
@@ -142,21 +186,22 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	}
 
 	out := applicationStatus{
-		Err:           application.Err,
-		Charm:         application.Charm,
-		Series:        application.Series,
-		OS:            strings.ToLower(appOS.String()),
-		CharmOrigin:   charmOrigin,
-		CharmName:     charmName,
-		CharmRev:      charmRev,
-		Exposed:       application.Exposed,
-		Life:          application.Life,
-		Relations:     application.Relations,
-		CanUpgradeTo:  application.CanUpgradeTo,
-		SubordinateTo: application.SubordinateTo,
-		Units:         make(map[string]unitStatus),
-		StatusInfo:    sf.getServiceStatusInfo(application),
-		Version:       application.WorkloadVersion,
+		Err:                application.Err,
+		Charm:              application.Charm,
+		Series:             application.Series,
+		OS:                 strings.ToLower(appOS.String()),
+		CharmOrigin:        charmOrigin,
+		CharmName:          charmName,
+		CharmRev:           charmRev,
+		Exposed:            application.Exposed,
+		Life:               application.Life,
+		Relations:          application.Relations,
+		SuspendedRelations: sf.suspendedRelations(name),
+		CanUpgradeTo:       application.CanUpgradeTo,
+		SubordinateTo:      application.SubordinateTo,
+		Units:              make(map[string]unitStatus),
+		StatusInfo:         sf.getServiceStatusInfo(application),
+		Version:            application.WorkloadVersion,
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
@@ -169,6 +214,48 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+// suspendedRelations returns the related applications of the named
+// application's suspended relations, keyed by endpoint name.
+func (sf *statusFormatter) suspendedRelations(applicationName string) map[string][]string {
+	var out map[string][]string
+	for _, relation := range sf.status.Relations {
+		if !relation.Suspended {
+			continue
+		}
+		ep, ok := findEndpoint(relation.Endpoints, applicationName)
+		if !ok {
+			continue
+		}
+		other, ok := findOtherEndpoint(relation.Endpoints, applicationName)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string][]string)
+		}
+		out[ep.Name] = append(out[ep.Name], other.ApplicationName)
+	}
+	return out
+}
+
+func (sf *statusFormatter) formatRemoteApplication(app params.RemoteApplicationStatus) remoteApplicationStatus {
+	out := remoteApplicationStatus{
+		Err:        app.Err,
+		OfferURL:   app.OfferURL,
+		OfferName:  app.OfferName,
+		Life:       app.Life,
+		StatusInfo: sf.getStatusInfoContents(app.Status),
+		Relations:  app.Relations,
+	}
+	if len(app.Endpoints) > 0 {
+		out.Endpoints = make(map[string]string)
+		for _, ep := range app.Endpoints {
+			out.Endpoints[ep.Name] = ep.Interface
+		}
+	}
+	return out
+}
+
 func (sf *statusFormatter) getServiceStatusInfo(service params.ApplicationStatus) statusInfoContents {
 	// TODO(perrito66) add status validation.
 	info := statusInfoContents{
@@ -315,3 +402,12 @@ func findOtherEndpoint(endpoints []params.EndpointStatus, applicationName string
 	}
 	return params.EndpointStatus{}, false
 }
+
+func findEndpoint(endpoints []params.EndpointStatus, applicationName string) (params.EndpointStatus, bool) {
+	for _, endpoint := range endpoints {
+		if endpoint.ApplicationName == applicationName {
+			return endpoint, true
+		}
+	}
+	return params.EndpointStatus{}, false
+}
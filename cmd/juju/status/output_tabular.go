@@ -26,6 +26,7 @@ type statusRelation struct {
 	application2 string
 	relation     string
 	subordinate  bool
+	suspended    bool
 }
 
 func (s *statusRelation) relationType() string {
@@ -53,7 +54,7 @@ func (r *relationFormatter) len() int {
 	return r.relationIndex.Size()
 }
 
-func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool) {
+func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1, suspended bool) {
 	rel := []string{rel1, rel2}
 	if !is2SubOf1 {
 		sort.Sort(sort.StringSlice(rel))
@@ -64,6 +65,7 @@ func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool) {
 		application2: rel[1],
 		relation:     relation,
 		subordinate:  is2SubOf1,
+		suspended:    suspended,
 	}
 	r.relationIndex.Add(k)
 }
@@ -117,6 +119,16 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 	p(header...)
 	p(values...)
 
+	if len(fs.RemoteApplications) > 0 {
+		outputHeaders("SAAS", "STATUS", "URL")
+		for _, appName := range utils.SortStringsNaturally(stringKeysFromMap(fs.RemoteApplications)) {
+			app := fs.RemoteApplications[appName]
+			w.Print(appName)
+			w.PrintStatus(app.StatusInfo.Current)
+			p(app.OfferURL)
+		}
+	}
+
 	units := make(map[string]unitStatus)
 	metering := false
 	relations := newRelationFormatter()
@@ -164,8 +176,9 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 
 		subs := set.NewStrings(app.SubordinateTo...)
 		for _, relType := range sortedRelTypes {
+			suspended := set.NewStrings(app.SuspendedRelations[relType]...)
 			for _, related := range app.Relations[relType] {
-				relations.add(related, appName, relType, subs.Contains(related))
+				relations.add(related, appName, relType, subs.Contains(related), suspended.Contains(related))
 			}
 		}
 
@@ -175,7 +188,11 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		for _, k := range relations.sorted() {
 			r := relations.get(k)
 			if r != nil {
-				p(r.relation, r.application1, r.application2, r.relationType())
+				relType := r.relationType()
+				if r.suspended {
+					relType += " (suspended)"
+				}
+				p(r.relation, r.application1, r.application2, relType)
 			}
 		}
 	}
@@ -218,9 +235,53 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 	p()
 	printMachines(tw, fs.Machines)
 	tw.Flush()
+
+	if len(fs.Relations) > 0 {
+		// The relation units are printed last, after the column
+		// alignment used above has been reset by flushing.
+		printRelationUnits(w, fs.Relations)
+		tw.Flush()
+	}
 	return nil
 }
 
+// printRelationUnits prints the status of each unit in each relation,
+// ordered by relation id.
+func printRelationUnits(w output.Wrapper, relations map[string]relationStatus) {
+	byId := make(map[int]string)
+	var ids []int
+	for key, r := range relations {
+		byId[r.Id] = key
+		ids = append(ids, r.Id)
+	}
+	sort.Ints(ids)
+
+	w.Println()
+	w.Println("ID", "RELATION", "LIFE", "UNIT", "ENDPOINT", "STATUS", "MESSAGE")
+	for _, id := range ids {
+		key := byId[id]
+		r := relations[key]
+		if len(r.Units) == 0 {
+			w.Println(id, key, r.Life, "", "", "", "")
+			continue
+		}
+		for _, unitName := range utils.SortStringsNaturally(relationUnitNames(r.Units)) {
+			u := r.Units[unitName]
+			w.Print(id, key, r.Life, unitName, u.Endpoint)
+			w.PrintStatus(status.Status(u.Status))
+			w.Println(u.Message)
+		}
+	}
+}
+
+func relationUnitNames(units map[string]relationUnitStatus) []string {
+	result := make([]string, 0, len(units))
+	for name := range units {
+		result = append(result, name)
+	}
+	return result
+}
+
 func getModelMessage(model modelStatus) string {
 	// Select the most important message about the model (if any).
 	switch {
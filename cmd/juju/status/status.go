@@ -23,6 +23,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	StatusWithRelationUnits(patterns []string) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -39,7 +40,8 @@ type statusCommand struct {
 	isoTime  bool
 	api      statusAPI
 
-	color bool
+	color     bool
+	relations bool
 }
 
 var usageSummary = `
@@ -55,6 +57,25 @@ machines, applications, and units will also be displayed. If a subordinate unit
 is matched, then its principal unit will be displayed. If a principal unit is
 matched, then all of its subordinates will be displayed.
 
+Filters may also be structured predicates of the form key=value, which are
+evaluated by the controller so that only matching entities are returned.
+The supported keys are:
+
+    status       agent or workload status (e.g. status=error)
+    workload     workload status only (e.g. workload=blocked)
+    series       machine, application or unit series (e.g. series=xenial)
+    charm        charm URL or name (e.g. charm=cs:mysql)
+    machine      machine id, including its containers (e.g. machine=3)
+    application  application name (e.g. application=mysql)
+    unit         unit name, '*' allowed (e.g. unit=mysql/*)
+    space        space an application is bound to, or a machine has an
+                 address in (e.g. space=db)
+    exposed      whether an application is exposed (e.g. exposed=true)
+
+Annotations may be matched with annotation:key=value. Adjacent terms must all
+match; "and" may be used to make this explicit, and "or" separates
+alternatives.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -73,10 +94,19 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+The --relations option adds a relation-centric section to the output, showing
+the life of each relation and, for each unit taking part in it, whether the
+unit has joined, is pending, is running one of the relation's hooks, is
+departing, or has had a relation hook fail.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status status=error
+    juju show-status series=xenial and workload=blocked or machine=3
+    juju show-status annotation:owner=ops exposed=true
+    juju show-status --relations mysql
 
 See also:
     machines
@@ -99,6 +129,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.relations, "relations", false, "Show the status of units in each relation")
 
 	defaultFormat := "tabular"
 
@@ -140,7 +171,12 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	var status *params.FullStatus
+	if c.relations {
+		status, err = apiclient.StatusWithRelationUnits(c.patterns)
+	} else {
+		status, err = apiclient.Status(c.patterns)
+	}
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
@@ -153,7 +189,12 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 
 	formatter := newStatusFormatter(status, c.ControllerName(), c.isoTime)
-	formatted := formatter.format()
+	var formatted formattedStatus
+	if c.relations {
+		formatted = formatter.formatWithRelations()
+	} else {
+		formatted = formatter.format()
+	}
 	return c.out.Write(ctx, formatted)
 }
 
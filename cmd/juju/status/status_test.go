@@ -3271,9 +3271,10 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 }
 
 type fakeApiClient struct {
-	statusReturn *params.FullStatus
-	patternsUsed []string
-	closeCalled  bool
+	statusReturn      *params.FullStatus
+	patternsUsed      []string
+	relationUnitsUsed bool
+	closeCalled       bool
 }
 
 func (a *fakeApiClient) Status(patterns []string) (*params.FullStatus, error) {
@@ -3281,6 +3282,12 @@ func (a *fakeApiClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeApiClient) StatusWithRelationUnits(patterns []string) (*params.FullStatus, error) {
+	a.patternsUsed = patterns
+	a.relationUnitsUsed = true
+	return a.statusReturn, nil
+}
+
 func (a *fakeApiClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -3673,6 +3680,111 @@ MACHINE  STATE  DNS  INS-ID  SERIES  AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularRelationUnits(c *gc.C) {
+	status := formattedStatus{
+		Relations: map[string]relationStatus{
+			"mysql:cluster": {
+				Id:   0,
+				Life: "alive",
+				Units: map[string]relationUnitStatus{
+					"mysql/0": {Endpoint: "cluster", Status: "joined"},
+				},
+			},
+			"wordpress:db mysql:server": {
+				Id:   1,
+				Life: "alive",
+				Units: map[string]relationUnitStatus{
+					"wordpress/0": {Endpoint: "db", Status: "joined"},
+					"mysql/0": {
+						Endpoint: "server",
+						Status:   "error",
+						Message:  `hook failed: "server-relation-changed"`,
+					},
+				},
+			},
+			"wordpress:logging logging:info": {
+				Id:   2,
+				Life: "dying",
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+MODEL  CONTROLLER  CLOUD/REGION  VERSION
+                                 
+
+APP  VERSION  STATUS  SCALE  CHARM  STORE  REV  OS  NOTES
+
+UNIT  WORKLOAD  AGENT  MACHINE  PUBLIC-ADDRESS  PORTS  MESSAGE
+
+MACHINE  STATE  DNS  INS-ID  SERIES  AZ
+
+ID  RELATION                        LIFE   UNIT         ENDPOINT  STATUS  MESSAGE
+0   mysql:cluster                   alive  mysql/0      cluster   joined  
+1   wordpress:db mysql:server       alive  mysql/0      server    error   hook failed: "server-relation-changed"
+1   wordpress:db mysql:server       alive  wordpress/0  db        joined  
+2   wordpress:logging logging:info  dying                                 
+`[1:])
+}
+
+func (s *StatusSuite) TestStatusWithRelationsFlag(c *gc.C) {
+	client := &fakeApiClient{
+		statusReturn: &params.FullStatus{
+			Relations: []params.RelationStatus{{
+				Id:        0,
+				Key:       "mysql:cluster",
+				Interface: "mysql-ha",
+				Scope:     "global",
+				Life:      "alive",
+				Endpoints: []params.EndpointStatus{{
+					ApplicationName: "mysql",
+					Name:            "cluster",
+					Role:            "peer",
+				}},
+				Units: []params.RelationUnitStatus{{
+					UnitName: "mysql/0",
+					Endpoint: "cluster",
+					Status:   params.RelationUnitPending,
+				}},
+			}},
+		},
+	}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "yaml", "--relations", "mysql")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(client.relationUnitsUsed, jc.IsTrue)
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"mysql"})
+
+	expected := M{
+		"mysql:cluster": M{
+			"id":        0,
+			"interface": "mysql-ha",
+			"scope":     "global",
+			"life":      "alive",
+			"endpoints": L{"mysql:cluster"},
+			"units": M{
+				"mysql/0": M{
+					"endpoint": "cluster",
+					"status":   "pending",
+				},
+			},
+		},
+	}
+	buf, err := goyaml.Marshal(M{"relations": expected})
+	c.Assert(err, jc.ErrorIsNil)
+	var expectedOut M
+	c.Assert(goyaml.Unmarshal(buf, &expectedOut), jc.ErrorIsNil)
+
+	var out M
+	c.Assert(goyaml.Unmarshal(stdout, &out), jc.ErrorIsNil)
+	c.Check(out["relations"], jc.DeepEquals, expectedOut["relations"])
+}
+
 //
 // Filtering Feature
 //
@@ -4190,6 +4302,112 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatRemoteApplications(c *gc.C) {
+	status := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"wordpress": {
+				Charm:     "cs:quantal/wordpress-3",
+				Series:    "quantal",
+				Relations: map[string][]string{"db": {"mysql"}},
+			},
+		},
+		RemoteApplications: map[string]params.RemoteApplicationStatus{
+			"mysql": {
+				OfferURL:  "admin/prod.hosted-mysql",
+				OfferName: "hosted-mysql",
+				Endpoints: []params.RemoteEndpoint{{
+					Name:      "db",
+					Role:      "provider",
+					Interface: "mysql",
+					Scope:     "global",
+				}},
+				Relations: map[string][]string{"db": {"wordpress"}},
+				Status:    params.DetailedStatus{Status: "active"},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted := formatter.format()
+	c.Check(formatted.RemoteApplications, jc.DeepEquals, map[string]remoteApplicationStatus{
+		"mysql": {
+			OfferURL:   "admin/prod.hosted-mysql",
+			OfferName:  "hosted-mysql",
+			Endpoints:  map[string]string{"db": "mysql"},
+			StatusInfo: statusInfoContents{Current: "active"},
+			Relations:  map[string][]string{"db": {"wordpress"}},
+		},
+	})
+
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	sections, err := splitTableSections(out.Bytes())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sections["SAAS"], gc.DeepEquals, []string{
+		"SAAS   STATUS  URL",
+		"mysql  active  admin/prod.hosted-mysql",
+	})
+	c.Assert(sections["RELATION"], gc.DeepEquals, []string{
+		"RELATION  PROVIDES  CONSUMES   TYPE",
+		"db        mysql     wordpress  regular",
+	})
+}
+
+func (s *StatusSuite) TestFormatSuspendedRelations(c *gc.C) {
+	status := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:     "cs:quantal/mysql-1",
+				Series:    "quantal",
+				Relations: map[string][]string{"server": {"wordpress"}},
+			},
+			"wordpress": {
+				Charm:     "cs:quantal/wordpress-3",
+				Series:    "quantal",
+				Relations: map[string][]string{"db": {"mysql"}},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Id:        1,
+			Key:       "wordpress:db mysql:server",
+			Interface: "mysql",
+			Scope:     "global",
+			Endpoints: []params.EndpointStatus{{
+				ApplicationName: "wordpress",
+				Name:            "db",
+				Role:            "requirer",
+			}, {
+				ApplicationName: "mysql",
+				Name:            "server",
+				Role:            "provider",
+			}},
+			Suspended:       true,
+			SuspendedReason: "misbehaving",
+		}},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted := formatter.formatWithRelations()
+	c.Check(formatted.Applications["wordpress"].SuspendedRelations, jc.DeepEquals, map[string][]string{
+		"db": {"mysql"},
+	})
+	c.Check(formatted.Applications["mysql"].SuspendedRelations, jc.DeepEquals, map[string][]string{
+		"server": {"wordpress"},
+	})
+	rel := formatted.Relations["wordpress:db mysql:server"]
+	c.Check(rel.Suspended, jc.IsTrue)
+	c.Check(rel.SuspendedReason, gc.Equals, "misbehaving")
+
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	sections, err := splitTableSections(out.Bytes())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sections["RELATION"], gc.DeepEquals, []string{
+		"RELATION  PROVIDES  CONSUMES   TYPE",
+		"db        mysql     wordpress  regular (suspended)",
+	})
+}
+
 type tableSections map[string][]string
 
 func sectionTitle(lines []string) string {
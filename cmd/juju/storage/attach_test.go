@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type attachStorageSuite struct {
+	SubStorageSuite
+	api *mockStorageAttachAPI
+}
+
+var _ = gc.Suite(&attachStorageSuite{})
+
+func (s *attachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockStorageAttachAPI{}
+}
+
+func (s *attachStorageSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, storage.NewAttachStorageCommandForTest(s.api, s.store), args...)
+	return err
+}
+
+func (s *attachStorageSuite) TestInitErrors(c *gc.C) {
+	c.Assert(s.run(c), gc.ErrorMatches, "attach-storage requires a unit and a storage ID")
+	c.Assert(s.run(c, "mysql/0"), gc.ErrorMatches, "attach-storage requires a unit and a storage ID")
+	c.Assert(s.run(c, "mysql", "data/0"), gc.ErrorMatches, `unit name "mysql" not valid`)
+	c.Assert(s.run(c, "mysql/0", "data"), gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *attachStorageSuite) TestAttach(c *gc.C) {
+	err := s.run(c, "mysql/1", "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.unitId, gc.Equals, "mysql/1")
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0", "data/1"})
+}
+
+func (s *attachStorageSuite) TestAttachError(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{Error: &params.Error{Message: "storage is not detached"}},
+	}
+	err := s.run(c, "mysql/1", "data/0")
+	c.Assert(err, gc.ErrorMatches, "storage is not detached")
+}
+
+type mockStorageAttachAPI struct {
+	unitId     string
+	storageIds []string
+	results    []params.ErrorResult
+}
+
+func (m *mockStorageAttachAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageAttachAPI) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	m.unitId = unitId
+	m.storageIds = storageIds
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type detachStorageSuite struct {
+	SubStorageSuite
+	api *mockStorageDetachAPI
+}
+
+var _ = gc.Suite(&detachStorageSuite{})
+
+func (s *detachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockStorageDetachAPI{}
+}
+
+func (s *detachStorageSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, storage.NewDetachStorageCommandForTest(s.api, s.store), args...)
+	return err
+}
+
+func (s *detachStorageSuite) TestInitErrors(c *gc.C) {
+	c.Assert(s.run(c), gc.ErrorMatches, "detach-storage requires at least one storage ID")
+	c.Assert(s.run(c, "data/0", "mysql/0/1"), gc.ErrorMatches, `storage ID "mysql/0/1" not valid`)
+}
+
+func (s *detachStorageSuite) TestDetach(c *gc.C) {
+	err := s.run(c, "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0", "data/1"})
+}
+
+func (s *detachStorageSuite) TestDetachBlocked(c *gc.C) {
+	s.api.err = common.OperationBlockedError("TestDetachBlocked")
+	err := s.run(c, "data/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+}
+
+func (s *detachStorageSuite) TestDetachError(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{Error: common.ServerError(errors.New(`cannot detach storage data/0 from unit mysql/0: charm requires at least 1 "data" storage instance(s)`))},
+	}
+	err := s.run(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit mysql/0: charm requires at least 1 "data" storage instance\(s\)`)
+}
+
+type mockStorageDetachAPI struct {
+	storageIds []string
+	results    []params.ErrorResult
+	err        error
+}
+
+func (m *mockStorageDetachAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageDetachAPI) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	m.storageIds = storageIds
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+// NewImportFilesystemCommand returns a command used to import a
+// filesystem into the model.
+func NewImportFilesystemCommand() cmd.Command {
+	cmd := &importFilesystemCommand{}
+	cmd.newAPIFunc = func() (StorageImporter, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	importFilesystemCommandDoc = `
+Import an existing filesystem into the model. This will lead to the
+model taking ownership of the storage, so you must take care not to
+import storage that is in use by another Juju model.
+
+To import a filesystem, you must specify three things:
+
+ - the storage pool which manages the storage, and with which the
+   storage will be associated
+ - the storage provider ID for the filesystem, or the volume that
+   backs the filesystem
+ - the storage name to assign to the filesystem, corresponding to
+   the storage name used by a charm
+
+Once a filesystem is imported, Juju will create an associated storage
+instance using the given storage name. The storage is detached, and
+may be attached to a unit when it is added, using the --attach-storage
+option of "juju deploy" or "juju add-unit".
+
+Examples:
+    # Import an existing filesystem backed by an EBS volume,
+    # and assign it the "pgdata" storage name. Juju will
+    # associate a storage instance ID like "pgdata/0" with
+    # the volume and filesystem contained within.
+    juju import-filesystem ebs vol-123456 pgdata
+
+See also:
+    add-unit
+    deploy
+    storage
+`
+	importFilesystemCommandArgs = `
+<storage-provider> <provider-id> <storage-name>
+`
+)
+
+// importFilesystemCommand imports filesystems into the model.
+type importFilesystemCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageImporter, error)
+
+	storagePool       string
+	storageProviderId string
+	storageName       string
+}
+
+// Info implements Command.Info.
+func (c *importFilesystemCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-filesystem",
+		Purpose: "Imports a filesystem into the model.",
+		Doc:     importFilesystemCommandDoc,
+		Args:    importFilesystemCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *importFilesystemCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("import-filesystem requires a storage provider, provider ID, and storage name")
+	}
+	c.storagePool = args[0]
+	c.storageProviderId = args[1]
+	c.storageName = args[2]
+	if !names.IsValidStorageName(c.storageName) {
+		return errors.NotValidf("storage name %q", c.storageName)
+	}
+	return cmd.CheckEmpty(args[3:])
+}
+
+// Run implements Command.Run.
+func (c *importFilesystemCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ctx.Infof(
+		"importing %q from storage pool %q as storage %q",
+		c.storageProviderId, c.storagePool, c.storageName,
+	)
+	storageTag, err := api.Import(
+		storage.StorageKindFilesystem,
+		c.storagePool,
+		c.storageProviderId,
+		c.storageName,
+	)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("imported storage %s", storageTag.Id())
+	return nil
+}
+
+// StorageImporter provides a method for importing storage into the model.
+type StorageImporter interface {
+	Close() error
+	Import(
+		kind storage.StorageKind,
+		storagePool string,
+		storageProviderId string,
+		storageName string,
+	) (names.StorageTag, error)
+}
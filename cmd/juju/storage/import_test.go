@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/storage"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type importFilesystemSuite struct {
+	SubStorageSuite
+	importer mockStorageImporter
+}
+
+var _ = gc.Suite(&importFilesystemSuite{})
+
+func (s *importFilesystemSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.importer = mockStorageImporter{}
+}
+
+func (s *importFilesystemSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewImportFilesystemCommandForTest(&s.importer, s.store), args...)
+}
+
+func (s *importFilesystemSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "import-filesystem requires a storage provider, provider ID, and storage name",
+	}, {
+		args: []string{"foo", "bar"},
+		err:  "import-filesystem requires a storage provider, provider ID, and storage name",
+	}, {
+		args: []string{"foo", "bar", "123"},
+		err:  `storage name "123" not valid`,
+	}, {
+		args: []string{"foo", "bar", "baz", "qux"},
+		err:  `unrecognized args: \["qux"\]`,
+	}} {
+		_, err := s.run(c, t.args...)
+		c.Assert(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *importFilesystemSuite) TestImportSuccess(c *gc.C) {
+	s.importer.result = names.NewStorageTag("pgdata/0")
+	ctx, err := s.run(c, "foo", "bar", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `importing "bar" from storage pool "foo" as storage "pgdata"
+imported storage pgdata/0
+`)
+	c.Assert(s.importer.args, jc.DeepEquals, []interface{}{
+		jujustorage.StorageKindFilesystem, "foo", "bar", "pgdata",
+	})
+}
+
+func (s *importFilesystemSuite) TestImportError(c *gc.C) {
+	s.importer.err = errors.New("nope")
+	_, err := s.run(c, "foo", "bar", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+func (s *importFilesystemSuite) TestImportBlocked(c *gc.C) {
+	s.importer.err = common.OperationBlockedError("TestImportBlocked")
+	_, err := s.run(c, "foo", "bar", "pgdata")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+}
+
+type mockStorageImporter struct {
+	args   []interface{}
+	result names.StorageTag
+	err    error
+}
+
+func (m *mockStorageImporter) Close() error {
+	return nil
+}
+
+func (m *mockStorageImporter) Import(
+	kind jujustorage.StorageKind,
+	storagePool, storageProviderId, storageName string,
+) (names.StorageTag, error) {
+	m.args = []interface{}{kind, storagePool, storageProviderId, storageName}
+	return m.result, m.err
+}
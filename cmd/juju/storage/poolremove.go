@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// PoolRemoveAPI defines the API methods that pool remove command uses.
+type PoolRemoveAPI interface {
+	Close() error
+	RemovePool(pname string) error
+}
+
+const poolRemoveCommandDoc = `
+Remove a storage pool from the model.
+
+A pool cannot be removed while any volumes or filesystems in the model
+have been, or are to be, provisioned from it. Pools that are provided
+by default by a storage provider, such as "loop", cannot be removed.
+
+Examples:
+    juju remove-storage-pool ebs-fast
+
+See also:
+    create-storage-pool
+    update-storage-pool
+    storage-pools
+`
+
+// NewPoolRemoveCommand returns a command that removes a storage pool.
+func NewPoolRemoveCommand() cmd.Command {
+	cmd := &poolRemoveCommand{}
+	cmd.newAPIFunc = func() (PoolRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolRemoveCommand removes a storage pool.
+type poolRemoveCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolRemoveAPI, error)
+	poolName   string
+}
+
+// Init implements Command.Init.
+func (c *poolRemoveCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("pool removal requires a pool name")
+	}
+	c.poolName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *poolRemoveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-pool",
+		Args:    "<name>",
+		Purpose: "Remove a storage pool.",
+		Doc:     poolRemoveCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *poolRemoveCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return api.RemovePool(c.poolName)
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// PoolUpdateAPI defines the API methods that pool update command uses.
+type PoolUpdateAPI interface {
+	Close() error
+	UpdatePool(pname string, pconfig map[string]interface{}) error
+}
+
+const poolUpdateCommandDoc = `
+Update the configuration attributes of an existing storage pool.
+
+Attributes are specified as space-separated key=value pairs, and are
+validated by the pool's storage provider. Attributes not specified
+retain their current values; an attribute given an empty value, e.g.
+"tags=", is removed from the pool's configuration.
+
+The provider type of a pool cannot be changed.
+
+Updating a pool affects only storage that is provisioned from the pool
+after the update; existing volumes and filesystems are not modified.
+
+Examples:
+    juju update-storage-pool ebs-fast volume-type=io1 iops=40
+    juju update-storage-pool ebs-fast iops=
+
+See also:
+    create-storage-pool
+    remove-storage-pool
+    storage-pools
+`
+
+// NewPoolUpdateCommand returns a command that updates a storage pool.
+func NewPoolUpdateCommand() cmd.Command {
+	cmd := &poolUpdateCommand{}
+	cmd.newAPIFunc = func() (PoolUpdateAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolUpdateCommand updates the attributes of a storage pool.
+type poolUpdateCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolUpdateAPI, error)
+	poolName   string
+	attrs      map[string]interface{}
+}
+
+// Init implements Command.Init.
+func (c *poolUpdateCommand) Init(args []string) (err error) {
+	if len(args) < 2 {
+		return errors.New("pool update requires name and attrs for configuration")
+	}
+
+	c.poolName = args[0]
+
+	options, err := keyvalues.Parse(args[1:], true)
+	if err != nil {
+		return err
+	}
+
+	c.attrs = make(map[string]interface{})
+	for key, value := range options {
+		c.attrs[key] = value
+	}
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *poolUpdateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-storage-pool",
+		Args:    "<name> <key>=<value> [<key>=<value>...]",
+		Purpose: "Update the attributes of a storage pool.",
+		Doc:     poolUpdateCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *poolUpdateCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return api.UpdatePool(c.poolName, c.attrs)
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRemoveStorageCommand returns a command used to remove storage
+// from the model.
+func NewRemoveStorageCommand() cmd.Command {
+	cmd := &removeStorageCommand{}
+	cmd.newAPIFunc = func() (StorageRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	removeStorageCommandDoc = `
+Remove storage from the model. Storage that is attached to units is
+first detached, running the charm's storage-detaching hook.
+
+By default, or with --destroy, the volume or filesystem backing the
+storage is destroyed along with it. With --release, the storage is
+removed from the model but its volume or filesystem is retained.
+
+Examples:
+    juju remove-storage pgdata/0
+    juju remove-storage --release pgdata/1
+`
+	removeStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// removeStorageCommand removes storage instances from the model.
+type removeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageRemoveAPI, error)
+	storageIds []string
+	destroy    bool
+	release    bool
+}
+
+// Info implements Command.Info.
+func (c *removeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage",
+		Purpose: "Removes storage from the model.",
+		Doc:     removeStorageCommandDoc,
+		Args:    removeStorageCommandArgs,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.destroy, "destroy", false, "Destroy the volume or filesystem backing the storage (default)")
+	f.BoolVar(&c.release, "release", false, "Retain the volume or filesystem backing the storage")
+}
+
+// Init implements Command.Init.
+func (c *removeStorageCommand) Init(args []string) error {
+	if c.destroy && c.release {
+		return errors.New("--destroy and --release are mutually exclusive")
+	}
+	if len(args) < 1 {
+		return errors.New("remove-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Remove(c.storageIds, c.release)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	return params.ErrorResults{Results: results}.Combine()
+}
+
+// StorageRemoveAPI defines the API methods that the remove-storage
+// command uses.
+type StorageRemoveAPI interface {
+	Close() error
+	Remove(storageIds []string, release bool) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type removeStorageSuite struct {
+	SubStorageSuite
+	api *mockStorageRemoveAPI
+}
+
+var _ = gc.Suite(&removeStorageSuite{})
+
+func (s *removeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockStorageRemoveAPI{}
+}
+
+func (s *removeStorageSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, storage.NewRemoveStorageCommandForTest(s.api, s.store), args...)
+	return err
+}
+
+func (s *removeStorageSuite) TestInitErrors(c *gc.C) {
+	c.Assert(s.run(c), gc.ErrorMatches, "remove-storage requires at least one storage ID")
+	c.Assert(s.run(c, "--destroy", "--release", "data/0"), gc.ErrorMatches, "--destroy and --release are mutually exclusive")
+	c.Assert(s.run(c, "data"), gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *removeStorageSuite) TestRemoveDefaultDestroys(c *gc.C) {
+	err := s.run(c, "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0", "data/1"})
+	c.Assert(s.api.release, jc.IsFalse)
+}
+
+func (s *removeStorageSuite) TestRemoveDestroy(c *gc.C) {
+	err := s.run(c, "--destroy", "data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.release, jc.IsFalse)
+}
+
+func (s *removeStorageSuite) TestRemoveRelease(c *gc.C) {
+	err := s.run(c, "--release", "data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0"})
+	c.Assert(s.api.release, jc.IsTrue)
+}
+
+func (s *removeStorageSuite) TestRemoveError(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{Error: &params.Error{Message: `cannot release storage "data/0": moving machine-scoped volume 0/1 not supported`}},
+	}
+	err := s.run(c, "--release", "data/0")
+	c.Assert(err, gc.ErrorMatches, `cannot release storage "data/0": moving machine-scoped volume 0/1 not supported`)
+}
+
+type mockStorageRemoveAPI struct {
+	storageIds []string
+	release    bool
+	results    []params.ErrorResult
+}
+
+func (m *mockStorageRemoveAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageRemoveAPI) Remove(storageIds []string, release bool) ([]params.ErrorResult, error) {
+	m.storageIds = storageIds
+	m.release = release
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type resizeStorageSuite struct {
+	SubStorageSuite
+	api *mockStorageResizeAPI
+}
+
+var _ = gc.Suite(&resizeStorageSuite{})
+
+func (s *resizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockStorageResizeAPI{}
+}
+
+func (s *resizeStorageSuite) run(c *gc.C, args ...string) error {
+	_, err := testing.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), args...)
+	return err
+}
+
+func (s *resizeStorageSuite) TestInitErrors(c *gc.C) {
+	c.Assert(s.run(c), gc.ErrorMatches, "resize-storage requires a storage ID and a size")
+	c.Assert(s.run(c, "data/0"), gc.ErrorMatches, "resize-storage requires a storage ID and a size")
+	c.Assert(s.run(c, "data", "20G"), gc.ErrorMatches, `storage ID "data" not valid`)
+	c.Assert(s.run(c, "data/0", "big"), gc.ErrorMatches, `cannot parse size "big": .*`)
+	c.Assert(s.run(c, "data/0", "0"), gc.ErrorMatches, "size must be greater than zero")
+	c.Assert(s.run(c, "data/0", "20G", "extra"), gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *resizeStorageSuite) TestResize(c *gc.C) {
+	err := s.run(c, "data/0", "20G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0"})
+	c.Assert(s.api.size, gc.Equals, uint64(20*1024))
+}
+
+func (s *resizeStorageSuite) TestResizeDefaultMiB(c *gc.C) {
+	err := s.run(c, "data/0", "512")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.size, gc.Equals, uint64(512))
+}
+
+func (s *resizeStorageSuite) TestResizeError(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{Error: &params.Error{Message: `cannot resize volume "0": new size 512MiB must be greater than current size 1024MiB`}},
+	}
+	err := s.run(c, "data/0", "512")
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0": new size 512MiB must be greater than current size 1024MiB`)
+}
+
+type mockStorageResizeAPI struct {
+	storageIds []string
+	size       uint64
+	results    []params.ErrorResult
+}
+
+func (m *mockStorageResizeAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageResizeAPI) Resize(storageIds []string, size uint64) ([]params.ErrorResult, error) {
+	m.storageIds = storageIds
+	m.size = size
+	if m.results != nil {
+		return m.results, nil
+	}
+	return make([]params.ErrorResult, len(storageIds)), nil
+}
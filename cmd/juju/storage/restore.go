@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRestoreStorageCommand returns a command used to restore storage
+// from a volume snapshot.
+func NewRestoreStorageCommand() cmd.Command {
+	cmd := &restoreStorageCommand{}
+	cmd.newAPIFunc = func() (StorageRestoreAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	restoreStorageCommandDoc = `
+Create new storage from a volume snapshot. The new storage is detached,
+and is backed by a volume that will be created from the snapshot once
+the storage is attached to a unit with "juju attach-storage".
+
+Snapshots of volumes scoped to a machine, such as loop devices, can
+only be restored to units on that same machine.
+
+Examples:
+    juju restore-storage 3
+
+See also:
+    snapshot-storage
+    storage-snapshots
+    attach-storage
+`
+	restoreStorageCommandArgs = `<snapshot>`
+)
+
+// restoreStorageCommand creates storage from a volume snapshot.
+type restoreStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageRestoreAPI, error)
+	snapshotId string
+}
+
+// Info implements Command.Info.
+func (c *restoreStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-storage",
+		Purpose: "Creates storage from a volume snapshot.",
+		Doc:     restoreStorageCommandDoc,
+		Args:    restoreStorageCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *restoreStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("restore-storage requires a snapshot ID")
+	}
+	c.snapshotId = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *restoreStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RestoreSnapshots([]string{c.snapshotId})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	storageTag, err := names.ParseStorageTag(results[0].Result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof(
+		"restored snapshot %s as storage %s; use \"juju attach-storage\" to attach it to a unit",
+		c.snapshotId, storageTag.Id(),
+	)
+	return nil
+}
+
+// StorageRestoreAPI defines the API methods that the restore-storage
+// command uses.
+type StorageRestoreAPI interface {
+	Close() error
+	RestoreSnapshots(snapshotIds []string) ([]params.StringResult, error)
+}
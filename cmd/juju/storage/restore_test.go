@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type restoreStorageSuite struct {
+	SubStorageSuite
+	api *mockStorageRestoreAPI
+}
+
+var _ = gc.Suite(&restoreStorageSuite{})
+
+func (s *restoreStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockStorageRestoreAPI{}
+}
+
+func (s *restoreStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewRestoreStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *restoreStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "restore-storage requires a snapshot ID")
+	_, err = s.run(c, "0", "1")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["1"\]`)
+}
+
+func (s *restoreStorageSuite) TestRestore(c *gc.C) {
+	s.api.results = []params.StringResult{{Result: "storage-data-1"}}
+	ctx, err := s.run(c, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.snapshotIds, jc.DeepEquals, []string{"0"})
+	c.Assert(testing.Stderr(ctx), gc.Equals,
+		"restored snapshot 0 as storage data/1; use \"juju attach-storage\" to attach it to a unit\n",
+	)
+}
+
+func (s *restoreStorageSuite) TestRestoreError(c *gc.C) {
+	s.api.results = []params.StringResult{
+		{Error: &params.Error{Message: `cannot restore volume snapshot "0": snapshot is not available`}},
+	}
+	_, err := s.run(c, "0")
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0": snapshot is not available`)
+}
+
+type mockStorageRestoreAPI struct {
+	snapshotIds []string
+	results     []params.StringResult
+}
+
+func (m *mockStorageRestoreAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageRestoreAPI) RestoreSnapshots(snapshotIds []string) ([]params.StringResult, error) {
+	m.snapshotIds = snapshotIds
+	return m.results, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSnapshotStorageCommand returns a command used to take snapshots
+// of the volumes backing storage.
+func NewSnapshotStorageCommand() cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Take point-in-time snapshots of the volumes backing the specified
+storage. The snapshots are taken asynchronously; their progress can
+be followed with "juju storage-snapshots".
+
+Only storage backed by a volume can be snapshotted, and not all
+storage providers support snapshots.
+
+Examples:
+    juju snapshot-storage pgdata/0
+    juju snapshot-storage pgdata/0 pgdata/1
+
+See also:
+    storage-snapshots
+    restore-storage
+`
+	snapshotStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// snapshotStorageCommand takes snapshots of the volumes backing
+// storage instances.
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	storageIds []string
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Takes snapshots of the volumes backing storage.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	for _, arg := range args {
+		if !names.IsValidStorage(arg) {
+			return errors.NotValidf("storage ID %q", arg)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	var anyFailed bool
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %v", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("snapshotting %s as snapshot %s", c.storageIds[i], result.Result)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the snapshot-storage
+// command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshots(storageIds []string) ([]params.StringResult, error)
+}
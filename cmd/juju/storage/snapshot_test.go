@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type snapshotStorageSuite struct {
+	SubStorageSuite
+	api *mockStorageSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotStorageSuite{})
+
+func (s *snapshotStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockStorageSnapshotAPI{}
+}
+
+func (s *snapshotStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewSnapshotStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *snapshotStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one storage ID")
+	_, err = s.run(c, "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *snapshotStorageSuite) TestSnapshot(c *gc.C) {
+	s.api.results = []params.StringResult{{Result: "0"}, {Result: "1"}}
+	ctx, err := s.run(c, "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0", "data/1"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+snapshotting data/0 as snapshot 0
+snapshotting data/1 as snapshot 1
+`[1:])
+}
+
+func (s *snapshotStorageSuite) TestSnapshotError(c *gc.C) {
+	s.api.results = []params.StringResult{
+		{Result: "0"},
+		{Error: &params.Error{Message: "snapshotting storage without a backing volume not supported"}},
+	}
+	ctx, err := s.run(c, "data/0", "data/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+snapshotting data/0 as snapshot 0
+failed to snapshot data/1: snapshotting storage without a backing volume not supported
+`[1:])
+}
+
+type mockStorageSnapshotAPI struct {
+	storageIds []string
+	results    []params.StringResult
+}
+
+func (m *mockStorageSnapshotAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageSnapshotAPI) CreateSnapshots(storageIds []string) ([]params.StringResult, error) {
+	m.storageIds = storageIds
+	return m.results, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewListSnapshotsCommand returns a command used to list volume
+// snapshots.
+func NewListSnapshotsCommand() cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = func() (StorageListSnapshotsAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listSnapshotsCommandDoc = `
+List the snapshots taken of the volumes backing storage in the model.
+If storage IDs are specified, only the snapshots taken of that
+storage are listed.
+
+Examples:
+    juju storage-snapshots
+    juju storage-snapshots pgdata/0
+
+See also:
+    snapshot-storage
+    restore-storage
+`
+
+// listSnapshotsCommand lists volume snapshots.
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageListSnapshotsAPI, error)
+	storageIds []string
+	out        cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists snapshots of the volumes backing storage.",
+		Doc:     listSnapshotsCommandDoc,
+		Args:    "[<storage> ...]",
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	for _, arg := range args {
+		if !names.IsValidStorage(arg) {
+			return errors.NotValidf("storage ID %q", arg)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListSnapshots(c.storageIds)
+	if err != nil {
+		return err
+	}
+	var details []params.VolumeSnapshotDetails
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "%v\n", result.Error)
+			continue
+		}
+		details = append(details, result.Result...)
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	snapshots, err := convertToSnapshotInfo(details)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, snapshots)
+}
+
+// StorageListSnapshotsAPI defines the API methods that the
+// storage-snapshots command uses.
+type StorageListSnapshotsAPI interface {
+	Close() error
+	ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetailsListResult, error)
+}
+
+// SnapshotInfo defines the serialization behaviour of volume snapshot
+// information.
+type SnapshotInfo struct {
+	Storage    string       `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Volume     string       `yaml:"volume" json:"volume"`
+	SnapshotId string       `yaml:"snapshot-id,omitempty" json:"snapshot-id,omitempty"`
+	Size       uint64       `yaml:"size,omitempty" json:"size,omitempty"`
+	Created    time.Time    `yaml:"created" json:"created"`
+	Status     EntityStatus `yaml:"status" json:"status"`
+}
+
+func convertToSnapshotInfo(all []params.VolumeSnapshotDetails) (map[string]SnapshotInfo, error) {
+	result := make(map[string]SnapshotInfo)
+	for _, details := range all {
+		volumeTag, err := names.ParseVolumeTag(details.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume:     volumeTag.Id(),
+			SnapshotId: details.SnapshotId,
+			Size:       details.Size,
+			Created:    details.Created,
+			Status: EntityStatus{
+				details.Status.Status,
+				details.Status.Info,
+				common.FormatTime(details.Status.Since, false),
+			},
+		}
+		if details.StorageTag != "" {
+			storageTag, err := names.ParseStorageTag(details.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.Storage = storageTag.Id()
+		}
+		result[details.Id] = info
+	}
+	return result, nil
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume
+// snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("SNAPSHOT", "STORAGE", "VOLUME", "PROVIDER ID", "SIZE", "STATUS", "MESSAGE")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		snapshot := snapshots[id]
+		var size string
+		if snapshot.Size > 0 {
+			size = humanize.IBytes(snapshot.Size * humanize.MiByte)
+		}
+		print(
+			id, snapshot.Storage, snapshot.Volume, snapshot.SnapshotId,
+			size, string(snapshot.Status.Current), snapshot.Status.Message,
+		)
+	}
+	return tw.Flush()
+}
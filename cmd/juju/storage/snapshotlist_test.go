@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type listSnapshotsSuite struct {
+	SubStorageSuite
+	api *mockStorageListSnapshotsAPI
+}
+
+var _ = gc.Suite(&listSnapshotsSuite{})
+
+func (s *listSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	created := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.api = &mockStorageListSnapshotsAPI{
+		snapshots: []params.VolumeSnapshotDetails{{
+			Id:         "0",
+			StorageTag: "storage-data-0",
+			VolumeTag:  "volume-0",
+			SnapshotId: "snap-0",
+			Size:       1024,
+			Created:    created,
+			Status:     params.EntityStatus{Status: status.StatusAvailable},
+		}, {
+			Id:         "1",
+			StorageTag: "storage-data-0",
+			VolumeTag:  "volume-0",
+			Created:    created,
+			Status:     params.EntityStatus{Status: status.StatusPending},
+		}},
+	}
+}
+
+func (s *listSnapshotsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewListSnapshotsCommandForTest(s.api, s.store), args...)
+}
+
+func (s *listSnapshotsSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *listSnapshotsSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, gc.HasLen, 0)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+SNAPSHOT  STORAGE  VOLUME  PROVIDER ID  SIZE    STATUS     MESSAGE
+0         data/0   0       snap-0       1.0GiB  available  
+1         data/0   0                            pending    
+`[1:])
+}
+
+func (s *listSnapshotsSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.run(c, "data/0", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.storageIds, jc.DeepEquals, []string{"data/0"})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+"0":
+  storage: data/0
+  volume: "0"
+  snapshot-id: snap-0
+  size: 1024
+  created: 2017-01-02T03:04:05Z
+  status:
+    current: available
+"1":
+  storage: data/0
+  volume: "0"
+  created: 2017-01-02T03:04:05Z
+  status:
+    current: pending
+`[1:])
+}
+
+func (s *listSnapshotsSuite) TestListError(c *gc.C) {
+	s.api.err = &params.Error{Message: `"volume-0" is not a valid storage tag`}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `"volume-0" is not a valid storage tag`+"\n")
+}
+
+type mockStorageListSnapshotsAPI struct {
+	storageIds []string
+	snapshots  []params.VolumeSnapshotDetails
+	err        *params.Error
+}
+
+func (m *mockStorageListSnapshotsAPI) Close() error {
+	return nil
+}
+
+func (m *mockStorageListSnapshotsAPI) ListSnapshots(storageIds []string) ([]params.VolumeSnapshotDetailsListResult, error) {
+	m.storageIds = storageIds
+	if m.err != nil {
+		return []params.VolumeSnapshotDetailsListResult{{Error: m.err}}, nil
+	}
+	return []params.VolumeSnapshotDetailsListResult{{Result: m.snapshots}}, nil
+}
@@ -37,7 +37,9 @@ var (
 		"spaces-imported-gate",
 	}
 	aliveModelWorkers = []string{
+		"charm-auto-upgrader",
 		"charm-revision-updater",
+		"charm-rollout",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -48,6 +50,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"remote-relations",
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
@@ -121,6 +124,7 @@ var (
 		"storage-provisioner",
 		"unconverted-api-workers",
 		"unit-agent-deployer",
+		"upgrade-series",
 	}
 )
 
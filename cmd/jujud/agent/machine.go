@@ -771,6 +771,10 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := setSecretConfigKey(st, agentConfig); err != nil {
+		st.Close()
+		return nil, errors.Trace(err)
+	}
 	return st, nil
 }
 
@@ -1006,6 +1010,7 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		CharmAutoUpgradeInterval:    15 * time.Minute,
 		InstPollerAggregationDelay:  3 * time.Second,
 		// TODO(perrito666) the status history pruning numbers need
 		// to be adjusting, after collecting user data from large install
@@ -1294,6 +1299,17 @@ func (a *MachineAgent) ensureMongoServer(agentConfig agent.Config) (err error) {
 	return nil
 }
 
+// setSecretConfigKey gives the state the key, kept in the agent's
+// state serving info, with which secret config values are encrypted.
+func setSecretConfigKey(st *state.State, agentConfig agent.Config) error {
+	info, ok := agentConfig.StateServingInfo()
+	if !ok || info.SecretConfigKey == "" {
+		logger.Warningf("agent config has no secret config key; secret config values cannot be set or read")
+		return nil
+	}
+	return st.SetSecretConfigKey(info.SecretConfigKey)
+}
+
 func openState(agentConfig agent.Config, dialOpts mongo.DialOpts) (_ *state.State, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1312,6 +1328,9 @@ func openState(agentConfig agent.Config, dialOpts mongo.DialOpts) (_ *state.Stat
 			st.Close()
 		}
 	}()
+	if err := setSecretConfigKey(st, agentConfig); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	m0, err := st.FindEntity(agentConfig.Tag())
 	if err != nil {
 		if errors.IsNotFound(err) {
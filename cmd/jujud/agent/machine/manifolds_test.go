@@ -72,6 +72,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"unit-agent-deployer",
 		"upgrade-check-flag",
 		"upgrade-check-gate",
+		"upgrade-series",
 		"upgrade-steps-flag",
 		"upgrade-steps-gate",
 		"upgrade-steps-runner",
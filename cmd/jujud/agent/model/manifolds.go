@@ -20,8 +20,10 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/charmautoupgrade"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
+	"github.com/juju/juju/worker/charmrollout"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/discoverspaces"
@@ -36,6 +38,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -78,6 +81,11 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// CharmAutoUpgradeInterval determines how often the charm-
+	// auto-upgrader worker will upgrade applications that have
+	// opted in to automatic charm upgrades.
+	CharmAutoUpgradeInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerMaxHistoryTime time.Duration
@@ -257,6 +265,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     applicationscaler.NewFacade,
 			NewWorker:     applicationscaler.New,
 		})),
+		charmRolloutName: ifNotMigrating(charmrollout.Manifold(charmrollout.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     charmrollout.NewFacade,
+			NewWorker:     charmrollout.New,
+		})),
+		remoteRelationsName: ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     remoterelations.NewFacade,
+			NewWorker:     remoterelations.New,
+		})),
 		instancePollerName: ifNotMigrating(instancepoller.Manifold(instancepoller.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -271,6 +289,13 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
 		})),
+		charmAutoUpgraderName: ifNotMigrating(charmautoupgrade.Manifold(charmautoupgrade.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Period:        config.CharmAutoUpgradeInterval,
+			NewFacade:     charmautoupgrade.NewFacade,
+			NewWorker:     charmautoupgrade.NewWorker,
+		})),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -377,8 +402,11 @@ const (
 	firewallerName           = "firewaller"
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
+	remoteRelationsName      = "remote-relations"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	charmAutoUpgraderName    = "charm-auto-upgrader"
+	charmRolloutName         = "charm-rollout"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
@@ -35,7 +35,9 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"charm-auto-upgrader",
 		"charm-revision-updater",
+		"charm-rollout",
 		"clock",
 		"compute-provisioner",
 		"environ-tracker",
@@ -49,6 +51,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"migration-master",
 		"not-alive-flag",
 		"not-dead-flag",
+		"remote-relations",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
@@ -208,8 +208,16 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	if !ok {
 		return fmt.Errorf("bootstrap machine config has no state serving info")
 	}
+	// Generate the key with which secret config values are
+	// encrypted. It is kept in the agent config rather than in
+	// the database holding the values.
+	secretConfigKey, err := state.NewSecretConfigKey()
+	if err != nil {
+		return err
+	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+	info.SecretConfigKey = secretConfigKey
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		return nil
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel contains types used to describe applications
+// offered by one model for consumption by other models on the same
+// controller.
+package crossmodel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// ApplicationURL represents the location of an offered application,
+// in the form [<user>/]<model-name>.<offer-name>.
+type ApplicationURL struct {
+	// User is the name of the owner of the offering model. It may be
+	// empty, in which case the consuming user is assumed.
+	User string
+
+	// ModelName is the name of the offering model.
+	ModelName string
+
+	// OfferName is the name of the offer within the model.
+	OfferName string
+}
+
+var modelNamePattern = regexp.MustCompile(`^[a-z0-9]+[a-z0-9-]*$`)
+
+// ParseApplicationURL parses the given offer URL.
+func ParseApplicationURL(urlStr string) (*ApplicationURL, error) {
+	var url ApplicationURL
+	rest := urlStr
+	if i := strings.Index(rest, "/"); i != -1 {
+		url.User, rest = rest[:i], rest[i+1:]
+		if !names.IsValidUser(url.User) {
+			return nil, errors.NotValidf("user name %q in application URL %q", url.User, urlStr)
+		}
+	}
+	i := strings.LastIndex(rest, ".")
+	if i == -1 {
+		return nil, errors.NotValidf("application URL %q without offer name", urlStr)
+	}
+	url.ModelName, url.OfferName = rest[:i], rest[i+1:]
+	if !modelNamePattern.MatchString(url.ModelName) {
+		return nil, errors.NotValidf("model name %q in application URL %q", url.ModelName, urlStr)
+	}
+	if !names.IsValidApplication(url.OfferName) {
+		return nil, errors.NotValidf("offer name %q in application URL %q", url.OfferName, urlStr)
+	}
+	return &url, nil
+}
+
+// String returns the string form of the URL.
+func (u *ApplicationURL) String() string {
+	return MakeURL(u.User, u.ModelName, u.OfferName)
+}
+
+// MakeURL returns the string form of the URL of the given offer in
+// the given user's model.
+func MakeURL(user, modelName, offerName string) string {
+	if user == "" {
+		return fmt.Sprintf("%s.%s", modelName, offerName)
+	}
+	return fmt.Sprintf("%s/%s.%s", user, modelName, offerName)
+}
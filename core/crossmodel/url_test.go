@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type URLSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&URLSuite{})
+
+func (*URLSuite) TestParseApplicationURL(c *gc.C) {
+	for i, test := range []struct {
+		url    string
+		expect *crossmodel.ApplicationURL
+		err    string
+	}{{
+		url:    "prod.hosted-mysql",
+		expect: &crossmodel.ApplicationURL{ModelName: "prod", OfferName: "hosted-mysql"},
+	}, {
+		url:    "bob/prod.hosted-mysql",
+		expect: &crossmodel.ApplicationURL{User: "bob", ModelName: "prod", OfferName: "hosted-mysql"},
+	}, {
+		url: "prod",
+		err: `application URL "prod" without offer name not valid`,
+	}, {
+		url: "bob/Prod.mysql",
+		err: `model name "Prod" in application URL "bob/Prod.mysql" not valid`,
+	}, {
+		url: "prod.1mysql",
+		err: `offer name "1mysql" in application URL "prod.1mysql" not valid`,
+	}, {
+		url: "b^b/prod.mysql",
+		err: `user name "b\^b" in application URL "b\^b/prod.mysql" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.url)
+		url, err := crossmodel.ParseApplicationURL(test.url)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(url, jc.DeepEquals, test.expect)
+		c.Check(url.String(), gc.Equals, test.url)
+	}
+}
@@ -37,6 +37,9 @@ type application struct {
 
 	Settings_ map[string]interface{} `yaml:"settings"`
 
+	// SecretConfigKeys_ names the settings whose values are secret.
+	SecretConfigKeys_ []string `yaml:"secret-config-keys,omitempty"`
+
 	Leader_             string                 `yaml:"leader,omitempty"`
 	LeadershipSettings_ map[string]interface{} `yaml:"leadership-settings"`
 
@@ -63,6 +66,7 @@ type ApplicationArgs struct {
 	Exposed              bool
 	MinUnits             int
 	Settings             map[string]interface{}
+	SecretConfigKeys     []string
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	StorageConstraints   map[string]StorageConstraintArgs
@@ -82,6 +86,7 @@ func newApplication(args ApplicationArgs) *application {
 		Exposed_:              args.Exposed,
 		MinUnits_:             args.MinUnits,
 		Settings_:             args.Settings,
+		SecretConfigKeys_:     args.SecretConfigKeys,
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
 		MetricsCredentials_:   creds,
@@ -152,6 +157,11 @@ func (s *application) Settings() map[string]interface{} {
 	return s.Settings_
 }
 
+// SecretConfigKeys implements Application.
+func (s *application) SecretConfigKeys() []string {
+	return s.SecretConfigKeys_
+}
+
 // Leader implements Application.
 func (s *application) Leader() string {
 	return s.Leader_
@@ -317,6 +327,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
+		"secret-config-keys":  schema.List(schema.String()),
 		"leader":              schema.String(),
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"storage-constraints": schema.StringMap(schema.StringMap(schema.Any())),
@@ -331,6 +342,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"min-units":           int64(0),
 		"leader":              "",
 		"metrics-creds":       "",
+		"secret-config-keys":  schema.Omit,
 		"storage-constraints": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
@@ -365,6 +377,12 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if keys, ok := valid["secret-config-keys"]; ok {
+		for _, key := range keys.([]interface{}) {
+			result.SecretConfigKeys_ = append(result.SecretConfigKeys_, key.(string))
+		}
+	}
+
 	if constraintsMap, ok := valid["constraints"]; ok {
 		constraints, err := importConstraints(constraintsMap.(map[string]interface{}))
 		if err != nil {
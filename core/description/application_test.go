@@ -179,6 +179,15 @@ func (s *ApplicationSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(application, jc.DeepEquals, svc)
 }
 
+func (s *ApplicationSerializationSuite) TestSecretConfigKeys(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.SecretConfigKeys = []string{"admin-password", "token"}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.SecretConfigKeys(), jc.DeepEquals, []string{"admin-password", "token"})
+}
+
 func (s *ApplicationSerializationSuite) TestAnnotations(c *gc.C) {
 	initial := minimalApplication()
 	annotations := map[string]string{
@@ -257,6 +257,7 @@ type Application interface {
 	MinUnits() int
 
 	Settings() map[string]interface{}
+	SecretConfigKeys() []string
 
 	Leader() string
 	LeadershipSettings() map[string]interface{}
@@ -50,6 +50,10 @@ type SerializedModel struct {
 	// their URIs. The URIs can be used to download the tools from the
 	// source controller.
 	Tools map[version.Binary]string // version -> tools URI
+
+	// SecretKey is the key with which the values of secret
+	// application settings in the serialized model are encrypted.
+	SecretKey string
 }
 
 // ModelInfo is used to report basic details about a model.
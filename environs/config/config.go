@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strings"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -129,6 +131,14 @@ const (
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
 
+	// AddressPreferenceKey is the key for the IP address family
+	// (ipv4, ipv6 or dual) preferred when selecting addresses.
+	AddressPreferenceKey = "address-preference"
+
+	// EgressSubnetsKey is the key for the comma-separated list of
+	// CIDRs reported as the egress subnets of units in the model.
+	EgressSubnetsKey = "egress-subnets"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -284,6 +294,8 @@ var defaultConfigValues = map[string]interface{}{
 	IgnoreMachineAddresses:       false,
 	"ssl-hostname-verification":  true,
 	"proxy-ssh":                  false,
+	AddressPreferenceKey:         string(network.PreferIPv4),
+	EgressSubnetsKey:             "",
 
 	"default-series":           series.LatestLts(),
 	ProvisionerHarvestModeKey:  HarvestDestroyed.String(),
@@ -451,6 +463,12 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	for _, cidr := range cfg.EgressSubnets() {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid egress subnet %q in model configuration", cidr)
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -794,6 +812,29 @@ func (c *Config) ProvisionerHarvestMode() HarvestMode {
 	}
 }
 
+// AddressPreference reports which IP address family should be
+// preferred when selecting addresses for machines, units and
+// API servers.
+func (c *Config) AddressPreference() network.AddressPreference {
+	if v, ok := c.defined[AddressPreferenceKey].(string); ok && v != "" {
+		return network.AddressPreference(v)
+	}
+	return network.PreferIPv4
+}
+
+// EgressSubnets returns the CIDRs reported to charms as the source
+// of traffic leaving units in the model. An empty result means the
+// subnets are derived from each unit's ingress address.
+func (c *Config) EgressSubnets() []string {
+	var subnets []string
+	for _, cidr := range strings.Split(c.asString(EgressSubnetsKey), ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			subnets = append(subnets, cidr)
+		}
+	}
+	return subnets
+}
+
 // ImageStream returns the simplestreams stream
 // used to identify which image ids to search
 // when starting an instance.
@@ -969,6 +1010,8 @@ var alwaysOptional = schema.Defaults{
 	"proxy-ssh":                  schema.Omit,
 	"disable-network-management": schema.Omit,
 	IgnoreMachineAddresses:       schema.Omit,
+	AddressPreferenceKey:         schema.Omit,
+	EgressSubnetsKey:             schema.Omit,
 	AutomaticallyRetryHooks:      schema.Omit,
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
@@ -1175,6 +1218,24 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	AddressPreferenceKey: {
+		Description: `Which IP address family to prefer when selecting machine, unit and API server addresses.
+
+'ipv4' prefers IPv4 addresses over IPv6 ones with the same scope.
+
+'ipv6' prefers IPv6 addresses over IPv4 ones with the same scope,
+which is needed for IPv6-only clouds.
+
+'dual' gives neither family priority.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{string(network.PreferIPv4), string(network.PreferIPv6), string(network.PreferDualStack)},
+		Group:  environschema.EnvironGroup,
+	},
+	EgressSubnetsKey: {
+		Description: "Comma-separated list of CIDRs reported to charms as the source of traffic leaving units in the model (default: derived from each unit's ingress address)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"enable-os-refresh-update": {
 		Description: `Whether newly provisioned instances should run their respective OS's update capability.`,
 		Type:        environschema.Tbool,
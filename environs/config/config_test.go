@@ -22,6 +22,7 @@ import (
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -177,6 +178,38 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"ignore-machine-addresses": true,
 		}),
+	}, {
+		about:       "address-preference ipv6",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"address-preference": "ipv6",
+		}),
+	}, {
+		about:       "address-preference dual",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"address-preference": "dual",
+		}),
+	}, {
+		about:       "Invalid address-preference",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"address-preference": "ipv5",
+		}),
+		err: `address-preference: expected one of \[ipv4 ipv6 dual\], got "ipv5"`,
+	}, {
+		about:       "egress-subnets",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"egress-subnets": "10.0.0.0/8, 192.168.1.1/32",
+		}),
+	}, {
+		about:       "Invalid egress-subnets",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"egress-subnets": "10.0.0.0/8,10.0.0.1",
+		}),
+		err: `invalid egress subnet "10.0.0.1" in model configuration`,
 	}, {
 		about:       "set-numa-control-policy on",
 		useDefaults: config.UseDefaults,
@@ -719,6 +752,18 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	} else {
 		c.Check(xmit, jc.IsTrue)
 	}
+
+	if subnets, ok := test.attrs["egress-subnets"]; ok && subnets != "" {
+		c.Check(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.1/32"})
+	} else {
+		c.Check(cfg.EgressSubnets(), gc.HasLen, 0)
+	}
+
+	if pref, ok := test.attrs["address-preference"]; ok {
+		c.Check(cfg.AddressPreference(), gc.Equals, network.AddressPreference(pref.(string)))
+	} else {
+		c.Check(cfg.AddressPreference(), gc.Equals, network.PreferIPv4)
+	}
 }
 
 func (test configTest) assertDuration(c *gc.C, name string, actual time.Duration, defaultInSeconds int) {
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
@@ -103,14 +104,27 @@ func getEffectiveBindingsForCharmMeta(charmMeta *charm.Meta, givenBindings map[s
 }
 
 // AddUnits starts n units of the given application using the specified placement
-// directives to allocate the machines.
-func AddUnits(st *state.State, svc *state.Application, n int, placement []*instance.Placement) ([]*state.Unit, error) {
+// directives to allocate the machines. If attachStorage is non-empty, then n must
+// be 1, and the identified detached storage instances will be attached to the
+// new unit.
+func AddUnits(
+	st *state.State,
+	svc *state.Application,
+	n int,
+	placement []*instance.Placement,
+	attachStorage []names.StorageTag,
+) ([]*state.Unit, error) {
+	if len(attachStorage) > 0 && n != 1 {
+		return nil, errors.Errorf("cannot attach storage to %d units, must add exactly one unit", n)
+	}
 	units := make([]*state.Unit, n)
 	// Hard code for now till we implement a different approach.
 	policy := state.AssignCleanEmpty
 	// TODO what do we do if we fail half-way through this process?
 	for i := 0; i < n; i++ {
-		unit, err := svc.AddUnit()
+		unit, err := svc.AddUnitWithParams(state.AddUnitParams{
+			AttachStorage: attachStorage,
+		})
 		if err != nil {
 			return nil, errors.Annotatef(err, "cannot add unit %d/%d to application %q", i+1, n, svc.Name())
 		}
@@ -490,6 +490,10 @@ func newState(controllerUUID string, environ environs.Environ, mongoInfo *mongo.
 	} else if err != nil {
 		return nil, err
 	}
+	if err := st.SetSecretConfigKey(testing.SecretConfigKey); err != nil {
+		st.Close()
+		return nil, err
+	}
 	return st, nil
 }
 
@@ -48,14 +48,16 @@ func ExportModel(st StateExporter) ([]byte, error) {
 
 // ImportModel deserializes a model description from the bytes, transforms
 // the model config based on information from the controller model, and then
-// imports that as a new database model.
-func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, error) {
+// imports that as a new database model. The secret key is the one with
+// which the model's secret config values were encrypted when it was
+// exported.
+func ImportModel(st *state.State, bytes []byte, secretKey string) (*state.Model, *state.State, error) {
 	model, err := description.Deserialize(bytes)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
-	dbModel, dbState, err := st.Import(model)
+	dbModel, dbState, err := st.Import(model, secretKey)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -45,7 +45,7 @@ func (s *ImportSuite) SetUpTest(c *gc.C) {
 
 func (s *ImportSuite) TestBadBytes(c *gc.C) {
 	bytes := []byte("not a model")
-	model, st, err := migration.ImportModel(s.State, bytes)
+	model, st, err := migration.ImportModel(s.State, bytes, "")
 	c.Check(st, gc.IsNil)
 	c.Check(model, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
@@ -67,7 +67,7 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	bytes, err := description.Serialize(model)
 	c.Check(err, jc.ErrorIsNil)
 
-	dbModel, dbState, err := migration.ImportModel(s.State, bytes)
+	dbModel, dbState, err := migration.ImportModel(s.State, bytes, "")
 	c.Check(err, jc.ErrorIsNil)
 	defer dbState.Close()
 
@@ -351,9 +351,16 @@ func SelectMongoHostPortsByScope(hostPorts []HostPort, machineLocal bool) []stri
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return SelectPublicAddressWithPreference(addresses, PreferDualStack)
+}
+
+// SelectPublicAddressWithPreference works like SelectPublicAddress, but
+// among the addresses with the best matching scope, the ones of the
+// address family preferred by pref are chosen first.
+func SelectPublicAddressWithPreference(addresses []Address, pref AddressPreference) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, publicMatch, pref)
 	if index < 0 {
 		return Address{}, false
 	}
@@ -364,9 +371,15 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 // appropriate to display as a publicly accessible endpoint. If there
 // are no suitable candidates, the empty string is returned.
 func SelectPublicHostPort(hps []HostPort) string {
+	return SelectPublicHostPortWithPreference(hps, PreferDualStack)
+}
+
+// SelectPublicHostPortWithPreference works like SelectPublicHostPort,
+// but honours the given address family preference.
+func SelectPublicHostPortWithPreference(hps []HostPort, pref AddressPreference) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, publicMatch)
+	}, publicMatch, pref)
 	if index < 0 {
 		return ""
 	}
@@ -378,9 +391,16 @@ func SelectPublicHostPort(hps []HostPort) string {
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return SelectInternalAddressWithPreference(addresses, machineLocal, PreferDualStack)
+}
+
+// SelectInternalAddressWithPreference works like SelectInternalAddress,
+// but among the addresses with the best matching scope, the ones of the
+// address family preferred by pref are chosen first.
+func SelectInternalAddressWithPreference(addresses []Address, machineLocal bool, pref AddressPreference) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal), pref)
 	if index < 0 {
 		return Address{}, false
 	}
@@ -392,9 +412,15 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 // in its NetAddr form. If there are no suitable addresses, the empty
 // string is returned.
 func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
+	return SelectInternalHostPortWithPreference(hps, machineLocal, PreferDualStack)
+}
+
+// SelectInternalHostPortWithPreference works like SelectInternalHostPort,
+// but honours the given address family preference.
+func SelectInternalHostPortWithPreference(hps []HostPort, machineLocal bool, pref AddressPreference) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal), pref)
 	if index < 0 {
 		return ""
 	}
@@ -408,7 +434,7 @@ func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := bestAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal), PreferDualStack)
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -422,9 +448,16 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 // returns them in NetAddr form. If there are no suitable addresses
 // then an empty slice is returned.
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	return PrioritizeInternalHostPortsWithPreference(hps, machineLocal, PreferDualStack)
+}
+
+// PrioritizeInternalHostPortsWithPreference works like
+// PrioritizeInternalHostPorts, but within each scope the addresses of
+// the family preferred by pref come first.
+func PrioritizeInternalHostPortsWithPreference(hps []HostPort, machineLocal bool, pref AddressPreference) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal), pref)
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -480,10 +513,10 @@ type scopeMatchFunc func(addr Address) scopeMatch
 type addressByIndexFunc func(index int) Address
 
 // bestAddressIndex returns the index of the addresses with the best matching
-// scope (according to the matchFunc). -1 is returned if there were no suitable
-// addresses.
-func bestAddressIndex(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc) int {
-	indexes := bestAddressIndexes(numAddr, getAddrFunc, matchFunc)
+// scope (according to the matchFunc) and address family (according to pref).
+// -1 is returned if there were no suitable addresses.
+func bestAddressIndex(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc, pref AddressPreference) int {
+	indexes := bestAddressIndexes(numAddr, getAddrFunc, matchFunc, pref)
 	if len(indexes) > 0 {
 		return indexes[0]
 	}
@@ -491,9 +524,10 @@ func bestAddressIndex(numAddr int, getAddrFunc addressByIndexFunc, matchFunc sco
 }
 
 // bestAddressIndexes returns the indexes of the addresses with the best
-// matching scope and type (according to the matchFunc). An empty slice is
-// returned if there were no suitable addresses.
-func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc) []int {
+// matching scope and type (according to the matchFunc), ordered by address
+// family according to pref. An empty slice is returned if there were no
+// suitable addresses.
+func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc, pref AddressPreference) []int {
 	// Categorise addresses by scope and type matching quality.
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
@@ -502,13 +536,13 @@ func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc s
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
-			return indexes
+			return pref.orderIndexes(indexes, getAddrFunc)
 		}
 	}
 	return []int{}
 }
 
-func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc) []int {
+func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc scopeMatchFunc, pref AddressPreference) []int {
 	// Categorise addresses by scope and type matching quality.
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
@@ -518,7 +552,7 @@ func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matc
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
-			prioritized = append(prioritized, indexes...)
+			prioritized = append(prioritized, pref.orderIndexes(indexes, getAddrFunc)...)
 		}
 	}
 	return prioritized
@@ -544,7 +578,9 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// Within the same scope, IP addresses of the family preferred by pref
+// come before those of the other family.
+func (a Address) sortOrder(pref AddressPreference) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -562,23 +598,24 @@ func (a Address) sortOrder() int {
 		if a.Value == "localhost" {
 			order++
 		}
-	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		order += pref.familyOrder(a)
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesByPreference struct {
+	addrs []Address
+	pref  AddressPreference
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesByPreference) Len() int      { return len(a.addrs) }
+func (a addressesByPreference) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesByPreference) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrder(a.pref)
+	order2 := addr2.sortOrder(a.pref)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -586,9 +623,16 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 }
 
 // SortAddresses sorts the given Address slice according to the sortOrder of
-// each address. See Address.sortOrder() for more info.
+// each address, preferring IPv4 addresses. See Address.sortOrder() for more
+// info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	SortAddressesWithPreference(addrs, PreferIPv4)
+}
+
+// SortAddressesWithPreference sorts the given Address slice according to
+// the sortOrder of each address, using pref to rank the address families.
+func SortAddressesWithPreference(addrs []Address, pref AddressPreference) {
+	sort.Sort(addressesByPreference{addrs, pref})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
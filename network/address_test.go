@@ -482,6 +482,100 @@ func (s *AddressSuite) TestSelectInternalMachineAddress(c *gc.C) {
 	}
 }
 
+type selectWithPreferenceTest struct {
+	about     string
+	addresses []network.Address
+	pref      network.AddressPreference
+	expected  string
+}
+
+var selectPublicWithPreferenceTests = []selectWithPreferenceTest{{
+	"first public address is selected with dual-stack preference",
+	[]network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	},
+	network.PreferDualStack,
+	"8.8.8.8",
+}, {
+	"public IPv4 address is preferred to an earlier public IPv6 address",
+	[]network.Address{
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	network.PreferIPv4,
+	"8.8.8.8",
+}, {
+	"public IPv6 address is preferred to an earlier public IPv4 address",
+	[]network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	},
+	network.PreferIPv6,
+	"2001:db8::1",
+}, {
+	"public IPv4 address is selected on its own with IPv6 preference",
+	[]network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	},
+	network.PreferIPv6,
+	"8.8.8.8",
+}}
+
+func (s *AddressSuite) TestSelectPublicAddressWithPreference(c *gc.C) {
+	for i, t := range selectPublicWithPreferenceTests {
+		c.Logf("test %d: %s", i, t.about)
+		actualAddr, actualOK := network.SelectPublicAddressWithPreference(t.addresses, t.pref)
+		c.Check(actualOK, jc.IsTrue)
+		c.Check(actualAddr.Value, gc.Equals, t.expected)
+	}
+}
+
+var selectInternalWithPreferenceTests = []selectWithPreferenceTest{{
+	"first cloud local address is selected with dual-stack preference",
+	[]network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	},
+	network.PreferDualStack,
+	"fc00::1",
+}, {
+	"cloud local IPv4 address is preferred to an earlier IPv6 one",
+	[]network.Address{
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	},
+	network.PreferIPv4,
+	"10.0.0.1",
+}, {
+	"cloud local IPv6 address is preferred to an earlier IPv4 one",
+	[]network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	},
+	network.PreferIPv6,
+	"fc00::1",
+}, {
+	"scope still takes precedence over the address family",
+	[]network.Address{
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	},
+	network.PreferIPv6,
+	"10.0.0.1",
+}}
+
+func (s *AddressSuite) TestSelectInternalAddressWithPreference(c *gc.C) {
+	for i, t := range selectInternalWithPreferenceTests {
+		c.Logf("test %d: %s", i, t.about)
+		actualAddr, actualOK := network.SelectInternalAddressWithPreference(t.addresses, false, t.pref)
+		c.Check(actualOK, jc.IsTrue)
+		c.Check(actualAddr.Value, gc.Equals, t.expected)
+	}
+}
+
 type selectInternalHostPortsTest struct {
 	about     string
 	addresses []network.HostPort
@@ -577,6 +671,24 @@ func (s *AddressSuite) TestPrioritizeInternalHostPorts(c *gc.C) {
 	}
 }
 
+func (s *AddressSuite) TestPrioritizeInternalHostPortsWithPreference(c *gc.C) {
+	hps := []network.HostPort{
+		{network.NewScopedAddress("2001:db8::1", network.ScopePublic), 123},
+		{network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal), 123},
+		{network.NewScopedAddress("8.8.8.8", network.ScopePublic), 123},
+		{network.NewScopedAddress("fc00::1", network.ScopeCloudLocal), 123},
+	}
+	c.Check(network.PrioritizeInternalHostPortsWithPreference(hps, false, network.PreferIPv4), gc.DeepEquals, []string{
+		"10.0.0.1:123", "[fc00::1]:123", "8.8.8.8:123", "[2001:db8::1]:123",
+	})
+	c.Check(network.PrioritizeInternalHostPortsWithPreference(hps, false, network.PreferIPv6), gc.DeepEquals, []string{
+		"[fc00::1]:123", "10.0.0.1:123", "[2001:db8::1]:123", "8.8.8.8:123",
+	})
+	c.Check(network.PrioritizeInternalHostPortsWithPreference(hps, false, network.PreferDualStack), gc.DeepEquals, []string{
+		"10.0.0.1:123", "[fc00::1]:123", "[2001:db8::1]:123", "8.8.8.8:123",
+	})
+}
+
 var stringTests = []struct {
 	addr network.Address
 	str  string
@@ -687,6 +799,71 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	))
 }
 
+func (*AddressSuite) TestSortAddressesPreferringIPv6(c *gc.C) {
+	addrs := network.NewAddresses(
+		"127.0.0.1",
+		"::1",
+		"fc00::1",
+		"localhost",
+		"2001:db8::1",
+		"172.16.0.1",
+		"example.com",
+		"8.8.8.8",
+	)
+	network.SortAddressesWithPreference(addrs, network.PreferIPv6)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		// Public IPv6 addresses on top.
+		"2001:db8::1",
+		// After that public IPv4 addresses.
+		"8.8.8.8",
+		// Then hostnames.
+		"example.com",
+		"localhost",
+		// Then IPv6 cloud-local addresses.
+		"fc00::1",
+		// Then IPv4 cloud-local addresses.
+		"172.16.0.1",
+		// Then machine-local addresses, IPv6 first.
+		"::1",
+		"127.0.0.1",
+	))
+}
+
+func (*AddressSuite) TestSortAddressesDualStack(c *gc.C) {
+	addrs := network.NewAddresses(
+		"fc00::1",
+		"8.8.8.8",
+		"172.16.0.1",
+		"2001:db8::1",
+	)
+	network.SortAddressesWithPreference(addrs, network.PreferDualStack)
+	// Only the scope matters, addresses with the same scope are
+	// sorted by value.
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		"2001:db8::1",
+		"8.8.8.8",
+		"172.16.0.1",
+		"fc00::1",
+	))
+}
+
+func (*AddressSuite) TestOrderAddressesByPreference(c *gc.C) {
+	addrs := network.NewAddresses(
+		"10.0.0.2",
+		"fc00::2",
+		"10.0.0.1",
+		"example.com",
+		"fc00::1",
+	)
+	c.Check(network.OrderAddressesByPreference(addrs, network.PreferIPv4), jc.DeepEquals, network.NewAddresses(
+		"10.0.0.2", "10.0.0.1", "example.com", "fc00::2", "fc00::1",
+	))
+	c.Check(network.OrderAddressesByPreference(addrs, network.PreferIPv6), jc.DeepEquals, network.NewAddresses(
+		"fc00::2", "example.com", "fc00::1", "10.0.0.2", "10.0.0.1",
+	))
+	c.Check(network.OrderAddressesByPreference(addrs, network.PreferDualStack), jc.DeepEquals, addrs)
+}
+
 func (*AddressSuite) TestIPv4ToDecimal(c *gc.C) {
 	zeroIP, err := network.IPv4ToDecimal(net.ParseIP("0.0.0.0"))
 	c.Assert(err, jc.ErrorIsNil)
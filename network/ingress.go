@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// OpenIngressCIDR is the source CIDR used for ingress rules that
+// allow traffic from anywhere.
+const OpenIngressCIDR = "0.0.0.0/0"
+
+// IngressRule represents a range of ports that may be accessed from
+// the given source CIDRs.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs contains the CIDRs from which the port range may
+	// be accessed. The CIDRs are kept sorted and unique; use
+	// NewIngressRule to construct an IngressRule.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range and
+// source CIDRs. If no source CIDRs are specified, the port range is
+// open to any source.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) (IngressRule, error) {
+	if err := portRange.Validate(); err != nil {
+		return IngressRule{}, errors.Trace(err)
+	}
+	for _, cidr := range sourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return IngressRule{}, errors.Errorf("invalid source CIDR %q", cidr)
+		}
+	}
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{OpenIngressCIDR}
+	}
+	return IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: set.NewStrings(sourceCIDRs...).SortedValues(),
+	}, nil
+}
+
+// MustNewIngressRule returns an IngressRule for the given port range
+// and source CIDRs, and panics if the rule is invalid.
+func MustNewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(portRange, sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// NewOpenIngressRules returns ingress rules allowing access to each
+// of the given port ranges from any source.
+func NewOpenIngressRules(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: []string{OpenIngressCIDR},
+		}
+	}
+	return rules
+}
+
+// IsOpen reports whether the rule allows access from any source.
+func (r IngressRule) IsOpen() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if cidr == OpenIngressCIDR {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IngressRule) String() string {
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	if r[i].PortRange != r[j].PortRange {
+		return portRangeSlice{r[i].PortRange, r[j].PortRange}.Less(0, 1)
+	}
+	return strings.Join(r[i].SourceCIDRs, ",") < strings.Join(r[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by port range, then
+// by source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
+
+// IngressRulePortRanges returns the port ranges of the given rules,
+// without duplicates.
+func IngressRulePortRanges(rules []IngressRule) []PortRange {
+	seen := make(map[PortRange]bool)
+	var portRanges []PortRange
+	for _, rule := range rules {
+		if seen[rule.PortRange] {
+			continue
+		}
+		seen[rule.PortRange] = true
+		portRanges = append(portRanges, rule.PortRange)
+	}
+	return portRanges
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	rule, err := network.NewIngressRule(
+		network.MustParsePortRange("80/tcp"),
+		"192.168.1.0/24", "10.0.0.0/8", "192.168.1.0/24",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{
+		PortRange:   network.PortRange{80, 80, "tcp"},
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(rule.IsOpen(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleDefaultsToOpen(c *gc.C) {
+	rule, err := network.NewIngressRule(network.MustParsePortRange("8000-8080/udp"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(rule.IsOpen(), jc.IsTrue)
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalid(c *gc.C) {
+	_, err := network.NewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `invalid source CIDR "10.0.0.0"`)
+	_, err = network.NewIngressRule(network.PortRange{80, 70, "tcp"})
+	c.Assert(err, gc.ErrorMatches, "invalid port range 80-70/tcp")
+}
+
+func (*IngressRuleSuite) TestNewOpenIngressRules(c *gc.C) {
+	rules := network.NewOpenIngressRules([]network.PortRange{
+		{80, 80, "tcp"}, {53, 53, "udp"},
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+	})
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+	})
+}
+
+func (*IngressRuleSuite) TestIngressRulePortRanges(c *gc.C) {
+	portRanges := network.IngressRulePortRanges([]network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+	})
+	c.Assert(portRanges, jc.DeepEquals, []network.PortRange{
+		{80, 80, "tcp"}, {22, 22, "tcp"},
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+// AddressPreference determines which IP address family is preferred
+// when selecting or ordering addresses with otherwise equal scope.
+type AddressPreference string
+
+const (
+	// PreferIPv4 ranks IPv4 addresses ahead of IPv6 addresses.
+	// This is the default preference.
+	PreferIPv4 AddressPreference = "ipv4"
+
+	// PreferIPv6 ranks IPv6 addresses ahead of IPv4 addresses,
+	// which is needed for IPv6-only clouds.
+	PreferIPv6 AddressPreference = "ipv6"
+
+	// PreferDualStack gives neither address family priority, so
+	// addresses with the same scope keep the order they were given.
+	PreferDualStack AddressPreference = "dual"
+)
+
+// familyOrder returns 0 for addresses of the preferred family (and for
+// hostnames, which have no family) and 1 for addresses of the other
+// family. With PreferDualStack every address gets 0.
+func (p AddressPreference) familyOrder(addr Address) int {
+	switch {
+	case p == PreferIPv4 && addr.Type == IPv6Address:
+		return 1
+	case p == PreferIPv6 && addr.Type == IPv4Address:
+		return 1
+	}
+	return 0
+}
+
+// orderIndexes returns the given address indexes with the addresses of
+// the preferred family first, keeping the relative order otherwise.
+func (p AddressPreference) orderIndexes(indexes []int, getAddrFunc addressByIndexFunc) []int {
+	if p == PreferDualStack || len(indexes) < 2 {
+		return indexes
+	}
+	ordered := make([]int, 0, len(indexes))
+	var others []int
+	for _, index := range indexes {
+		if p.familyOrder(getAddrFunc(index)) == 0 {
+			ordered = append(ordered, index)
+		} else {
+			others = append(others, index)
+		}
+	}
+	return append(ordered, others...)
+}
+
+// OrderAddressesByPreference returns a copy of the given addresses with
+// those of the preferred family first. The relative order of addresses
+// of the same family is preserved.
+func OrderAddressesByPreference(addresses []Address, pref AddressPreference) []Address {
+	indexes := make([]int, len(addresses))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	indexes = pref.orderIndexes(indexes, func(i int) Address {
+		return addresses[i]
+	})
+	ordered := make([]Address, len(indexes))
+	for i, index := range indexes {
+		ordered[i] = addresses[index]
+	}
+	return ordered
+}
@@ -4,6 +4,7 @@
 package azure
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"net/http"
 	"strings"
@@ -27,6 +28,8 @@ type azureInstance struct {
 	publicIPAddresses []network.PublicIPAddress
 }
 
+var _ instance.InstanceIngressFirewaller = (*azureInstance)(nil)
+
 // Id is specified in the Instance interface.
 func (inst *azureInstance) Id() instance.Id {
 	// Note: we use Name and not Id, since all VM operations are in
@@ -183,6 +186,25 @@ func (inst *azureInstance) primaryNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.NewOpenIngressRules(ports))
+}
+
+// ClosePorts is specified in the Instance interface.
+func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.NewOpenIngressRules(ports))
+}
+
+// Ports is specified in the Instance interface.
+func (inst *azureInstance) Ports(machineId string) ([]jujunetwork.PortRange, error) {
+	rules, err := inst.securityGroupIngressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jujunetwork.IngressRulePortRanges(rules), nil
+}
+
+// OpenIngressRules is specified in the InstanceIngressFirewaller interface.
+func (inst *azureInstance) OpenIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	primaryNetworkAddress, err := inst.primaryNetworkAddress()
@@ -210,75 +232,88 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// Create rules one at a time; this is necessary to avoid trampling
 	// on changes made by the provisioner. We still record rules in the
 	// NSG in memory, so we can easily tell which priorities are available.
+	//
+	// Azure security rules have a single source address prefix, so
+	// we create one rule for each port range and source CIDR.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-
-		// Check if the rule already exists; OpenPorts must be idempotent.
-		var found bool
-		for _, rule := range securityRules {
-			if to.String(rule.Name) == ruleName {
-				found = true
-				break
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		for _, cidr := range ingressRule.SourceCIDRs {
+			ruleName := securityRuleName(prefix, ports, cidr)
+
+			// Check if the rule already exists; OpenIngressRules must be idempotent.
+			var found bool
+			for _, rule := range securityRules {
+				if to.String(rule.Name) == ruleName {
+					found = true
+					break
+				}
 			}
-		}
-		if found {
-			logger.Debugf("security rule %q already exists", ruleName)
-			continue
-		}
-		logger.Debugf("creating security rule %q", ruleName)
+			if found {
+				logger.Debugf("security rule %q already exists", ruleName)
+				continue
+			}
+			logger.Debugf("creating security rule %q", ruleName)
 
-		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
-		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
-		}
+			priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
+			if err != nil {
+				return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			}
 
-		var protocol network.SecurityRuleProtocol
-		switch ports.Protocol {
-		case "tcp":
-			protocol = network.TCP
-		case "udp":
-			protocol = network.UDP
-		default:
-			return errors.Errorf("invalid protocol %q", ports.Protocol)
-		}
+			var protocol network.SecurityRuleProtocol
+			switch ports.Protocol {
+			case "tcp":
+				protocol = network.TCP
+			case "udp":
+				protocol = network.UDP
+			default:
+				return errors.Errorf("invalid protocol %q", ports.Protocol)
+			}
 
-		var portRange string
-		if ports.FromPort != ports.ToPort {
-			portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
-		} else {
-			portRange = fmt.Sprint(ports.FromPort)
-		}
+			var portRange string
+			if ports.FromPort != ports.ToPort {
+				portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
+			} else {
+				portRange = fmt.Sprint(ports.FromPort)
+			}
 
-		rule := network.SecurityRule{
-			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
-				Protocol:                 protocol,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr(primaryNetworkAddress.Value),
-				Access:    network.Allow,
-				Priority:  to.Int32Ptr(priority),
-				Direction: network.Inbound,
-			},
-		}
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			return securityRuleClient.CreateOrUpdate(
-				inst.env.resourceGroup, securityGroupName, ruleName, rule,
-				nil, // abort channel
-			)
-		}); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			description := ports.String()
+			sourceAddressPrefix := "*"
+			if cidr != jujunetwork.OpenIngressCIDR {
+				description = fmt.Sprintf("%s from %s", ports, cidr)
+				sourceAddressPrefix = cidr
+			}
+
+			rule := network.SecurityRule{
+				Properties: &network.SecurityRulePropertiesFormat{
+					Description:              to.StringPtr(description),
+					Protocol:                 protocol,
+					SourcePortRange:          to.StringPtr("*"),
+					DestinationPortRange:     to.StringPtr(portRange),
+					SourceAddressPrefix:      to.StringPtr(sourceAddressPrefix),
+					DestinationAddressPrefix: to.StringPtr(primaryNetworkAddress.Value),
+					Access:    network.Allow,
+					Priority:  to.Int32Ptr(priority),
+					Direction: network.Inbound,
+				},
+			}
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				return securityRuleClient.CreateOrUpdate(
+					inst.env.resourceGroup, securityGroupName, ruleName, rule,
+					nil, // abort channel
+				)
+			}); err != nil {
+				return errors.Annotatef(err, "creating security rule for %s", ports)
+			}
+			securityRules = append(securityRules, rule)
 		}
-		securityRules = append(securityRules, rule)
 	}
 	return nil
 }
 
-// ClosePorts is specified in the Instance interface.
-func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+// CloseIngressRules is specified in the InstanceIngressFirewaller interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	securityGroupName := internalSecurityGroupName
 
@@ -286,28 +321,41 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-		logger.Debugf("deleting security rule %q", ruleName)
-		var result autorest.Response
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			var err error
-			result, err = securityRuleClient.Delete(
-				inst.env.resourceGroup, securityGroupName, ruleName,
-				nil, // abort channel
-			)
-			return result, err
-		}); err != nil {
-			if result.Response == nil || result.StatusCode != http.StatusNotFound {
-				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+	for _, ingressRule := range rules {
+		for _, cidr := range ingressRule.SourceCIDRs {
+			ruleName := securityRuleName(prefix, ingressRule.PortRange, cidr)
+			logger.Debugf("deleting security rule %q", ruleName)
+			var result autorest.Response
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				var err error
+				result, err = securityRuleClient.Delete(
+					inst.env.resourceGroup, securityGroupName, ruleName,
+					nil, // abort channel
+				)
+				return result, err
+			}); err != nil {
+				if result.Response == nil || result.StatusCode != http.StatusNotFound {
+					return errors.Annotatef(err, "deleting security rule %q", ruleName)
+				}
 			}
 		}
 	}
 	return nil
 }
 
-// Ports is specified in the Instance interface.
-func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+// IngressRules is specified in the InstanceIngressFirewaller interface.
+func (inst *azureInstance) IngressRules(machineId string) ([]jujunetwork.IngressRule, error) {
+	rules, err := inst.securityGroupIngressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	jujunetwork.SortIngressRules(rules)
+	return rules, nil
+}
+
+// securityGroupIngressRules returns the ingress rules for the machine,
+// in the order of the security rules they were built from.
+func (inst *azureInstance) securityGroupIngressRules(machineId string) ([]jujunetwork.IngressRule, error) {
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityGroupName := internalSecurityGroupName
 	var nsg network.SecurityGroup
@@ -322,6 +370,11 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		return nil, nil
 	}
 
+	// Azure reports one security rule for each port range and source
+	// address prefix, so we gather the source CIDRs for each port
+	// range before building the ingress rules.
+	var portRanges []jujunetwork.PortRange
+	sourceCIDRs := make(map[jujunetwork.PortRange][]string)
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
 	for _, rule := range *nsg.Properties.SecurityRules {
@@ -343,6 +396,7 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 			portRange.FromPort = 0
 			portRange.ToPort = 65535
 		} else {
+			var err error
 			portRange, err = jujunetwork.ParsePortRange(
 				*rule.Properties.DestinationPortRange,
 			)
@@ -354,6 +408,13 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 			}
 		}
 
+		cidr := jujunetwork.OpenIngressCIDR
+		switch source := to.String(rule.Properties.SourceAddressPrefix); source {
+		case "", "*", "Internet":
+		default:
+			cidr = source
+		}
+
 		var protocols []string
 		switch rule.Properties.Protocol {
 		case network.TCP:
@@ -365,10 +426,22 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			if _, ok := sourceCIDRs[portRange]; !ok {
+				portRanges = append(portRanges, portRange)
+			}
+			sourceCIDRs[portRange] = append(sourceCIDRs[portRange], cidr)
 		}
 	}
-	return ports, nil
+
+	rules := make([]jujunetwork.IngressRule, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		rule, err := jujunetwork.NewIngressRule(portRange, sourceCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing security rules for %s", portRange)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -426,12 +499,17 @@ func instanceNetworkSecurityRulePrefix(id instance.Id) string {
 	return string(id) + "-"
 }
 
-// securityRuleName returns the security rule name for the given port range,
-// and prefix returned by instanceNetworkSecurityRulePrefix.
-func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
+// securityRuleName returns the security rule name for the given port range
+// and source CIDR, and prefix returned by instanceNetworkSecurityRulePrefix.
+// Rules open to any source are named for the port range alone.
+func securityRuleName(prefix string, ports jujunetwork.PortRange, cidr string) string {
 	ruleName := fmt.Sprintf("%s%s-%d", prefix, ports.Protocol, ports.FromPort)
 	if ports.FromPort != ports.ToPort {
 		ruleName += fmt.Sprintf("-%d", ports.ToPort)
 	}
+	if cidr != jujunetwork.OpenIngressCIDR {
+		hash := sha256.Sum256([]byte(cidr))
+		ruleName += fmt.Sprintf("-%x", hash[:4])
+	}
 	return ruleName
 }
@@ -484,6 +484,17 @@ func (v *azureVolumeSource) attachVolume(
 	return &volumeAttachment, true, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+func (v *azureVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	// Azure data disks are page blobs attached to a virtual machine,
+	// and cannot be grown while attached.
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Error = errors.NotSupportedf("resizing volume %s", p.VolumeId)
+	}
+	return results, nil
+}
+
 // DetachVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) DetachVolumes(attachParams []storage.VolumeAttachmentParams) ([]error, error) {
 	results := make([]error, len(attachParams))
@@ -37,6 +37,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
@@ -190,17 +191,19 @@ type OpStopInstances struct {
 }
 
 type OpOpenPorts struct {
-	Env        string
-	MachineId  string
-	InstanceId instance.Id
-	Ports      []network.PortRange
+	Env          string
+	MachineId    string
+	InstanceId   instance.Id
+	Ports        []network.PortRange
+	IngressRules []network.IngressRule
 }
 
 type OpClosePorts struct {
-	Env        string
-	MachineId  string
-	InstanceId instance.Id
-	Ports      []network.PortRange
+	Env          string
+	MachineId    string
+	InstanceId   instance.Id
+	Ports        []network.PortRange
+	IngressRules []network.IngressRule
 }
 
 type OpPutFile struct {
@@ -237,7 +240,7 @@ type environState struct {
 	maxId          int // maximum instance id allocated so far.
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
-	globalPorts    map[network.PortRange]bool
+	globalPorts    map[network.PortRange]set.Strings
 	bootstrapped   bool
 	apiListener    net.Listener
 	apiServer      *apiserver.Server
@@ -414,7 +417,7 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		ops:            ops,
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
-		globalPorts:    make(map[network.PortRange]bool),
+		globalPorts:    make(map[network.PortRange]set.Strings),
 		creator:        string(buf),
 	}
 	return s
@@ -712,7 +715,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	i := &dummyInstance{
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
-		ports:        make(map[network.PortRange]bool),
+		ports:        make(map[network.PortRange]set.Strings),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -772,6 +775,9 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 			if err != nil {
 				return err
 			}
+			if err := st.SetSecretConfigKey(testing.SecretConfigKey); err != nil {
+				return err
+			}
 			if err := st.SetModelConstraints(args.ModelConstraints); err != nil {
 				return err
 			}
@@ -966,7 +972,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	i := &dummyInstance{
 		id:           instance.Id(idString),
 		addresses:    addrs,
-		ports:        make(map[network.PortRange]bool),
+		ports:        make(map[network.PortRange]set.Strings),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1358,6 +1364,25 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.NewOpenIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.NewOpenIngressRules(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+var _ environs.IngressFirewaller = (*environ)(nil)
+
+// OpenIngressRules is specified in the environs.IngressFirewaller interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
@@ -1367,13 +1392,12 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		estate.globalPorts[p] = true
-	}
+	openIngressRules(estate.globalPorts, rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified in the environs.IngressFirewaller interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
@@ -1383,13 +1407,12 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		delete(estate.globalPorts, p)
-	}
+	closeIngressRules(estate.globalPorts, rules)
 	return nil
 }
 
-func (e *environ) Ports() (ports []network.PortRange, err error) {
+// IngressRules is specified in the environs.IngressFirewaller interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
 	}
@@ -1399,11 +1422,50 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for p := range estate.globalPorts {
-		ports = append(ports, p)
+	return ingressRules(estate.globalPorts), nil
+}
+
+// openIngressRules records the given rules as open in the map of
+// port ranges to the source CIDRs they are open to.
+func openIngressRules(open map[network.PortRange]set.Strings, rules []network.IngressRule) {
+	for _, rule := range rules {
+		cidrs, ok := open[rule.PortRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			open[rule.PortRange] = cidrs
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+}
+
+// closeIngressRules removes the given rules from the map of port
+// ranges to the source CIDRs they are open to.
+func closeIngressRules(open map[network.PortRange]set.Strings, rules []network.IngressRule) {
+	for _, rule := range rules {
+		cidrs, ok := open[rule.PortRange]
+		if !ok {
+			continue
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			cidrs.Remove(cidr)
+		}
+		if cidrs.IsEmpty() {
+			delete(open, rule.PortRange)
+		}
 	}
-	network.SortPortRanges(ports)
-	return
+}
+
+// ingressRules returns the rules recorded in the map of port ranges
+// to the source CIDRs they are open to.
+func ingressRules(open map[network.PortRange]set.Strings) []network.IngressRule {
+	var rules []network.IngressRule
+	for portRange, cidrs := range open {
+		rules = append(rules, network.MustNewIngressRule(portRange, cidrs.Values()...))
+	}
+	network.SortIngressRules(rules)
+	return rules
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1412,7 +1474,7 @@ func (*environ) Provider() environs.EnvironProvider {
 
 type dummyInstance struct {
 	state        *environState
-	ports        map[network.PortRange]bool
+	ports        map[network.PortRange]set.Strings
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1495,77 +1557,104 @@ func (inst *dummyInstance) Addresses() ([]network.Address, error) {
 }
 
 func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.openIngressRules("OpenPorts", machineId, network.NewOpenIngressRules(ports))
+}
+
+func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.closeIngressRules("ClosePorts", machineId, network.NewOpenIngressRules(ports))
+}
+
+func (inst *dummyInstance) Ports(machineId string) ([]network.PortRange, error) {
+	rules, err := inst.ingressRules("Ports", machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+var _ instance.InstanceIngressFirewaller = (*dummyInstance)(nil)
+
+// OpenIngressRules is specified in the instance.InstanceIngressFirewaller interface.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.openIngressRules("OpenIngressRules", machineId, rules)
+}
+
+// CloseIngressRules is specified in the instance.InstanceIngressFirewaller interface.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.closeIngressRules("CloseIngressRules", machineId, rules)
+}
+
+// IngressRules is specified in the instance.InstanceIngressFirewaller interface.
+func (inst *dummyInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.ingressRules("IngressRules", machineId)
+}
+
+func (inst *dummyInstance) openIngressRules(method, machineId string, rules []network.IngressRule) error {
 	defer delay()
-	logger.Infof("openPorts %s, %#v", machineId, ports)
+	logger.Infof("openPorts %s, %#v", machineId, rules)
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("OpenPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %q got %q", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("OpenPorts"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return err
 	}
 	inst.state.ops <- OpOpenPorts{
-		Env:        inst.state.name,
-		MachineId:  machineId,
-		InstanceId: inst.Id(),
-		Ports:      ports,
-	}
-	for _, p := range ports {
-		inst.ports[p] = true
+		Env:          inst.state.name,
+		MachineId:    machineId,
+		InstanceId:   inst.Id(),
+		Ports:        network.IngressRulePortRanges(rules),
+		IngressRules: rules,
 	}
+	openIngressRules(inst.ports, rules)
 	return nil
 }
 
-func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+func (inst *dummyInstance) closeIngressRules(method, machineId string, rules []network.IngressRule) error {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("ClosePorts with mismatched machine id, expected %s got %s", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %s got %s", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("ClosePorts"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return err
 	}
 	inst.state.ops <- OpClosePorts{
-		Env:        inst.state.name,
-		MachineId:  machineId,
-		InstanceId: inst.Id(),
-		Ports:      ports,
-	}
-	for _, p := range ports {
-		delete(inst.ports, p)
+		Env:          inst.state.name,
+		MachineId:    machineId,
+		InstanceId:   inst.Id(),
+		Ports:        network.IngressRulePortRanges(rules),
+		IngressRules: rules,
 	}
+	closeIngressRules(inst.ports, rules)
 	return nil
 }
 
-func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, err error) {
+func (inst *dummyInstance) ingressRules(method, machineId string) ([]network.IngressRule, error) {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("Ports with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %q got %q", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("Ports"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return nil, err
 	}
-	for p := range inst.ports {
-		ports = append(ports, p)
-	}
-	network.SortPortRanges(ports)
-	return
+	return ingressRules(inst.ports), nil
 }
 
 // providerDelay controls the delay before dummy responds.
@@ -156,6 +156,49 @@ func (s *suite) TestAvailabilityZone(c *gc.C) {
 	c.Check(hwc.AvailabilityZone, gc.IsNil)
 }
 
+func (s *suite) TestInstanceIngressRules(c *gc.C) {
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	inst, _ := jujutesting.AssertStartInstance(c, e, s.ControllerUUID, "0")
+	fw, ok := inst.(instance.InstanceIngressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	err := fw.OpenIngressRules("0", []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = inst.OpenPorts("0", []network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := fw.IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "0.0.0.0/0", "10.0.0.0/8", "192.168.1.0/24"),
+	})
+	ports, err := inst.Ports("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{22, 22, "tcp"}, {80, 80, "tcp"}})
+
+	// Closing a source CIDR leaves the others open.
+	err = fw.CloseIngressRules("0", []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = inst.ClosePorts("0", []network.PortRange{{80, 80, "tcp"}, {22, 22, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"),
+	})
+}
+
 func (s *suite) TestSupportsSpaces(c *gc.C) {
 	e := s.bootstrapTestEnviron(c)
 	defer func() {
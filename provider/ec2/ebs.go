@@ -4,14 +4,22 @@
 package ec2
 
 import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/set"
+	"gopkg.in/amz.v3/aws"
 	"gopkg.in/amz.v3/ec2"
 
 	"github.com/juju/juju/constraints"
@@ -227,6 +235,7 @@ func (e *ebsProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, e
 		env:       e.env,
 		envName:   environConfig.Name(),
 		modelUUID: environConfig.UUID(),
+		clock:     clock.WallClock,
 	}
 	return source, nil
 }
@@ -240,9 +249,12 @@ type ebsVolumeSource struct {
 	env       *environ
 	envName   string // non-unique, informational only
 	modelUUID string
+	clock     clock.Clock
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeImporter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -340,6 +352,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.env.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -831,6 +844,156 @@ func detachVolumes(client *ec2.EC2, attachParams []storage.VolumeAttachmentParam
 	return results, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := resizeVolume(v.env.ec2, v.clock, p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing EBS volume %s", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func resizeVolume(client *ec2.EC2, clock clock.Clock, p storage.VolumeResizeParams) (uint64, error) {
+	volume, err := describeVolume(client, p.VolumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	size := mibToGib(p.Size)
+	if size <= uint64(volume.Size) {
+		return 0, errors.Errorf("cannot shrink volume from %dGiB to %dGiB", volume.Size, size)
+	}
+	targetSize, err := modifyVolumeSize(client, clock, p.VolumeId, size)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return gibToMib(targetSize), nil
+}
+
+// modifyVolumeAPIVersion is the first version of the EC2 API that
+// supports the ModifyVolume action.
+const modifyVolumeAPIVersion = "2016-11-15"
+
+// modifyVolumeSize calls the EC2 ModifyVolume action, which the EC2
+// client does not support, to grow the volume with the given ID to
+// size GiB, and returns the target size of the modification. The
+// request is signed with the client's signer and credentials, and
+// dated by the given clock. The volume may be used at its new size
+// while the modification is optimizing. EC2 allows a volume to be
+// modified at most once every six hours.
+func modifyVolumeSize(client *ec2.EC2, clock clock.Clock, volumeId string, size uint64) (uint64, error) {
+	endpoint, err := url.Parse(client.Region.EC2Endpoint)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	endpoint.RawQuery = url.Values{
+		"Action":   {"ModifyVolume"},
+		"Version":  {modifyVolumeAPIVersion},
+		"VolumeId": {volumeId},
+		"Size":     {strconv.FormatUint(size, 10)},
+	}.Encode()
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	req.Header.Set("X-Amz-Date", clock.Now().UTC().Format(aws.ISO8601BasicFormat))
+	if err := client.Sign(req, client.Auth); err != nil {
+		return 0, errors.Annotate(err, "signing request")
+	}
+	resp, err := utils.GetValidatingHTTPClient().Do(req)
+	if err != nil {
+		return 0, errors.Annotate(err, "modifying volume")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Errors []struct {
+				Code    string
+				Message string
+			} `xml:"Errors>Error"`
+		}
+		if err := xml.NewDecoder(resp.Body).Decode(&errResp); err != nil || len(errResp.Errors) == 0 {
+			return 0, errors.Errorf("modifying volume: %s", resp.Status)
+		}
+		e := errResp.Errors[0]
+		return 0, errors.Errorf("modifying volume: %s (%s)", e.Message, e.Code)
+	}
+	var modifyResp struct {
+		TargetSize uint64 `xml:"volumeModification>targetSize"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&modifyResp); err != nil {
+		return 0, errors.Annotate(err, "decoding ModifyVolume response")
+	}
+	return modifyResp.TargetSize, nil
+}
+
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting EBS volume %s", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	description := fmt.Sprintf("snapshot %s of volume %s", p.Id, p.Volume.Id())
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, description)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotId := resp.Snapshot.Id
+
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = fmt.Sprintf("juju-%s-snapshot-%s", v.envName, strings.Replace(p.Id, "/", "-", -1))
+	if err := tagResources(v.env.ec2, resourceTags, snapshotId); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+
+	// AWS reports the snapshot's volume size in GiB.
+	sizeInGib, err := strconv.ParseUint(resp.Snapshot.VolumeSize, 10, 64)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing snapshot size %q", resp.Snapshot.VolumeSize)
+	}
+	return &storage.VolumeSnapshot{
+		SnapshotId: snapshotId,
+		Size:       gibToMib(sizeInGib),
+	}, nil
+}
+
+// ImportVolume is specified on the storage.VolumeImporter interface.
+func (v *ebsVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	volume, err := describeVolume(v.env.ec2, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "getting volume information")
+	}
+	if volume.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume with status %q", volume.Status,
+		)
+	}
+	if err := tagResources(v.env.ec2, resourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+	}
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(volume.Size)),
+		Persistent: true,
+	}, nil
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
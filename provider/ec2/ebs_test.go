@@ -5,15 +5,19 @@ package ec2_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strconv"
 	"time"
 
 	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"gopkg.in/amz.v3/aws"
 	awsec2 "gopkg.in/amz.v3/ec2"
 	"gopkg.in/amz.v3/ec2/ec2test"
 	gc "gopkg.in/check.v1"
@@ -414,6 +418,122 @@ func (s *ebsSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
 }
 
+func (s *ebsSuite) TestResizeVolumesShrink(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	results, err := vs.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     10240,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		"resizing EBS volume vol-0: cannot shrink volume from 10GiB to 10GiB")
+}
+
+func (s *ebsSuite) TestResizeVolumesNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	results, err := vs.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("42"),
+		VolumeId: "vol-42",
+		Size:     10240,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing EBS volume vol-42: vol-42 not found")
+}
+
+func newModifyVolumeClient(handler http.HandlerFunc) (*httptest.Server, *awsec2.EC2) {
+	server := httptest.NewServer(handler)
+	client := awsec2.New(
+		aws.Auth{AccessKey: "x", SecretKey: "x"},
+		aws.Region{Name: "test", EC2Endpoint: server.URL},
+		aws.SignV4Factory("test", "ec2"),
+	)
+	return server, client
+}
+
+func (s *ebsSuite) TestModifyVolumeSize(c *gc.C) {
+	server, client := newModifyVolumeClient(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.URL.Query().Get("Action"), gc.Equals, "ModifyVolume")
+		c.Check(req.URL.Query().Get("VolumeId"), gc.Equals, "vol-0")
+		c.Check(req.URL.Query().Get("Size"), gc.Equals, "20")
+		c.Check(req.Header.Get("Authorization"), gc.Matches, "AWS4-HMAC-SHA256 .*")
+		c.Check(req.Header.Get("X-Amz-Date"), gc.Equals, "20170401T120000Z")
+		fmt.Fprint(w, `<ModifyVolumeResponse>
+  <volumeModification>
+    <volumeId>vol-0</volumeId>
+    <modificationState>modifying</modificationState>
+    <targetSize>20</targetSize>
+    <originalSize>10</originalSize>
+  </volumeModification>
+</ModifyVolumeResponse>`)
+	})
+	defer server.Close()
+
+	clock := gitjujutesting.NewClock(time.Date(2017, 4, 1, 12, 0, 0, 0, time.UTC))
+	size, err := ec2.ModifyVolumeSize(client, clock, "vol-0", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, uint64(20))
+}
+
+func (s *ebsSuite) TestModifyVolumeSizeError(c *gc.C) {
+	server, client := newModifyVolumeClient(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<Response>
+  <Errors>
+    <Error>
+      <Code>VolumeModificationRateExceeded</Code>
+      <Message>You've reached the maximum modification rate per volume limit.</Message>
+    </Error>
+  </Errors>
+</Response>`)
+	})
+	defer server.Close()
+
+	_, err := ec2.ModifyVolumeSize(client, clock.WallClock, "vol-0", 20)
+	c.Assert(err, gc.ErrorMatches,
+		`modifying volume: You've reached the maximum modification rate per volume limit. \(VolumeModificationRateExceeded\)`)
+}
+
+func (s *ebsSuite) TestImportVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
+
+	ec2Client := ec2.StorageEC2(vs)
+	resp, err := ec2Client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := vs.(storage.VolumeImporter).ImportVolume(resp.Id, map[string]string{
+		"juju-model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   resp.Id,
+		Size:       1024,
+		Persistent: true,
+	})
+
+	ec2Vols, err := ec2Client.Volumes([]string{resp.Id}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"juju-model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	})
+}
+
+func (s *ebsSuite) TestImportVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, err := vs.(storage.VolumeImporter).ImportVolume("vol-42", nil)
+	c.Assert(err, gc.ErrorMatches, "getting volume information: vol-42 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *ebsSuite) TestListVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
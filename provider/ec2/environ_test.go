@@ -23,6 +23,7 @@ var (
 	_ simplestreams.HasRegion    = (*environ)(nil)
 	_ state.Prechecker           = (*environ)(nil)
 	_ instance.Distributor       = (*environ)(nil)
+	_ environs.IngressFirewaller = (*environ)(nil)
 )
 
 type Suite struct{}
@@ -167,3 +168,21 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
+	ipperms := rulesToIPPerms([]network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+	})
+	c.Assert(ipperms, gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  53,
+		ToPort:    53,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}})
+}
@@ -19,6 +19,8 @@ import (
 	jujustorage "github.com/juju/juju/storage"
 )
 
+var ModifyVolumeSize = modifyVolumeSize
+
 func StorageEC2(vs jujustorage.VolumeSource) *ec2.EC2 {
 	return vs.(*ebsVolumeSource).env.ec2
 }
@@ -736,6 +736,36 @@ func (t *localServerSuite) TestInstanceStatus(c *gc.C) {
 	c.Assert(inst.Status().Message, gc.Equals, "terminated")
 }
 
+func (t *localServerSuite) TestInstanceIngressRules(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	fw, ok := inst.(instance.InstanceIngressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	err := fw.OpenIngressRules("1", []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := fw.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	err = fw.CloseIngressRules("1", []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "192.168.1.0/24"),
+	})
+}
+
 func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 	_, hc := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
@@ -70,6 +70,8 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -205,6 +207,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SourceSnapshot:     p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -406,6 +409,77 @@ func (v *volumeSource) DetachVolumes(attachParams []storage.VolumeAttachmentPara
 	return result, nil
 }
 
+func (v *volumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing disk %s", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(p storage.VolumeResizeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	sizeGB := mibToGib(p.Size)
+	if sizeGB <= mibToGib(disk.Size) {
+		return 0, errors.Errorf(
+			"cannot shrink disk from %dGiB to %dGiB",
+			mibToGib(disk.Size), sizeGB,
+		)
+	}
+	if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return sizeGB * 1024, nil
+}
+
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (v *volumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting disk %s", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	// Snapshots are global rather than zonal, so there is
+	// no need to incorporate the zone as we do for volumes.
+	snapshotName := "snapshot-" + snapshotUUID.String()
+	snapshot, err := v.gce.CreateSnapshot(zone, p.VolumeId, snapshotName, v.modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		SnapshotId: snapshot.Name,
+		Size:       snapshot.SizeGB * 1024,
+	}, nil
+}
+
 func (v *volumeSource) detachOneVolume(attachParam storage.VolumeAttachmentParams) error {
 	instId := attachParam.InstanceId
 	volumeName := attachParam.VolumeId
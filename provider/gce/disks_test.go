@@ -4,6 +4,7 @@
 package gce_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -179,6 +180,81 @@ func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, "a--volume-name")
 }
 
+func (s *volumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	s.FakeConn.GoogleSnapshot = &google.Snapshot{
+		Name:   "snapshot-foo",
+		SizeGB: 10,
+	}
+	results, err := s.source.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "a--volume-name",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "snapshot-foo",
+			Size:       10 * 1024,
+		},
+	}})
+
+	snapshotCalled, call := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(snapshotCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "a")
+	c.Assert(call[0].VolumeName, gc.Equals, "a--volume-name")
+	c.Assert(call[0].SnapshotName, gc.Matches, "snapshot-.*")
+}
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	results, err := s.source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     1025,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		Size: 2048,
+	}})
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, s.BaseDisk.Name)
+	c.Assert(call[0].SizeGB, gc.Equals, uint64(2))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesShrink(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	results, err := s.source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     512,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing disk .*: cannot shrink disk from 1GiB to 1GiB")
+
+	resizeCalled, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesError(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.Err = errors.New("<unknown>")
+	s.FakeConn.FailOnCall = 1
+	results, err := s.source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing disk .*: <unknown>")
+}
+
 func (s *volumeSourceSuite) TestListVolumes(c *gc.C) {
 	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
 	s.FakeConn.Zones = []google.AvailabilityZone{google.NewZone("home-zone", "Ready", "", "")}
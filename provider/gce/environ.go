@@ -32,6 +32,9 @@ type gceConnection interface {
 	Ports(fwname string) ([]network.PortRange, error)
 	OpenPorts(fwname string, ports ...network.PortRange) error
 	ClosePorts(fwname string, ports ...network.PortRange) error
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenIngressRules(fwname string, rules ...network.IngressRule) error
+	CloseIngressRules(fwname string, rules ...network.IngressRule) error
 
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
@@ -45,6 +48,12 @@ type gceConnection interface {
 	// Disk will return a Disk representing the disk identified by the
 	// passed <name> or error.
 	Disk(zone, id string) (*google.Disk, error)
+	// CreateSnapshot will create a snapshot named <snapshotName> of the
+	// disk identified by <diskName> in the passed <zone>.
+	CreateSnapshot(zone, diskName, snapshotName, description string) (*google.Snapshot, error)
+	// ResizeDisk will grow the disk identified by <name> in <zone>
+	// to <sizeGB> GiB.
+	ResizeDisk(zone, name string, sizeGB uint64) error
 	// RemoveDisk will destroy the disk identified by <name> in <zone>.
 	RemoveDisk(zone, id string) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
@@ -205,13 +214,13 @@ func (env *environ) Bootstrap(ctx environs.BootstrapContext, params environs.Boo
 // Destroy shuts down all known machines and destroys the rest of the
 // known environment.
 func (env *environ) Destroy() error {
-	ports, err := env.Ports()
+	rules, err := env.IngressRules()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	if len(ports) > 0 {
-		if err := env.ClosePorts(ports); err != nil {
+	if len(rules) > 0 {
+		if err := env.CloseIngressRules(rules); err != nil {
 			return errors.Trace(err)
 		}
 	}
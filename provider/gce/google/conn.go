@@ -4,6 +4,8 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
 )
@@ -35,6 +37,10 @@ type rawConnectionWrapper interface {
 	// the named firewall and returns it. If the firewall is not found,
 	// errors.NotFound is returned.
 	GetFirewall(projectID, name string) (*compute.Firewall, error)
+	// GetFirewalls sends an API request to GCE for the information
+	// about all firewalls whose names match the provided regular
+	// expression and returns them. If none match, the list is empty.
+	GetFirewalls(projectID, namePattern string) ([]*compute.Firewall, error)
 	// AddFirewall requests GCE to add a firewall with the provided info.
 	// If the firewall already exists then an error will be returned.
 	// The call blocks until the firewall is added or the request fails.
@@ -70,6 +76,13 @@ type rawConnectionWrapper interface {
 	// InstanceDisks returns the disks attached to the instance identified
 	// by instanceId
 	InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot of the disk identified by
+	// diskName, as specified in spec.
+	CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error
+	// GetSnapshot will return the snapshot with the given name.
+	GetSnapshot(project, name string) (*compute.Snapshot, error)
+	// ResizeDisk will grow the disk identified by name to sizeGb GiB.
+	ResizeDisk(project, zone, name string, sizeGb int64) error
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -94,20 +107,20 @@ type Connection struct {
 // result in an error. All errors that happen while authenticating and
 // connecting are returned by Connect.
 func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error) {
-	raw, err := newRawConnection(creds)
+	service, client, err := newRawConnection(creds)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	conn := &Connection{
-		raw:       &rawConn{raw},
+		raw:       &rawConn{Service: service, client: client},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
 	}
 	return conn, nil
 }
 
-var newRawConnection = func(creds *Credentials) (*compute.Service, error) {
+var newRawConnection = func(creds *Credentials) (*compute.Service, *http.Client, error) {
 	return newConnection(creds)
 }
 
@@ -31,6 +31,33 @@ func (gce *Connection) createDisk(zone string, disk *compute.Disk) error {
 	return gce.raw.CreateDisk(gce.projectID, zone, disk)
 }
 
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, diskName, snapshotName, description string) (*Snapshot, error) {
+	spec := &compute.Snapshot{
+		Name:        snapshotName,
+		Description: description,
+	}
+	if err := gce.raw.CreateSnapshot(gce.projectID, zone, diskName, spec); err != nil {
+		return nil, errors.Annotatef(err, "cannot create snapshot %q", snapshotName)
+	}
+	snapshot, err := gce.raw.GetSnapshot(gce.projectID, snapshotName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q", snapshotName)
+	}
+	return &Snapshot{
+		Name:   snapshot.Name,
+		SizeGB: uint64(snapshot.DiskSizeGb),
+	}, nil
+}
+
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGB uint64) error {
+	if err := gce.raw.ResizeDisk(gce.projectID, zone, name, int64(sizeGB)); err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+	}
+	return nil
+}
+
 // Disks implements storage section of gceConnection.
 func (gce *Connection) Disks(zone string) ([]*Disk, error) {
 	computeDisks, err := gce.raw.ListDisks(gce.projectID, zone)
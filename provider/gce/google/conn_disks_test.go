@@ -4,6 +4,7 @@
 package google_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
@@ -40,6 +41,51 @@ func (s *connSuite) TestConnectionCreateDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ComputeDisk.Name, gc.Equals, fakeVolName)
 }
 
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{
+		Name:       "snap",
+		DiskSizeGb: 10,
+	}
+
+	snapshot, err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "snap", "a snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, &google.Snapshot{
+		Name:   "snap",
+		SizeGB: 10,
+	})
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot, jc.DeepEquals, &compute.Snapshot{
+		Name:        "snap",
+		Description: "a snapshot",
+	})
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetSnapshot")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "snap")
+}
+
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGb, gc.Equals, int64(20))
+}
+
+func (s *connSuite) TestConnectionResizeDiskError(c *gc.C) {
+	s.FakeConn.Err = errors.New("<unknown>")
+
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Assert(err, gc.ErrorMatches, `cannot resize disk ".*" in zone "home-zone": <unknown>`)
+}
+
 func (s *connSuite) TestConnectionDisks(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
@@ -4,7 +4,15 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -111,3 +119,251 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 	return nil
 }
+
+// ingressFirewallName returns the name of the firewall holding the
+// port ranges opened to the given sorted source CIDRs for the named
+// firewall. GCE applies a firewall's source ranges to all of its
+// allowed ports, so each distinct set of source CIDRs requires its
+// own firewall. Port ranges open to any source are held in the named
+// firewall itself, as with OpenPorts.
+func ingressFirewallName(fwname string, sourceCIDRs []string) string {
+	if len(sourceCIDRs) == 1 && sourceCIDRs[0] == network.OpenIngressCIDR {
+		return fwname
+	}
+	hash := sha256.Sum256([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s-%x", fwname, hash[:4])
+}
+
+// ingressFirewallsPattern returns a regular expression matching the
+// names of all the firewalls holding ingress rules for the named
+// firewall.
+func ingressFirewallsPattern(fwname string) string {
+	return regexp.QuoteMeta(fwname) + "(-[0-9a-f]{8})?"
+}
+
+// ingressPorts maps each opened port range to its source CIDRs.
+type ingressPorts map[network.PortRange]set.Strings
+
+// firewallPortRanges returns the port ranges allowed by the firewall.
+func firewallPortRanges(firewall *compute.Firewall) ([]network.PortRange, error) {
+	var ports []network.PortRange
+	for _, allowed := range firewall.Allowed {
+		for _, portRangeStr := range allowed.Ports {
+			portRange, err := network.ParsePortRange(portRangeStr)
+			if err != nil {
+				return nil, errors.Annotate(err, "bad ports from GCE")
+			}
+			portRange.Protocol = allowed.IPProtocol
+			ports = append(ports, portRange)
+		}
+	}
+	return ports, nil
+}
+
+// ingressFirewalls returns the firewalls holding ingress rules for
+// the named firewall, keyed by name, along with the ingress ports
+// they allow.
+func (gce Connection) ingressFirewalls(fwname string) (map[string]*compute.Firewall, ingressPorts, error) {
+	firewalls, err := gce.raw.GetFirewalls(gce.projectID, ingressFirewallsPattern(fwname))
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+	byName := make(map[string]*compute.Firewall)
+	ports := make(ingressPorts)
+	for _, firewall := range firewalls {
+		portRanges, err := firewallPortRanges(firewall)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		byName[firewall.Name] = firewall
+		for _, portRange := range portRanges {
+			if _, ok := ports[portRange]; !ok {
+				ports[portRange] = set.NewStrings()
+			}
+			for _, cidr := range firewall.SourceRanges {
+				ports[portRange].Add(cidr)
+			}
+		}
+	}
+	return byName, ports, nil
+}
+
+// IngressRules builds a list of all ingress rules for a given
+// firewall name (within the Connection's project) and returns it,
+// sorted by network.SortIngressRules. If there are no such rules
+// then the list will be empty and no error is returned.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	_, ports, err := gce.ingressFirewalls(fwname)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.IngressRule
+	for portRange, cidrs := range ports {
+		rule, err := network.NewIngressRule(portRange, cidrs.Values()...)
+		if err != nil {
+			return nil, errors.Annotate(err, "bad ingress rule from GCE")
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules for the named firewall. Rules are grouped into one
+// firewall for each distinct set of source CIDRs; firewalls are
+// created, updated and removed as needed. The call blocks until the
+// rules are opened or a request fails.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	err := gce.updateIngressRules(fwname, func(ports ingressPorts) {
+		for _, rule := range rules {
+			if _, ok := ports[rule.PortRange]; !ok {
+				ports[rule.PortRange] = set.NewStrings()
+			}
+			for _, cidr := range rule.SourceCIDRs {
+				ports[rule.PortRange].Add(cidr)
+			}
+		}
+	})
+	return errors.Annotatef(err, "opening ingress rules %v", rules)
+}
+
+// CloseIngressRules sends requests to the GCE API to close the
+// provided ingress rules for the named firewall. Firewalls left with
+// no ports are removed. The call blocks until the rules are closed or
+// a request fails.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	err := gce.updateIngressRules(fwname, func(ports ingressPorts) {
+		for _, rule := range rules {
+			cidrs, ok := ports[rule.PortRange]
+			if !ok {
+				continue
+			}
+			for _, cidr := range rule.SourceCIDRs {
+				cidrs.Remove(cidr)
+			}
+		}
+	})
+	return errors.Annotatef(err, "closing ingress rules %v", rules)
+}
+
+// updateIngressRules applies the given change to the ingress ports of
+// the named firewall, then brings the firewalls in GCE in line with
+// the result.
+func (gce Connection) updateIngressRules(fwname string, change func(ingressPorts)) error {
+	current, ports, err := gce.ingressFirewalls(fwname)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	change(ports)
+
+	// Group the port ranges by their source CIDRs.
+	wanted := make(map[string]*compute.Firewall)
+	for portRange, cidrs := range ports {
+		if cidrs.IsEmpty() {
+			continue
+		}
+		sourceCIDRs := cidrs.SortedValues()
+		name := ingressFirewallName(fwname, sourceCIDRs)
+		firewall, ok := wanted[name]
+		if !ok {
+			firewall = &compute.Firewall{
+				Name:         name,
+				TargetTags:   []string{fwname},
+				SourceRanges: sourceCIDRs,
+			}
+			wanted[name] = firewall
+		}
+		addFirewallPortRange(firewall, portRange)
+	}
+
+	var names []string
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		firewall := wanted[name]
+		sortFirewallPorts(firewall)
+		existing, ok := current[name]
+		if !ok {
+			if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		if sameFirewallPorts(existing, firewall) {
+			continue
+		}
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for name := range current {
+		if _, ok := wanted[name]; ok {
+			continue
+		}
+		if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// addFirewallPortRange adds the port range to the firewall's allowed
+// ports, keeping it as a range rather than expanding it.
+func addFirewallPortRange(firewall *compute.Firewall, portRange network.PortRange) {
+	portStr := fmt.Sprint(portRange.FromPort)
+	if portRange.FromPort != portRange.ToPort {
+		portStr = fmt.Sprintf("%d-%d", portRange.FromPort, portRange.ToPort)
+	}
+	for _, allowed := range firewall.Allowed {
+		if allowed.IPProtocol == portRange.Protocol {
+			allowed.Ports = append(allowed.Ports, portStr)
+			return
+		}
+	}
+	firewall.Allowed = append(firewall.Allowed, &compute.FirewallAllowed{
+		IPProtocol: portRange.Protocol,
+		Ports:      []string{portStr},
+	})
+}
+
+// sortFirewallPorts sorts the firewall's allowed ports by protocol
+// and port range, so that firewalls may be compared.
+func sortFirewallPorts(firewall *compute.Firewall) {
+	sort.Sort(firewallAllowedSlice(firewall.Allowed))
+	for _, allowed := range firewall.Allowed {
+		sort.Strings(allowed.Ports)
+	}
+}
+
+type firewallAllowedSlice []*compute.FirewallAllowed
+
+func (s firewallAllowedSlice) Len() int           { return len(s) }
+func (s firewallAllowedSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s firewallAllowedSlice) Less(i, j int) bool { return s[i].IPProtocol < s[j].IPProtocol }
+
+// sameFirewallPorts reports whether the two firewalls allow the same
+// port ranges.
+func sameFirewallPorts(a, b *compute.Firewall) bool {
+	aPorts, err := firewallPortRanges(a)
+	if err != nil {
+		return false
+	}
+	bPorts, err := firewallPortRanges(b)
+	if err != nil {
+		return false
+	}
+	if len(aPorts) != len(bPorts) {
+		return false
+	}
+	network.SortPortRanges(aPorts)
+	network.SortPortRanges(bPorts)
+	for i := range aPorts {
+		if aPorts[i] != bPorts[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -114,6 +114,9 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be created, if any.
+	SourceSnapshot string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -175,13 +178,26 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SourceSnapshot != "" {
+		disk.SourceSnapshot = "global/snapshots/" + ds.SourceSnapshot
+	}
+	return disk, nil
+}
+
+// Snapshot represents a gce disk snapshot.
+type Snapshot struct {
+	// Name is the unique name of the snapshot.
+	Name string
+	// SizeGB is the size of the disk from which the snapshot
+	// was taken, in GiB.
+	SizeGB uint64
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
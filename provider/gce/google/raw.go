@@ -4,6 +4,8 @@
 package google
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"path"
@@ -39,6 +41,10 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// client is the HTTP client used by the compute service. It is
+	// used directly for API calls that the service does not support.
+	client *http.Client
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -129,6 +135,16 @@ func (rc *rawConn) GetFirewall(projectID, name string) (*compute.Firewall, error
 	return firewallList.Items[0], nil
 }
 
+func (rc *rawConn) GetFirewalls(projectID, namePattern string) ([]*compute.Firewall, error) {
+	call := rc.Firewalls.List(projectID)
+	call = call.Filter("name eq " + namePattern)
+	firewallList, err := call.Do()
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting firewalls from GCE")
+	}
+	return firewallList.Items, nil
+}
+
 func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := rc.Firewalls.Insert(projectID, firewall)
 	operation, err := call.Do()
@@ -249,6 +265,64 @@ func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return disk, nil
 }
 
+func (rc *rawConn) CreateSnapshot(project, zone, diskName string, spec *compute.Snapshot) error {
+	call := rc.Disks.CreateSnapshot(project, zone, diskName, spec)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "cannot snapshot disk %q", diskName)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+func (rc *rawConn) GetSnapshot(project, name string) (*compute.Snapshot, error) {
+	call := rc.Snapshots.Get(project, name)
+	snapshot, err := call.Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q in project %q", name, project)
+	}
+	return snapshot, nil
+}
+
+// ResizeDisk calls the disks.resize method of the GCE API, which the
+// version of the compute client we depend on does not support, and
+// waits for the resulting operation to complete.
+func (rc *rawConn) ResizeDisk(project, zone, name string, sizeGb int64) error {
+	body, err := json.Marshal(diskResizeRequest{SizeGb: sizeGb})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	urls := googleapi.ResolveRelative(rc.BasePath, "{project}/zones/{zone}/disks/{disk}/resize")
+	req, err := http.NewRequest("POST", urls, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	googleapi.Expand(req.URL, map[string]string{
+		"project": project,
+		"zone":    zone,
+		"disk":    name,
+	})
+	req.Header.Set("Content-Type", "application/json")
+	res, err := rc.client.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q", name)
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return errors.Annotatef(convertRawAPIError(err), "cannot resize disk %q", name)
+	}
+	var op compute.Operation
+	if err := json.NewDecoder(res.Body).Decode(&op); err != nil {
+		return errors.Annotatef(err, "cannot decode resize operation for disk %q", name)
+	}
+	return errors.Trace(rc.waitOperation(project, &op, attemptsLong))
+}
+
+// diskResizeRequest is the body of a disks.resize request. The
+// API encodes int64 values as strings.
+type diskResizeRequest struct {
+	SizeGb int64 `json:"sizeGb,string"`
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
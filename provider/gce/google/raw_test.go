@@ -4,6 +4,11 @@
 package google
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -36,7 +41,7 @@ func (s *rawConnSuite) SetUpTest(c *gc.C) {
 	service.ZoneOperations = compute.NewZoneOperationsService(service)
 	service.RegionOperations = compute.NewRegionOperationsService(service)
 	service.GlobalOperations = compute.NewGlobalOperationsService(service)
-	s.rawConn = &rawConn{service}
+	s.rawConn = &rawConn{Service: service}
 	s.strategy.Min = 4
 
 	s.callCount = 0
@@ -149,3 +154,39 @@ func (s *rawConnSuite) TestConnectionWaitOperationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.* "testing-wait-operation-error" .*`)
 	c.Check(s.callCount, gc.Equals, 1)
 }
+
+func (s *rawConnSuite) TestResizeDisk(c *gc.C) {
+	var (
+		method, path string
+		body         map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		data, err := ioutil.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(json.Unmarshal(data, &body), jc.ErrorIsNil)
+		json.NewEncoder(w).Encode(s.op)
+	}))
+	defer server.Close()
+	s.rawConn.BasePath = server.URL + "/"
+	s.rawConn.client = http.DefaultClient
+
+	err := s.rawConn.ResizeDisk("proj", "a-zone", "a-disk", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(method, gc.Equals, "POST")
+	c.Check(path, gc.Equals, "/proj/zones/a-zone/disks/a-disk/resize")
+	c.Check(body, jc.DeepEquals, map[string]interface{}{"sizeGb": "20"})
+}
+
+func (s *rawConnSuite) TestResizeDiskNotFound(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 404, "message": "disk not found"}}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+	s.rawConn.BasePath = server.URL + "/"
+	s.rawConn.client = http.DefaultClient
+
+	err := s.rawConn.ResizeDisk("proj", "a-zone", "a-disk", 20)
+	c.Assert(err, gc.ErrorMatches, `cannot resize disk "a-disk": .*disk not found.*`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -125,6 +125,8 @@ type BaseSuiteUnpatched struct {
 var _ environs.Environ = (*environ)(nil)
 var _ simplestreams.HasRegion = (*environ)(nil)
 var _ instance.Instance = (*environInstance)(nil)
+var _ environs.IngressFirewaller = (*environ)(nil)
+var _ instance.InstanceIngressFirewaller = (*environInstance)(nil)
 
 func (s *BaseSuiteUnpatched) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
@@ -464,11 +466,14 @@ type fakeConnCall struct {
 	InstanceSpec google.InstanceSpec
 	FirewallName string
 	PortRanges   []network.PortRange
+	Rules        []network.IngressRule
 	Region       string
 	Disks        []google.DiskSpec
 	VolumeName   string
 	InstanceId   string
 	Mode         string
+	SnapshotName string
+	SizeGB       uint64
 }
 
 type fakeConn struct {
@@ -477,12 +482,14 @@ type fakeConn struct {
 	Inst       *google.Instance
 	Insts      []google.Instance
 	PortRanges []network.PortRange
+	Rules      []network.IngressRule
 	Zones      []google.AvailabilityZone
 
-	GoogleDisks   []*google.Disk
-	GoogleDisk    *google.Disk
-	AttachedDisk  *google.AttachedDisk
-	AttachedDisks []*google.AttachedDisk
+	GoogleDisks    []*google.Disk
+	GoogleDisk     *google.Disk
+	AttachedDisk   *google.AttachedDisk
+	AttachedDisks  []*google.AttachedDisk
+	GoogleSnapshot *google.Snapshot
 
 	Err        error
 	FailOnCall int
@@ -564,6 +571,32 @@ func (fc *fakeConn) ClosePorts(fwname string, ports ...network.PortRange) error
 	return fc.err()
 }
 
+func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "IngressRules",
+		FirewallName: fwname,
+	})
+	return fc.Rules, fc.err()
+}
+
+func (fc *fakeConn) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenIngressRules",
+		FirewallName: fwname,
+		Rules:        rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseIngressRules",
+		FirewallName: fwname,
+		Rules:        rules,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -581,6 +614,26 @@ func (fc *fakeConn) CreateDisks(zone string, disks []google.DiskSpec) ([]*google
 	return fc.GoogleDisks, fc.err()
 }
 
+func (fc *fakeConn) CreateSnapshot(zone, diskName, snapshotName, description string) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CreateSnapshot",
+		ZoneName:     zone,
+		VolumeName:   diskName,
+		SnapshotName: snapshotName,
+	})
+	return fc.GoogleSnapshot, fc.err()
+}
+
+func (fc *fakeConn) ResizeDisk(zone, name string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "ResizeDisk",
+		ZoneName:   zone,
+		VolumeName: name,
+		SizeGB:     sizeGB,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) Disks(zone string) ([]*google.Disk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "Disks",
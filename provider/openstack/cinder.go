@@ -4,8 +4,14 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,10 +32,12 @@ const (
 	// you'd like Cinder to automatically assign a mount point.
 	autoAssignedMountPoint = ""
 
-	volumeStatusAvailable = "available"
-	volumeStatusDeleting  = "deleting"
-	volumeStatusError     = "error"
-	volumeStatusInUse     = "in-use"
+	volumeStatusAvailable      = "available"
+	volumeStatusDeleting       = "deleting"
+	volumeStatusError          = "error"
+	volumeStatusErrorExtending = "error_extending"
+	volumeStatusExtending      = "extending"
+	volumeStatusInUse          = "in-use"
 )
 
 // StorageProviderTypes implements storage.ProviderRegistry.
@@ -70,14 +78,19 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	endpointUrl := env.volumeURL
 	authClient := env.client
 	envNovaClient := env.novaUnlocked
+	verify := utils.VerifySSLHostnames
+	if !env.ecfgUnlocked.SSLHostnameVerification() {
+		verify = utils.NoVerifySSLHostnames
+	}
 	env.ecfgMutex.Unlock()
 
 	if endpointUrl == nil {
 		return nil, errors.NotSupportedf("volumes")
 	}
 
+	httpClient := utils.GetHTTPClient(verify)
 	return &openstackStorageAdapter{
-		cinderClient{cinder.Basic(endpointUrl, authClient.TenantId(), authClient.Token)},
+		newCinderClient(endpointUrl, authClient.TenantId(), authClient.Token, httpClient),
 		novaClient{envNovaClient},
 	}, nil
 }
@@ -151,6 +164,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -179,6 +193,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -409,6 +424,75 @@ func (s *cinderVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams
 	return detachVolumes(s.storageAdapter, args)
 }
 
+// ResizeVolumes implements storage.VolumeSource.
+func (s *cinderVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := s.resizeVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing cinder volume %s", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(p storage.VolumeResizeParams) (uint64, error) {
+	volume, err := s.storageAdapter.GetVolume(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotate(err, "getting volume")
+	}
+	// Cinder sizes volumes in GiB.
+	newSize := int(math.Ceil(float64(p.Size) / 1024))
+	if newSize <= volume.Size {
+		return 0, errors.Errorf("cannot shrink volume from %dGiB to %dGiB", volume.Size, newSize)
+	}
+	// Depending on the Cinder release, a volume may have to be
+	// detached before it can be extended; Cinder reports that.
+	if err := s.storageAdapter.ExtendVolume(p.VolumeId, newSize); err != nil {
+		return 0, errors.Trace(err)
+	}
+	volume, err = waitVolume(s.storageAdapter, p.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusExtending:
+			return false, nil
+		case volumeStatusErrorExtending:
+			return false, errors.New("volume could not be extended")
+		}
+		return true, nil
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "waiting for volume to be extended")
+	}
+	return uint64(volume.Size * 1024), nil
+}
+
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId:    p.VolumeId,
+			Name:        fmt.Sprintf("juju-%s-snapshot-%s", s.envName, strings.Replace(p.Id, "/", "-", -1)),
+			Description: fmt.Sprintf("snapshot of volume %s", p.Volume.Id()),
+			// Cinder refuses to snapshot in-use volumes
+			// unless forced to.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting cinder volume %s", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = &storage.VolumeSnapshot{
+			SnapshotId: snapshot.ID,
+			// Cinder reports sizes in GiB.
+			Size: uint64(snapshot.Size * 1024),
+		}
+	}
+	return results, nil
+}
+
 func detachVolumes(storageAdapter OpenstackStorage, args []storage.VolumeAttachmentParams) ([]error, error) {
 	results := make([]error, len(args))
 	for i, arg := range args {
@@ -470,6 +554,8 @@ type OpenstackStorage interface {
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -497,6 +583,57 @@ type openstackStorageAdapter struct {
 
 type cinderClient struct {
 	*cinder.Client
+
+	// endpoint, tenantId and handleRequest are used to make the
+	// requests that the goose client does not support.
+	endpoint      *url.URL
+	tenantId      string
+	handleRequest cinder.RequestHandlerFn
+}
+
+// newCinderClient returns a cinderClient which sends all its requests,
+// including those the goose client does not support, with the given
+// HTTP client, authorized with the given token.
+func newCinderClient(endpoint *url.URL, tenantId string, token func() string, httpClient *http.Client) cinderClient {
+	handleRequest := cinder.SetAuthHeaderFn(token, httpClient.Do)
+	return cinderClient{
+		Client:        cinder.NewClient(tenantId, endpoint, handleRequest),
+		endpoint:      endpoint,
+		tenantId:      tenantId,
+		handleRequest: handleRequest,
+	}
+}
+
+// extendVolume requests the Cinder "os-extend" volume action, which
+// grows the volume to newSize GiB. Like the goose client, it sends the
+// request to the host of the endpoint, with the path of the v2 API.
+func (c cinderClient) extendVolume(volumeId string, newSize int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	actionURL := url.URL{
+		Scheme: c.endpoint.Scheme,
+		Host:   c.endpoint.Host,
+		Path:   fmt.Sprintf("/v2/%s/volumes/%s/action", c.tenantId, volumeId),
+	}
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.handleRequest(req)
+	if err != nil {
+		return errors.Annotate(err, "extending volume")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("extending volume: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
 }
 
 type novaClient struct {
@@ -521,6 +658,20 @@ func (ga *openstackStorageAdapter) GetVolumesDetail() ([]cinder.Volume, error) {
 	return resp.Volumes, nil
 }
 
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, newSize int) error {
+	return ga.cinderClient.extendVolume(volumeId, newSize)
+}
+
 // GetVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
 	resp, err := ga.cinderClient.GetVolume(volumeId)
@@ -4,7 +4,12 @@
 package openstack_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"time"
 
 	"github.com/juju/errors"
@@ -355,6 +360,136 @@ func (s *cinderVolumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	}})
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				VolumeId:    mockVolId,
+				Name:        "juju-testenv-snapshot-0",
+				Description: "snapshot of volume 123",
+				Force:       true,
+			})
+			return &cinder.Snapshot{ID: "snap-0", Size: 2}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "snap-0",
+			Size:       2048,
+		},
+	}})
+	mockAdapter.CheckCallNames(c, "CreateSnapshot")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	size := 1
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: size, Status: "available"}, nil
+		},
+		extendVolume: func(volumeId string, newSize int) error {
+			size = newSize
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"ExtendVolume", []interface{}{mockVolId, 2}},
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesShrink(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: 2, Status: "in-use"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`resizing cinder volume 0: cannot shrink volume from 2GiB to 1GiB`)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesExtendError(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: 1, Status: "error_extending"}, nil
+		},
+		extendVolume: func(string, int) error { return nil },
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`resizing cinder volume 0: waiting for volume to be extended: volume could not be extended`)
+}
+
+func (s *cinderVolumeSourceSuite) TestExtendCinderVolume(c *gc.C) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/v2/tenant/volumes/vol-0/action")
+		c.Check(req.Header.Get("X-Auth-Token"), gc.Equals, "token")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		var action map[string]map[string]int
+		c.Check(json.Unmarshal(body, &action), jc.ErrorIsNil)
+		c.Check(action, jc.DeepEquals, map[string]map[string]int{
+			"os-extend": {"new_size": 3},
+		})
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	endpoint, err := url.Parse(server.URL + "/v2/tenant")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = openstack.ExtendCinderVolume(endpoint, "tenant", "token", "vol-0", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cinderVolumeSourceSuite) TestExtendCinderVolumeError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("volume is in-use\n"))
+	}))
+	defer server.Close()
+	endpoint, err := url.Parse(server.URL + "/v2/tenant")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = openstack.ExtendCinderVolume(endpoint, "tenant", "token", "vol-0", 3)
+	c.Assert(err, gc.ErrorMatches, "extending volume: 400 Bad Request: volume is in-use")
+}
+
 func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	var numCreateCalls, numDestroyCalls, numGetCalls int
 	mockAdapter := &mockAdapter{
@@ -442,6 +577,8 @@ type mockAdapter struct {
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -479,6 +616,22 @@ func (ma *mockAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cind
 	return nil, errors.NotImplementedf("CreateVolume")
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return errors.NotImplementedf("ExtendVolume")
+}
+
 func (ma *mockAdapter) AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error) {
 	ma.MethodCall(ma, "AttachVolume", serverId, volumeId, mountPoint)
 	if ma.attachVolume != nil {
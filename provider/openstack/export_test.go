@@ -6,6 +6,8 @@ package openstack
 import (
 	"bytes"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 	"text/template"
 
@@ -74,6 +76,13 @@ var (
 	NewOpenstackStorage         = &newOpenstackStorage
 )
 
+// ExtendCinderVolume requests the Cinder "os-extend" action at the
+// given endpoint.
+func ExtendCinderVolume(endpoint *url.URL, tenantId, token, volumeId string, newSize int) error {
+	client := newCinderClient(endpoint, tenantId, func() string { return token }, &http.Client{})
+	return client.extendVolume(volumeId, newSize)
+}
+
 func NewCinderVolumeSource(s OpenstackStorage) storage.VolumeSource {
 	const envName = "testenv"
 	modelUUID := testing.ModelTag.Id()
@@ -485,6 +494,7 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 }
 
 var PortsToRuleInfo = portsToRuleInfo
+var IngressRulesToRuleInfo = ingressRulesToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
 
 var MakeServiceURL = &makeServiceURL
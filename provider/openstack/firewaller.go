@@ -38,6 +38,18 @@ type Firewaller interface {
 	// Ports returns the port ranges opened for the whole environment.
 	Ports() ([]network.PortRange, error)
 
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment.
+	IngressRules() ([]network.IngressRule, error)
+
 	// Implementations are expected to delete all security groups for the
 	// environment.
 	DeleteAllModelGroups() error
@@ -63,6 +75,18 @@ type Firewaller interface {
 
 	// InstancePorts returns the port ranges opened for the specified  instance.
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the
+	// specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for the
+	// specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the
+	// specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -308,79 +332,117 @@ func deleteSecurityGroup(novaclient *nova.Client, name, id string) {
 
 // OpenPorts implements Firewaller interface.
 func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
+	return c.OpenIngressRules(network.NewOpenIngressRules(ports))
+}
+
+// ClosePorts implements Firewaller interface.
+func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+	return c.CloseIngressRules(network.NewOpenIngressRules(ports))
+}
+
+// Ports implements Firewaller interface.
+func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+	rules, err := c.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.openPortsInGroup(c.globalGroupRegexp(), ports); err != nil {
+	if err := c.openPortsInGroup(c.globalGroupRegexp(), rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ports in global group: %v", rules)
 	return nil
 }
 
-// ClosePorts implements Firewaller interface.
-func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.closePortsInGroup(c.globalGroupRegexp(), ports); err != nil {
+	if err := c.closePortsInGroup(c.globalGroupRegexp(), rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ports in global group: %v", rules)
 	return nil
 }
 
-// Ports implements Firewaller interface.
-func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+// IngressRules implements Firewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			c.environ.Config().FirewallMode())
 	}
-	return c.portsInGroup(c.globalGroupRegexp())
+	return c.ingressRulesInGroup(c.globalGroupRegexp())
 }
 
 // OpenInstancePorts implements Firewaller interface.
 func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.OpenInstanceIngressRules(inst, machineId, network.NewOpenIngressRules(ports))
+}
+
+// CloseInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.CloseInstanceIngressRules(inst, machineId, network.NewOpenIngressRules(ports))
+}
+
+// InstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+	rules, err := c.InstanceIngressRules(inst, machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := c.openPortsInGroup(nameRegexp, ports); err != nil {
+	if err := c.openPortsInGroup(nameRegexp, rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, ports)
+	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
 	return nil
 }
 
-// CloseInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := c.closePortsInGroup(nameRegexp, ports); err != nil {
+	if err := c.closePortsInGroup(nameRegexp, rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, ports)
+	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
 	return nil
 }
 
-// InstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+// InstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	portRanges, err := c.portsInGroup(nameRegexp)
+	rules, err := c.ingressRulesInGroup(nameRegexp)
 	if err != nil {
 		return nil, err
 	}
-	return portRanges, nil
+	return rules, nil
 }
 
 func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
@@ -405,15 +467,15 @@ func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup
 	return matchingGroups[0], nil
 }
 
-func (c *defaultFirewaller) openPortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
+func (c *defaultFirewaller) openPortsInGroup(nameRegExp string, rules []network.IngressRule) error {
 	group, err := c.matchingGroup(nameRegExp)
 	if err != nil {
 		return err
 	}
 	novaclient := c.environ.nova()
-	rules := portsToRuleInfo(group.Id, portRanges)
-	for _, rule := range rules {
-		_, err := novaclient.CreateSecurityGroupRule(rule)
+	ruleInfos := ingressRulesToRuleInfo(group.Id, rules)
+	for _, ruleInfo := range ruleInfos {
+		_, err := novaclient.CreateSecurityGroupRule(ruleInfo)
 		if err != nil {
 			// TODO: if err is not rule already exists, raise?
 			logger.Debugf("error creating security group rule: %v", err.Error())
@@ -432,8 +494,17 @@ func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRan
 		*rule.ToPort == portRange.ToPort
 }
 
-func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+// ruleSourceCIDR returns the source CIDR of the supplied nova security
+// group rule. Nova treats rules without a CIDR as open to any source.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.OpenIngressCIDR
+}
+
+func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
 	group, err := c.matchingGroup(nameRegExp)
@@ -442,9 +513,14 @@ func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	}
 	novaclient := c.environ.nova()
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
+	for _, ruleInfo := range ingressRulesToRuleInfo(group.Id, rules) {
+		portRange := network.PortRange{
+			Protocol: ruleInfo.IPProtocol,
+			FromPort: ruleInfo.FromPort,
+			ToPort:   ruleInfo.ToPort,
+		}
 		for _, p := range group.Rules {
-			if !ruleMatchesPortRange(p, portRange) {
+			if !ruleMatchesPortRange(p, portRange) || ruleSourceCIDR(p) != ruleInfo.Cidr {
 				continue
 			}
 			err := novaclient.DeleteSecurityGroupRule(p.Id)
@@ -457,20 +533,37 @@ func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	return nil
 }
 
-func (c *defaultFirewaller) portsInGroup(nameRegexp string) (portRanges []network.PortRange, err error) {
+func (c *defaultFirewaller) ingressRulesInGroup(nameRegexp string) (rules []network.IngressRule, err error) {
 	group, err := c.matchingGroup(nameRegexp)
 	if err != nil {
 		return nil, err
 	}
+	// Nova reports one rule for each port range and source CIDR,
+	// so we gather the source CIDRs for each port range before
+	// building the ingress rules.
+	var portRanges []network.PortRange
+	sourceCIDRs := make(map[network.PortRange][]string)
 	for _, p := range group.Rules {
-		portRanges = append(portRanges, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: *p.IPProtocol,
 			FromPort: *p.FromPort,
 			ToPort:   *p.ToPort,
-		})
+		}
+		if _, ok := sourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		sourceCIDRs[portRange] = append(sourceCIDRs[portRange], ruleSourceCIDR(p))
+	}
+	for _, portRange := range portRanges {
+		rule, err := network.NewIngressRule(portRange, sourceCIDRs[portRange]...)
+		if err != nil {
+			logger.Errorf("ignoring unexpected security group rule for %v: %v", portRange, err)
+			continue
+		}
+		rules = append(rules, rule)
 	}
-	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (c *defaultFirewaller) globalGroupName(controllerUUID string) string {
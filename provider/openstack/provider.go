@@ -175,6 +175,7 @@ var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.IngressFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -192,6 +193,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ instance.InstanceIngressFirewaller = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -338,6 +340,21 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is specified in the instance.InstanceIngressFirewaller interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstanceIngressRules(inst, machineId, rules)
+}
+
+// CloseIngressRules is specified in the instance.InstanceIngressFirewaller interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstanceIngressRules(inst, machineId, rules)
+}
+
+// IngressRules is specified in the instance.InstanceIngressFirewaller interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1300,17 +1317,29 @@ func (e *Environ) machinesFilter() *nova.Filter {
 
 // portsToRuleInfo maps port ranges to nova rules
 func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
-		rules[i] = nova.RuleInfo{
-			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+	return ingressRulesToRuleInfo(groupId, network.NewOpenIngressRules(ports))
+}
+
+// ingressRulesToRuleInfo maps ingress rules to nova rules, one for
+// each port range and source CIDR.
+func ingressRulesToRuleInfo(groupId string, rules []network.IngressRule) []nova.RuleInfo {
+	var ruleInfos []nova.RuleInfo
+	for _, rule := range rules {
+		sourceCIDRs := rule.SourceCIDRs
+		if len(sourceCIDRs) == 0 {
+			sourceCIDRs = []string{network.OpenIngressCIDR}
+		}
+		for _, cidr := range sourceCIDRs {
+			ruleInfos = append(ruleInfos, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      rule.FromPort,
+				ToPort:        rule.ToPort,
+				IPProtocol:    rule.Protocol,
+				Cidr:          cidr,
+			})
 		}
 	}
-	return rules
+	return ruleInfos
 }
 
 func (e *Environ) OpenPorts(ports []network.PortRange) error {
@@ -1325,6 +1354,21 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+// OpenIngressRules is specified in the environs.IngressFirewaller interface.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.OpenIngressRules(rules)
+}
+
+// CloseIngressRules is specified in the environs.IngressFirewaller interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.CloseIngressRules(rules)
+}
+
+// IngressRules is specified in the environs.IngressFirewaller interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
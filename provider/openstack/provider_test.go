@@ -281,6 +281,34 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 	}
 }
 
+func (*localTests) TestIngressRulesToRuleInfo(c *gc.C) {
+	groupId := "groupid"
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	}
+	ruleInfos := IngressRulesToRuleInfo(groupId, rules)
+	c.Assert(ruleInfos, jc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        80,
+		Cidr:          "0.0.0.0/0",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      443,
+		ToPort:        443,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      443,
+		ToPort:        443,
+		Cidr:          "192.168.1.0/24",
+		ParentGroupId: groupId,
+	}})
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
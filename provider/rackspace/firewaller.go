@@ -55,6 +55,21 @@ func (c *rackspaceFirewaller) Ports() ([]network.PortRange, error) {
 	return nil, errors.NotSupportedf("Ports")
 }
 
+// OpenIngressRules is not supported.
+func (c *rackspaceFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenIngressRules")
+}
+
+// CloseIngressRules is not supported.
+func (c *rackspaceFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseIngressRules")
+}
+
+// IngressRules is not supported.
+func (c *rackspaceFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("IngressRules")
+}
+
 // DeleteAllModelGroups implements OpenstackFirewaller interface.
 func (c *rackspaceFirewaller) DeleteAllModelGroups() error {
 	return nil
@@ -94,6 +109,49 @@ func (c *rackspaceFirewaller) InstancePorts(inst instance.Instance, machineId st
 	return configurator.FindOpenPorts()
 }
 
+// OpenInstanceIngressRules implements Firewaller interface.
+// The instance's iptables rules cannot restrict access to specific
+// source CIDRs, so rules that are not open to all sources are
+// rejected.
+func (c *rackspaceFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	ports, err := openIngressPortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.changePorts(inst, true, ports)
+}
+
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	ports, err := openIngressPortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.changePorts(inst, false, ports)
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	ports, err := c.InstancePorts(inst, machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.NewOpenIngressRules(ports), nil
+}
+
+// openIngressPortRanges returns the port ranges of the given rules,
+// which must all be open to any source.
+func openIngressPortRanges(rules []network.IngressRule) ([]network.PortRange, error) {
+	ports := make([]network.PortRange, len(rules))
+	for i, rule := range rules {
+		if !rule.IsOpen() {
+			return nil, errors.NotSupportedf("ingress rule %v restricted to source CIDRs", rule)
+		}
+		ports[i] = rule.PortRange
+	}
+	return ports, nil
+}
+
 func (c *rackspaceFirewaller) changePorts(inst instance.Instance, insert bool, ports []network.PortRange) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
@@ -33,6 +33,7 @@ func PatchFileOps(patcher patcher, stub *testing.Stub) *StubFileOps {
 	patcher.PatchValue(&removeAll, fops.RemoveAll)
 	patcher.PatchValue(&mkdirAll, fops.MkdirAll)
 	patcher.PatchValue(&createFile, fops.CreateFile)
+	patcher.PatchValue(&createSymlink, fops.CreateSymlink)
 	return fops
 }
 
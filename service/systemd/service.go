@@ -482,6 +482,34 @@ func (s *Service) install() error {
 	return nil
 }
 
+// WriteService writes the unit file of the service, then links and
+// enables it the way "systemctl link" and "systemctl enable" would,
+// without talking to systemd. It is used to install services that
+// systemd is to start on the next boot, such as when the series of a
+// machine running another init system is upgraded.
+func (s *Service) WriteService() error {
+	if s.NoConf() {
+		return s.errorf(nil, "missing conf")
+	}
+	filename, err := s.writeConf()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, link := range []string{
+		path.Join(unitLinkDir, s.UnitName),
+		path.Join(unitLinkDir, "multi-user.target.wants", s.UnitName),
+	} {
+		if err := createSymlink(filename, link); err != nil {
+			return s.errorf(err, "failed to link %q", link)
+		}
+	}
+	return nil
+}
+
+// unitLinkDir is the directory in which systemd looks for the unit
+// files linked by the administrator.
+const unitLinkDir = "/etc/systemd/system"
+
 func (s *Service) writeConf() (string, error) {
 	data, err := s.serialize()
 	if err != nil {
@@ -520,6 +548,16 @@ var createFile = func(filename string, data []byte, perm os.FileMode) error {
 	return ioutil.WriteFile(filename, data, perm)
 }
 
+var createSymlink = func(oldname, newname string) error {
+	if err := os.MkdirAll(path.Dir(newname), 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(newname); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(oldname, newname)
+}
+
 // InstallCommands implements Service.
 func (s *Service) InstallCommands() ([]string, error) {
 	if s.NoConf() {
@@ -673,6 +673,20 @@ func (s *initSystemSuite) TestInstall(c *gc.C) {
 	s.checkCreateFileCall(c, 2, filename, s.newConfStr(s.name), 0644)
 }
 
+func (s *initSystemSuite) TestWriteService(c *gc.C) {
+	err := s.service.WriteService()
+	c.Assert(err, jc.ErrorIsNil)
+
+	dirname := fmt.Sprintf("%s/init/%s", s.dataDir, s.name)
+	filename := fmt.Sprintf("%s/%s.service", dirname, s.name)
+	s.stub.CheckCallNames(c, "MkdirAll", "CreateFile", "CreateSymlink", "CreateSymlink")
+	s.checkCreateFileCall(c, 1, filename, s.newConfStr(s.name), 0644)
+	s.stub.CheckCall(c, 2, "CreateSymlink",
+		filename, "/etc/systemd/system/"+s.name+".service")
+	s.stub.CheckCall(c, 3, "CreateSymlink",
+		filename, "/etc/systemd/system/multi-user.target.wants/"+s.name+".service")
+}
+
 func (s *initSystemSuite) TestInstallAlreadyInstalled(c *gc.C) {
 	s.addService("jujud-machine-0", "inactive")
 	s.addListResponse()
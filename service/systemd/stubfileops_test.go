@@ -25,6 +25,12 @@ func (sfo *StubFileOps) MkdirAll(dirname string) error {
 	return sfo.NextErr()
 }
 
+func (sfo *StubFileOps) CreateSymlink(oldname, newname string) error {
+	sfo.AddCall("CreateSymlink", oldname, newname)
+
+	return sfo.NextErr()
+}
+
 func (sfo *StubFileOps) CreateFile(filename string, data []byte, perm os.FileMode) error {
 	sfo.AddCall("CreateFile", filename, data, perm)
 
@@ -291,6 +291,16 @@ func (s *Service) Remove() error {
 	return os.Remove(s.confPath())
 }
 
+// WriteService writes the job configuration of the service, without
+// stopping or starting any running job.
+func (s *Service) WriteService() error {
+	conf, err := s.render()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ioutil.WriteFile(s.confPath(), conf, 0644)
+}
+
 // Install installs and starts the service.
 func (s *Service) Install() error {
 	exists, same, conf, err := s.existsAndSame()
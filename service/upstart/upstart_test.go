@@ -162,6 +162,17 @@ func (s *UpstartSuite) TestStop(c *gc.C) {
 	c.Assert(s.service.Stop(), jc.ErrorIsNil)
 }
 
+func (s *UpstartSuite) TestWriteService(c *gc.C) {
+	err := s.service.WriteService()
+	c.Assert(err, jc.ErrorIsNil)
+	installed, err := s.service.Installed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(installed, jc.IsTrue)
+	exists, err := s.service.Exists()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.IsTrue)
+}
+
 func (s *UpstartSuite) TestRemoveMissing(c *gc.C) {
 	err := s.service.Remove()
 
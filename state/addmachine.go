@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -272,7 +273,10 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	mdoc, err := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -350,7 +354,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
@@ -400,7 +407,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		}
 	}
 
-	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	parentDoc, err := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	newId, err := st.newContainerId(parentDoc.Id, containerType)
 	if err != nil {
 		return nil, nil, err
@@ -409,7 +419,10 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc, err := st.machineDocForTemplate(template, newId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
 	if err != nil {
@@ -429,13 +442,17 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
-func (st *State) machineDocForTemplate(template MachineTemplate, id string) *machineDoc {
+func (st *State) machineDocForTemplate(template MachineTemplate, id string) (*machineDoc, error) {
+	pref, err := st.addressPreference()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	// We ignore the error from Select*Address as an error indicates
 	// no address is available, in which case the empty address is returned
 	// and setting the preferred address to an empty one is the correct
 	// thing to do when none is available.
-	privateAddr, _ := network.SelectInternalAddress(template.Addresses, false)
-	publicAddr, _ := network.SelectPublicAddress(template.Addresses)
+	privateAddr, _ := network.SelectInternalAddressWithPreference(template.Addresses, false, pref)
+	publicAddr, _ := network.SelectPublicAddressWithPreference(template.Addresses, pref)
 	logger.Infof(
 		"new machine %q has preferred addresses: private %q, public %q",
 		id, privateAddr, publicAddr,
@@ -455,7 +472,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
-	}
+	}, nil
 }
 
 // insertNewMachineOps returns operations to insert the given machine document
@@ -577,8 +594,24 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments for existing filesystems and volumes, such as
+	// those imported into the model and then attached to a unit.
+	for _, tag := range sortedFilesystemTags(args.filesystemAttachments) {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, attachExistingEntityOp(filesystemsC, tag.Id()))
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, names.NewStorageTag(f.doc.StorageId), args.filesystemAttachments[tag],
+		})
+	}
+	for _, tag := range sortedVolumeTags(args.volumeAttachments) {
+		volumeOps = append(volumeOps, attachExistingEntityOp(volumesC, tag.Id()))
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			tag, args.volumeAttachments[tag],
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -594,6 +627,44 @@ func (st *State) machineStorageOps(
 	return ops, volumeAttachments, fsAttachments, nil
 }
 
+// attachExistingEntityOp returns a txn.Op that increments the attachment
+// count of an existing volume or filesystem that is being attached to a
+// machine.
+func attachExistingEntityOp(collection, id string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}
+}
+
+func sortedVolumeTags(m map[names.VolumeTag]VolumeAttachmentParams) []names.VolumeTag {
+	ids := make([]string, 0, len(m))
+	for tag := range m {
+		ids = append(ids, tag.Id())
+	}
+	sort.Strings(ids)
+	tags := make([]names.VolumeTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	return tags
+}
+
+func sortedFilesystemTags(m map[names.FilesystemTag]FilesystemAttachmentParams) []names.FilesystemTag {
+	ids := make([]string, 0, len(m))
+	for tag := range m {
+		ids = append(ids, tag.Id())
+	}
+	sort.Strings(ids)
+	tags := make([]names.FilesystemTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewFilesystemTag(id)
+	}
+	return tags
+}
+
 // addMachineStorageAttachmentsOps returns txn.Ops for adding the IDs of
 // attached volumes and filesystems to an existing machine. Filesystem
 // mount points are checked against existing filesystem attachments for
@@ -49,10 +49,14 @@ func (st *State) controllerAddresses() ([]string, error) {
 	if len(allAddresses) == 0 {
 		return nil, errors.New("no controller machines found")
 	}
+	pref, err := ssState.addressPreference()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	apiAddrs := make([]string, 0, len(allAddresses))
 	for _, addrs := range allAddresses {
 		naddrs := networkAddresses(addrs.Addresses)
-		addr, ok := network.SelectControllerAddress(naddrs, false)
+		addr, ok := network.SelectInternalAddressWithPreference(naddrs, false, pref)
 		if ok {
 			apiAddrs = append(apiAddrs, addr.Value)
 		}
@@ -63,6 +67,16 @@ func (st *State) controllerAddresses() ([]string, error) {
 	return apiAddrs, nil
 }
 
+// addressPreference returns the IP address family preference configured
+// for the model.
+func (st *State) addressPreference() (network.AddressPreference, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.AddressPreference(), nil
+}
+
 func appendPort(addrs []string, port int) []string {
 	newAddrs := make([]string, len(addrs))
 	for i, addr := range addrs {
@@ -234,6 +234,41 @@ func allCollections() collectionSchema {
 		// These collections hold information associated with applications.
 		charmsC:       {},
 		applicationsC: {},
+
+		// This collection holds the applications offered for
+		// consumption by other models on the controller.
+		applicationOffersC: {},
+
+		// This collection holds the applications in other models
+		// on the controller that applications in this model can
+		// be related to.
+		remoteApplicationsC: {},
+
+		// These collections hold the secrets owned by applications,
+		// the encrypted content of each revision of each secret, and
+		// the grants giving other applications access to them.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id", "revision"},
+			}},
+		},
+		secretGrantsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}, {
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
+		// This collection holds the rolling charm upgrades in
+		// progress, keyed by application name.
+		charmRolloutsC: {},
+
 		unitsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "application"},
@@ -307,6 +342,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "storageid"},
+			}},
+		},
 
 		// -----
 
@@ -372,6 +412,14 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds a bounded history of the changes made
+		// to application and model settings.
+		settingsHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "globalkey", "revision"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global: true,
@@ -445,8 +493,15 @@ const (
 	restoreInfoC             = "restoreInfo"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
+	applicationOffersC       = "applicationOffers"
+	remoteApplicationsC      = "remoteApplications"
+	secretsC                 = "secrets"
+	secretRevisionsC         = "secretRevisions"
+	secretGrantsC            = "secretGrants"
+	charmRolloutsC           = "charmRollouts"
 	endpointBindingsC        = "endpointbindings"
 	settingsC                = "settings"
+	settingsHistoryC         = "settingshistory"
 	refcountsC               = "refcounts"
 	sshHostKeysC             = "sshhostkeys"
 	spacesC                  = "spaces"
@@ -469,5 +524,6 @@ const (
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	volumeSnapshotsC         = "volumesnapshots"
 	// "resources" (see resource/persistence/mongo.go)
 )
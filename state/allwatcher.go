@@ -450,7 +450,8 @@ func (svc *backingApplication) updated(st *State, store *multiwatcherStore, id s
 		if err != nil {
 			return errors.Trace(err)
 		}
-		info.Config = doc.Settings
+		// Secret values are not for the watcher's clients.
+		info.Config = redactConfigSettings(doc.Settings)
 	}
 	store.Update(info)
 	return nil
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -48,9 +51,15 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
+	ExposedSpaces        []string   `bson:"exposed-spaces,omitempty"`
+	ExposedEndpoints     []string   `bson:"exposed-endpoints,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+	SecretConfigKeys     *[]string  `bson:"secret-config-keys,omitempty"`
+	AutoUpgrade          string     `bson:"auto-upgrade,omitempty"`
+	MaintenanceWindow    string     `bson:"maintenance-window,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -267,7 +276,18 @@ func (s *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		return nil, errors.Trace(err)
 	}
 	ops = append(ops, charmOps...)
+	offerOps, err := removeApplicationOffersOps(s.st, s.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, offerOps...)
+	secretOps, err := removeApplicationSecretsOps(s.st, s.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
 	ops = append(ops,
+		removeCharmRolloutOp(s.st, s.doc.Name),
 		removeEndpointBindingsOp(s.globalKey()),
 		removeStorageConstraintsOp(s.globalKey()),
 		removeConstraintsOp(s.st, s.globalKey()),
@@ -292,29 +312,118 @@ func (s *Application) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// SetExposed marks the application as exposed to all sources.
 // See ClearExposed and IsExposed.
 func (s *Application) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil, nil, nil)
+}
+
+// SetExposedTo marks the application as exposed, restricting access
+// to its opened ports to the given source CIDRs and to the subnets in
+// the given spaces. If both are empty, the application is exposed to
+// all sources, as with SetExposed.
+func (s *Application) SetExposedTo(cidrs, spaces []string) error {
+	return s.SetExposedEndpoints(nil, cidrs, spaces)
+}
+
+// SetExposedEndpoints marks the application as exposed for the given
+// endpoints only, so that just the ports opened for those endpoints
+// (and the ports opened for all endpoints) are accessible. Access is
+// restricted to sources as with SetExposedTo. If no endpoints are
+// given, all the application's opened ports are exposed.
+func (s *Application) SetExposedEndpoints(endpoints, cidrs, spaces []string) error {
+	if err := s.checkEndpointNames(endpoints...); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", s)
+	}
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("cannot expose application %q: invalid CIDR %q", s, cidr)
+		}
+	}
+	for _, spaceName := range spaces {
+		if _, err := s.st.Space(spaceName); err != nil {
+			return errors.Annotatef(err, "cannot expose application %q", s)
+		}
+	}
+	return s.setExposed(true, endpoints, cidrs, spaces)
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (s *Application) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil, nil, nil)
+}
+
+// ExposedEndpoints returns the names of the endpoints whose opened
+// ports are exposed. If empty, the ports opened for all endpoints
+// are exposed. See SetExposedEndpoints.
+func (s *Application) ExposedEndpoints() []string {
+	return s.doc.ExposedEndpoints
+}
+
+// ExposedCIDRs returns the source CIDRs to which access to the
+// application's opened ports is restricted. See SetExposedTo.
+func (s *Application) ExposedCIDRs() []string {
+	return s.doc.ExposedCIDRs
+}
+
+// ExposedSpaces returns the names of the spaces to whose subnets
+// access to the application's opened ports is restricted. See
+// SetExposedTo.
+func (s *Application) ExposedSpaces() []string {
+	return s.doc.ExposedSpaces
+}
+
+// ExposedIngressCIDRs returns the source CIDRs from which the
+// application's opened ports may be accessed when it is exposed,
+// resolving exposed spaces to the CIDRs of their subnets. If access
+// is not restricted, the result holds only network.OpenIngressCIDR;
+// if access is restricted to spaces without subnets, the result is
+// empty.
+func (s *Application) ExposedIngressCIDRs() ([]string, error) {
+	if len(s.doc.ExposedCIDRs) == 0 && len(s.doc.ExposedSpaces) == 0 {
+		return []string{network.OpenIngressCIDR}, nil
+	}
+	cidrs := set.NewStrings(s.doc.ExposedCIDRs...)
+	for _, spaceName := range s.doc.ExposedSpaces {
+		space, err := s.st.Space(spaceName)
+		if errors.IsNotFound(err) {
+			// The space has been removed since the
+			// application was exposed to it.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			cidrs.Add(subnet.CIDR())
+		}
+	}
+	return cidrs.SortedValues(), nil
 }
 
-func (s *Application) setExposed(exposed bool) (err error) {
+func (s *Application) setExposed(exposed bool, endpoints, cidrs, spaces []string) (err error) {
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-cidrs", cidrs},
+			{"exposed-spaces", spaces},
+			{"exposed-endpoints", endpoints},
+		}}},
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
+	s.doc.ExposedSpaces = spaces
+	s.doc.ExposedEndpoints = endpoints
 	return nil
 }
 
@@ -385,6 +494,26 @@ func (s *Application) Endpoints() (eps []Endpoint, err error) {
 	return eps, nil
 }
 
+// checkEndpointNames returns an error satisfying errors.IsNotFound if
+// any of the given names is neither a relation endpoint nor an extra
+// binding of the application's charm.
+func (s *Application) checkEndpointNames(names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	ch, _, err := s.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := DefaultEndpointBindingsForCharm(ch.Meta())
+	for _, name := range names {
+		if _, ok := known[name]; !ok {
+			return errors.NotFoundf("endpoint %q", name)
+		}
+	}
+	return nil
+}
+
 // Endpoint returns the relation endpoint with the supplied name, if it exists.
 func (s *Application) Endpoint(relationName string) (Endpoint, error) {
 	eps, err := s.Endpoints()
@@ -603,8 +732,14 @@ func (s *Application) changeCharmOps(ch *Charm, channel string, forceUnits bool,
 	var newSettings charm.Settings
 	oldSettings, err := readSettings(s.st, settingsC, s.settingsKey())
 	if err == nil {
-		// Filter the old settings through to get the new settings.
+		// Filter the old settings through to get the new settings,
+		// encrypting any that are secret under the new charm.
 		newSettings = ch.Config().FilterSettings(oldSettings.Map())
+		secrets := secretConfigKeys(ch.Config(), s.doc.SecretConfigKeys)
+		newSettings, err = s.st.encryptConfigSettings(secrets, newSettings)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	} else if errors.IsNotFound(err) {
 		// No old settings, start with empty new settings.
 		newSettings = make(charm.Settings)
@@ -768,6 +903,9 @@ type SetCharmConfig struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resourceids"`
+	// Rollout, if set, upgrades the existing units in batches rather
+	// than all at once.
+	Rollout *CharmRolloutParams `json:"rollout,omitempty"`
 }
 
 // SetCharm changes the charm for the application. New units will be started with
@@ -775,7 +913,20 @@ type SetCharmConfig struct {
 // If forceUnits is true, units will be upgraded even if they are in an error state.
 // If forceSeries is true, the charm will be used even if it's the service's series
 // is not supported by the charm.
+// If a rollout is requested, the existing units are upgraded in batches
+// as the rollout is advanced; see AdvanceCharmRollout.
 func (s *Application) SetCharm(cfg SetCharmConfig) error {
+	if cfg.Rollout != nil {
+		if err := cfg.Rollout.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return s.setCharm(cfg, false)
+}
+
+// setCharm implements SetCharm. If abortRollout is true, the rolling
+// upgrade in progress is removed in the same transaction.
+func (s *Application) setCharm(cfg SetCharmConfig, abortRollout bool) error {
 	if cfg.Charm.Meta().Subordinate != s.doc.Subordinate {
 		return errors.Errorf("cannot change a service's subordinacy")
 	}
@@ -876,6 +1027,12 @@ func (s *Application) SetCharm(cfg SetCharmConfig) error {
 			Assert: bson.D{{"charmmodifiedversion", charmModifiedVersion}},
 		}}
 
+		rolloutOps, err := s.charmRolloutOps(cfg.Charm.URL(), cfg.Rollout, abortRollout)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, rolloutOps...)
+
 		// Make sure the application doesn't have this charm already.
 		sel := bson.D{{"_id", s.doc.DocID}, {"charmurl", cfg.Charm.URL()}}
 		count, err := services.Find(sel).Count()
@@ -955,7 +1112,7 @@ const MessageWaitForAgentInit = "Waiting for agent initialization to finish"
 // application will be assigned to a given principal. The asserts param can be used
 // to include additional assertions for the application document.  This method
 // assumes that the application already exists in the db.
-func (s *Application) addUnitOps(principalName string, asserts bson.D) (string, []txn.Op, error) {
+func (s *Application) addUnitOps(principalName string, params AddUnitParams, asserts bson.D) (string, []txn.Op, error) {
 	var cons constraints.Value
 	if !s.doc.Subordinate {
 		scons, err := s.Constraints()
@@ -978,6 +1135,7 @@ func (s *Application) addUnitOps(principalName string, asserts bson.D) (string,
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
+		attachStorage: params.AttachStorage,
 	}
 	names, ops, err := s.addUnitOpsWithCons(args)
 	if err != nil {
@@ -993,6 +1151,7 @@ type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+	attachStorage []names.StorageTag
 }
 
 // addServiceUnitOps is just like addUnitOps but explicitly takes a
@@ -1017,11 +1176,24 @@ func (s *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		return "", nil, err
 	}
 
-	// Create instances of the charm's declared stores.
-	storageOps, numStorageAttachments, err := s.unitStorageOps(name, args.storageCons)
+	// Attach any existing storage instances, and create instances
+	// of the charm's declared stores for the remainder.
+	storageCons := args.storageCons
+	var attachStorageOps []txn.Op
+	if len(args.attachStorage) > 0 {
+		attachStorageOps, storageCons, err = s.unitAttachStorageOps(
+			name, args.attachStorage, storageCons,
+		)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+	}
+	storageOps, numStorageAttachments, err := s.unitStorageOps(name, storageCons)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
+	storageOps = append(storageOps, attachStorageOps...)
+	numStorageAttachments += len(args.attachStorage)
 
 	docID := s.st.docID(name)
 	globalKey := unitGlobalKey(name)
@@ -1124,10 +1296,118 @@ func (s *Application) unitStorageOps(unitName string, cons map[string]StorageCon
 	return ops, numStorageAttachments, nil
 }
 
+// unitAttachStorageOps returns operations for attaching the specified
+// detached storage instances to a new unit. The unit's storage
+// constraints are returned adjusted to account for the attached
+// storage, so that no more storage instances are created for the
+// unit than its charm requires.
+func (s *Application) unitAttachStorageOps(
+	unitName string,
+	storageTags []names.StorageTag,
+	cons map[string]StorageConstraints,
+) ([]txn.Op, map[string]StorageConstraints, error) {
+	ch, _, err := s.Charm()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	charmMeta := ch.Meta()
+	unitTag := names.NewUnitTag(unitName)
+
+	var ops []txn.Op
+	seen := make(set.Strings)
+	counts := make(map[string]uint64)
+	for _, tag := range storageTags {
+		if seen.Contains(tag.Id()) {
+			return nil, nil, errors.Errorf("storage %s specified more than once", tag.Id())
+		}
+		seen.Add(tag.Id())
+		si, err := s.st.storageInstance(tag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, nil, errors.Errorf("storage %s is not alive", tag.Id())
+		}
+		if si.doc.Owner != "" || si.doc.AttachmentCount > 0 {
+			return nil, nil, errors.Errorf("storage %s is not detached", tag.Id())
+		}
+		charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+		if !ok {
+			return nil, nil, errors.NotFoundf("charm storage %q", si.doc.StorageName)
+		}
+		if charmStorage.Shared {
+			return nil, nil, errors.NotSupportedf("attaching shared storage")
+		}
+		kind := StorageKindBlock
+		if charmStorage.Type == charm.StorageFilesystem {
+			kind = StorageKindFilesystem
+		}
+		if kind != si.doc.Kind {
+			return nil, nil, errors.Errorf(
+				"charm storage %q is of kind %s, storage %s is of kind %s",
+				si.doc.StorageName, kind, tag.Id(), si.doc.Kind,
+			)
+		}
+		// The unit has not yet been assigned to a machine, so the
+		// storage's volume or filesystem must be free to be
+		// attached to any machine.
+		if _, err := s.st.detachableStorageEntity(si, ""); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		counts[si.doc.StorageName]++
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"life", Alive}, {"owner", ""}, {"attachmentcount", 0}},
+			Update: bson.D{
+				{"$set", bson.D{{"owner", unitTag.String()}}},
+				{"$inc", bson.D{{"attachmentcount", 1}}},
+			},
+		}, createStorageAttachmentOp(tag, unitTag))
+	}
+
+	adjusted := make(map[string]StorageConstraints, len(cons))
+	for name, c := range cons {
+		adjusted[name] = c
+	}
+	for name, n := range counts {
+		c := adjusted[name]
+		if c.Count > n {
+			c.Count -= n
+		} else {
+			c.Count = 0
+		}
+		countMax := charmMeta.Storage[name].CountMax
+		if countMax >= 0 && n+c.Count > uint64(countMax) {
+			return nil, nil, errors.Errorf(
+				"charm allows at most %d %q storage instance(s)",
+				countMax, name,
+			)
+		}
+		adjusted[name] = c
+	}
+	return ops, adjusted, nil
+}
+
+// AddUnitParams contains parameters for the Application.AddUnitWithParams
+// method.
+type AddUnitParams struct {
+	// AttachStorage identifies detached storage instances that are
+	// to be attached to the new unit, in place of the storage that
+	// would otherwise be created for it.
+	AttachStorage []names.StorageTag
+}
+
 // AddUnit adds a new principal unit to the service.
 func (s *Application) AddUnit() (unit *Unit, err error) {
+	return s.AddUnitWithParams(AddUnitParams{})
+}
+
+// AddUnitWithParams adds a new principal unit to the service, with
+// the specified parameters.
+func (s *Application) AddUnitWithParams(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", s)
-	name, ops, err := s.addUnitOps("", nil)
+	name, ops, err := s.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1288,12 +1568,19 @@ func (s *Application) ConfigSettings() (charm.Settings, error) {
 	if err != nil {
 		return nil, err
 	}
-	return settings.Map(), nil
+	return s.st.decryptConfigSettings(settings.Map())
 }
 
 // UpdateConfigSettings changes a service's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (s *Application) UpdateConfigSettings(changes charm.Settings) error {
+	return s.UpdateConfigSettingsAs("", changes)
+}
+
+// UpdateConfigSettingsAs changes the application's charm config settings
+// as UpdateConfigSettings does, recording the named user as having made
+// the change in the settings history.
+func (s *Application) UpdateConfigSettingsAs(user string, changes charm.Settings) error {
 	charm, _, err := s.Charm()
 	if err != nil {
 		return err
@@ -1302,6 +1589,9 @@ func (s *Application) UpdateConfigSettings(changes charm.Settings) error {
 	if err != nil {
 		return err
 	}
+	if err := validateConfigValues(changes); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(fwereade) state.Settings is itself really problematic in just
 	// about every use case. This needs to be resolved some time; but at
 	// least the settings docs are keyed by charm url as well as service
@@ -1310,15 +1600,207 @@ func (s *Application) UpdateConfigSettings(changes charm.Settings) error {
 	if err != nil {
 		return err
 	}
+	secrets := secretConfigKeys(charm.Config(), s.doc.SecretConfigKeys)
 	for name, value := range changes {
 		if value == nil {
 			node.Delete(name)
-		} else {
-			node.Set(name, value)
+			continue
 		}
+		if plain, ok := value.(string); ok && secrets.Contains(name) {
+			// Encrypting yields a different value every time, so
+			// leave an unchanged secret alone rather than record a
+			// spurious change.
+			if current, ok := node.Get(name); ok && isEncryptedConfigValue(current) {
+				if old, err := s.st.plainConfigValue(current); err == nil && old == plain {
+					continue
+				}
+			}
+			if value, err = s.st.encryptConfigValue(plain); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		node.Set(name, value)
 	}
-	_, err = node.Write()
-	return err
+	return writeSettingsWithHistory(node, s.globalKey(), user)
+}
+
+// ConfigSettingsHistory returns the recorded changes to the application's
+// charm config settings, most recent first.
+func (s *Application) ConfigSettingsHistory() ([]SettingsRevision, error) {
+	revisions, err := settingsHistory(s.st, s.globalKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i := range revisions {
+		if err := s.decryptSettingsRevision(&revisions[i]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return revisions, nil
+}
+
+// decryptSettingsRevision replaces the encrypted values recorded in the
+// given revision of the settings history with their plain text.
+func (s *Application) decryptSettingsRevision(rev *SettingsRevision) error {
+	settings, err := s.st.decryptConfigSettings(rev.Settings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rev.Settings = settings
+	for i, change := range rev.Changes {
+		if rev.Changes[i].OldValue, err = s.st.plainConfigValue(change.OldValue); err != nil {
+			return errors.Annotatef(err, "change to %q", change.Key)
+		}
+		if rev.Changes[i].NewValue, err = s.st.plainConfigValue(change.NewValue); err != nil {
+			return errors.Annotatef(err, "change to %q", change.Key)
+		}
+	}
+	return nil
+}
+
+// RollbackConfigSettings restores the application's charm config settings
+// to those recorded at the given revision of the settings history. The
+// rollback is itself recorded in the history as a change made by the
+// named user.
+func (s *Application) RollbackConfigSettings(user string, revision int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back config settings of application %q to revision %d", s, revision)
+	rev, err := settingsHistoryRevision(s.st, s.globalKey(), revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.decryptSettingsRevision(rev); err != nil {
+		return errors.Trace(err)
+	}
+	current, err := s.ConfigSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes := make(charm.Settings)
+	for name, value := range rev.Settings {
+		changes[name] = value
+	}
+	for name := range current {
+		if _, ok := rev.Settings[name]; !ok {
+			changes[name] = nil
+		}
+	}
+	return errors.Trace(s.UpdateConfigSettingsAs(user, changes))
+}
+
+// SecretConfigKeys returns the names of the application's config
+// settings whose values are secret, sorted. Until the operator sets
+// them with SetSecretConfigKeys, these are the string options of the
+// charm whose names suggest they hold credentials. Secret values are
+// stored encrypted.
+func (s *Application) SecretConfigKeys() ([]string, error) {
+	ch, _, err := s.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secretConfigKeys(ch.Config(), s.doc.SecretConfigKeys).SortedValues(), nil
+}
+
+// SetSecretConfigKeys marks the named config settings of the application
+// as secret, and only those: the given keys replace both the keys marked
+// previously and those chosen by option name, so an empty list leaves no
+// setting secret. Only string options of the application's charm may be
+// marked. Existing values are encrypted or decrypted as necessary.
+func (s *Application) SetSecretConfigKeys(keys []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set secret config keys of application %q", s)
+	keys = set.NewStrings(keys...).SortedValues()
+	app := &Application{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, key := range keys {
+			option, ok := ch.Config().Options[key]
+			if !ok {
+				return nil, errors.NotFoundf("config option %q", key)
+			}
+			if option.Type != "string" {
+				return nil, errors.Errorf("config option %q has type %q, only string options can be secret", key, option.Type)
+			}
+		}
+		if app.doc.SecretConfigKeys != nil {
+			current, wanted := set.NewStrings(*app.doc.SecretConfigKeys...), set.NewStrings(keys...)
+			if current.Difference(wanted).IsEmpty() && wanted.Difference(current).IsEmpty() {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", app.doc.CharmURL},
+			},
+			Update: bson.D{{"$set", bson.D{{"secret-config-keys", keys}}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return err
+	}
+	s.doc.SecretConfigKeys = &keys
+	return errors.Trace(s.reencryptConfigSettings())
+}
+
+// reencryptConfigSettings rewrites the application's config settings so
+// that the values of secret settings, and only those, are encrypted, and
+// encrypts the values of secret settings recorded in the settings
+// history. The rewrite changes how values are stored, not what they
+// are, so it is not itself recorded in the history: the revision would
+// hold no changes, and rolling back past it would restore the same
+// values.
+func (s *Application) reencryptConfigSettings() error {
+	ch, _, err := s.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	secrets := secretConfigKeys(ch.Config(), s.doc.SecretConfigKeys)
+	node, err := readSettings(s.st, settingsC, s.settingsKey())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, value := range node.Map() {
+		switch {
+		case secrets.Contains(name) && !isEncryptedConfigValue(value):
+			plain, ok := value.(string)
+			if !ok {
+				continue
+			}
+			encrypted, err := s.st.encryptConfigValue(plain)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			node.Set(name, encrypted)
+		case !secrets.Contains(name) && isEncryptedConfigValue(value):
+			plain, err := s.st.plainConfigValue(value)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			node.Set(name, plain)
+		}
+	}
+	_, ops := node.settingsUpdateOps()
+	historyOps, err := encryptSettingsHistoryOps(s.st, s.globalKey(), secrets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
+	if len(ops) == 0 {
+		return nil
+	}
+	return node.write(ops)
 }
 
 // LeaderSettings returns a service's leader settings. If nothing has been set
@@ -1462,6 +1944,100 @@ func (s *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// SetEndpointBindings updates the spaces the given endpoints of the
+// application are bound to, leaving the bindings of other endpoints
+// unchanged. Each machine hosting a unit of the application must have
+// an address in every space an endpoint is to be bound to.
+func (s *Application) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", s)
+	app := &Application{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(app.st, app.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := app.validateBindingSpaces(bindings); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", app.doc.CharmURL},
+				{"unitcount", app.doc.UnitCount},
+			},
+		}, bindingsOp}, nil
+	}
+	return s.st.run(buildTxn)
+}
+
+// validateBindingSpaces verifies that each machine hosting a unit of the
+// application has an address in every space the given bindings bind an
+// endpoint to. Units not yet assigned to a machine are not checked.
+func (s *Application) validateBindingSpaces(bindings map[string]string) error {
+	spaces := set.NewStrings()
+	for _, space := range bindings {
+		if space != "" {
+			spaces.Add(space)
+		}
+	}
+	if spaces.IsEmpty() {
+		return nil
+	}
+	units, err := s.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineSpaces := make(map[string]set.Strings)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		addressSpaces, ok := machineSpaces[machineId]
+		if !ok {
+			machine, err := s.st.Machine(machineId)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if addressSpaces, err = machine.addressSpaces(); err != nil {
+				return errors.Trace(err)
+			}
+			machineSpaces[machineId] = addressSpaces
+		}
+		if missing := spaces.Difference(addressSpaces); !missing.IsEmpty() {
+			return errors.Errorf(
+				"machine %q hosting unit %q has no address in space %q",
+				machineId, unit.Name(), missing.SortedValues()[0],
+			)
+		}
+	}
+	return nil
+}
+
+// WatchEndpointBindings returns a watcher notifying of changes to the
+// application's endpoint bindings.
+func (s *Application) WatchEndpointBindings() NotifyWatcher {
+	return newEntityWatcher(s.st, endpointBindingsC, s.st.docID(s.globalKey()))
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -172,6 +173,89 @@ func (s *ServiceSuite) TestSetCharmUpdatesBindings(c *gc.C) {
 	})
 }
 
+func (s *ServiceSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("client", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", s.AddMetaCharm(c, "mysql", metaBase, 44), map[string]string{
+		"server": "db",
+	})
+
+	err = service.SetEndpointBindings(map[string]string{"client": "client"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"client":  "client",
+		"cluster": "",
+	})
+
+	err = service.SetEndpointBindings(map[string]string{"foo": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": unknown endpoint "foo" not valid`)
+	err = service.SetEndpointBindings(map[string]string{"server": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "yoursql": unknown space "bar" not valid`)
+}
+
+func (s *ServiceSuite) TestSetEndpointBindingsChecksMachineAddresses(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.AddTestingService(c, "yoursql", s.AddMetaCharm(c, "mysql", metaBase, 44))
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = service.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot set endpoint bindings for application "yoursql": machine %q hosting unit "yoursql/0" has no address in space "db"`,
+		machineId,
+	))
+
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "10.0.0.5/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = service.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+}
+
+func (s *ServiceSuite) TestWatchEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	w := s.mysql.WatchEndpointBindings()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Setting the same bindings again is a no-op.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ServiceSuite) TestSetCharmWithWeirdlyNamedEndpoints(c *gc.C) {
 	// This test ensures if special characters appear in endpoint names of the
 	// charm metadata, they are properly escaped before saving to mongo, and
@@ -943,6 +1027,311 @@ func (s *ServiceSuite) TestUpdateConfigSettings(c *gc.C) {
 	}
 }
 
+func (s *ServiceSuite) TestConfigSettingsHistory(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+
+	err = svc.UpdateConfigSettingsAs("bob", charm.Settings{"title": "foo", "outlook": "good"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.UpdateConfigSettingsAs("mary", charm.Settings{"title": "bar", "outlook": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	// Settings that do not change are not recorded.
+	err = svc.UpdateConfigSettingsAs("mary", charm.Settings{"title": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err = svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Revision, gc.Equals, 2)
+	c.Assert(history[0].User, gc.Equals, "mary")
+	c.Assert(history[0].Time.IsZero(), jc.IsFalse)
+	c.Assert(history[0].Changes, jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemDeleted, Key: "outlook", OldValue: "good"},
+		{Type: state.ItemModified, Key: "title", OldValue: "foo", NewValue: "bar"},
+	})
+	c.Assert(history[0].Settings, jc.DeepEquals, map[string]interface{}{"title": "bar"})
+	c.Assert(history[1].Revision, gc.Equals, 1)
+	c.Assert(history[1].User, gc.Equals, "bob")
+	c.Assert(history[1].Changes, jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemAdded, Key: "outlook", NewValue: "good"},
+		{Type: state.ItemAdded, Key: "title", NewValue: "foo"},
+	})
+	c.Assert(history[1].Settings, jc.DeepEquals, map[string]interface{}{
+		"title":   "foo",
+		"outlook": "good",
+	})
+}
+
+func (s *ServiceSuite) TestConfigSettingsHistoryIsBounded(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	for i := 0; i < state.MaxSettingsHistory+5; i++ {
+		err := svc.UpdateConfigSettings(charm.Settings{"skill-level": i})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxSettingsHistory)
+	c.Assert(history[0].Revision, gc.Equals, state.MaxSettingsHistory+5)
+	c.Assert(history[0].User, gc.Equals, "")
+	c.Assert(history[len(history)-1].Revision, gc.Equals, 6)
+}
+
+func (s *ServiceSuite) TestConfigSettingsHistoryConcurrentChange(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := svc.UpdateConfigSettingsAs("bob", charm.Settings{"outlook": "good"})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := svc.UpdateConfigSettingsAs("mary", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Revision, gc.Equals, 2)
+	c.Assert(history[0].User, gc.Equals, "mary")
+	c.Assert(history[1].Revision, gc.Equals, 1)
+	c.Assert(history[1].User, gc.Equals, "bob")
+}
+
+func (s *ServiceSuite) TestSetSecretConfigKeysEncryptsHistory(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := svc.UpdateConfigSettings(charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.UpdateConfigSettings(charm.Settings{"title": "bar", "outlook": "good"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.SetSecretConfigKeys([]string{"title"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	raw, err := state.RawConfigSettingsHistory(svc)
+	c.Assert(err, jc.ErrorIsNil)
+	// Marking the key secret is not itself recorded.
+	c.Assert(raw, gc.HasLen, 2)
+	for _, rev := range raw {
+		c.Check(state.IsEncryptedConfigValue(rev.Settings["title"]), jc.IsTrue)
+		for _, change := range rev.Changes {
+			if change.Key != "title" {
+				continue
+			}
+			c.Check(state.IsEncryptedConfigValue(change.NewValue), jc.IsTrue)
+			if change.OldValue != nil {
+				c.Check(state.IsEncryptedConfigValue(change.OldValue), jc.IsTrue)
+			}
+		}
+	}
+	c.Check(raw[0].Settings["outlook"], gc.Equals, "good")
+
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history[0].Changes, jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemAdded, Key: "outlook", NewValue: "good"},
+		{Type: state.ItemModified, Key: "title", OldValue: "foo", NewValue: "bar"},
+	})
+	c.Assert(history[1].Settings, jc.DeepEquals, map[string]interface{}{"title": "foo"})
+}
+
+func (s *ServiceSuite) TestRollbackConfigSettings(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := svc.UpdateConfigSettingsAs("bob", charm.Settings{"title": "foo", "outlook": "good"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.UpdateConfigSettingsAs("bob", charm.Settings{"title": "bar", "outlook": nil, "username": "root"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.RollbackConfigSettings("mary", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "foo", "outlook": "good"})
+
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[0].Revision, gc.Equals, 3)
+	c.Assert(history[0].User, gc.Equals, "mary")
+
+	err = svc.RollbackConfigSettings("mary", 42)
+	c.Assert(err, gc.ErrorMatches, `cannot roll back config settings of application "dummy-application" to revision 42: settings revision 42 not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+var dottedConfig = `
+options:
+  proxy.url: {description: Proxy, type: string}
+`
+
+func (s *ServiceSuite) TestRollbackConfigSettingsDottedKey(c *gc.C) {
+	svc := s.AddTestingService(c, "dotted", s.AddConfigCharm(c, "wordpress", dottedConfig, 1))
+	err := svc.UpdateConfigSettings(charm.Settings{"proxy.url": "http://one"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.UpdateConfigSettings(charm.Settings{"proxy.url": "http://two"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[1].Settings, jc.DeepEquals, map[string]interface{}{"proxy.url": "http://one"})
+
+	err = svc.RollbackConfigSettings("mary", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"proxy.url": "http://one"})
+}
+
+var secretConfig = `
+options:
+  admin-password: {description: Password, type: string}
+  api-token: {description: Token, type: string}
+  title: {default: My Title, description: Title, type: string}
+  port: {default: 80, description: Port, type: int}
+`
+
+func (s *ServiceSuite) TestSecretConfigKeys(c *gc.C) {
+	svc := s.AddTestingService(c, "secret", s.AddConfigCharm(c, "wordpress", secretConfig, 1))
+	keys, err := svc.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"admin-password", "api-token"})
+
+	// Keys marked by the operator replace those chosen by name.
+	err = svc.SetSecretConfigKeys([]string{"admin-password", "title"})
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err = svc.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"admin-password", "title"})
+
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err = svc.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"admin-password", "title"})
+
+	err = svc.SetSecretConfigKeys(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err = svc.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, gc.HasLen, 0)
+}
+
+var tokenConfig = `
+options:
+  api-token: {description: Token, type: string}
+  token-expiry-seconds: {default: "3600", description: Token lifetime, type: string}
+`
+
+func (s *ServiceSuite) TestSetSecretConfigKeysOverridesNames(c *gc.C) {
+	svc := s.AddTestingService(c, "token", s.AddConfigCharm(c, "wordpress", tokenConfig, 1))
+	err := svc.UpdateConfigSettings(charm.Settings{"token-expiry-seconds": "60"})
+	c.Assert(err, jc.ErrorIsNil)
+	raw, err := state.RawConfigSettings(svc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.IsEncryptedConfigValue(raw["token-expiry-seconds"]), jc.IsTrue)
+
+	err = svc.SetSecretConfigKeys([]string{"api-token"})
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err := svc.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"api-token"})
+	raw, err = state.RawConfigSettings(svc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw["token-expiry-seconds"], gc.Equals, "60")
+}
+
+func (s *ServiceSuite) TestSetSecretConfigKeysInvalid(c *gc.C) {
+	svc := s.AddTestingService(c, "secret", s.AddConfigCharm(c, "wordpress", secretConfig, 1))
+	err := svc.SetSecretConfigKeys([]string{"missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot set secret config keys of application "secret": config option "missing" not found`)
+	err = svc.SetSecretConfigKeys([]string{"port"})
+	c.Assert(err, gc.ErrorMatches, `cannot set secret config keys of application "secret": config option "port" has type "int", only string options can be secret`)
+}
+
+func (s *ServiceSuite) TestSecretConfigSettingsEncrypted(c *gc.C) {
+	ch := s.AddConfigCharm(c, "wordpress", secretConfig, 1)
+	svc := s.AddTestingService(c, "secret", ch)
+	err := svc.UpdateConfigSettings(charm.Settings{"admin-password": "sekrit", "title": "public"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	raw, err := state.RawConfigSettings(svc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.IsEncryptedConfigValue(raw["admin-password"]), jc.IsTrue)
+	c.Assert(raw["title"], gc.Equals, "public")
+
+	settings, err := svc.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"admin-password": "sekrit", "title": "public"})
+
+	// Setting a secret to its current value is not a change.
+	err = svc.UpdateConfigSettings(charm.Settings{"admin-password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := svc.ConfigSettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Settings, jc.DeepEquals, map[string]interface{}{
+		"admin-password": "sekrit",
+		"title":          "public",
+	})
+
+	// Marking a setting secret encrypts its existing value.
+	err = svc.SetSecretConfigKeys([]string{"admin-password", "title"})
+	c.Assert(err, jc.ErrorIsNil)
+	raw, err = state.RawConfigSettings(svc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.IsEncryptedConfigValue(raw["title"]), jc.IsTrue)
+	c.Assert(state.IsEncryptedConfigValue(raw["admin-password"]), jc.IsTrue)
+
+	// Leaving it out decrypts it again.
+	err = svc.SetSecretConfigKeys([]string{"admin-password"})
+	c.Assert(err, jc.ErrorIsNil)
+	raw, err = state.RawConfigSettings(svc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw["title"], gc.Equals, "public")
+	c.Assert(state.IsEncryptedConfigValue(raw["admin-password"]), jc.IsTrue)
+
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["admin-password"], gc.Equals, "sekrit")
+}
+
+func (s *ServiceSuite) TestSecretConfigKeyNotInDatabase(c *gc.C) {
+	svc := s.AddTestingService(c, "secret", s.AddConfigCharm(c, "wordpress", secretConfig, 1))
+	err := svc.UpdateConfigSettings(charm.Settings{"admin-password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The values can only be decrypted with the key the
+	// state was given, which is not kept in the database.
+	otherKey, err := state.NewSecretConfigKey()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetSecretConfigKey(otherKey)
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.State.SetSecretConfigKey(coretesting.SecretConfigKey)
+	_, err = svc.ConfigSettings()
+	c.Assert(err, gc.ErrorMatches, `setting "admin-password": cannot decrypt secret config value: .*`)
+}
+
+func (s *ServiceSuite) TestSetSecretConfigKeyInvalid(c *gc.C) {
+	err := s.State.SetSecretConfigKey("not base64!")
+	c.Assert(err, gc.ErrorMatches, "cannot decode secret config key: .*")
+	err = s.State.SetSecretConfigKey("Zm9v")
+	c.Assert(err, gc.ErrorMatches, "secret config key of 3 bytes not valid")
+	c.Assert(s.State.SecretConfigKey(), gc.Equals, coretesting.SecretConfigKey)
+}
+
+func (s *ServiceSuite) TestUpdateConfigSettingsEncryptedPrefix(c *gc.C) {
+	svc := s.AddTestingService(c, "secret", s.AddConfigCharm(c, "wordpress", secretConfig, 1))
+	err := svc.UpdateConfigSettings(charm.Settings{"title": "juju-encrypted:v1:c2Vrcml0"})
+	c.Assert(err, gc.ErrorMatches, `value of setting "title" beginning with "juju-encrypted:v1:" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func assertNoSettingsRef(c *gc.C, st *state.State, svcName string, sch *state.Charm) {
 	_, err := state.ServiceSettingsRefCount(st, svcName, sch.URL())
 	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
@@ -1393,6 +1782,89 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceExposedTo(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("public", "", []string{"192.168.1.0/24", "192.168.2.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, []string{"public"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(s.mysql.ExposedSpaces(), jc.DeepEquals, []string{"public"})
+	cidrs, err := s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24", "192.168.2.0/24"})
+
+	// Exposing without restrictions clears the CIDRs and spaces.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+	c.Assert(s.mysql.ExposedSpaces(), gc.HasLen, 0)
+	cidrs, err = s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	// Exposing to a space with no subnets allows no access.
+	_, err = s.State.AddSpace("empty", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetExposedTo(nil, []string{"empty"})
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.mysql.ExposedIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestServiceExposedToInvalid(c *gc.C) {
+	err := s.mysql.SetExposedTo([]string{"10.0.0.0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": invalid CIDR "10.0.0.0"`)
+	err = s.mysql.SetExposedTo(nil, []string{"missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": space "missing" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ServiceSuite) TestServiceExposedEndpoints(c *gc.C) {
+	err := s.mysql.SetExposedEndpoints([]string{"server"}, []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, []string{"server"})
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	// Exposing without endpoints exposes all of them.
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+
+	err = s.mysql.SetExposedEndpoints([]string{"server"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestServiceExposedEndpointsInvalid(c *gc.C) {
+	err := s.mysql.SetExposedEndpoints([]string{"missing"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "missing" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
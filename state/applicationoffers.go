@@ -0,0 +1,193 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationOffer holds the details of an application offered for
+// consumption by other models on the same controller.
+type ApplicationOffer struct {
+	// OfferName is the name of the offer, unique within the model.
+	OfferName string
+
+	// ApplicationName is the name of the offered application.
+	ApplicationName string
+
+	// ApplicationDescription is a description of the offered
+	// application, shown to potential consumers.
+	ApplicationDescription string
+
+	// Endpoints maps the names of the offered endpoints to the
+	// names of the application's endpoints.
+	Endpoints map[string]string
+}
+
+// applicationOfferDoc represents the internal state of an application
+// offer in MongoDB.
+type applicationOfferDoc struct {
+	DocID                  string            `bson:"_id"`
+	OfferName              string            `bson:"offer-name"`
+	ModelUUID              string            `bson:"model-uuid"`
+	ApplicationName        string            `bson:"application-name"`
+	ApplicationDescription string            `bson:"application-description"`
+	Endpoints              map[string]string `bson:"endpoints"`
+}
+
+func (doc *applicationOfferDoc) offer() *ApplicationOffer {
+	offer := &ApplicationOffer{
+		OfferName:              doc.OfferName,
+		ApplicationName:        doc.ApplicationName,
+		ApplicationDescription: doc.ApplicationDescription,
+		Endpoints:              make(map[string]string),
+	}
+	for alias, name := range doc.Endpoints {
+		offer.Endpoints[alias] = name
+	}
+	return offer
+}
+
+// AddApplicationOffer offers the given endpoints of an application for
+// consumption by other models on the controller.
+func (st *State) AddApplicationOffer(offer ApplicationOffer) (_ *ApplicationOffer, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add application offer %q", offer.OfferName)
+	if !names.IsValidApplication(offer.OfferName) {
+		return nil, errors.NotValidf("offer name %q", offer.OfferName)
+	}
+	if len(offer.Endpoints) == 0 {
+		return nil, errors.NotValidf("offer with no endpoints")
+	}
+	app, err := st.Application(offer.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app.Life() != Alive {
+		return nil, errors.Errorf("application %q is not alive", offer.ApplicationName)
+	}
+	for alias, name := range offer.Endpoints {
+		ep, err := app.Endpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("cannot offer endpoint %q as %q: only global provider and requirer endpoints can be offered", name, alias)
+		}
+	}
+	doc := &applicationOfferDoc{
+		DocID:                  st.docID(offer.OfferName),
+		OfferName:              offer.OfferName,
+		ModelUUID:              st.ModelUUID(),
+		ApplicationName:        offer.ApplicationName,
+		ApplicationDescription: offer.ApplicationDescription,
+		Endpoints:              offer.Endpoints,
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(offer.ApplicationName),
+		Assert: isAliveDoc,
+	}, {
+		C:      applicationOffersC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if _, err := st.ApplicationOffer(offer.OfferName); err == nil {
+			return nil, errors.AlreadyExistsf("application offer %q", offer.OfferName)
+		}
+		return nil, errors.Errorf("application %q is not alive", offer.ApplicationName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.offer(), nil
+}
+
+// ApplicationOffer returns the application offer with the given name.
+func (st *State) ApplicationOffer(offerName string) (*ApplicationOffer, error) {
+	offers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var doc applicationOfferDoc
+	err := offers.FindId(offerName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("application offer %q", offerName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get application offer %q", offerName)
+	}
+	return doc.offer(), nil
+}
+
+// AllApplicationOffers returns all the application offers in the model,
+// ordered by offer name.
+func (st *State) AllApplicationOffers() ([]*ApplicationOffer, error) {
+	return st.applicationOffers(nil)
+}
+
+func (st *State) applicationOffers(query bson.D) ([]*ApplicationOffer, error) {
+	offers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []applicationOfferDoc
+	if err := offers.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get application offers")
+	}
+	result := make([]*ApplicationOffer, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.offer()
+	}
+	sort.Sort(applicationOffersByName(result))
+	return result, nil
+}
+
+// RemoveApplicationOffer removes the application offer with the given
+// name. Existing relations with consumers of the offer are not affected.
+func (st *State) RemoveApplicationOffer(offerName string) error {
+	ops := []txn.Op{{
+		C:      applicationOffersC,
+		Id:     st.docID(offerName),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("application offer %q", offerName)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove application offer %q", offerName)
+	}
+	return nil
+}
+
+// removeApplicationOffersOps returns the operations required to remove
+// all the offers of the named application.
+func removeApplicationOffersOps(st *State, appName string) ([]txn.Op, error) {
+	offers, err := st.applicationOffers(bson.D{{"application-name", appName}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, offer := range offers {
+		ops = append(ops, txn.Op{
+			C:      applicationOffersC,
+			Id:     st.docID(offer.OfferName),
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+type applicationOffersByName []*ApplicationOffer
+
+func (s applicationOffersByName) Len() int      { return len(s) }
+func (s applicationOffersByName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s applicationOffersByName) Less(i, j int) bool {
+	return s[i].OfferName < s[j].OfferName
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type applicationOffersSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&applicationOffersSuite{})
+
+func (s *applicationOffersSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *applicationOffersSuite) TestAddApplicationOffer(c *gc.C) {
+	offer, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:              "hosted-mysql",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "a database",
+		Endpoints:              map[string]string{"database": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := &state.ApplicationOffer{
+		OfferName:              "hosted-mysql",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "a database",
+		Endpoints:              map[string]string{"database": "server"},
+	}
+	c.Check(offer, jc.DeepEquals, expected)
+
+	offer, err = s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offer, jc.DeepEquals, expected)
+
+	offers, err := s.State.AllApplicationOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offers, jc.DeepEquals, []*state.ApplicationOffer{expected})
+}
+
+func (s *applicationOffersSuite) TestAddApplicationOfferDuplicate(c *gc.C) {
+	args := state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+	}
+	_, err := s.State.AddApplicationOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddApplicationOffer(args)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *applicationOffersSuite) TestAddApplicationOfferInvalidEndpoint(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"db": "admin"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application offer "hosted-mysql": application "mysql" has no "admin" relation`)
+
+	_, err = s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application offer "hosted-mysql": offer with no endpoints not valid`)
+}
+
+func (s *applicationOffersSuite) TestRemoveApplicationOffer(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationOffersSuite) TestOffersRemovedWithApplication(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.ApplicationOffer{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	offers, err := s.State.AllApplicationOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offers, gc.HasLen, 0)
+}
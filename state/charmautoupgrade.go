@@ -0,0 +1,252 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// AutoUpgradeMode determines whether, and to which revisions, the
+// charm of an application is upgraded automatically.
+type AutoUpgradeMode string
+
+const (
+	// AutoUpgradeOff disables automatic upgrades. It is the default.
+	AutoUpgradeOff AutoUpgradeMode = "off"
+
+	// AutoUpgradePatchChannel upgrades to newer revisions in the
+	// tracked channel only when they are compatible with the deployed
+	// charm: every config key must keep its type, and every endpoint
+	// its interface and role.
+	AutoUpgradePatchChannel AutoUpgradeMode = "patch-channel"
+
+	// AutoUpgradeChannel upgrades to any newer revision in the
+	// tracked channel.
+	AutoUpgradeChannel AutoUpgradeMode = "channel"
+)
+
+// Validate returns an error if the mode is not known.
+func (m AutoUpgradeMode) Validate() error {
+	switch m {
+	case AutoUpgradeOff, AutoUpgradePatchChannel, AutoUpgradeChannel:
+		return nil
+	}
+	return errors.NotValidf("auto-upgrade mode %q", m)
+}
+
+// MaintenanceWindow is a daily period, in UTC, during which automatic
+// charm upgrades may happen. The zero value allows upgrades at any
+// time.
+type MaintenanceWindow struct {
+	// Start is the time of day at which the window opens.
+	Start time.Duration
+	// Duration is the length of the window.
+	Duration time.Duration
+}
+
+// ParseMaintenanceWindow parses a window of the form "HH:MM-HH:MM".
+// A window whose end is before its start runs past midnight. An empty
+// string yields the zero window.
+func ParseMaintenanceWindow(s string) (MaintenanceWindow, error) {
+	if s == "" {
+		return MaintenanceWindow{}, nil
+	}
+	var startH, startM, endH, endM int
+	var rest string
+	n, _ := fmt.Sscanf(s, "%d:%d-%d:%d%s", &startH, &startM, &endH, &endM, &rest)
+	if n != 4 || !validTimeOfDay(startH, startM) || !validTimeOfDay(endH, endM) {
+		return MaintenanceWindow{}, errors.NotValidf("maintenance window %q", s)
+	}
+	start := time.Duration(startH)*time.Hour + time.Duration(startM)*time.Minute
+	end := time.Duration(endH)*time.Hour + time.Duration(endM)*time.Minute
+	if start == end {
+		return MaintenanceWindow{}, errors.NotValidf("empty maintenance window %q", s)
+	}
+	duration := end - start
+	if duration < 0 {
+		duration += 24 * time.Hour
+	}
+	return MaintenanceWindow{Start: start, Duration: duration}, nil
+}
+
+func validTimeOfDay(hours, minutes int) bool {
+	return hours >= 0 && hours < 24 && minutes >= 0 && minutes < 60
+}
+
+// IsZero reports whether the window allows upgrades at any time.
+func (w MaintenanceWindow) IsZero() bool {
+	return w.Duration == 0
+}
+
+// Contains reports whether the given time falls within the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	if w.IsZero() {
+		return true
+	}
+	t = t.UTC()
+	sinceMidnight := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	sinceStart := sinceMidnight - w.Start
+	if sinceStart < 0 {
+		sinceStart += 24 * time.Hour
+	}
+	return sinceStart < w.Duration
+}
+
+// String returns the window in the form accepted by
+// ParseMaintenanceWindow.
+func (w MaintenanceWindow) String() string {
+	if w.IsZero() {
+		return ""
+	}
+	end := (w.Start + w.Duration) % (24 * time.Hour)
+	return fmt.Sprintf("%s-%s", formatTimeOfDay(w.Start), formatTimeOfDay(end))
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// AutoUpgradePolicy holds the automatic charm upgrade settings of an
+// application.
+type AutoUpgradePolicy struct {
+	Mode   AutoUpgradeMode
+	Window MaintenanceWindow
+}
+
+// Allows reports whether the policy allows an automatic upgrade at the
+// given time.
+func (p AutoUpgradePolicy) Allows(now time.Time) bool {
+	return p.Mode != AutoUpgradeOff && p.Window.Contains(now)
+}
+
+// AutoUpgradePolicy returns the automatic charm upgrade policy of the
+// application.
+func (s *Application) AutoUpgradePolicy() (AutoUpgradePolicy, error) {
+	policy := AutoUpgradePolicy{Mode: AutoUpgradeOff}
+	if s.doc.AutoUpgrade != "" {
+		policy.Mode = AutoUpgradeMode(s.doc.AutoUpgrade)
+	}
+	window, err := ParseMaintenanceWindow(s.doc.MaintenanceWindow)
+	if err != nil {
+		return AutoUpgradePolicy{}, errors.Trace(err)
+	}
+	policy.Window = window
+	return policy, nil
+}
+
+// SetAutoUpgradePolicy sets the automatic charm upgrade policy of the
+// application.
+func (s *Application) SetAutoUpgradePolicy(policy AutoUpgradePolicy) error {
+	if err := policy.Mode.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	mode := string(policy.Mode)
+	if policy.Mode == AutoUpgradeOff {
+		mode = ""
+	}
+	window := policy.Window.String()
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{
+			{"auto-upgrade", mode},
+			{"maintenance-window", window},
+		}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errNotAlive), "cannot set auto-upgrade policy for application %q", s)
+	}
+	s.doc.AutoUpgrade = mode
+	s.doc.MaintenanceWindow = window
+	return nil
+}
+
+// AutoUpgradeCharm upgrades the application to the given charm if its
+// auto-upgrade policy allows it at the given time, and records the
+// upgrade in the application's status history. It reports whether the
+// application was upgraded.
+func (s *Application) AutoUpgradeCharm(ch *Charm, now time.Time) (bool, error) {
+	policy, err := s.AutoUpgradePolicy()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !policy.Allows(now) {
+		return false, nil
+	}
+	oldURL := s.doc.CharmURL
+	newURL := ch.URL()
+	if *newURL.WithRevision(-1) != *oldURL.WithRevision(-1) || newURL.Revision <= oldURL.Revision {
+		return false, nil
+	}
+	if policy.Mode == AutoUpgradePatchChannel {
+		current, _, err := s.Charm()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if reason := incompatibleCharmReason(current, ch); reason != "" {
+			logger.Infof("not upgrading application %q to %q: %s", s, newURL, reason)
+			return false, nil
+		}
+	}
+	err = s.SetCharm(SetCharmConfig{
+		Charm:   ch,
+		Channel: csparams.Channel(s.doc.Channel),
+	})
+	if err != nil {
+		return false, errors.Annotatef(err, "cannot upgrade application %q", s)
+	}
+	current, err := s.Status()
+	if err != nil {
+		return true, errors.Trace(err)
+	}
+	probablyUpdateStatusHistory(s.st, s.globalKey(), statusDoc{
+		Status:     current.Status,
+		StatusInfo: fmt.Sprintf("charm automatically upgraded from %q to %q", oldURL, newURL),
+		Updated:    now.UnixNano(),
+	})
+	return true, nil
+}
+
+// incompatibleCharmReason returns why the new charm is not a
+// compatible replacement for the old one, or "" if it is.
+func incompatibleCharmReason(oldCharm, newCharm *Charm) string {
+	newOptions := charmOptions(newCharm)
+	for name, oldOption := range charmOptions(oldCharm) {
+		newOption, ok := newOptions[name]
+		if !ok {
+			return fmt.Sprintf("config key %q removed", name)
+		}
+		if newOption.Type != oldOption.Type {
+			return fmt.Sprintf("config key %q changes type", name)
+		}
+	}
+	newRelations := newCharm.Meta().CombinedRelations()
+	for name, oldRelation := range oldCharm.Meta().CombinedRelations() {
+		newRelation, ok := newRelations[name]
+		if !ok {
+			return fmt.Sprintf("endpoint %q removed", name)
+		}
+		if newRelation.Interface != oldRelation.Interface || newRelation.Role != oldRelation.Role {
+			return fmt.Sprintf("endpoint %q changes interface", name)
+		}
+	}
+	return ""
+}
+
+func charmOptions(ch *Charm) map[string]charm.Option {
+	if ch.Config() == nil {
+		return nil
+	}
+	return ch.Config().Options
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type CharmAutoUpgradeSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&CharmAutoUpgradeSuite{})
+
+const autoUpgradeConfig = `
+options:
+  port:
+    type: int
+    default: 3306
+`
+
+func (s *CharmAutoUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddConfigCharm(c, "mysql", autoUpgradeConfig, 1)
+	s.mysql = s.AddTestingService(c, "mysql", ch)
+}
+
+func (s *CharmAutoUpgradeSuite) TestParseMaintenanceWindow(c *gc.C) {
+	window, err := state.ParseMaintenanceWindow("22:30-02:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window, gc.Equals, state.MaintenanceWindow{
+		Start:    22*time.Hour + 30*time.Minute,
+		Duration: 3*time.Hour + 30*time.Minute,
+	})
+	c.Assert(window.String(), gc.Equals, "22:30-02:00")
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2017, 3, 1, hour, minute, 0, 0, time.UTC)
+	}
+	c.Assert(window.Contains(at(23, 0)), jc.IsTrue)
+	c.Assert(window.Contains(at(1, 59)), jc.IsTrue)
+	c.Assert(window.Contains(at(2, 0)), jc.IsFalse)
+	c.Assert(window.Contains(at(12, 0)), jc.IsFalse)
+
+	window, err = state.ParseMaintenanceWindow("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window.IsZero(), jc.IsTrue)
+	c.Assert(window.Contains(at(12, 0)), jc.IsTrue)
+
+	for _, bad := range []string{"2am-4am", "02:00", "25:00-02:00", "02:00-02:00", "02:00-03:00x"} {
+		_, err := state.ParseMaintenanceWindow(bad)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("%q", bad))
+	}
+}
+
+func (s *CharmAutoUpgradeSuite) TestAutoUpgradePolicy(c *gc.C) {
+	policy, err := s.mysql.AutoUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, state.AutoUpgradePolicy{Mode: state.AutoUpgradeOff})
+
+	window, err := state.ParseMaintenanceWindow("02:00-04:00")
+	c.Assert(err, jc.ErrorIsNil)
+	expect := state.AutoUpgradePolicy{Mode: state.AutoUpgradeChannel, Window: window}
+	err = s.mysql.SetAutoUpgradePolicy(expect)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err = mysql.AutoUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, expect)
+
+	err = s.mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{Mode: "sometimes"})
+	c.Assert(err, gc.ErrorMatches, `auto-upgrade mode "sometimes" not valid`)
+}
+
+func (s *CharmAutoUpgradeSuite) TestAutoUpgradeCharm(c *gc.C) {
+	newCharm := s.AddConfigCharm(c, "mysql", autoUpgradeConfig+`
+  engine:
+    type: string
+`, 2)
+	now := time.Date(2017, 3, 1, 3, 0, 0, 0, time.UTC)
+
+	// Nothing happens while the policy is off.
+	upgraded, err := s.mysql.AutoUpgradeCharm(newCharm, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgraded, jc.IsFalse)
+
+	// Nor outside the maintenance window.
+	window, err := state.ParseMaintenanceWindow("04:00-05:00")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{
+		Mode:   state.AutoUpgradePatchChannel,
+		Window: window,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	upgraded, err = s.mysql.AutoUpgradeCharm(newCharm, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgraded, jc.IsFalse)
+
+	upgraded, err = s.mysql.AutoUpgradeCharm(newCharm, now.Add(90*time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgraded, jc.IsTrue)
+	curl, _ := s.mysql.CharmURL()
+	c.Assert(curl, gc.DeepEquals, newCharm.URL())
+
+	history, err := s.mysql.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	var found bool
+	for _, entry := range history {
+		found = found || entry.Message == `charm automatically upgraded from "local:quantal/quantal-mysql-1" to "local:quantal/quantal-mysql-2"`
+	}
+	c.Assert(found, jc.IsTrue)
+
+	// Older revisions are ignored.
+	upgraded, err = s.mysql.AutoUpgradeCharm(newCharm, now.Add(90*time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgraded, jc.IsFalse)
+}
+
+func (s *CharmAutoUpgradeSuite) TestAutoUpgradeCharmIncompatible(c *gc.C) {
+	newCharm := s.AddConfigCharm(c, "mysql", `
+options:
+  port:
+    type: string
+`, 2)
+	now := time.Now()
+
+	err := s.mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{Mode: state.AutoUpgradePatchChannel})
+	c.Assert(err, jc.ErrorIsNil)
+	upgraded, err := s.mysql.AutoUpgradeCharm(newCharm, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgraded, jc.IsFalse)
+
+	// With the channel mode, any newer revision is used.
+	err = s.mysql.SetAutoUpgradePolicy(state.AutoUpgradePolicy{Mode: state.AutoUpgradeChannel})
+	c.Assert(err, jc.ErrorIsNil)
+	upgraded, err = s.mysql.AutoUpgradeCharm(newCharm, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgraded, jc.IsTrue)
+}
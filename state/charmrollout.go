@@ -0,0 +1,510 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
+)
+
+// CharmRolloutParams holds the parameters of a rolling charm upgrade.
+type CharmRolloutParams struct {
+	// BatchSize is the maximum number of units that may be upgrading
+	// at any one time.
+	BatchSize int
+
+	// WaitForActive, if true, holds each unit in its batch until its
+	// workload status is active again after the upgrade.
+	WaitForActive bool
+
+	// PauseOnError, if true, pauses the rollout when a unit in the
+	// current batch goes into an error state.
+	PauseOnError bool
+}
+
+// Validate returns an error if the parameters are not valid.
+func (p CharmRolloutParams) Validate() error {
+	if p.BatchSize < 1 {
+		return errors.NotValidf("batch size %d", p.BatchSize)
+	}
+	return nil
+}
+
+// CharmRollout describes a rolling charm upgrade of an application.
+type CharmRollout struct {
+	CharmRolloutParams
+
+	// Application is the name of the application being upgraded.
+	Application string
+
+	// CharmURL is the charm the units are being upgraded to.
+	CharmURL *charm.URL
+
+	// PreviousCharmURL is the charm the units are being upgraded from.
+	PreviousCharmURL *charm.URL
+
+	// Paused reports whether the rollout has been paused, and Message
+	// why.
+	Paused  bool
+	Message string
+
+	// Pending holds the names of the units still waiting to upgrade,
+	// in the order in which they will be released.
+	Pending []string
+
+	// Upgrading holds the names of the units in the current batch.
+	Upgrading []string
+}
+
+// charmRolloutDoc records a rolling charm upgrade in progress. While
+// the rollout exists, each unit still waiting for its turn has its
+// TargetCharmURL pinned to the previous charm; releasing a unit clears
+// the pin so it follows the application's charm.
+type charmRolloutDoc struct {
+	DocID            string     `bson:"_id"`
+	ModelUUID        string     `bson:"model-uuid"`
+	Application      string     `bson:"application"`
+	CharmURL         *charm.URL `bson:"charm-url"`
+	PreviousCharmURL *charm.URL `bson:"previous-charm-url"`
+	BatchSize        int        `bson:"batch-size"`
+	WaitForActive    bool       `bson:"wait-for-active"`
+	PauseOnError     bool       `bson:"pause-on-error"`
+	Paused           bool       `bson:"paused"`
+	Message          string     `bson:"message,omitempty"`
+	Pending          []string   `bson:"pending"`
+	Upgrading        []string   `bson:"upgrading"`
+	TxnRevno         int64      `bson:"txn-revno"`
+}
+
+func (doc *charmRolloutDoc) rollout() *CharmRollout {
+	return &CharmRollout{
+		CharmRolloutParams: CharmRolloutParams{
+			BatchSize:     doc.BatchSize,
+			WaitForActive: doc.WaitForActive,
+			PauseOnError:  doc.PauseOnError,
+		},
+		Application:      doc.Application,
+		CharmURL:         doc.CharmURL,
+		PreviousCharmURL: doc.PreviousCharmURL,
+		Paused:           doc.Paused,
+		Message:          doc.Message,
+		Pending:          doc.Pending,
+		Upgrading:        doc.Upgrading,
+	}
+}
+
+func (s *Application) charmRolloutDoc() (*charmRolloutDoc, error) {
+	rollouts, closer := s.st.getCollection(charmRolloutsC)
+	defer closer()
+
+	var doc charmRolloutDoc
+	err := rollouts.FindId(s.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("rolling charm upgrade of application %q", s.doc.Name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get rolling charm upgrade of application %q", s.doc.Name)
+	}
+	return &doc, nil
+}
+
+// CharmRollout returns the rolling charm upgrade of the application
+// in progress. An error satisfying errors.IsNotFound is returned if
+// there is none.
+func (s *Application) CharmRollout() (*CharmRollout, error) {
+	doc, err := s.charmRolloutDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.rollout(), nil
+}
+
+// charmRolloutOps returns the operations, run as part of SetCharm,
+// that start, or abort, a rolling upgrade of the application's units
+// to the given charm. Otherwise, they ensure that no rollout is in
+// progress, so that a plain upgrade cannot overtake one.
+func (s *Application) charmRolloutOps(curl *charm.URL, params *CharmRolloutParams, abort bool) ([]txn.Op, error) {
+	doc, err := s.charmRolloutDoc()
+	if abort && err != nil {
+		return nil, errors.Trace(err)
+	} else if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	units, err := s.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if abort {
+		// Clear every unit's pin; all of them follow the application
+		// back to the previous charm.
+		ops := []txn.Op{{
+			C:      charmRolloutsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Remove: true,
+		}}
+		for _, unit := range units {
+			ops = append(ops, releaseUnitOp(s.st, unit.Name()))
+		}
+		return ops, nil
+	}
+	if doc != nil {
+		return nil, errors.Errorf("rolling upgrade of application %q to %q in progress", s.doc.Name, doc.CharmURL)
+	}
+	if params == nil {
+		return []txn.Op{{
+			C:      charmRolloutsC,
+			Id:     s.st.docID(s.doc.Name),
+			Assert: txn.DocMissing,
+		}}, nil
+	}
+	if s.doc.CharmURL.String() == curl.String() {
+		return nil, errors.Errorf("cannot start rolling upgrade: application %q already uses charm %q", s.doc.Name, curl)
+	}
+	names := make([]string, len(units))
+	for i, unit := range units {
+		names[i] = unit.Name()
+	}
+	names = utils.SortStringsNaturally(names)
+	ops := []txn.Op{{
+		C:      charmRolloutsC,
+		Id:     s.st.docID(s.doc.Name),
+		Assert: txn.DocMissing,
+		Insert: &charmRolloutDoc{
+			Application:      s.doc.Name,
+			CharmURL:         curl,
+			PreviousCharmURL: s.doc.CharmURL,
+			BatchSize:        params.BatchSize,
+			WaitForActive:    params.WaitForActive,
+			PauseOnError:     params.PauseOnError,
+			Pending:          names,
+			Upgrading:        []string{},
+		},
+	}, {
+		// Every existing unit must be pinned.
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"unitcount", len(units)}},
+	}}
+	for _, name := range names {
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     s.st.docID(name),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"targetcharmurl", s.doc.CharmURL}}}},
+		})
+	}
+	return ops, nil
+}
+
+// releaseUnitOp returns the operation that clears the pin holding the
+// named unit at its charm during a rollout. Units removed meanwhile
+// are ignored.
+func releaseUnitOp(st *State, unitName string) txn.Op {
+	return txn.Op{
+		C:      unitsC,
+		Id:     st.docID(unitName),
+		Update: bson.D{{"$unset", bson.D{{"targetcharmurl", nil}}}},
+	}
+}
+
+// AdvanceCharmRollout moves a rolling charm upgrade of the application
+// forward. Units that have finished upgrading leave the current batch,
+// and further units are released until the batch is full again; once
+// every unit has been upgraded, the rollout is removed. A unit that
+// goes into an error state pauses the rollout if it was started with
+// PauseOnError, and otherwise counts as finished. Nothing is done if
+// there is no rollout in progress, or if it is paused.
+func (s *Application) AdvanceCharmRollout() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.charmRolloutDoc()
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Paused {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		var paused bool
+		var message string
+		upgrading := []string{}
+		for _, name := range doc.Upgrading {
+			done, failure, err := s.st.charmRolloutUnitDone(doc, name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if failure != "" && doc.PauseOnError {
+				paused = true
+				message = failure
+			} else if done {
+				continue
+			}
+			upgrading = append(upgrading, name)
+		}
+		pending := doc.Pending
+		var released []string
+		for !paused && len(upgrading) < doc.BatchSize && len(pending) > 0 {
+			released = append(released, pending[0])
+			upgrading = append(upgrading, pending[0])
+			pending = pending[1:]
+		}
+		if !paused && len(released) == 0 && len(upgrading) == len(doc.Upgrading) {
+			// Nothing has changed; updating the document anyway
+			// would only trigger another attempt to advance.
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		sameRollout := bson.D{{"txn-revno", doc.TxnRevno}}
+		var ops []txn.Op
+		if len(pending) == 0 && len(upgrading) == 0 {
+			ops = append(ops, txn.Op{
+				C:      charmRolloutsC,
+				Id:     doc.DocID,
+				Assert: sameRollout,
+				Remove: true,
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      charmRolloutsC,
+				Id:     doc.DocID,
+				Assert: sameRollout,
+				Update: bson.D{{"$set", bson.D{
+					{"paused", paused},
+					{"message", message},
+					{"pending", pending},
+					{"upgrading", upgrading},
+				}}},
+			})
+		}
+		for _, name := range released {
+			ops = append(ops, releaseUnitOp(s.st, name))
+		}
+		return ops, nil
+	}
+	err := s.st.run(buildTxn)
+	return errors.Annotatef(err, "cannot advance rolling upgrade of application %q", s.doc.Name)
+}
+
+// charmRolloutUnitDone reports whether the named unit has finished its
+// part of a rollout. If the unit is in an error state, failure
+// describes it.
+func (st *State) charmRolloutUnitDone(doc *charmRolloutDoc, name string) (done bool, failure string, _ error) {
+	unit, err := st.Unit(name)
+	if errors.IsNotFound(err) {
+		return true, "", nil
+	} else if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	if unit.Life() != Alive {
+		// Departing units must not hold up the rollout.
+		return true, "", nil
+	}
+	info, err := unit.Status()
+	if err != nil {
+		return false, "", errors.Trace(err)
+	}
+	if info.Status == status.StatusError {
+		return true, fmt.Sprintf("unit %s failed: %s", name, info.Message), nil
+	}
+	curl, _ := unit.CharmURL()
+	if curl == nil || curl.String() != doc.CharmURL.String() {
+		return false, "", nil
+	}
+	if doc.WaitForActive && info.Status != status.StatusActive {
+		return false, "", nil
+	}
+	return true, "", nil
+}
+
+// ResumeCharmRollout resumes a paused rolling charm upgrade of the
+// application.
+func (s *Application) ResumeCharmRollout() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.charmRolloutDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !doc.Paused {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      charmRolloutsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"paused", false},
+				{"message", ""},
+			}}},
+		}}, nil
+	}
+	err := s.st.run(buildTxn)
+	return errors.Annotatef(err, "cannot resume rolling upgrade of application %q", s.doc.Name)
+}
+
+// AbortCharmRollout aborts a rolling charm upgrade of the application.
+// The application is set back to its previous charm, and every unit,
+// including those already upgraded, returns to it.
+func (s *Application) AbortCharmRollout() error {
+	doc, err := s.charmRolloutDoc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	previous, err := s.st.Charm(doc.PreviousCharmURL)
+	if err != nil {
+		return errors.Annotatef(err, "cannot abort rolling upgrade of application %q", s.doc.Name)
+	}
+	err = s.setCharm(SetCharmConfig{
+		Charm:   previous,
+		Channel: s.Channel(),
+	}, true)
+	return errors.Annotatef(err, "cannot abort rolling upgrade of application %q", s.doc.Name)
+}
+
+// TargetCharmURL returns the charm URL the unit should be running: the
+// charm it is pinned at during a rolling charm upgrade, or otherwise
+// the charm of its application. The force flag is that of the
+// application, and is never set for a pinned unit.
+func (u *Unit) TargetCharmURL() (*charm.URL, bool, error) {
+	if u.doc.TargetCharmURL != nil {
+		return u.doc.TargetCharmURL, false, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	curl, force := app.CharmURL()
+	return curl, force, nil
+}
+
+// removeCharmRolloutOp returns the operation that removes any rolling
+// charm upgrade of the named application.
+func removeCharmRolloutOp(st *State, appName string) txn.Op {
+	return txn.Op{
+		C:      charmRolloutsC,
+		Id:     st.docID(appName),
+		Remove: true,
+	}
+}
+
+// charmRolloutsWatcher notifies of applications whose rolling charm
+// upgrades may be ready to advance.
+type charmRolloutsWatcher struct {
+	commonWatcher
+	rolling set.Strings
+	out     chan []string
+}
+
+var _ Watcher = (*charmRolloutsWatcher)(nil)
+
+// WatchCharmRollouts returns a StringsWatcher reporting the names of
+// applications with a rolling charm upgrade in progress that may be
+// ready to advance: when the rollout itself changes, or when one of
+// the application's units, or its status, changes.
+func (st *State) WatchCharmRollouts() StringsWatcher {
+	w := &charmRolloutsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		rolling:       make(set.Strings),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+func (w *charmRolloutsWatcher) initial() (set.Strings, error) {
+	rollouts, closer := w.st.getCollection(charmRolloutsC)
+	defer closer()
+
+	var doc charmRolloutDoc
+	iter := rollouts.Find(nil).Iter()
+	for iter.Next(&doc) {
+		w.rolling.Add(doc.Application)
+	}
+	return set.NewStrings(w.rolling.Values()...), iter.Close()
+}
+
+// unitApplication returns the application of the unit whose unit or
+// status document changed, if it is being upgraded.
+func (w *charmRolloutsWatcher) unitApplication(key string) (string, bool) {
+	key = strings.TrimSuffix(strings.TrimPrefix(key, "u#"), "#charm")
+	appName, err := names.UnitApplication(key)
+	if err != nil || !w.rolling.Contains(appName) {
+		return "", false
+	}
+	return appName, true
+}
+
+func (w *charmRolloutsWatcher) loop() error {
+	rolloutsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(charmRolloutsC, rolloutsCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(charmRolloutsC, rolloutsCh)
+	unitsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(unitsC, unitsCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(unitsC, unitsCh)
+	statusesCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(statusesC, statusesCh, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(statusesC, statusesCh)
+
+	changes, err := w.initial()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-rolloutsCh:
+			appName := w.st.localID(change.Id.(string))
+			if change.Revno == -1 {
+				// The rollout is complete, or was aborted.
+				w.rolling.Remove(appName)
+				changes.Remove(appName)
+			} else {
+				w.rolling.Add(appName)
+				changes.Add(appName)
+			}
+		case change := <-unitsCh:
+			if appName, ok := w.unitApplication(w.st.localID(change.Id.(string))); ok {
+				changes.Add(appName)
+			}
+		case change := <-statusesCh:
+			if appName, ok := w.unitApplication(w.st.localID(change.Id.(string))); ok {
+				changes.Add(appName)
+			}
+		case out <- changes.Values():
+			out = nil
+			changes = make(set.Strings)
+		}
+		if !changes.IsEmpty() {
+			out = w.out
+		}
+	}
+}
+
+// Changes is part of StringsWatcher.
+func (w *charmRolloutsWatcher) Changes() <-chan []string {
+	return w.out
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+)
+
+type CharmRolloutSuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Application
+	units    []*state.Unit
+}
+
+var _ = gc.Suite(&CharmRolloutSuite{})
+
+func (s *CharmRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = s.AddMetaCharm(c, "mysql", metaBase, 2)
+	s.mysql = s.AddTestingService(c, "mysql", s.charm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.mysql.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.charm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *CharmRolloutSuite) startRollout(c *gc.C, params state.CharmRolloutParams) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:   s.newCharm,
+		Rollout: &params,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) assertTargetCharmURL(c *gc.C, unit *state.Unit, expect *charm.URL) {
+	err := unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, force, err := unit.TargetCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, expect)
+	c.Assert(force, jc.IsFalse)
+}
+
+func (s *CharmRolloutSuite) upgradeUnit(c *gc.C, unit *state.Unit, st status.Status) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = unit.SetStatus(status.StatusInfo{Status: st, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmRolloutSuite) TestSetCharmInvalidBatchSize(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:   s.newCharm,
+		Rollout: &state.CharmRolloutParams{},
+	})
+	c.Assert(err, gc.ErrorMatches, "batch size 0 not valid")
+}
+
+func (s *CharmRolloutSuite) TestStartRolloutPinsUnits(c *gc.C) {
+	s.startRollout(c, state.CharmRolloutParams{BatchSize: 2})
+
+	rollout, err := s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.CharmURL, gc.DeepEquals, s.newCharm.URL())
+	c.Assert(rollout.PreviousCharmURL, gc.DeepEquals, s.charm.URL())
+	c.Assert(rollout.Pending, jc.DeepEquals, []string{"mysql/0", "mysql/1", "mysql/2"})
+	c.Assert(rollout.Upgrading, gc.HasLen, 0)
+	for _, unit := range s.units {
+		s.assertTargetCharmURL(c, unit, s.charm.URL())
+	}
+
+	// New units follow the application.
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertTargetCharmURL(c, unit, s.newCharm.URL())
+}
+
+func (s *CharmRolloutSuite) TestSetCharmWhileRolling(c *gc.C) {
+	s.startRollout(c, state.CharmRolloutParams{BatchSize: 1})
+	other := s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: other})
+	c.Assert(err, gc.ErrorMatches, `.*rolling upgrade of application "mysql" to "local:quantal/quantal-mysql-2" in progress`)
+}
+
+func (s *CharmRolloutSuite) TestAdvance(c *gc.C) {
+	s.startRollout(c, state.CharmRolloutParams{BatchSize: 2, WaitForActive: true})
+
+	err := s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err := s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Pending, jc.DeepEquals, []string{"mysql/2"})
+	c.Assert(rollout.Upgrading, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	s.assertTargetCharmURL(c, s.units[0], s.newCharm.URL())
+	s.assertTargetCharmURL(c, s.units[1], s.newCharm.URL())
+	s.assertTargetCharmURL(c, s.units[2], s.charm.URL())
+
+	// A unit that has upgraded is held until its workload is active.
+	s.upgradeUnit(c, s.units[0], status.StatusMaintenance)
+	err = s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err = s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Upgrading, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+
+	s.upgradeUnit(c, s.units[0], status.StatusActive)
+	err = s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err = s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Pending, gc.HasLen, 0)
+	c.Assert(rollout.Upgrading, jc.DeepEquals, []string{"mysql/1", "mysql/2"})
+	s.assertTargetCharmURL(c, s.units[2], s.newCharm.URL())
+
+	s.upgradeUnit(c, s.units[1], status.StatusActive)
+	s.upgradeUnit(c, s.units[2], status.StatusActive)
+	err = s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.CharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRolloutSuite) TestPauseOnErrorAndResume(c *gc.C) {
+	s.startRollout(c, state.CharmRolloutParams{BatchSize: 1, PauseOnError: true})
+	err := s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	err = s.units[0].SetAgentStatus(status.StatusInfo{
+		Status:  status.StatusError,
+		Message: "upgrade-charm hook failed",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err := s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Paused, jc.IsTrue)
+	c.Assert(rollout.Message, gc.Equals, "unit mysql/0 failed: upgrade-charm hook failed")
+	c.Assert(rollout.Upgrading, jc.DeepEquals, []string{"mysql/0"})
+	s.assertTargetCharmURL(c, s.units[1], s.charm.URL())
+
+	// Paused rollouts do not advance.
+	s.upgradeUnit(c, s.units[0], status.StatusActive)
+	err = s.units[0].SetAgentStatus(status.StatusInfo{Status: status.StatusIdle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertTargetCharmURL(c, s.units[1], s.charm.URL())
+
+	err = s.mysql.ResumeCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err = s.mysql.CharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Paused, jc.IsFalse)
+	c.Assert(rollout.Upgrading, jc.DeepEquals, []string{"mysql/1"})
+	s.assertTargetCharmURL(c, s.units[1], s.newCharm.URL())
+}
+
+func (s *CharmRolloutSuite) TestAbort(c *gc.C) {
+	s.startRollout(c, state.CharmRolloutParams{BatchSize: 1})
+	err := s.mysql.AdvanceCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.AbortCharmRollout()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.CharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	curl, _ := s.mysql.CharmURL()
+	c.Assert(curl, gc.DeepEquals, s.charm.URL())
+	for _, unit := range s.units {
+		s.assertTargetCharmURL(c, unit, s.charm.URL())
+	}
+
+	err = s.mysql.AbortCharmRollout()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmRolloutSuite) TestWatchCharmRollouts(c *gc.C) {
+	w := s.State.WatchCharmRollouts()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Units of applications that are not rolling are ignored.
+	now := time.Now()
+	err := s.units[0].SetStatus(status.StatusInfo{Status: status.StatusActive, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	s.startRollout(c, state.CharmRolloutParams{BatchSize: 1})
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	err = s.units[1].SetStatus(status.StatusInfo{Status: status.StatusMaintenance, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+}
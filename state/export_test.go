@@ -40,6 +40,8 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxSettingsHistory = maxSettingsHistory
 )
 
 var (
@@ -113,6 +115,32 @@ func ServiceSettingsRefCount(st *State, appName string, curl *charm.URL) (int, e
 	return nsRefcounts.read(refcounts, key)
 }
 
+// RawConfigSettings returns the application's config settings as
+// stored, without decrypting secret values.
+func RawConfigSettings(app *Application) (map[string]interface{}, error) {
+	settings, err := readSettings(app.st, settingsC, app.settingsKey())
+	if err != nil {
+		return nil, err
+	}
+	return settings.Map(), nil
+}
+
+// RawConfigSettingsHistory returns the application's config settings
+// history as stored, without decrypting secret values.
+func RawConfigSettingsHistory(app *Application) ([]SettingsRevision, error) {
+	return settingsHistory(app.st, app.globalKey())
+}
+
+func IsEncryptedConfigValue(value interface{}) bool {
+	return isEncryptedConfigValue(value)
+}
+
+// ApplicationHasSecretConfigKeys reports whether the operator has
+// chosen the application's secret config keys.
+func ApplicationHasSecretConfigKeys(app *Application) bool {
+	return app.doc.SecretConfigKeys != nil
+}
+
 func AddTestingCharm(c *gc.C, st *State, name string) *Charm {
 	return addCharm(c, st, "quantal", testcharms.Repo.CharmDir(name))
 }
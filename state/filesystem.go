@@ -135,6 +135,12 @@ type FilesystemParams struct {
 	// the filesystem's lifecycle will be bound.
 	binding names.Tag
 
+	// volume, if non-zero, is the tag of an existing volume on
+	// which the filesystem is to be managed. If zero, and the
+	// storage provider cannot create filesystems directly, a
+	// new backing volume will be created.
+	volume names.VolumeTag
+
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
 }
@@ -737,7 +743,13 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if err != nil {
 		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
 	}
-	if !provider.Supports(storage.StorageKindFilesystem) {
+	if !provider.Supports(storage.StorageKindFilesystem) && params.volume != (names.VolumeTag{}) {
+		// The filesystem is to be managed on an existing volume,
+		// such as one imported into the model.
+		volumeTag = params.volume
+		volumeId = volumeTag.Id()
+		ops = append(ops, attachExistingEntityOp(volumesC, volumeId))
+	} else if !provider.Supports(storage.StorageKindFilesystem) {
 		var volumeOps []txn.Op
 		volumeParams := VolumeParams{
 			params.storage,
@@ -901,6 +913,88 @@ func (st *State) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo)
 	return st.run(buildTxn)
 }
 
+// ResizeFilesystem requests that the filesystem with the specified tag
+// be grown to the given size in MiB. Filesystems backed by a volume are
+// resized by resizing the volume; the filesystem's size is updated when
+// the volume has grown. If the filesystem has not yet been provisioned,
+// its provisioning parameters are updated. Provisioned filesystems that
+// are not backed by a volume cannot be resized.
+func (st *State) ResizeFilesystem(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize filesystem %q", tag.Id())
+	f, err := st.filesystemByTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if volumeTag, err := f.Volume(); err == nil {
+		return st.ResizeVolume(volumeTag, size)
+	} else if errors.Cause(err) != ErrNoBackingVolume {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			f, err = st.filesystemByTag(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if f.Life() != Alive {
+			return nil, errors.New("filesystem is not alive")
+		}
+		params, ok := f.Params()
+		if !ok {
+			return nil, errors.NotSupportedf("resizing provisioned filesystem without a backing volume")
+		}
+		if size <= params.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be greater than current size %dMiB",
+				size, params.Size,
+			)
+		}
+		return []txn.Op{{
+			C:  filesystemsC,
+			Id: tag.Id(),
+			Assert: append(bson.D{
+				{"info", bson.D{{"$exists", false}}},
+				{"params.size", params.Size},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"params.size", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// growVolumeFilesystemOps returns txn.Ops to grow the filesystem
+// backed by the volume with the specified tag, if any, to the given
+// size in MiB. The filesystem's info is updated if it has been
+// provisioned, and its parameters otherwise.
+func (st *State) growVolumeFilesystemOps(volumeTag names.VolumeTag, size uint64) ([]txn.Op, error) {
+	f, err := st.volumeFilesystem(volumeTag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	field := "params.size"
+	var current uint64
+	if info, err := f.Info(); err == nil {
+		field = "info.size"
+		current = info.Size
+	} else if params, ok := f.Params(); ok {
+		current = params.Size
+	} else {
+		return nil, errors.Trace(err)
+	}
+	if size <= current {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.FilesystemTag().Id(),
+		Assert: bson.D{{field, current}},
+		Update: bson.D{{"$set", bson.D{{field, size}}}},
+	}}, nil
+}
+
 func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -1176,3 +1270,110 @@ func (st *State) SetFilesystemStatus(tag names.FilesystemTag, fsStatus status.St
 		updated:   updated,
 	})
 }
+
+// AddExistingFilesystem imports an existing, already-provisioned
+// filesystem into the model. The filesystem, or the volume backing it
+// if backingVolume is non-nil, starts out detached, bound to a new
+// detached storage instance with the given storage name. The tag of
+// the new storage instance is returned, so that the storage may then
+// be attached to a unit.
+//
+// If backingVolume is non-nil, only the volume is recorded; a
+// filesystem will be managed on it once the storage is attached to
+// a unit's machine.
+func (st *State) AddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing filesystem")
+	if err := st.validateAddExistingFilesystem(info, backingVolume, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	storageTag := names.NewStorageTag(storageId)
+	detachedStatus := statusDoc{
+		Status:  status.StatusDetached,
+		Updated: time.Now().UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindFilesystem,
+			StorageName: storageName,
+		},
+	}}
+	if backingVolume == nil {
+		filesystemId, err := newFilesystemId(st, "")
+		if err != nil {
+			return names.StorageTag{}, errors.Annotate(err, "cannot generate filesystem name")
+		}
+		doc := filesystemDoc{
+			FilesystemId: filesystemId,
+			StorageId:    storageId,
+			Binding:      storageTag.String(),
+			Info:         &info,
+		}
+		ops = append(ops, st.newFilesystemOps(doc, detachedStatus)...)
+	} else {
+		volumeName, err := newVolumeName(st, "")
+		if err != nil {
+			return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+		}
+		volumeInfo := *backingVolume
+		volumeInfo.Pool = info.Pool
+		doc := volumeDoc{
+			Name:      volumeName,
+			StorageId: storageId,
+			Binding:   storageTag.String(),
+			Info:      &volumeInfo,
+		}
+		ops = append(ops, st.newVolumeOps(doc, detachedStatus)...)
+	}
+	if err := st.runTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+func (st *State) validateAddExistingFilesystem(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageName string,
+) error {
+	if !names.IsValidStorageName(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if info.Pool == "" {
+		return errors.NotValidf("empty pool name")
+	}
+	_, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() == storage.ScopeMachine {
+		return errors.NotSupportedf("importing machine-scoped storage")
+	}
+	if backingVolume == nil {
+		if !provider.Supports(storage.StorageKindFilesystem) {
+			return errors.NotValidf("backing volume info missing")
+		}
+		if info.FilesystemId == "" {
+			return errors.NotValidf("empty filesystem ID")
+		}
+	} else {
+		if provider.Supports(storage.StorageKindFilesystem) {
+			return errors.NotValidf("backing volume info for filesystem provider")
+		}
+		if backingVolume.VolumeId == "" {
+			return errors.NotValidf("empty volume ID")
+		}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 )
 
 type FilesystemStateSuite struct {
@@ -308,6 +309,102 @@ func (s *FilesystemStateSuite) TestVolumeBackedFilesystemScope(c *gc.C) {
 	c.Assert(volumeTag, gc.Equals, names.NewVolumeTag("0"))
 }
 
+func (s *FilesystemStateSuite) TestResizeStorageInstanceFilesystemUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	params, ok := s.filesystem(c, filesystemTag).Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Size, gc.Equals, uint64(4096))
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, gc.ErrorMatches, `cannot resize filesystem "0/0": new size 4096MiB must be greater than current size 4096MiB`)
+}
+
+func (s *FilesystemStateSuite) TestResizeStorageInstanceFilesystemProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	assignedMachineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(assignedMachineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{Size: 123, FilesystemId: "fs-id"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `cannot resize filesystem "0/0": resizing provisioned filesystem without a backing volume not supported`)
+}
+
+func (s *FilesystemStateSuite) TestResizeStorageInstanceVolumeBackedFilesystemUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeParams, ok := s.volume(c, volumeTag).Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams.Size, gc.Equals, uint64(4096))
+	filesystemParams, ok := s.filesystem(c, filesystem.FilesystemTag()).Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(filesystemParams.Size, gc.Equals, uint64(4096))
+}
+
+func (s *FilesystemStateSuite) TestResizeStorageInstanceVolumeBackedFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	filesystemTag := filesystem.FilesystemTag()
+	volumeTag, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+
+	assignedMachineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(assignedMachineId)
+	machine, err := s.State.Machine(assignedMachineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumeInfo := state.VolumeInfo{Size: 1024, VolumeId: "vol-123"}
+	err = s.State.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volumeTag, state.VolumeAttachmentInfo{DeviceName: "loop0"})
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemInfo := state.FilesystemInfo{Size: 1024, FilesystemId: "fs-id"}
+	err = s.State.SetFilesystemInfo(filesystemTag, filesystemInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(4096))
+
+	// The filesystem is grown along with its backing volume.
+	volumeInfo.Pool = "loop-pool" // taken from params
+	volumeInfo.Size = 4096
+	err = s.State.SetVolumeInfo(volumeTag, volumeInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemInfo.Pool = "loop-pool" // taken from params
+	filesystemInfo.Size = 4096
+	s.assertFilesystemInfo(c, filesystemTag, filesystemInfo)
+}
+
 func (s *FilesystemStateSuite) TestWatchModelFilesystems(c *gc.C) {
 	service := s.setupMixedScopeStorageService(c, "filesystem")
 	addUnit := func() {
@@ -1031,3 +1128,175 @@ func (s *FilesystemStateSuite) setupFilesystemAttachment(c *gc.C, pool string) (
 	assertMachineStorageRefs(c, s.State, machine.MachineTag())
 	return s.filesystem(c, attachments[0].Filesystem()), machine
 }
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystem(c *gc.C) {
+	info := state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+		Size:         1024,
+	}
+	storageTag, err := s.State.AddExistingFilesystem(info, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("data/0"))
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindFilesystem)
+	_, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag("0"))
+	c.Assert(filesystem.LifeBinding(), gc.Equals, storageTag)
+	s.assertFilesystemInfo(c, filesystem.FilesystemTag(), info)
+	statusInfo, err := filesystem.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusDetached)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemWithBackingVolume(c *gc.C) {
+	info := state.FilesystemInfo{Pool: "environscoped-block"}
+	volumeInfo := state.VolumeInfo{VolumeId: "vol-123", Size: 1024}
+	storageTag, err := s.State.AddExistingFilesystem(info, &volumeInfo, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the volume is recorded; the filesystem is created
+	// on it when the storage is attached to a unit's machine.
+	_, err = s.State.StorageInstanceFilesystem(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	volume := s.storageInstanceVolume(c, storageTag)
+	c.Assert(volume.LifeBinding(), gc.Equals, storageTag)
+	volumeInfo.Pool = "environscoped-block"
+	s.assertVolumeInfo(c, volume.VolumeTag(), volumeInfo)
+}
+
+func (s *FilesystemStateSuite) TestAddExistingFilesystemInvalid(c *gc.C) {
+	for _, test := range []struct {
+		info          state.FilesystemInfo
+		backingVolume *state.VolumeInfo
+		storageName   string
+		expect        string
+	}{{
+		info:        state.FilesystemInfo{FilesystemId: "fs-123", Pool: "environscoped"},
+		storageName: "data/0",
+		expect:      `cannot add existing filesystem: storage name "data/0" not valid`,
+	}, {
+		info:        state.FilesystemInfo{FilesystemId: "fs-123"},
+		storageName: "data",
+		expect:      "cannot add existing filesystem: empty pool name not valid",
+	}, {
+		info:        state.FilesystemInfo{FilesystemId: "fs-123", Pool: "loop-pool"},
+		storageName: "data",
+		expect:      "cannot add existing filesystem: importing machine-scoped storage not supported",
+	}, {
+		info:        state.FilesystemInfo{Pool: "environscoped"},
+		storageName: "data",
+		expect:      "cannot add existing filesystem: empty filesystem ID not valid",
+	}, {
+		info:        state.FilesystemInfo{Pool: "environscoped-block"},
+		storageName: "data",
+		expect:      "cannot add existing filesystem: backing volume info missing not valid",
+	}, {
+		info:          state.FilesystemInfo{Pool: "environscoped-block"},
+		backingVolume: &state.VolumeInfo{},
+		storageName:   "data",
+		expect:        "cannot add existing filesystem: empty volume ID not valid",
+	}} {
+		_, err := s.State.AddExistingFilesystem(test.info, test.backingVolume, test.storageName)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *FilesystemStateSuite) TestAddUnitAttachingExistingFilesystem(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+		Size:         1024,
+	}, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	app := s.AddTestingServiceWithStorage(c, "storage-filesystem", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped", 1024, 1),
+	})
+	u, err := app.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The imported storage takes the place of the storage
+	// that would otherwise have been created for the unit.
+	attachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].StorageInstance(), gc.Equals, storageTag)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, _ := si.Owner()
+	c.Assert(owner, gc.Equals, u.UnitTag())
+
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	attachment := s.filesystemAttachment(c, names.NewMachineTag(machineId), names.NewFilesystemTag("0"))
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *FilesystemStateSuite) TestAddUnitAttachingExistingFilesystemWithBackingVolume(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "environscoped-block"},
+		&state.VolumeInfo{VolumeId: "vol-123", Size: 1024},
+		"data",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	app := s.AddTestingServiceWithStorage(c, "storage-filesystem", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped-block", 1024, 1),
+	})
+	u, err := app.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+
+	// A filesystem is created on the existing volume, rather
+	// than on a new one.
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag(machineId+"/0"))
+	filesystemVolume, err := filesystem.Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystemVolume, gc.Equals, volume.VolumeTag())
+	s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+	s.volumeAttachment(c, machineTag, volume.VolumeTag())
+}
+
+func (s *FilesystemStateSuite) TestAddUnitAttachingStorageNotDetached(c *gc.C) {
+	app, _, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	_, err := app.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-filesystem": storage data/0 is not detached`)
+}
+
+func (s *FilesystemStateSuite) TestAddUnitAttachingStorageWrongKind(c *gc.C) {
+	storageTag, err := s.State.AddExistingFilesystem(state.FilesystemInfo{
+		FilesystemId: "fs-123",
+		Pool:         "environscoped",
+	}, nil, "data")
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped", 1024, 1),
+	})
+	_, err = app.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block": charm storage "data" is of kind block, storage data/0 is of kind filesystem`)
+}
@@ -148,6 +148,12 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// UpgradeSeriesStatus and UpgradeSeriesTarget record the progress
+	// of an upgrade of the machine's series, and the series it is
+	// being upgraded to.
+	UpgradeSeriesStatus UpgradeSeriesStatus `bson:"upgrade-series-status,omitempty"`
+	UpgradeSeriesTarget string              `bson:"upgrade-series-target,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -1184,7 +1190,7 @@ func (m *Machine) PublicAddress() (network.Address, error) {
 // match, and if not it selects the best from the slice of all available
 // addresses. It returns the new address and a bool indicating if a different
 // one was picked.
-func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []address, getAddr func([]address) network.Address, checkScope func(address) bool) (address, bool) {
+func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []address, getAddr func([]address) network.Address, checkScope func(address) bool, pref network.AddressPreference) (address, bool) {
 	// For picking the best address, try provider addresses first.
 	var newAddr address
 	netAddr := getAddr(providerAddresses)
@@ -1199,8 +1205,10 @@ func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []addr
 	// first. If the stored address is unavilable we also *must* check for
 	// a new address so we do that next. If the original is a machine
 	// address and a provider address is available we want to switch to
-	// that. Finally we check to see if a better match on scope from the
-	// same origin is available.
+	// that. Then we check to see if a better match on scope from the
+	// same origin is available. Finally, if both match the scope, we
+	// switch to the new address when it is of the preferred IP address
+	// family.
 	if addr.Value == "" {
 		return newAddr, newAddr.Value != ""
 	}
@@ -1218,9 +1226,24 @@ func maybeGetNewAddress(addr address, providerAddresses, machineAddresses []addr
 			return newAddr, checkScope(newAddr)
 		}
 	}
+	if pref != network.PreferDualStack && addr.Origin == newAddr.Origin &&
+		addr.Scope == newAddr.Scope && isIPAddress(addr) && isIPAddress(newAddr) &&
+		addr.AddressType != newAddr.AddressType && checkScope(newAddr) {
+		// getAddr only returns an address of the other family
+		// when there is none of the preferred one with this scope.
+		return newAddr, true
+	}
 	return addr, false
 }
 
+func isIPAddress(addr address) bool {
+	switch network.AddressType(addr.AddressType) {
+	case network.IPv4Address, network.IPv6Address:
+		return true
+	}
+	return false
+}
+
 // PrivateAddress returns a private address for the machine. If no address is
 // available it returns an error that satisfies network.IsNoAddressError().
 func (m *Machine) PrivateAddress() (network.Address, error) {
@@ -1273,7 +1296,7 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, pref network.AddressPreference) ([]txn.Op, address, bool) {
 	publicAddress := m.doc.PreferredPublicAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1281,11 +1304,11 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := network.SelectPublicAddressWithPreference(networkAddresses(addresses), pref)
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope, pref)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, publicAddress, false
@@ -1295,7 +1318,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, newAddr, true
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, pref network.AddressPreference) ([]txn.Op, address, bool) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1303,11 +1326,11 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := network.SelectInternalAddressWithPreference(networkAddresses(addresses), false, pref)
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope, pref)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, privateAddress, false
@@ -1367,11 +1390,15 @@ func (m *Machine) SetMachineAddresses(addresses ...network.Address) (err error)
 // only predicated on the machine not being Dead; concurrent address
 // changes are ignored.
 func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fieldName string) error {
+	pref, err := m.st.addressPreference()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	addressesToSet := make([]network.Address, len(addresses))
 	copy(addressesToSet, addresses)
 
 	// Update addresses now.
-	network.SortAddresses(addressesToSet)
+	network.SortAddressesWithPreference(addressesToSet, pref)
 	origin := OriginProvider
 	if fieldName == "machineaddresses" {
 		origin = OriginMachine
@@ -1381,7 +1408,6 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	var (
 		newPrivate, newPublic         address
 		changedPrivate, changedPublic bool
-		err                           error
 	)
 	machine := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1411,8 +1437,8 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		}
 
 		var setPrivateAddressOps, setPublicAddressOps []txn.Op
-		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses)
-		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses, pref)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses, pref)
 		ops = append(ops, setPrivateAddressOps...)
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
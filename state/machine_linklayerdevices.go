@@ -847,6 +847,28 @@ func (m *Machine) AllAddresses() ([]*Address, error) {
 	return allAddresses, nil
 }
 
+// addressSpaces returns the names of the spaces of the subnets the
+// machine has addresses in. Addresses in unknown subnets are ignored.
+func (m *Machine) addressSpaces() (set.Strings, error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaces := set.NewStrings()
+	for _, addr := range addresses {
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if space := subnet.SpaceName(); space != "" {
+			spaces.Add(space)
+		}
+	}
+	return spaces, nil
+}
+
 // SetParentLinkLayerDevicesBeforeTheirChildren splits the given devicesArgs
 // into multiple sets of args and calls SetLinkLayerDevices() for each set, such
 // that child devices are set only after their parents.
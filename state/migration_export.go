@@ -18,17 +18,37 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// Export the current model for the State.
+// Export the current model for the State. The values of secret
+// application settings are redacted; use ExportForMigration to export
+// a model that is to be imported elsewhere.
 func (st *State) Export() (description.Model, error) {
+	return st.export(nil)
+}
+
+// ExportForMigration exports the current model for the State, with the
+// values of secret application settings encrypted with the given key,
+// as returned by NewSecretConfigKey, in place of this controller's own.
+// The same key must be passed to Import by the controller importing the
+// model.
+func (st *State) ExportForMigration(migrationKey string) (description.Model, error) {
+	key, err := decodeSecretConfigKey(migrationKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "migration key")
+	}
+	return st.export(key)
+}
+
+func (st *State) export(migrationKey []byte) (description.Model, error) {
 	dbModel, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	export := exporter{
-		st:      st,
-		dbModel: dbModel,
-		logger:  loggo.GetLogger("juju.state.export-model"),
+		st:           st,
+		dbModel:      dbModel,
+		logger:       loggo.GetLogger("juju.state.export-model"),
+		migrationKey: migrationKey,
 	}
 	if err := export.readAllStatuses(); err != nil {
 		return nil, errors.Annotate(err, "reading statuses")
@@ -128,6 +148,9 @@ type exporter struct {
 	dbModel *Model
 	model   description.Model
 	logger  loggo.Logger
+	// migrationKey is the key with which secret config values are
+	// encrypted in the exported model; if nil, they are redacted.
+	migrationKey []byte
 
 	annotations   map[string]annotatorDoc
 	constraints   map[string]bson.M
@@ -562,6 +585,30 @@ type addApplicationContext struct {
 	payloads           map[string][]payload.FullPayloadInfo
 }
 
+// applicationSettings returns the given application settings as they
+// are to be exported. Secret values are encrypted with a key that
+// belongs to this controller, so they're encrypted again with the
+// migration key, never exported in plain text.
+func (e *exporter) applicationSettings(settings map[string]interface{}) (map[string]interface{}, error) {
+	if e.migrationKey == nil {
+		return redactConfigSettings(settings), nil
+	}
+	result := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		if isEncryptedConfigValue(value) {
+			plain, err := e.st.decryptConfigValue(value.(string))
+			if err != nil {
+				return nil, errors.Annotatef(err, "setting %q", name)
+			}
+			if value, err = encryptConfigValueWithKey(e.migrationKey, plain); err != nil {
+				return nil, errors.Annotatef(err, "setting %q", name)
+			}
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
 func (e *exporter) addApplication(ctx addApplicationContext) error {
 	application := ctx.application
 	appName := application.Name()
@@ -576,6 +623,14 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	if !found {
 		return errors.Errorf("missing leadership settings for application %q", appName)
 	}
+	settings, err := e.applicationSettings(applicationSettingsDoc.Settings)
+	if err != nil {
+		return errors.Annotatef(err, "settings for application %q", appName)
+	}
+	secretConfigKeys, err := application.SecretConfigKeys()
+	if err != nil {
+		return errors.Annotatef(err, "secret config keys for application %q", appName)
+	}
 
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
@@ -587,7 +642,8 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
 		MinUnits:             application.doc.MinUnits,
-		Settings:             applicationSettingsDoc.Settings,
+		Settings:             settings,
+		SecretConfigKeys:     secretConfigKeys,
 		Leader:               ctx.leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
@@ -720,6 +776,9 @@ func (e *exporter) relations() error {
 	}
 
 	for _, relation := range rels {
+		if relation.Suspended() {
+			return errors.NotSupportedf("migrating suspended relation %q", relation)
+		}
 		exRelation := e.model.AddRelation(description.RelationArgs{
 			Id:  relation.Id(),
 			Key: relation.String(),
@@ -1453,10 +1512,11 @@ func (e *exporter) storageInstances() error {
 }
 
 func (e *exporter) addStorage(instance *storageInstance, attachments []names.UnitTag) error {
+	owner, _ := instance.Owner()
 	args := description.StorageArgs{
 		Tag:         instance.StorageTag(),
 		Kind:        instance.Kind().String(),
-		Owner:       instance.Owner(),
+		Owner:       owner,
 		Name:        instance.StorageName(),
 		Attachments: attachments,
 	}
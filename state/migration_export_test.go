@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -376,6 +377,50 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 	s.checkStatusHistory(c, history[:addedHistoryCount], status.StatusActive)
 }
 
+func (s *MigrationExportSuite) TestApplicationSecretConfigRedacted(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetSecretConfigKeys([]string{"blog-title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.UpdateConfigSettings(charm.Settings{"blog-title": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Settings(), jc.DeepEquals, map[string]interface{}{
+		"blog-title": state.RedactedConfigValue,
+	})
+	c.Assert(applications[0].SecretConfigKeys(), jc.DeepEquals, []string{"blog-title"})
+}
+
+func (s *MigrationExportSuite) TestApplicationSecretConfigForMigration(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetSecretConfigKeys([]string{"blog-title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.UpdateConfigSettings(charm.Settings{"blog-title": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	raw, err := state.RawConfigSettings(application)
+	c.Assert(err, jc.ErrorIsNil)
+	migrationKey, err := state.NewSecretConfigKey()
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.ExportForMigration(migrationKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	value := applications[0].Settings()["blog-title"]
+	c.Assert(state.IsEncryptedConfigValue(value), jc.IsTrue)
+	c.Assert(value, gc.Not(gc.Equals), raw["blog-title"])
+}
+
+func (s *MigrationExportSuite) TestExportForMigrationInvalidKey(c *gc.C) {
+	_, err := s.State.ExportForMigration("Zm9v")
+	c.Assert(err, gc.ErrorMatches, "migration key: secret config key of 3 bytes not valid")
+}
+
 func (s *MigrationExportSuite) TestMultipleApplications(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "first"})
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "second"})
@@ -546,6 +591,21 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	checkEndpoint(exEps[1], wordpress_0.Name(), wpEp, wordpressSettings)
 }
 
+func (s *MigrationExportSuite) TestSuspendedRelationNotSupported(c *gc.C) {
+	state.AddTestingService(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
+	state.AddTestingService(c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSuspended(true, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `migrating suspended relation "wordpress:db mysql:server" not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MigrationExportSuite) TestSpaces(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{
 		Name: "one", ProviderID: network.Id("provider"), IsPublic: true})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -34,7 +35,10 @@ import (
 var initialLeaderClaimTime = time.Minute
 
 // Import the database agnostic model representation into the database.
-func (st *State) Import(model description.Model) (_ *Model, _ *State, err error) {
+// The migration key is the one passed to ExportForMigration by the
+// controller exporting the model; it may be empty if the model has no
+// secret config values.
+func (st *State) Import(model description.Model, migrationKey string) (_ *Model, _ *State, err error) {
 	logger := loggo.GetLogger("juju.state.import-model")
 	logger.Debugf("import starting for model %s", model.Tag().Id())
 	// At this stage, attempting to import a model with the same
@@ -78,10 +82,11 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 
 	// I would have loved to use import, but that is a reserved word.
 	restore := importer{
-		st:      newSt,
-		dbModel: dbModel,
-		model:   model,
-		logger:  logger,
+		st:           newSt,
+		dbModel:      dbModel,
+		model:        model,
+		logger:       logger,
+		migrationKey: migrationKey,
 	}
 	if err := restore.sequences(); err != nil {
 		return nil, nil, errors.Annotate(err, "sequences")
@@ -146,6 +151,9 @@ type importer struct {
 	dbModel *Model
 	model   description.Model
 	logger  loggo.Logger
+	// migrationKey is the key with which secret config values are
+	// encrypted in the model description.
+	migrationKey string
 	// applicationUnits is populated at the end of loading the applications, and is a
 	// map of application name to units of that application.
 	applicationUnits map[string][]*Unit
@@ -626,12 +634,17 @@ func (i *importer) application(s description.Application) error {
 	statusDoc := i.makeStatusDoc(status)
 	// TODO: update never set malarky... maybe...
 
+	settings, err := i.applicationSettings(s)
+	if err != nil {
+		return errors.Annotate(err, "secret settings")
+	}
+
 	ops, err := addApplicationOps(i.st, addApplicationOpsArgs{
 		applicationDoc:     sdoc,
 		statusDoc:          statusDoc,
 		constraints:        i.constraints(s.Constraints()),
 		storage:            i.storageConstraints(s.StorageConstraints()),
-		settings:           s.Settings(),
+		settings:           settings,
 		leadershipSettings: s.LeadershipSettings(),
 	})
 	if err != nil {
@@ -804,6 +817,14 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The exported secret keys are those in effect, whether the
+	// operator chose them or not; they are imported as chosen. If
+	// there are none, the charm's options decide, as they did before
+	// the migration.
+	var secretConfigKeys *[]string
+	if keys := s.SecretConfigKeys(); len(keys) > 0 {
+		secretConfigKeys = &keys
+	}
 
 	return &applicationDoc{
 		Name:                 s.Name(),
@@ -819,9 +840,30 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		Exposed:              s.Exposed(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
+		SecretConfigKeys:     secretConfigKeys,
 	}, nil
 }
 
+// applicationSettings returns the settings of the given application as
+// they are to be stored, with the secret values, which are exported
+// encrypted with the migration key, encrypted with this controller's.
+func (i *importer) applicationSettings(s description.Application) (map[string]interface{}, error) {
+	settings := make(map[string]interface{}, len(s.Settings()))
+	for name, value := range s.Settings() {
+		if isEncryptedConfigValue(value) {
+			key, err := decodeSecretConfigKey(i.migrationKey)
+			if err != nil {
+				return nil, errors.Annotate(err, "migration key")
+			}
+			if value, err = decryptConfigValueWithKey(key, value.(string)); err != nil {
+				return nil, errors.Annotatef(err, "setting %q", name)
+			}
+		}
+		settings[name] = value
+	}
+	return i.st.encryptConfigSettings(set.NewStrings(s.SecretConfigKeys()...), settings)
+}
+
 func (i *importer) relationCount(application string) int {
 	count := 0
 
@@ -1329,10 +1371,13 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 	doc := &storageInstanceDoc{
 		Id:              storage.Tag().Id(),
 		Kind:            kind,
-		Owner:           owner.String(),
 		StorageName:     storage.Name(),
 		AttachmentCount: len(attachments),
 	}
+	if owner != nil {
+		// Storage that has been detached from its unit has no owner.
+		doc.Owner = owner.String()
+	}
 	ops = append(ops, txn.Op{
 		C:      storageInstancesC,
 		Id:     tag.Id(),
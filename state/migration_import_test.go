@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -49,18 +50,20 @@ func (s *MigrationImportSuite) TestExisting(c *gc.C) {
 	out, err := s.State.Export()
 	c.Assert(err, jc.ErrorIsNil)
 
-	_, _, err = s.State.Import(out)
+	_, _, err = s.State.Import(out, "")
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
 func (s *MigrationImportSuite) importModel(c *gc.C) (*state.Model, *state.State) {
-	out, err := s.State.Export()
+	migrationKey, err := state.NewSecretConfigKey()
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := s.State.ExportForMigration(migrationKey)
 	c.Assert(err, jc.ErrorIsNil)
 
 	uuid := utils.MustNewUUID().String()
 	in := newModel(out, uuid, "new")
 
-	newModel, newSt, err := s.State.Import(in)
+	newModel, newSt, err := s.State.Import(in, migrationKey)
 	c.Assert(err, jc.ErrorIsNil)
 	// add the cleanup here to close the model.
 	s.AddCleanup(func(c *gc.C) {
@@ -96,7 +99,7 @@ func (s *MigrationImportSuite) TestNewModel(c *gc.C) {
 	uuid := utils.MustNewUUID().String()
 	in := newModel(out, uuid, "new")
 
-	newModel, newSt, err := s.State.Import(in)
+	newModel, newSt, err := s.State.Import(in, "")
 	c.Assert(err, jc.ErrorIsNil)
 	defer newSt.Close()
 
@@ -277,6 +280,54 @@ func (s *MigrationImportSuite) TestMachines(c *gc.C) {
 	c.Assert(newCons.String(), gc.Equals, cons.String())
 }
 
+func (s *MigrationImportSuite) TestApplicationSecretConfig(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetSecretConfigKeys([]string{"blog-title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.UpdateConfigSettings(charm.Settings{"blog-title": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err := imported.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"blog-title"})
+	settings, err := imported.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"blog-title": "sekrit"})
+	raw, err := state.RawConfigSettings(imported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.IsEncryptedConfigValue(raw["blog-title"]), jc.IsTrue)
+}
+
+func (s *MigrationImportSuite) TestApplicationSecretConfigWrongMigrationKey(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetSecretConfigKeys([]string{"blog-title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.UpdateConfigSettings(charm.Settings{"blog-title": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	migrationKey, err := state.NewSecretConfigKey()
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := s.State.ExportForMigration(migrationKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+	_, _, err = s.State.Import(in, coretesting.SecretConfigKey)
+	c.Assert(err, gc.ErrorMatches, `.*setting "blog-title": cannot decrypt secret config value: .*`)
+}
+
+func (s *MigrationImportSuite) TestApplicationNoSecretConfigKeys(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.ApplicationHasSecretConfigKeys(imported), jc.IsFalse)
+}
+
 func (s *MigrationImportSuite) TestMachineDevices(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, nil)
 	// Create two devices, first with all fields set, second just to show that
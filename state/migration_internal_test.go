@@ -107,6 +107,24 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Not exported, but the tools will possibly need to be either bundled
 		// with the representation or sent separately.
 		toolsmetadataC,
+		// Volume snapshots are not yet migrated; the snapshots
+		// themselves live in the source cloud.
+		volumeSnapshotsC,
+		// Cross model relations are not yet migrated; offers and
+		// remote applications refer to other models on the source
+		// controller.
+		applicationOffersC,
+		remoteApplicationsC,
+		// Settings history is not migrated; the history of the
+		// migrated model starts afresh.
+		settingsHistoryC,
+		// Secrets are not yet migrated; their content is encrypted
+		// with a key held by the source controller.
+		secretsC,
+		secretRevisionsC,
+		secretGrantsC,
+		// Rolling charm upgrades are transient and not migrated.
+		charmRolloutsC,
 		// Bakery storage items are non-critical. We store root keys for
 		// temporary credentials in there; after migration you'll just have
 		// to log back in.
@@ -268,6 +286,9 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Series upgrades are not migrated.
+		"UpgradeSeriesStatus",
+		"UpgradeSeriesTarget",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -329,6 +350,10 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// The auto-upgrade policy is not yet part of the model
+		// description.
+		"AutoUpgrade",
+		"MaintenanceWindow",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -341,6 +366,7 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"Exposed",
 		"MinUnits",
 		"MetricCredentials",
+		"SecretConfigKeys",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
 }
@@ -357,7 +383,12 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		// Series and CharmURL also come from the service.
 		"Series",
 		"CharmURL",
+		// TargetCharmURL is only set during a rolling charm upgrade,
+		// which is not migrated.
+		"TargetCharmURL",
 		"TxnRevno",
+		// Series upgrades are not migrated.
+		"UpgradeSeriesStatus",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -416,6 +447,9 @@ func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 		// UnitCount isn't explicitly exported, but defined by the stored
 		// unit settings data for the relation endpoint.
 		"UnitCount",
+		// Suspended relations are refused by the exporter.
+		"Suspended",
+		"SuspendedReason",
 	)
 	s.AssertExportedFields(c, relationDoc{}, fields)
 	// We also need to check the Endpoint and nested charm.Relation field.
@@ -442,6 +476,9 @@ func (s *MigrationSuite) TestRelationScopeDocFields(c *gc.C) {
 		"Key",
 		// Departing isn't exported as we only deal with live, stable systems.
 		"Departing",
+		// Settled isn't exported; the unit agents report it again once
+		// they are running against the target controller.
+		"Settled",
 	)
 	s.AssertExportedFields(c, relationScopeDoc{}, fields)
 }
@@ -275,6 +275,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.secretConfigKey = st.secretConfigKey
 
 	modelOps, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
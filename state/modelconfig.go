@@ -262,6 +262,13 @@ type ValidateConfigFunc func(updateAttrs map[string]interface{}, removeAttrs []s
 // configuration of the model with the provided updateAttrs and
 // removeAttrs.
 func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ValidateConfigFunc) error {
+	return st.UpdateModelConfigAs("", updateAttrs, removeAttrs, additionalValidation)
+}
+
+// UpdateModelConfigAs updates the model configuration as UpdateModelConfig
+// does, recording the named user as having made the change in the
+// settings history.
+func (st *State) UpdateModelConfigAs(user string, updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ValidateConfigFunc) error {
 	if len(updateAttrs)+len(removeAttrs) == 0 {
 		return nil
 	}
@@ -329,8 +336,39 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	validAttrs = config.CoerceForStorage(validAttrs)
 
 	modelSettings.Update(validAttrs)
-	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	return writeSettingsWithHistory(modelSettings, modelGlobalKey, user)
+}
+
+// ModelConfigHistory returns the recorded changes to the model
+// configuration, most recent first.
+func (st *State) ModelConfigHistory() ([]SettingsRevision, error) {
+	return settingsHistory(st, modelGlobalKey)
+}
+
+// RollbackModelConfig restores the model configuration to that recorded
+// at the given revision of the settings history. The agent version is
+// never rolled back; it is changed only by upgrading the model. The
+// rollback is itself recorded in the history as a change made by the
+// named user.
+func (st *State) RollbackModelConfig(user string, revision int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back model config to revision %d", revision)
+	rev, err := settingsHistoryRevision(st, modelGlobalKey, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := readSettings(st, settingsC, modelGlobalKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updateAttrs := copyMap(rev.Settings, nil)
+	delete(updateAttrs, config.AgentVersionKey)
+	var removeAttrs []string
+	for _, key := range current.Keys() {
+		if _, ok := rev.Settings[key]; !ok && key != config.AgentVersionKey {
+			removeAttrs = append(removeAttrs, key)
+		}
+	}
+	return errors.Trace(st.UpdateModelConfigAs(user, updateAttrs, removeAttrs, nil))
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
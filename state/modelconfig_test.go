@@ -256,6 +256,63 @@ func (s *ModelConfigSuite) TestUpdateModelConfigPreferredOverRemove(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func (s *ModelConfigSuite) TestModelConfigHistory(c *gc.C) {
+	initial, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigAs("bob", map[string]interface{}{"arbitrary-key": "shazam!"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigAs("mary", nil, []string{"arbitrary-key"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, len(initial)+2)
+	c.Assert(history[0].User, gc.Equals, "mary")
+	c.Assert(history[0].Changes, jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemDeleted, Key: "arbitrary-key", OldValue: "shazam!"},
+	})
+	c.Assert(history[1].User, gc.Equals, "bob")
+	c.Assert(history[1].Changes, jc.DeepEquals, []state.ItemChange{
+		{Type: state.ItemAdded, Key: "arbitrary-key", NewValue: "shazam!"},
+	})
+	c.Assert(history[1].Settings["arbitrary-key"], gc.Equals, "shazam!")
+}
+
+func (s *ModelConfigSuite) TestRollbackModelConfig(c *gc.C) {
+	err := s.State.UpdateModelConfigAs("bob", map[string]interface{}{
+		"apt-mirror":    "http://different-mirror",
+		"arbitrary-key": "shazam!",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	revision := history[0].Revision
+
+	err = s.State.UpdateModelConfigAs("bob", map[string]interface{}{
+		"apt-mirror":  "http://another-mirror",
+		"another-key": "kapow!",
+	}, []string{"arbitrary-key"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RollbackModelConfig("mary", revision)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	allAttrs := cfg.AllAttrs()
+	c.Assert(allAttrs["apt-mirror"], gc.Equals, "http://different-mirror")
+	c.Assert(allAttrs["arbitrary-key"], gc.Equals, "shazam!")
+	_, ok := allAttrs["another-key"]
+	c.Assert(ok, jc.IsFalse)
+
+	history, err = s.State.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history[0].Revision, gc.Equals, revision+2)
+	c.Assert(history[0].User, gc.Equals, "mary")
+
+	err = s.State.RollbackModelConfig("mary", 42)
+	c.Assert(err, gc.ErrorMatches, `cannot roll back model config to revision 42: settings revision 42 not found`)
+}
+
 type ModelConfigSourceSuite struct {
 	ConnSuite
 }
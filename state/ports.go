@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -31,12 +32,14 @@ const (
 )
 
 // PortRange represents a single range of ports opened
-// by one unit.
+// by one unit. If Endpoint is empty, the range is opened
+// for all of the unit's endpoints.
 type PortRange struct {
 	UnitName string
 	FromPort int
 	ToPort   int
 	Protocol string
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -118,8 +121,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. The same unit may also open the
+	// same range for different endpoints.
+	if prA.sameUnitRange(prB) {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -131,8 +135,18 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	return nil
 }
 
+// sameUnitRange reports whether both port ranges cover the same
+// ports and were opened by the same unit, regardless of endpoint.
+func (prA PortRange) sameUnitRange(prB PortRange) bool {
+	prA.Endpoint, prB.Endpoint = "", ""
+	return prA == prB
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if p.Endpoint != "" {
+		return fmt.Sprintf("%d-%d/%s (%q, endpoint %q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, p.Endpoint)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
@@ -267,7 +281,8 @@ func (p *Ports) verifySubnetAliveWhenSet() error {
 }
 
 // ClosePorts removes the specified port range from the list of ports
-// maintained by this document.
+// maintained by this document. If the port range has no endpoint, it
+// is closed for all the endpoints it was opened for.
 func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %s", portRange)
 
@@ -293,7 +308,8 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			if existingPortsDef == portRange ||
+				(portRange.Endpoint == "" && existingPortsDef.sameUnitRange(portRange)) {
 				found = true
 				continue
 			}
@@ -362,6 +378,26 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// AllPortRangeEndpoints returns a map with network.PortRange as keys
+// and the sorted names of the endpoints each range was opened for as
+// values. An empty endpoint name means the range was opened for all
+// of the unit's endpoints.
+func (p *Ports) AllPortRangeEndpoints() map[network.PortRange][]string {
+	result := make(map[network.PortRange][]string)
+	for _, portRange := range p.doc.Ports {
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		result[rawRange] = append(result[rawRange], portRange.Endpoint)
+	}
+	for _, endpoints := range result {
+		sort.Strings(endpoints)
+	}
+	return result
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -505,17 +541,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
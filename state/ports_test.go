@@ -299,6 +299,63 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
 }
 
+func (s *PortsDocSuite) TestOpenAndCloseEndpointPorts(c *gc.C) {
+	portRange := state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+	}
+	urlRange, dbRange := portRange, portRange
+	urlRange.Endpoint = "url"
+	dbRange.Endpoint = "db"
+
+	// The same unit can open the same range for several endpoints.
+	for _, r := range []state.PortRange{urlRange, dbRange, portRange} {
+		err := s.portsWithoutSubnet.OpenPorts(r)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.portsWithoutSubnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.PortsForUnit(s.unit1.Name()), jc.DeepEquals, []state.PortRange{
+		urlRange, dbRange, portRange,
+	})
+	endpoints := s.portsWithoutSubnet.AllPortRangeEndpoints()
+	c.Assert(endpoints, jc.DeepEquals, map[network.PortRange][]string{
+		{100, 200, "tcp"}: {"", "db", "url"},
+	})
+
+	// Closing for one endpoint leaves the others open.
+	err = s.portsWithoutSubnet.ClosePorts(dbRange)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.PortsForUnit(s.unit1.Name()), jc.DeepEquals, []state.PortRange{
+		urlRange, portRange,
+	})
+
+	// Closing without an endpoint closes all of them.
+	err = s.portsWithoutSubnet.ClosePorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.PortsForUnit(s.unit1.Name()), gc.HasLen, 0)
+}
+
+func (s *PortsDocSuite) TestOpenEndpointPortsConflicts(c *gc.C) {
+	portRange := state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+		Endpoint: "url",
+	}
+	err := s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+
+	portRange.UnitName = s.unit2.Name()
+	err = s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 100-200/tcp \("wordpress/1", endpoint "url"\): port ranges 100-200/tcp \("wordpress/0", endpoint "url"\) and 100-200/tcp \("wordpress/1", endpoint "url"\) conflict`)
+}
+
 func (s *PortsDocSuite) TestOpenInvalidRange(c *gc.C) {
 	portRange := state.PortRange{
 		FromPort: 400,
@@ -16,6 +16,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo"
 )
 
 // relationKey returns a string describing the relation defined by
@@ -43,6 +45,12 @@ type relationDoc struct {
 	Endpoints []Endpoint
 	Life      Life
 	UnitCount int
+
+	// Suspended records whether the relation has been suspended,
+	// in which case all its units are reported as departed, but
+	// remain in scope with their settings intact.
+	Suspended       bool   `bson:"suspended"`
+	SuspendedReason string `bson:"suspended-reason,omitempty"`
 }
 
 // Relation represents a relation between one or two service endpoints.
@@ -97,6 +105,164 @@ func (r *Relation) Life() Life {
 	return r.doc.Life
 }
 
+// Suspended returns whether the relation is suspended.
+func (r *Relation) Suspended() bool {
+	return r.doc.Suspended
+}
+
+// SuspendedReason returns the reason given when the relation was
+// suspended, if any.
+func (r *Relation) SuspendedReason() string {
+	return r.doc.SuspendedReason
+}
+
+// SetSuspended suspends or resumes the relation. Suspending a relation
+// causes all its units to be reported as departed by watchers, without
+// leaving scope, so that departed hooks run but the relation and its
+// settings are retained. Resuming the relation causes its alive units
+// to be reported as joined once more.
+func (r *Relation) SetSuspended(suspended bool, reason string) (err error) {
+	verb := "resume"
+	if suspended {
+		verb = "suspend"
+	} else {
+		reason = ""
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot %s relation %q", verb, r)
+	if len(r.doc.Endpoints) == 1 && r.doc.Endpoints[0].Role == charm.RolePeer {
+		return errors.Errorf("is a peer relation")
+	}
+	rel := &Relation{r.st, r.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := rel.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if rel.doc.Life != Alive {
+			return nil, errors.New("relation is not alive")
+		}
+		if rel.doc.Suspended == suspended && rel.doc.SuspendedReason == reason {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:  relationsC,
+			Id: rel.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"unitcount", rel.doc.UnitCount},
+				suspendedAssert(rel.doc.Suspended),
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"suspended", suspended},
+				{"suspended-reason", reason},
+			}}},
+		}}
+		if rel.doc.Suspended != suspended {
+			scopeOps, err := rel.suspendScopeOps(suspended)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, scopeOps...)
+		}
+		return ops, nil
+	}
+	if err := r.st.run(buildTxn); err != nil {
+		return err
+	}
+	r.doc.Suspended = suspended
+	r.doc.SuspendedReason = reason
+	return nil
+}
+
+// suspendScopeOps returns the operations necessary to mark the units in
+// the relation's scope as departing when it is suspended, or to restore
+// its alive units when it is resumed. Units that are departing because
+// they are no longer alive are left departing.
+func (r *Relation) suspendScopeOps(suspended bool) ([]txn.Op, error) {
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	var departing interface{} = true
+	if suspended {
+		departing = bson.D{{"$ne", true}}
+	}
+	var docs []relationScopeDoc
+	sel := bson.D{
+		{"key", bson.D{{"$regex", fmt.Sprintf("^r#%d#", r.doc.Id)}}},
+		{"departing", departing},
+	}
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		if !suspended {
+			memberOp, alive, err := r.scopeMemberAliveOp(doc.unitName())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !alive {
+				continue
+			}
+			ops = append(ops, memberOp)
+		}
+		ops = append(ops, txn.Op{
+			C:      relationScopesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"departing", suspended}}}},
+		})
+	}
+	return ops, nil
+}
+
+// scopeMemberAliveOp returns an operation asserting that the named unit,
+// or the remote application it belongs to, is alive; and whether it is.
+func (r *Relation) scopeMemberAliveOp(unitName string) (txn.Op, bool, error) {
+	db, closer := r.st.newDB()
+	defer closer()
+
+	// Remote units have no unit document, so the life of their remote
+	// application is checked instead.
+	memberColl, memberDocID := unitsC, r.st.docID(unitName)
+	units, closer := db.GetCollection(unitsC)
+	defer closer()
+	if count, err := units.FindId(memberDocID).Count(); err != nil {
+		return txn.Op{}, false, errors.Trace(err)
+	} else if count == 0 {
+		memberColl = remoteApplicationsC
+		memberDocID = r.st.docID(unitApplicationName(unitName))
+	}
+	members, closer := db.GetCollection(memberColl)
+	defer closer()
+	alive, err := isAliveWithSession(members, memberDocID)
+	if err != nil {
+		return txn.Op{}, false, errors.Trace(err)
+	}
+	return txn.Op{
+		C:      memberColl,
+		Id:     memberDocID,
+		Assert: isAliveDoc,
+	}, alive, nil
+}
+
+// isSuspendedWithSession returns whether the relation with the given
+// document id is suspended.
+func isSuspendedWithSession(relations mongo.Collection, docID string) (bool, error) {
+	n, err := relations.Find(bson.D{{"_id", docID}, {"suspended", true}}).Count()
+	return n == 1, err
+}
+
+// suspendedAssert returns an assertion that a relation document's
+// suspended field matches the supplied value.
+func suspendedAssert(suspended bool) bson.DocElem {
+	if suspended {
+		return bson.DocElem{"suspended", true}
+	}
+	return bson.DocElem{"suspended", bson.D{{"$ne", true}}}
+}
+
 // Destroy ensures that the relation will be removed at some point; if no units
 // are currently in scope, it will be removed immediately.
 func (r *Relation) Destroy() (err error) {
@@ -144,7 +310,7 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 		return nil, false, errAlreadyDying
 	}
 	if r.doc.UnitCount == 0 {
-		removeOps, err := r.removeOps(ignoreService, nil)
+		removeOps, err := r.removeOps(ignoreService, "")
 		if err != nil {
 			return nil, false, err
 		}
@@ -160,16 +326,16 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 
 // removeOps returns the operations necessary to remove the relation. If
 // ignoreService is not empty, no operations affecting that service will be
-// included; if departingUnit is not nil, this implies that the relation's
-// services may be Dying and otherwise unreferenced, and may thus require
-// removal themselves.
-func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.Op, error) {
+// included; if departingUnitName is not empty, this implies that the
+// relation's services may be Dying and otherwise unreferenced, and may thus
+// require removal themselves.
+func (r *Relation) removeOps(ignoreService string, departingUnitName string) ([]txn.Op, error) {
 	relOp := txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Remove: true,
 	}
-	if departingUnit != nil {
+	if departingUnitName != "" {
 		relOp.Assert = bson.D{{"life", Dying}, {"unitcount", 1}}
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
@@ -179,14 +345,20 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 		if ep.ApplicationName == ignoreService {
 			continue
 		}
+		if remoteApp, err := r.st.RemoteApplication(ep.ApplicationName); err == nil {
+			ops = append(ops, remoteApp.decRelationCountOps()...)
+			continue
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
 		var asserts bson.D
 		hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
-		if departingUnit == nil {
+		if departingUnitName == "" {
 			// We're constructing a destroy operation, either of the relation
 			// or one of its services, and can therefore be assured that both
 			// services are Alive.
 			asserts = append(hasRelation, isAliveDoc...)
-		} else if ep.ApplicationName == departingUnit.ApplicationName() {
+		} else if ep.ApplicationName == unitApplicationName(departingUnitName) {
 			// This service must have at least one unit -- the one that's
 			// departing the relation -- so it cannot be ready for removal.
 			cannotDieYet := bson.D{{"unitcount", bson.D{{"$gt", 0}}}}
@@ -224,6 +396,11 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 			Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
 		})
 	}
+	grantOps, err := removeRelationSecretGrantsOps(r.st, r.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, grantOps...)
 	cleanupOp := r.st.newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
 	return append(ops, cleanupOp), nil
 }
@@ -291,7 +468,56 @@ func (r *Relation) Unit(u *Unit) (*RelationUnit, error) {
 		st:       r.st,
 		relation: r,
 		unit:     u,
+		unitName: u.doc.Name,
 		endpoint: ep,
 		scope:    strings.Join(scope, "#"),
 	}, nil
 }
+
+// RemoteUnit returns a RelationUnit for the named unit of a remote
+// application taking part in the relation. Remote units are not
+// represented in the model; they are only known by their presence in
+// the relation scope and their relation settings.
+func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	appName := unitApplicationName(unitName)
+	ep, err := r.Endpoint(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := r.st.RemoteApplication(appName); errors.IsNotFound(err) {
+		return nil, errors.NotValidf("unit %q of local application", unitName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RelationUnit{
+		st:       r.st,
+		relation: r,
+		unitName: unitName,
+		endpoint: ep,
+		scope:    "r#" + strconv.Itoa(r.doc.Id),
+	}, nil
+}
+
+// WatchApplicationUnits returns a watcher that notifies of the units
+// of the named application entering and leaving the relation's scope,
+// and of changes to their settings in the relation.
+func (r *Relation) WatchApplicationUnits(applicationName string) (RelationUnitsWatcher, error) {
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.NotSupportedf("watching units of container scoped relation %q", r)
+	}
+	scope := fmt.Sprintf("r#%d#%s", r.doc.Id, ep.Role)
+	return newRelationUnitsWatcher(r.st, newRelationScopeWatcher(r.st, scope, "")), nil
+}
+
+// unitApplicationName returns the name of the application the named
+// unit belongs to.
+func unitApplicationName(unitName string) string {
+	return strings.Split(unitName, "/")[0]
+}
@@ -286,6 +286,63 @@ func (s *RelationSuite) TestDestroyPeerRelation(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *RelationSuite) TestSetSuspended(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+
+	err = rel.SetSuspended(true, "misbehaving consumer")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+	c.Assert(rel.SuspendedReason(), gc.Equals, "misbehaving consumer")
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+	c.Assert(rel.SuspendedReason(), gc.Equals, "misbehaving consumer")
+
+	// Suspending again is a no-op.
+	err = rel.SetSuspended(true, "misbehaving consumer")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Resuming clears the reason.
+	err = rel.SetSuspended(false, "ignored")
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+	c.Assert(rel.SuspendedReason(), gc.Equals, "")
+}
+
+func (s *RelationSuite) TestSetSuspendedErrors(c *gc.C) {
+	riak := s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+	riakEP, err := riak.Endpoint("ring")
+	c.Assert(err, jc.ErrorIsNil)
+	peer := assertOneRelation(c, riak, 0, riakEP)
+	err = peer.SetSuspended(true, "")
+	c.Assert(err, gc.ErrorMatches, `cannot suspend relation "riak:ring": is a peer relation`)
+
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSuspended(true, "")
+	c.Assert(err, gc.ErrorMatches, `cannot suspend relation "wordpress:db mysql:server": relation is not alive`)
+}
+
 func assertNoRelations(c *gc.C, srv *state.Application) {
 	rels, err := srv.Relations()
 	c.Assert(err, jc.ErrorIsNil)
@@ -21,10 +21,15 @@ import (
 
 // RelationUnit holds information about a single unit in a relation, and
 // allows clients to conveniently access unit-specific functionality.
+//
+// The unit may be a unit of a remote application, in which case it has
+// no representation in the model other than its presence in the
+// relation's scope and its relation settings.
 type RelationUnit struct {
 	st       *State
 	relation *Relation
 	unit     *Unit
+	unitName string
 	endpoint Endpoint
 	scope    string
 }
@@ -42,9 +47,17 @@ func (ru *RelationUnit) Endpoint() Endpoint {
 
 // PrivateAddress returns the private address of the unit.
 func (ru *RelationUnit) PrivateAddress() (network.Address, error) {
+	if ru.unit == nil {
+		return network.Address{}, errors.NotSupportedf("private address of remote unit %q", ru.unitName)
+	}
 	return ru.unit.PrivateAddress()
 }
 
+// IsRemote returns whether the unit belongs to a remote application.
+func (ru *RelationUnit) IsRemote() bool {
+	return ru.unit == nil
+}
+
 // ErrCannotEnterScope indicates that a relation unit failed to enter its scope
 // due to either the unit or the relation not being Alive.
 var ErrCannotEnterScope = stderrors.New("cannot enter scope: unit or relation is not alive")
@@ -90,15 +103,33 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// * TODO(fwereade): check unit status == params.StatusActive (this
 	//   breaks a bunch of tests in a boring but noisy-to-fix way, and is
 	//   being saved for a followup).
-	unitDocID, relationDocID := ru.unit.doc.DocID, ru.relation.doc.DocID
+	//   Remote units have no unit document, so the life of their remote
+	//   application is checked instead.
+	memberColl, memberDocID := unitsC, ""
+	if ru.unit != nil {
+		memberDocID = ru.unit.doc.DocID
+	} else {
+		memberColl = remoteApplicationsC
+		memberDocID = ru.st.docID(ru.endpoint.ApplicationName)
+	}
+	//   Units entering the scope of a suspended relation are marked as
+	//   departing straight away, so they are not seen by other units
+	//   until the relation is resumed.
+	relationDocID := ru.relation.doc.DocID
+	relations, closer := db.GetCollection(relationsC)
+	defer closer()
+	suspended, err := isSuspendedWithSession(relations, relationDocID)
+	if err != nil {
+		return err
+	}
 	ops := []txn.Op{{
-		C:      unitsC,
-		Id:     unitDocID,
+		C:      memberColl,
+		Id:     memberDocID,
 		Assert: isAliveDoc,
 	}, {
 		C:      relationsC,
 		Id:     relationDocID,
-		Assert: isAliveDoc,
+		Assert: bson.D{{"life", Alive}, suspendedAssert(suspended)},
 		Update: bson.D{{"$inc", bson.D{{"unitcount", 1}}}},
 	}}
 
@@ -128,7 +159,8 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 		Id:     ruKey,
 		Assert: txn.DocMissing,
 		Insert: relationScopeDoc{
-			Key: ruKey,
+			Key:       ruKey,
+			Departing: suspended,
 		},
 	})
 
@@ -154,7 +186,7 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 
 	units, closer := db.GetCollection(unitsC)
 	defer closer()
-	relations, closer := db.GetCollection(relationsC)
+	members, closer := db.GetCollection(memberColl)
 	defer closer()
 
 	// The relation or unit might no longer be Alive. (Note that there is no
@@ -162,7 +194,7 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// unit: this could fail due to the subordinate service's not being Alive,
 	// but this case will always be caught by the check for the relation's
 	// life (because a relation cannot be Alive if its services are not).)
-	if alive, err := isAliveWithSession(units, unitDocID); err != nil {
+	if alive, err := isAliveWithSession(members, memberDocID); err != nil {
 		return err
 	} else if !alive {
 		return ErrCannotEnterScope
@@ -173,6 +205,13 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 		return ErrCannotEnterScope
 	}
 
+	prefix := fmt.Sprintf("cannot enter scope for unit %q in relation %q: ", ru.unitName, ru.relation)
+	if nowSuspended, err := isSuspendedWithSession(relations, relationDocID); err != nil {
+		return err
+	} else if nowSuspended != suspended {
+		return fmt.Errorf(prefix + "concurrent suspension change detected")
+	}
+
 	// Maybe a subordinate used to exist, but is no longer alive. If that is
 	// case, we will be unable to enter scope until that unit is gone.
 	if existingSubName != "" {
@@ -187,7 +226,6 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// has changed under our feet, preventing us from clearing it properly; if
 	// that is the case, something is seriously wrong (nobody else should be
 	// touching that doc under our feet) and we should bail out.
-	prefix := fmt.Sprintf("cannot enter scope for unit %q in relation %q: ", ru.unit, ru.relation)
 	if changed, err := settingsChanged(); err != nil {
 		return err
 	} else if changed {
@@ -207,7 +245,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	units, closer := ru.st.getCollection(unitsC)
 	defer closer()
 
-	if !ru.unit.IsPrincipal() || ru.endpoint.Scope != charm.ScopeContainer {
+	if ru.unit == nil || !ru.unit.IsPrincipal() || ru.endpoint.Scope != charm.ScopeContainer {
 		return nil, "", nil
 	}
 	related, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName)
@@ -225,7 +263,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 		if err != nil {
 			return nil, "", err
 		}
-		_, ops, err := application.addUnitOps(unitName, nil)
+		_, ops, err := application.addUnitOps(unitName, AddUnitParams{}, nil)
 		return ops, "", err
 	} else if err != nil {
 		return nil, "", err
@@ -292,7 +330,7 @@ func (ru *RelationUnit) LeaveScope() error {
 	// to have a Dying relation with a smaller-than-real unit count, because
 	// Destroy changes the Life attribute in memory (units could join before
 	// the database is actually changed).
-	desc := fmt.Sprintf("unit %q in relation %q", ru.unit, ru.relation)
+	desc := fmt.Sprintf("unit %q in relation %q", ru.unitName, ru.relation)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := ru.relation.Refresh(); errors.IsNotFound(err) {
@@ -328,7 +366,7 @@ func (ru *RelationUnit) LeaveScope() error {
 				Update: bson.D{{"$inc", bson.D{{"unitcount", -1}}}},
 			})
 		} else {
-			relOps, err := ru.relation.removeOps("", ru.unit)
+			relOps, err := ru.relation.removeOps("", ru.unitName)
 			if err != nil {
 				return nil, err
 			}
@@ -342,6 +380,29 @@ func (ru *RelationUnit) LeaveScope() error {
 	return nil
 }
 
+// SetSettled records whether the unit's agent has run all the relation
+// hooks due so far for the relation. A unit entering scope is not
+// settled until its agent reports otherwise.
+func (ru *RelationUnit) SetSettled(settled bool) error {
+	ops := []txn.Op{{
+		C:      relationScopesC,
+		Id:     ru.key(),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"settled", settled}}}},
+	}}
+	err := ru.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("relation scope")
+	}
+	return errors.Annotatef(err, "cannot record relation hook progress for unit %q in relation %q", ru.unitName, ru.relation)
+}
+
+// Settled returns whether the relation unit is in scope and its agent
+// has reported running all the relation hooks due so far.
+func (ru *RelationUnit) Settled() (bool, error) {
+	return ru.inScope(bson.D{{"settled", true}})
+}
+
 // InScope returns whether the relation unit has entered scope and not left it.
 func (ru *RelationUnit) InScope() (bool, error) {
 	return ru.inScope(nil)
@@ -372,7 +433,7 @@ func (ru *RelationUnit) inScope(sel bson.D) (bool, error) {
 func (ru *RelationUnit) WatchScope() *RelationScopeWatcher {
 	role := counterpartRole(ru.endpoint.Role)
 	scope := ru.scope + "#" + string(role)
-	return newRelationScopeWatcher(ru.st, scope, ru.unit.Name())
+	return newRelationScopeWatcher(ru.st, scope, ru.unitName)
 }
 
 // Settings returns a Settings which allows access to the unit's settings
@@ -421,7 +482,7 @@ func (ru *RelationUnit) unitKey(uname string) (string, error) {
 // which is used as a key for that unit within this relation in the settings,
 // presence, and relationScopes collections.
 func (ru *RelationUnit) key() string {
-	return ru._key(string(ru.endpoint.Role), ru.unit.Name())
+	return ru._key(string(ru.endpoint.Role), ru.unitName)
 }
 
 func (ru *RelationUnit) _key(role, unitname string) string {
@@ -436,6 +497,7 @@ type relationScopeDoc struct {
 	Key       string `bson:"key"`
 	ModelUUID string `bson:"model-uuid"`
 	Departing bool
+	Settled   bool
 }
 
 func (d *relationScopeDoc) unitName() string {
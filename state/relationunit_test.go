@@ -54,6 +54,12 @@ func assertNotJoined(c *gc.C, ru *state.RelationUnit) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func assertSettled(c *gc.C, ru *state.RelationUnit, expected bool) {
+	settled, err := ru.Settled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settled, gc.Equals, expected)
+}
+
 func (s *RelationUnitSuite) TestReadSettingsErrors(c *gc.C) {
 	riak := s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
 	u0, err := riak.AddUnit()
@@ -716,6 +722,80 @@ func (s *RelationUnitSuite) TestPrepareLeaveScope(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *RelationUnitSuite) TestSetSettled(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+
+	// A unit out of scope can't be settled.
+	err := prr.rru0.SetSettled(true)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// A unit entering scope is not settled until it says so.
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertSettled(c, prr.rru0, false)
+	err = prr.rru0.SetSettled(true)
+	c.Assert(err, jc.ErrorIsNil)
+	assertSettled(c, prr.rru0, true)
+	assertSettled(c, prr.rru1, false)
+	err = prr.rru0.SetSettled(false)
+	c.Assert(err, jc.ErrorIsNil)
+	assertSettled(c, prr.rru0, false)
+
+	// Leaving scope forgets the unit's progress.
+	err = prr.rru0.SetSettled(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	assertSettled(c, prr.rru0, false)
+}
+
+func (s *RelationUnitSuite) TestSuspendRelation(c *gc.C) {
+	prr := NewProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	w0 := prr.pru0.WatchScope()
+	defer testing.AssertStop(c, w0)
+	s.assertScopeChange(c, w0, nil, nil)
+
+	err := prr.rru0.EnterScope(map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertScopeChange(c, w0, []string{"wordpress/0", "wordpress/1"}, nil)
+	s.assertNoScopeChange(c, w0)
+
+	// Suspending the relation reports all units as departed, but they
+	// remain in scope and keep their settings.
+	err = prr.rel.SetSuspended(true, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertScopeChange(c, w0, nil, []string{"wordpress/0", "wordpress/1"})
+	s.assertNoScopeChange(c, w0)
+	assertInScope(c, prr.rru0)
+	assertNotJoined(c, prr.rru0)
+	settings, err := prr.pru0.ReadSettings("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+
+	// Units entering scope while suspended are not reported.
+	err = prr.pru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	assertInScope(c, prr.pru1)
+	assertNotJoined(c, prr.pru1)
+	s.assertNoScopeChange(c, w0)
+
+	// A unit that is dying when the relation is resumed stays departed.
+	preventUnitDestroyRemove(c, prr.ru1)
+	err = prr.ru1.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Resuming the relation reports the alive units as joined again.
+	err = prr.rel.SetSuspended(false, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertScopeChange(c, w0, []string{"mysql/1", "wordpress/0"}, nil)
+	s.assertNoScopeChange(c, w0)
+	assertJoined(c, prr.rru0)
+	assertJoined(c, prr.pru1)
+	assertNotJoined(c, prr.rru1)
+}
+
 func (s *RelationUnitSuite) assertScopeChange(c *gc.C, w *state.RelationScopeWatcher, entered, left []string) {
 	s.State.StartSync()
 	select {
@@ -0,0 +1,476 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// RemoteApplication represents the state of an application hosted
+// in another model on the same controller, with which applications
+// in this model can be related.
+//
+// In the consuming model, a remote application stands for an offered
+// application; in the offering model, a remote application stands for
+// the consuming application (a "consumer proxy").
+type RemoteApplication struct {
+	st  *State
+	doc remoteApplicationDoc
+}
+
+// remoteApplicationDoc represents the internal state of a remote
+// application in MongoDB.
+type remoteApplicationDoc struct {
+	DocID           string              `bson:"_id"`
+	Name            string              `bson:"name"`
+	ModelUUID       string              `bson:"model-uuid"`
+	OfferName       string              `bson:"offer-name"`
+	URL             string              `bson:"url,omitempty"`
+	SourceModelUUID string              `bson:"source-model-uuid"`
+	Endpoints       []remoteEndpointDoc `bson:"endpoints"`
+	IsConsumerProxy bool                `bson:"is-consumer-proxy"`
+	Life            Life                `bson:"life"`
+	RelationCount   int                 `bson:"relationcount"`
+}
+
+// remoteEndpointDoc represents the internal state of a remote
+// application endpoint in MongoDB.
+type remoteEndpointDoc struct {
+	Name      string              `bson:"name"`
+	Role      charm.RelationRole  `bson:"role"`
+	Interface string              `bson:"interface"`
+	Limit     int                 `bson:"limit"`
+	Scope     charm.RelationScope `bson:"scope"`
+}
+
+func newRemoteApplication(st *State, doc *remoteApplicationDoc) *RemoteApplication {
+	return &RemoteApplication{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// remoteApplicationGlobalKey returns the global database key for the
+// remote application with the given name.
+func remoteApplicationGlobalKey(appName string) string {
+	return "c#" + appName
+}
+
+// globalKey returns the global database key for the remote application.
+func (s *RemoteApplication) globalKey() string {
+	return remoteApplicationGlobalKey(s.doc.Name)
+}
+
+// Name returns the remote application name.
+func (s *RemoteApplication) Name() string {
+	return s.doc.Name
+}
+
+// String returns the remote application name.
+func (s *RemoteApplication) String() string {
+	return s.doc.Name
+}
+
+// Tag returns a name identifying the remote application.
+func (s *RemoteApplication) Tag() names.Tag {
+	return names.NewApplicationTag(s.doc.Name)
+}
+
+// OfferName returns the name of the offer the remote application was
+// consumed from. For consumer proxies, it is the name of the consuming
+// application in the source model.
+func (s *RemoteApplication) OfferName() string {
+	return s.doc.OfferName
+}
+
+// URL returns the URL of the offer the remote application was consumed
+// from; it is empty for consumer proxies.
+func (s *RemoteApplication) URL() string {
+	return s.doc.URL
+}
+
+// SourceModel returns the tag of the model hosting the application
+// represented by the remote application.
+func (s *RemoteApplication) SourceModel() names.ModelTag {
+	return names.NewModelTag(s.doc.SourceModelUUID)
+}
+
+// IsConsumerProxy returns whether the remote application stands for an
+// application consuming an offer from this model.
+func (s *RemoteApplication) IsConsumerProxy() bool {
+	return s.doc.IsConsumerProxy
+}
+
+// Life returns whether the remote application is Alive, Dying or Dead.
+func (s *RemoteApplication) Life() Life {
+	return s.doc.Life
+}
+
+// Endpoints returns the remote application's currently available
+// relation endpoints.
+func (s *RemoteApplication) Endpoints() ([]Endpoint, error) {
+	var eps []Endpoint
+	for _, ep := range s.doc.Endpoints {
+		eps = append(eps, Endpoint{
+			ApplicationName: s.doc.Name,
+			Relation: charm.Relation{
+				Name:      ep.Name,
+				Role:      ep.Role,
+				Interface: ep.Interface,
+				Limit:     ep.Limit,
+				Scope:     ep.Scope,
+			},
+		})
+	}
+	sort.Sort(epSlice(eps))
+	return eps, nil
+}
+
+// Endpoint returns the relation endpoint with the supplied name, if it
+// exists.
+func (s *RemoteApplication) Endpoint(relationName string) (Endpoint, error) {
+	eps, err := s.Endpoints()
+	if err != nil {
+		return Endpoint{}, err
+	}
+	for _, ep := range eps {
+		if ep.Name == relationName {
+			return ep, nil
+		}
+	}
+	return Endpoint{}, fmt.Errorf("remote application %q has no %q relation", s, relationName)
+}
+
+// Relations returns a Relation for every relation the remote
+// application is in.
+func (s *RemoteApplication) Relations() ([]*Relation, error) {
+	return applicationRelations(s.st, s.doc.Name)
+}
+
+// Status returns the status of the remote application.
+func (s *RemoteApplication) Status() (status.StatusInfo, error) {
+	return getStatus(s.st, s.globalKey(), "remote application")
+}
+
+// SetStatus sets the status of the remote application.
+func (s *RemoteApplication) SetStatus(statusInfo status.StatusInfo) error {
+	if !status.ValidWorkloadStatus(statusInfo.Status) {
+		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
+	}
+	return setStatus(s.st, setStatusParams{
+		badge:     "remote application",
+		globalKey: s.globalKey(),
+		status:    statusInfo.Status,
+		message:   statusInfo.Message,
+		rawData:   statusInfo.Data,
+		updated:   statusInfo.Since,
+	})
+}
+
+// Refresh refreshes the contents of the remote application from the
+// underlying state. It returns an error that satisfies errors.IsNotFound
+// if the remote application has been removed.
+func (s *RemoteApplication) Refresh() error {
+	applications, closer := s.st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	err := applications.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("remote application %q", s)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh remote application %q", s)
+	}
+	return nil
+}
+
+// Destroy ensures that the remote application and all its relations will
+// be removed at some point; if no relation involving the application has
+// any units in scope, they are all removed immediately.
+func (s *RemoteApplication) Destroy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy remote application %q", s)
+	defer func() {
+		if err == nil {
+			// This is a white lie; the document might actually be removed.
+			s.doc.Life = Dying
+		}
+	}()
+	app := &RemoteApplication{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		switch ops, err := app.destroyOps(); err {
+		case errRefresh:
+		case errAlreadyDying:
+			return nil, jujutxn.ErrNoOperations
+		case nil:
+			return ops, nil
+		default:
+			return nil, err
+		}
+		return nil, jujutxn.ErrTransientFailure
+	}
+	return s.st.run(buildTxn)
+}
+
+// destroyOps returns the operations required to destroy the remote
+// application. If it returns errRefresh, the application should be
+// refreshed and the destruction operations recalculated.
+func (s *RemoteApplication) destroyOps() ([]txn.Op, error) {
+	if s.doc.Life == Dying {
+		return nil, errAlreadyDying
+	}
+	rels, err := s.Relations()
+	if err != nil {
+		return nil, err
+	}
+	if len(rels) != s.doc.RelationCount {
+		return nil, errRefresh
+	}
+	var ops []txn.Op
+	removeCount := 0
+	for _, rel := range rels {
+		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
+		if err == errAlreadyDying {
+			relOps = []txn.Op{{
+				C:      relationsC,
+				Id:     rel.doc.DocID,
+				Assert: bson.D{{"life", Dying}},
+			}}
+		} else if err != nil {
+			return nil, err
+		}
+		if isRemove {
+			removeCount++
+		}
+		ops = append(ops, relOps...)
+	}
+	// If all the remote application's known relations will be removed,
+	// it can also be removed; otherwise, removal will be handled when the
+	// last relation referencing it is removed.
+	if s.doc.RelationCount == removeCount {
+		hasLastRefs := bson.D{{"life", Alive}, {"relationcount", removeCount}}
+		return append(ops, s.removeOps(hasLastRefs)...), nil
+	}
+	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	if removeCount != 0 {
+		decref := bson.D{{"$inc", bson.D{{"relationcount", -removeCount}}}}
+		update = append(update, decref...)
+	}
+	return append(ops, txn.Op{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"life", Alive}, {"relationcount", s.doc.RelationCount}},
+		Update: update,
+	}), nil
+}
+
+// removeOps returns the operations required to remove the remote
+// application. Supplied asserts will be included in the operation on
+// the remote application document.
+func (s *RemoteApplication) removeOps(asserts bson.D) []txn.Op {
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: asserts,
+		Remove: true,
+	},
+		removeStatusOp(s.st, s.globalKey()),
+	}
+}
+
+// decRelationCountOps returns the operations required to drop a
+// reference from a relation being removed to the remote application,
+// removing the remote application if it is Dying and the relation is
+// the last one referencing it.
+func (s *RemoteApplication) decRelationCountOps() []txn.Op {
+	if s.doc.Life == Dying && s.doc.RelationCount == 1 {
+		return s.removeOps(bson.D{{"life", Dying}, {"relationcount", 1}})
+	}
+	return []txn.Op{{
+		C:  remoteApplicationsC,
+		Id: s.doc.DocID,
+		Assert: bson.D{{"$or", []bson.D{
+			{{"life", Alive}},
+			{{"relationcount", bson.D{{"$gt", 1}}}},
+		}}},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}
+}
+
+// AddRemoteApplicationParams contains the parameters for adding a remote
+// application to the model.
+type AddRemoteApplicationParams struct {
+	// Name is the name to give the remote application.
+	Name string
+
+	// OfferName is the name of the offer in the source model. For
+	// consumer proxies, it is the name of the consuming application.
+	OfferName string
+
+	// URL is the URL of the consumed offer; it is empty for
+	// consumer proxies.
+	URL string
+
+	// SourceModel is the tag of the model hosting the application
+	// the remote application stands for.
+	SourceModel names.ModelTag
+
+	// Endpoints describes the endpoints that the remote application
+	// implements.
+	Endpoints []charm.Relation
+
+	// IsConsumerProxy is true when the remote application stands
+	// for an application consuming an offer from this model.
+	IsConsumerProxy bool
+}
+
+// Validate returns an error if there's a problem with the
+// parameters being used to create a remote application.
+func (p AddRemoteApplicationParams) Validate() error {
+	if !names.IsValidApplication(p.Name) {
+		return errors.NotValidf("name %q", p.Name)
+	}
+	if p.OfferName == "" {
+		return errors.NotValidf("empty offer name")
+	}
+	if !names.IsValidModel(p.SourceModel.Id()) {
+		return errors.NotValidf("source model %q", p.SourceModel.Id())
+	}
+	if len(p.Endpoints) == 0 {
+		return errors.NotValidf("remote application with no endpoints")
+	}
+	for _, ep := range p.Endpoints {
+		if ep.Role == charm.RolePeer {
+			return errors.NotValidf("peer endpoint %q", ep.Name)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return errors.NotValidf("container scoped endpoint %q", ep.Name)
+		}
+	}
+	return nil
+}
+
+// AddRemoteApplication creates a new remote application record, having
+// the supplied relation endpoints, with the supplied name (which must
+// be unique across all applications, local and remote).
+func (st *State) AddRemoteApplication(args AddRemoteApplicationParams) (_ *RemoteApplication, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote application %q", args.Name)
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkModelActive(st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	applicationID := st.docID(args.Name)
+	doc := &remoteApplicationDoc{
+		DocID:           applicationID,
+		Name:            args.Name,
+		ModelUUID:       st.ModelUUID(),
+		OfferName:       args.OfferName,
+		URL:             args.URL,
+		SourceModelUUID: args.SourceModel.Id(),
+		IsConsumerProxy: args.IsConsumerProxy,
+		Life:            Alive,
+	}
+	for _, ep := range args.Endpoints {
+		doc.Endpoints = append(doc.Endpoints, remoteEndpointDoc{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		})
+	}
+	app := newRemoteApplication(st, doc)
+	statusDoc := statusDoc{
+		ModelUUID: st.ModelUUID(),
+		Status:    status.StatusUnknown,
+		Updated:   time.Now().UnixNano(),
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if exists, err := isNotDead(st, remoteApplicationsC, args.Name); err != nil {
+			return nil, errors.Trace(err)
+		} else if exists {
+			return nil, errors.Errorf("remote application already exists")
+		}
+		if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
+			return nil, errors.Trace(err)
+		} else if exists {
+			return nil, errors.Errorf("local application with same name already exists")
+		}
+		return []txn.Op{
+			assertModelActiveOp(st.ModelUUID()),
+			{
+				C:      applicationsC,
+				Id:     applicationID,
+				Assert: txn.DocMissing,
+			}, {
+				C:      remoteApplicationsC,
+				Id:     applicationID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			},
+			createStatusOp(st, app.globalKey(), statusDoc),
+		}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
+// RemoteApplication returns a remote application by name.
+func (st *State) RemoteApplication(name string) (_ *RemoteApplication, err error) {
+	if !names.IsValidApplication(name) {
+		return nil, errors.NotValidf("remote application name %q", name)
+	}
+	applications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	doc := &remoteApplicationDoc{}
+	err = applications.FindId(name).One(doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get remote application %q", name)
+	}
+	return newRemoteApplication(st, doc), nil
+}
+
+// AllRemoteApplications returns all the remote applications in the
+// model, ordered by name.
+func (st *State) AllRemoteApplications() (applications []*RemoteApplication, err error) {
+	applicationsCollection, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	docs := []remoteApplicationDoc{}
+	err = applicationsCollection.Find(nil).Sort("name").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get all remote applications")
+	}
+	for _, v := range docs {
+		applications = append(applications, newRemoteApplication(st, &v))
+	}
+	return applications, nil
+}
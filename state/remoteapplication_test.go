@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type remoteApplicationSuite struct {
+	ConnSuite
+	application *state.RemoteApplication
+}
+
+var _ = gc.Suite(&remoteApplicationSuite{})
+
+func (s *remoteApplicationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.application, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		OfferName:   "hosted-mysql",
+		URL:         "admin/prod.hosted-mysql",
+		SourceModel: testing.ModelTag,
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteApplicationSuite) TestInitialStatus(c *gc.C) {
+	statusInfo, err := s.application.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.StatusUnknown)
+}
+
+func (s *remoteApplicationSuite) TestAttributes(c *gc.C) {
+	app, err := s.State.RemoteApplication("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(app.Name(), gc.Equals, "mysql")
+	c.Check(app.OfferName(), gc.Equals, "hosted-mysql")
+	c.Check(app.URL(), gc.Equals, "admin/prod.hosted-mysql")
+	c.Check(app.SourceModel(), gc.Equals, testing.ModelTag)
+	c.Check(app.IsConsumerProxy(), jc.IsFalse)
+	c.Check(app.Life(), gc.Equals, state.Alive)
+	ep, err := app.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ep, jc.DeepEquals, state.Endpoint{
+		ApplicationName: "mysql",
+		Relation: charm.Relation{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		},
+	})
+	_, err = app.Endpoint("foo")
+	c.Check(err, gc.ErrorMatches, `remote application "mysql" has no "foo" relation`)
+}
+
+func (s *remoteApplicationSuite) TestAllRemoteApplications(c *gc.C) {
+	apps, err := s.State.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, gc.HasLen, 1)
+	c.Check(apps[0].Name(), gc.Equals, "mysql")
+}
+
+func (s *remoteApplicationSuite) TestNameClashes(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		OfferName:   "hosted-mysql",
+		SourceModel: testing.ModelTag,
+		Endpoints:   []charm.Relation{{Interface: "mysql", Name: "db", Role: charm.RoleProvider}},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add remote application "mysql": remote application already exists`)
+
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "wordpress",
+		OfferName:   "hosted-wordpress",
+		SourceModel: testing.ModelTag,
+		Endpoints:   []charm.Relation{{Interface: "mysql", Name: "db", Role: charm.RoleRequirer}},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add remote application "wordpress": local application with same name already exists`)
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{Name: "mysql", Charm: s.AddTestingCharm(c, "mysql")})
+	c.Check(err, gc.ErrorMatches, `cannot add application "mysql": remote application with same name already exists`)
+}
+
+func (s *remoteApplicationSuite) TestAddRemoteApplicationInvalid(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "haproxy",
+		OfferName:   "hosted-haproxy",
+		SourceModel: testing.ModelTag,
+		Endpoints:   []charm.Relation{{Interface: "http", Name: "peers", Role: charm.RolePeer}},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add remote application "haproxy": peer endpoint "peers" not valid`)
+}
+
+func (s *remoteApplicationSuite) TestAddRelation(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rel.String(), gc.Equals, "wordpress:db mysql:db")
+
+	rels, err := s.application.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Check(rels[0].Id(), gc.Equals, rel.Id())
+}
+
+func (s *remoteApplicationSuite) TestRemoteUnitEnterAndLeaveScope(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = rel.RemoteUnit("wordpress/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	ru, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ru.IsRemote(), jc.IsTrue)
+	err = ru.EnterScope(map[string]interface{}{"host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := ru.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(inScope, jc.IsTrue)
+
+	// Local units see the remote unit's settings.
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	localRU, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := localRU.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, map[string]interface{}{"host": "10.0.0.1"})
+
+	// Destroying the remote application leaves the relation Dying until
+	// the remote unit leaves scope.
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rel.Life(), gc.Equals, state.Dying)
+
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.RemoteApplication("mysql")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteApplicationSuite) TestWatchApplicationUnits(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := rel.WatchApplicationUnits("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	defer w.Stop()
+	change := <-w.Changes()
+	c.Check(change.Changed, gc.HasLen, 0)
+
+	ru, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	change = <-w.Changes()
+	c.Check(change.Changed, gc.HasLen, 1)
+	_, ok := change.Changed["mysql/0"]
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *remoteApplicationSuite) TestDestroyWithoutRelations(c *gc.C) {
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoteApplication("mysql")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,243 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// RedactedConfigValue replaces the value of a secret config setting
+// wherever the value may not be revealed.
+const RedactedConfigValue = "<redacted>"
+
+// secretConfigKeySize is the size in bytes of the AES-256 key with
+// which secret config values are encrypted.
+const secretConfigKeySize = 32
+
+// encryptedConfigPrefix marks a config value stored encrypted.
+const encryptedConfigPrefix = "juju-encrypted:v1:"
+
+// secretConfigHints holds the fragments which, appearing in the name
+// of a string charm config option, mark the option as secret until the
+// operator chooses the secret options explicitly.
+var secretConfigHints = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"private-key",
+}
+
+// NewSecretConfigKey returns a new random key, base64 encoded, with
+// which secret config values may be encrypted. The key is generated at
+// bootstrap and kept in the controller agents' configuration, outside
+// the database holding the values it encrypts, so that a dump or backup
+// of the database alone does not reveal them.
+func NewSecretConfigKey() (string, error) {
+	key := make([]byte, secretConfigKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SetSecretConfigKey sets the key, as returned by NewSecretConfigKey,
+// with which secret config values are encrypted and decrypted. States
+// for other models obtained from this one share the key.
+func (st *State) SetSecretConfigKey(key string) error {
+	decoded, err := decodeSecretConfigKey(key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st.secretConfigKey = decoded
+	return nil
+}
+
+// SecretConfigKey returns the key set with SetSecretConfigKey, base64
+// encoded, or the empty string if no key has been set.
+func (st *State) SecretConfigKey() string {
+	if st.secretConfigKey == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(st.secretConfigKey)
+}
+
+func decodeSecretConfigKey(key string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode secret config key")
+	}
+	if len(decoded) != secretConfigKeySize {
+		return nil, errors.NotValidf("secret config key of %d bytes", len(decoded))
+	}
+	return decoded, nil
+}
+
+func secretConfigCipher(key []byte) (cipher.AEAD, error) {
+	if key == nil {
+		return nil, errors.NotFoundf("secret config key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// isEncryptedConfigValue reports whether the given config value is
+// stored encrypted.
+func isEncryptedConfigValue(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, encryptedConfigPrefix)
+}
+
+// validateConfigValues returns an error if any of the given config
+// values could be mistaken for a value stored encrypted.
+func validateConfigValues(settings map[string]interface{}) error {
+	for name, value := range settings {
+		if isEncryptedConfigValue(value) {
+			return errors.NotValidf("value of setting %q beginning with %q", name, encryptedConfigPrefix)
+		}
+	}
+	return nil
+}
+
+// encryptConfigValue returns the given config value in the encrypted
+// form in which secret values are stored.
+func (st *State) encryptConfigValue(value string) (string, error) {
+	return encryptConfigValueWithKey(st.secretConfigKey, value)
+}
+
+// decryptConfigValue returns the plain text of a config value stored
+// encrypted.
+func (st *State) decryptConfigValue(value string) (string, error) {
+	return decryptConfigValueWithKey(st.secretConfigKey, value)
+}
+
+// encryptConfigValueWithKey returns the given config value encrypted
+// with the given key.
+func encryptConfigValueWithKey(key []byte, value string) (string, error) {
+	aead, err := secretConfigCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Trace(err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedConfigPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptConfigValueWithKey returns the plain text of a config value
+// encrypted with the given key.
+func decryptConfigValueWithKey(key []byte, value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedConfigPrefix))
+	if err != nil {
+		return "", errors.Annotate(err, "cannot decode secret config value")
+	}
+	aead, err := secretConfigCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("cannot decrypt secret config value: value too short")
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot decrypt secret config value")
+	}
+	return string(plain), nil
+}
+
+// plainConfigValue returns the given config value, decrypted if it is
+// stored encrypted.
+func (st *State) plainConfigValue(value interface{}) (interface{}, error) {
+	if !isEncryptedConfigValue(value) {
+		return value, nil
+	}
+	return st.decryptConfigValue(value.(string))
+}
+
+// decryptConfigSettings returns a copy of the given settings, with any
+// values stored encrypted replaced by their plain text.
+func (st *State) decryptConfigSettings(settings map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		plain, err := st.plainConfigValue(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "setting %q", name)
+		}
+		result[name] = plain
+	}
+	return result, nil
+}
+
+// encryptConfigSettings returns a copy of the given settings, with the
+// values of the named secret settings encrypted.
+func (st *State) encryptConfigSettings(secrets set.Strings, settings map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		if s, ok := value.(string); ok && secrets.Contains(name) && !isEncryptedConfigValue(s) {
+			encrypted, err := st.encryptConfigValue(s)
+			if err != nil {
+				return nil, errors.Annotatef(err, "setting %q", name)
+			}
+			value = encrypted
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// redactConfigSettings returns a copy of the given settings, with any
+// values stored encrypted replaced by RedactedConfigValue.
+func redactConfigSettings(settings map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		if isEncryptedConfigValue(value) {
+			value = RedactedConfigValue
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// secretConfigKeys returns the names of the string options of the
+// given charm config that are secret. Once the operator has marked
+// keys as secret, those are the secret keys; until then, options whose
+// names suggest they hold credentials are.
+func secretConfigKeys(config *charm.Config, marked *[]string) set.Strings {
+	keys := set.NewStrings()
+	if marked != nil {
+		for _, name := range *marked {
+			if option, ok := config.Options[name]; ok && option.Type == "string" {
+				keys.Add(name)
+			}
+		}
+		return keys
+	}
+	for name, option := range config.Options {
+		if option.Type != "string" {
+			continue
+		}
+		lower := strings.ToLower(name)
+		for _, hint := range secretConfigHints {
+			if strings.Contains(lower, hint) {
+				keys.Add(name)
+				break
+			}
+		}
+	}
+	return keys
+}
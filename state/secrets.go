@@ -0,0 +1,657 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Secret holds the metadata of a secret owned by an application. The
+// content of the secret is held separately for each revision, and is
+// only available through SecretValue.
+type Secret struct {
+	// ID uniquely identifies the secret within the model.
+	ID string
+
+	// Owner is the name of the application owning the secret.
+	Owner string
+
+	// Label is an optional name given to the secret by its owner.
+	Label string
+
+	// Description is an optional description of the secret.
+	Description string
+
+	// Revision is the latest revision of the secret's content.
+	Revision int
+
+	// RotateInterval is the interval at which the owner is asked to
+	// rotate the secret, or zero if it need not be rotated.
+	RotateInterval time.Duration
+
+	// NextRotateTime is the time from which the owner is asked to
+	// rotate the secret, or the zero time if it need not be rotated.
+	NextRotateTime time.Time
+
+	// CreateTime is the time at which the secret was added.
+	CreateTime time.Time
+
+	// UpdateTime is the time at which the latest revision was added.
+	UpdateTime time.Time
+}
+
+// SecretGrant records that an application may read a secret, for as
+// long as the relation through which it was granted exists.
+type SecretGrant struct {
+	// Application is the name of the application granted access.
+	Application string
+
+	// RelationKey is the key of the relation the grant is scoped to.
+	RelationKey string
+}
+
+// AddSecretArgs holds the arguments for adding a secret.
+type AddSecretArgs struct {
+	// Owner is the name of the application owning the secret.
+	Owner string
+
+	// Label is an optional name for the secret.
+	Label string
+
+	// Description is an optional description of the secret.
+	Description string
+
+	// RotateInterval is the interval at which the owner is asked to
+	// rotate the secret, or zero if it need not be rotated.
+	RotateInterval time.Duration
+
+	// Data holds the content of the first revision of the secret.
+	Data map[string]string
+}
+
+// secretDoc represents the metadata of a secret in MongoDB.
+type secretDoc struct {
+	DocID          string `bson:"_id"`
+	ID             string `bson:"secret-id"`
+	ModelUUID      string `bson:"model-uuid"`
+	Owner          string `bson:"owner"`
+	Label          string `bson:"label,omitempty"`
+	Description    string `bson:"description,omitempty"`
+	Revision       int    `bson:"revision"`
+	RotateInterval int64  `bson:"rotate-interval,omitempty"`
+	NextRotateTime int64  `bson:"next-rotate-time,omitempty"`
+	CreateTime     int64  `bson:"create-time"`
+	UpdateTime     int64  `bson:"update-time"`
+}
+
+// secretRevisionDoc holds the content of one revision of a secret,
+// encrypted with the same controller key as secret config values.
+type secretRevisionDoc struct {
+	DocID      string `bson:"_id"`
+	ModelUUID  string `bson:"model-uuid"`
+	SecretID   string `bson:"secret-id"`
+	Revision   int    `bson:"revision"`
+	Data       string `bson:"data"`
+	CreateTime int64  `bson:"create-time"`
+}
+
+// secretGrantDoc records a grant of access to a secret.
+type secretGrantDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	SecretID    string `bson:"secret-id"`
+	Application string `bson:"application"`
+	RelationKey string `bson:"relation-key"`
+	RelationId  int    `bson:"relation-id"`
+}
+
+func (doc *secretDoc) secret() *Secret {
+	secret := &Secret{
+		ID:             doc.ID,
+		Owner:          doc.Owner,
+		Label:          doc.Label,
+		Description:    doc.Description,
+		Revision:       doc.Revision,
+		RotateInterval: time.Duration(doc.RotateInterval),
+		CreateTime:     time.Unix(0, doc.CreateTime).UTC(),
+		UpdateTime:     time.Unix(0, doc.UpdateTime).UTC(),
+	}
+	if doc.NextRotateTime != 0 {
+		secret.NextRotateTime = time.Unix(0, doc.NextRotateTime).UTC()
+	}
+	return secret
+}
+
+func secretRevisionKey(id string, revision int) string {
+	return fmt.Sprintf("%s#%d", id, revision)
+}
+
+func secretGrantKey(id, application string) string {
+	return id + "#" + application
+}
+
+// nextRotateTime returns the time from which a secret added or updated
+// at the given time should be rotated, as stored in a secretDoc.
+func nextRotateTime(now time.Time, interval time.Duration) int64 {
+	if interval <= 0 {
+		return 0
+	}
+	return now.Add(interval).UnixNano()
+}
+
+// encryptSecretData returns the given secret content in the encrypted
+// form in which it is stored.
+func (st *State) encryptSecretData(data map[string]string) (string, error) {
+	if len(data) == 0 {
+		return "", errors.NotValidf("empty secret content")
+	}
+	for key := range data {
+		if key == "" {
+			return "", errors.NotValidf("empty secret key")
+		}
+	}
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return st.encryptConfigValue(string(plain))
+}
+
+// AddSecret adds a secret owned by an application, and returns its
+// metadata. The given content becomes revision 1 of the secret.
+func (st *State) AddSecret(args AddSecretArgs) (_ *Secret, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add secret for application %q", args.Owner)
+	if args.RotateInterval < 0 {
+		return nil, errors.NotValidf("negative rotate interval")
+	}
+	app, err := st.Application(args.Owner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app.Life() != Alive {
+		return nil, errors.Errorf("application %q is not alive", args.Owner)
+	}
+	data, err := st.encryptSecretData(args.Data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := st.sequence("secret")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprintf("secret:%d", seq+1)
+	now := GetClock().Now()
+	doc := &secretDoc{
+		DocID:          st.docID(id),
+		ID:             id,
+		ModelUUID:      st.ModelUUID(),
+		Owner:          args.Owner,
+		Label:          args.Label,
+		Description:    args.Description,
+		Revision:       1,
+		RotateInterval: int64(args.RotateInterval),
+		NextRotateTime: nextRotateTime(now, args.RotateInterval),
+		CreateTime:     now.UnixNano(),
+		UpdateTime:     now.UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(args.Owner),
+		Assert: isAliveDoc,
+	}, {
+		C:      secretsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      secretRevisionsC,
+		Id:     st.docID(secretRevisionKey(id, 1)),
+		Assert: txn.DocMissing,
+		Insert: &secretRevisionDoc{
+			DocID:      st.docID(secretRevisionKey(id, 1)),
+			ModelUUID:  st.ModelUUID(),
+			SecretID:   id,
+			Revision:   1,
+			Data:       data,
+			CreateTime: now.UnixNano(),
+		},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("application %q is not alive", args.Owner)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.secret(), nil
+}
+
+// UpdateSecret adds a new revision of a secret with the given content,
+// and returns the updated metadata. Adding a revision counts as rotating
+// the secret, so the next rotation is due a full interval later.
+func (st *State) UpdateSecret(id string, data map[string]string) (_ *Secret, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update secret %q", id)
+	encrypted, err := st.encryptSecretData(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var doc *secretDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if doc, err = st.secretDoc(id); err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := GetClock().Now()
+		revision := doc.Revision + 1
+		update := bson.D{
+			{"revision", revision},
+			{"update-time", now.UnixNano()},
+		}
+		if doc.RotateInterval != 0 {
+			next := nextRotateTime(now, time.Duration(doc.RotateInterval))
+			update = append(update, bson.DocElem{"next-rotate-time", next})
+			doc.NextRotateTime = next
+		}
+		doc.Revision = revision
+		doc.UpdateTime = now.UnixNano()
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"revision", revision - 1}},
+			Update: bson.D{{"$set", update}},
+		}, {
+			C:      secretRevisionsC,
+			Id:     st.docID(secretRevisionKey(id, revision)),
+			Assert: txn.DocMissing,
+			Insert: &secretRevisionDoc{
+				DocID:      st.docID(secretRevisionKey(id, revision)),
+				ModelUUID:  st.ModelUUID(),
+				SecretID:   id,
+				Revision:   revision,
+				Data:       encrypted,
+				CreateTime: now.UnixNano(),
+			},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.secret(), nil
+}
+
+func (st *State) secretDoc(id string) (*secretDoc, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &doc, nil
+}
+
+// Secret returns the metadata of the secret with the given id.
+func (st *State) Secret(id string) (*Secret, error) {
+	doc, err := st.secretDoc(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.secret(), nil
+}
+
+// AllSecrets returns the metadata of all the secrets in the model,
+// ordered by id.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	return st.secrets(nil)
+}
+
+// SecretsToRotate returns the metadata of the secrets owned by the
+// given application that are due to be rotated.
+func (st *State) SecretsToRotate(owner string) ([]*Secret, error) {
+	return st.secrets(bson.D{
+		{"owner", owner},
+		{"next-rotate-time", bson.D{
+			{"$gt", 0},
+			{"$lte", GetClock().Now().UnixNano()},
+		}},
+	})
+}
+
+func (st *State) secrets(query bson.D) ([]*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.secret()
+	}
+	sort.Sort(secretsByID(result))
+	return result, nil
+}
+
+// SecretValue returns the content of the given revision of a secret.
+// A revision of zero means the latest revision.
+func (st *State) SecretValue(id string, revision int) (map[string]string, error) {
+	if revision == 0 {
+		doc, err := st.secretDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revision = doc.Revision
+	}
+	revisions, closer := st.getCollection(secretRevisionsC)
+	defer closer()
+
+	var doc secretRevisionDoc
+	err := revisions.FindId(secretRevisionKey(id, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", id, revision)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	plain, err := st.decryptConfigValue(doc.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read secret %q", id)
+	}
+	var data map[string]string
+	if err := json.Unmarshal([]byte(plain), &data); err != nil {
+		return nil, errors.Annotatef(err, "cannot read secret %q", id)
+	}
+	return data, nil
+}
+
+// GrantSecret allows the named application to read a secret, for as
+// long as the given relation exists. The application must be at the
+// other end of the relation from the secret's owner.
+func (st *State) GrantSecret(id string, relation *Relation, application string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot grant secret %q to application %q", id, application)
+	doc, err := st.secretDoc(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if application == doc.Owner {
+		return errors.Errorf("application owns the secret")
+	}
+	if _, err := relation.Endpoint(doc.Owner); err != nil {
+		return errors.Errorf("relation %q does not involve owner %q", relation, doc.Owner)
+	}
+	if _, err := relation.Endpoint(application); err != nil {
+		return errors.Errorf("relation %q does not involve application %q", relation, application)
+	}
+	grant := &secretGrantDoc{
+		DocID:       st.docID(secretGrantKey(id, application)),
+		ModelUUID:   st.ModelUUID(),
+		SecretID:    id,
+		Application: application,
+		RelationKey: relation.String(),
+		RelationId:  relation.Id(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     relation.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+		}}
+		existing, err := st.secretGrant(id, application)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      secretGrantsC,
+				Id:     grant.DocID,
+				Assert: txn.DocMissing,
+				Insert: grant,
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing.RelationId == grant.RelationId {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      secretGrantsC,
+			Id:     grant.DocID,
+			Assert: bson.D{{"relation-id", existing.RelationId}},
+			Update: bson.D{{"$set", bson.D{
+				{"relation-key", grant.RelationKey},
+				{"relation-id", grant.RelationId},
+			}}},
+		}), nil
+	}
+	if err := st.run(buildTxn); err == txn.ErrAborted {
+		return errors.Errorf("relation %q is not alive", relation)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RevokeSecret removes the named application's access to a secret,
+// granted through the given relation. Revoking access that was never
+// granted, or was granted through another relation, is not an error.
+func (st *State) RevokeSecret(id string, relation *Relation, application string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		grant, err := st.secretGrant(id, application)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if grant.RelationId != relation.Id() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      secretGrantsC,
+			Id:     grant.DocID,
+			Assert: bson.D{{"relation-id", grant.RelationId}},
+			Remove: true,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot revoke secret %q from application %q", id, application)
+	}
+	return nil
+}
+
+func (st *State) secretGrant(id, application string) (*secretGrantDoc, error) {
+	grants, closer := st.getCollection(secretGrantsC)
+	defer closer()
+
+	var doc secretGrantDoc
+	err := grants.FindId(secretGrantKey(id, application)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("grant of secret %q to application %q", id, application)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// SecretGrants returns the grants of access to a secret, ordered by
+// application name.
+func (st *State) SecretGrants(id string) ([]SecretGrant, error) {
+	docs, err := st.secretGrants(bson.D{{"secret-id", id}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]SecretGrant, len(docs))
+	for i, doc := range docs {
+		result[i] = SecretGrant{
+			Application: doc.Application,
+			RelationKey: doc.RelationKey,
+		}
+	}
+	return result, nil
+}
+
+func (st *State) secretGrants(query bson.D) ([]secretGrantDoc, error) {
+	grants, closer := st.getCollection(secretGrantsC)
+	defer closer()
+
+	var docs []secretGrantDoc
+	if err := grants.Find(query).Sort("secret-id", "application").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secret grants")
+	}
+	return docs, nil
+}
+
+// grantInForce reports whether the relation the given grant is scoped
+// to still exists. Grants lapse when their relation is removed, and are
+// not inherited by a relation added later between the same endpoints.
+func (st *State) grantInForce(grant *secretGrantDoc) (bool, error) {
+	relation, err := st.KeyRelation(grant.RelationKey)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return relation.Id() == grant.RelationId, nil
+}
+
+// CanReadSecret reports whether the named application may read the
+// content of a secret: it must own the secret, or have been granted
+// access to it through a relation that still exists.
+func (st *State) CanReadSecret(id string, application string) (bool, error) {
+	doc, err := st.secretDoc(id)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if doc.Owner == application {
+		return true, nil
+	}
+	grant, err := st.secretGrant(id, application)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return st.grantInForce(grant)
+}
+
+// GrantedSecrets returns the metadata of the secrets the named
+// application has been granted access to, ordered by id.
+func (st *State) GrantedSecrets(application string) ([]*Secret, error) {
+	grants, err := st.secretGrants(bson.D{{"application", application}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for i := range grants {
+		inForce, err := st.grantInForce(&grants[i])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inForce {
+			ids = append(ids, grants[i].SecretID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return st.secrets(bson.D{{"_id", bson.D{{"$in", ids}}}})
+}
+
+// WatchSecrets returns a NotifyWatcher that notifies of changes to the
+// metadata of any secret in the model, including new revisions.
+func (st *State) WatchSecrets() NotifyWatcher {
+	return newNotifyCollWatcher(st, secretsC, isLocalID(st))
+}
+
+// WatchSecretGrants returns a NotifyWatcher that notifies when access
+// to any secret in the model is granted or revoked.
+func (st *State) WatchSecretGrants() NotifyWatcher {
+	return newNotifyCollWatcher(st, secretGrantsC, isLocalID(st))
+}
+
+// removeApplicationSecretsOps returns the operations required to remove
+// the secrets owned by the named application, with all their revisions
+// and grants, and the grants of other secrets to the application.
+func removeApplicationSecretsOps(st *State, appName string) ([]txn.Op, error) {
+	secrets, err := st.secrets(bson.D{{"owner", appName}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	ids := []string{}
+	for _, secret := range secrets {
+		ids = append(ids, secret.ID)
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     st.docID(secret.ID),
+			Remove: true,
+		})
+		for revision := 1; revision <= secret.Revision; revision++ {
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     st.docID(secretRevisionKey(secret.ID, revision)),
+				Remove: true,
+			})
+		}
+	}
+	grants, err := st.secretGrants(bson.D{{"$or", []bson.D{
+		{{"application", appName}},
+		{{"secret-id", bson.D{{"$in", ids}}}},
+	}}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, grant := range grants {
+		ops = append(ops, txn.Op{
+			C:      secretGrantsC,
+			Id:     grant.DocID,
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// removeRelationSecretGrantsOps returns the operations required to
+// remove the grants of access to secrets scoped to the relation with
+// the given id.
+func removeRelationSecretGrantsOps(st *State, relationId int) ([]txn.Op, error) {
+	grants, err := st.secretGrants(bson.D{{"relation-id", relationId}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(grants))
+	for i, grant := range grants {
+		ops[i] = txn.Op{
+			C:      secretGrantsC,
+			Id:     grant.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+type secretsByID []*Secret
+
+func (s secretsByID) Len() int      { return len(s) }
+func (s secretsByID) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s secretsByID) Less(i, j int) bool {
+	// Ids differ only in their sequence numbers, so shorter ids
+	// sort first.
+	if len(s[i].ID) != len(s[j].ID) {
+		return len(s[i].ID) < len(s[j].ID)
+	}
+	return s[i].ID < s[j].ID
+}
@@ -0,0 +1,236 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type secretsSuite struct {
+	ConnSuite
+	clock    *jujutesting.Clock
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Now().Truncate(time.Second))
+	s.PatchValue(&state.GetClock, func() clock.Clock {
+		return s.clock
+	})
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) addSecret(c *gc.C) *state.Secret {
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner:          "mysql",
+		Label:          "root",
+		Description:    "the root password",
+		RotateInterval: time.Hour,
+		Data:           map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *secretsSuite) TestAddSecret(c *gc.C) {
+	secret := s.addSecret(c)
+	now := s.clock.Now().UTC()
+	expected := &state.Secret{
+		ID:             "secret:1",
+		Owner:          "mysql",
+		Label:          "root",
+		Description:    "the root password",
+		Revision:       1,
+		RotateInterval: time.Hour,
+		NextRotateTime: now.Add(time.Hour),
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+	c.Check(secret, jc.DeepEquals, expected)
+
+	secret, err := s.State.Secret("secret:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret, jc.DeepEquals, expected)
+
+	secrets, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, jc.DeepEquals, []*state.Secret{expected})
+
+	value, err := s.State.SecretValue("secret:1", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *secretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(state.AddSecretArgs{Owner: "mysql"})
+	c.Check(err, gc.ErrorMatches, `cannot add secret for application "mysql": empty secret content not valid`)
+
+	_, err = s.State.AddSecret(state.AddSecretArgs{
+		Owner: "foo",
+		Data:  map[string]string{"a": "b"},
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *secretsSuite) TestUpdateSecret(c *gc.C) {
+	s.addSecret(c)
+	s.clock.Advance(time.Minute)
+	secret, err := s.State.UpdateSecret("secret:1", map[string]string{"password": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.clock.Now().UTC()
+	c.Check(secret.Revision, gc.Equals, 2)
+	c.Check(secret.UpdateTime, gc.Equals, now)
+	c.Check(secret.NextRotateTime, gc.Equals, now.Add(time.Hour))
+
+	value, err := s.State.SecretValue("secret:1", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "changed"})
+	value, err = s.State.SecretValue("secret:1", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+	_, err = s.State.SecretValue("secret:1", 3)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *secretsSuite) TestSecretsToRotate(c *gc.C) {
+	s.addSecret(c)
+	secrets, err := s.State.SecretsToRotate("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, gc.HasLen, 0)
+
+	s.clock.Advance(time.Hour)
+	secrets, err = s.State.SecretsToRotate("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 1)
+	c.Check(secrets[0].ID, gc.Equals, "secret:1")
+
+	_, err = s.State.UpdateSecret("secret:1", map[string]string{"password": "rotated"})
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err = s.State.SecretsToRotate("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, gc.HasLen, 0)
+}
+
+func (s *secretsSuite) TestGrantAndRevokeSecret(c *gc.C) {
+	s.addSecret(c)
+	canRead, err := s.State.CanReadSecret("secret:1", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, jc.IsFalse)
+
+	err = s.State.GrantSecret("secret:1", s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	canRead, err = s.State.CanReadSecret("secret:1", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, jc.IsTrue)
+	grants, err := s.State.SecretGrants("secret:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(grants, jc.DeepEquals, []state.SecretGrant{{
+		Application: "wordpress",
+		RelationKey: s.relation.String(),
+	}})
+	secrets, err := s.State.GrantedSecrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 1)
+	c.Check(secrets[0].ID, gc.Equals, "secret:1")
+
+	err = s.State.RevokeSecret("secret:1", s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	canRead, err = s.State.CanReadSecret("secret:1", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, jc.IsFalse)
+}
+
+func (s *secretsSuite) TestGrantSecretOutsideRelation(c *gc.C) {
+	s.addSecret(c)
+	err := s.State.GrantSecret("secret:1", s.relation, "logging")
+	c.Check(err, gc.ErrorMatches, `cannot grant secret "secret:1" to application "logging": relation "wordpress:db mysql:server" does not involve application "logging"`)
+	err = s.State.GrantSecret("secret:1", s.relation, "mysql")
+	c.Check(err, gc.ErrorMatches, `cannot grant secret "secret:1" to application "mysql": application owns the secret`)
+}
+
+func (s *secretsSuite) TestGrantLapsesWithRelation(c *gc.C) {
+	s.addSecret(c)
+	err := s.State.GrantSecret("secret:1", s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	canRead, err := s.State.CanReadSecret("secret:1", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, jc.IsFalse)
+	secrets, err := s.State.GrantedSecrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, gc.HasLen, 0)
+	grants, err := s.State.SecretGrants("secret:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(grants, gc.HasLen, 0)
+}
+
+func (s *secretsSuite) TestGrantNotInheritedByNewRelation(c *gc.C) {
+	s.addSecret(c)
+	err := s.State.GrantSecret("secret:1", s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.String(), gc.Equals, s.relation.String())
+
+	canRead, err := s.State.CanReadSecret("secret:1", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, jc.IsFalse)
+}
+
+func (s *secretsSuite) TestRevokeSecretThroughOtherRelation(c *gc.C) {
+	s.addSecret(c)
+	err := s.State.GrantSecret("secret:1", s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("logging", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RevokeSecret("secret:1", other, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	canRead, err := s.State.CanReadSecret("secret:1", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, jc.IsTrue)
+}
+
+func (s *secretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	s.addSecret(c)
+	err := s.State.GrantSecret("secret:1", s.relation, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret("secret:1")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	grants, err := s.State.SecretGrants("secret:1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(grants, gc.HasLen, 0)
+}
@@ -365,6 +365,19 @@ func removeSettingsOp(collection, key string) txn.Op {
 	}
 }
 
+// replaceSettings replaces the settings with the specified key, removing
+// any existing settings not present in values.
+func replaceSettings(st *State, collection, key string, values map[string]interface{}) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		op, _, err := replaceSettingsOp(st, collection, key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{op}, nil
+	}
+	return st.run(buildTxn)
+}
+
 // listSettings returns all the settings with the specified key prefix.
 func listSettings(st *State, collection, keyPrefix string) (map[string]map[string]interface{}, error) {
 	settings, closer := st.getRawCollection(collection)
@@ -482,6 +495,11 @@ func (s *StateSettings) RemoveSettings(key string) error {
 	return removeSettings(s.st, s.collection, key)
 }
 
+// ReplaceSettings exposes replaceSettings on state for use outside the state package.
+func (s *StateSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	return replaceSettings(s.st, s.collection, key, settings)
+}
+
 // ListSettings exposes listSettings on state for use outside the state package.
 func (s *StateSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
 	return listSettings(s.st, s.collection, keyPrefix)
@@ -0,0 +1,271 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxSettingsHistory is the number of revisions retained in the
+// history of each settings document.
+const maxSettingsHistory = 20
+
+// SettingsRevision describes a single change made to application or
+// model settings, as recorded in the settings history.
+type SettingsRevision struct {
+	// Revision identifies the change; it increases with each change
+	// recorded for the same settings.
+	Revision int
+
+	// User is the name of the user who made the change, if known.
+	User string
+
+	// Time is the time at which the change was made.
+	Time time.Time
+
+	// Changes holds the individual changes, sorted by key.
+	Changes []ItemChange
+
+	// Settings holds the complete settings as they were after
+	// the change was made.
+	Settings map[string]interface{}
+}
+
+// settingsHistoryDoc is the mongo document representation of a
+// SettingsRevision.
+type settingsHistoryDoc struct {
+	DocID     string              `bson:"_id"`
+	ModelUUID string              `bson:"model-uuid"`
+	GlobalKey string              `bson:"globalkey"`
+	Revision  int                 `bson:"revision"`
+	User      string              `bson:"user"`
+	Time      int64               `bson:"time"`
+	Changes   []settingsChangeDoc `bson:"changes"`
+	Settings  settingsMap         `bson:"settings"`
+}
+
+// settingsChangeDoc is the mongo document representation of an
+// ItemChange.
+type settingsChangeDoc struct {
+	Type     int         `bson:"type"`
+	Key      string      `bson:"key"`
+	OldValue interface{} `bson:"old-value,omitempty"`
+	NewValue interface{} `bson:"new-value,omitempty"`
+}
+
+func (doc *settingsHistoryDoc) revision() SettingsRevision {
+	changes := make([]ItemChange, len(doc.Changes))
+	for i, change := range doc.Changes {
+		changes[i] = ItemChange{
+			Type:     change.Type,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+	}
+	return SettingsRevision{
+		Revision: doc.Revision,
+		User:     doc.User,
+		Time:     time.Unix(0, doc.Time).UTC(),
+		Changes:  changes,
+		Settings: copyMap(doc.Settings, unescapeReplacer.Replace),
+	}
+}
+
+// writeSettingsWithHistory writes the changes made to the given
+// settings back onto their node, recording them as made by user in the
+// history of the settings of the entity with the given global key. The
+// change and its record are written in the same transaction, so a
+// change is never made without being recorded.
+func writeSettingsWithHistory(node *Settings, globalKey, user string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := readSettingsDoc(node.st, node.collection, node.key); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		changes, ops := node.settingsUpdateOps()
+		if len(changes) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		historyOps, err := settingsHistoryOps(node.st, globalKey, user, changes, node.Map())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, historyOps...), nil
+	}
+	if err := node.st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot write settings")
+	}
+	node.disk = copyMap(node.core, nil)
+	return nil
+}
+
+// settingsHistoryOps returns the operations that record the given
+// changes, made by user to the settings of the entity with the given
+// global key, and discard the oldest revisions beyond
+// maxSettingsHistory. The new revision follows the latest recorded;
+// the operations assert that it has not been recorded already, so
+// they must be built afresh for each attempt at the transaction.
+func settingsHistoryOps(st *State, globalKey, user string, changes []ItemChange, settings map[string]interface{}) ([]txn.Op, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	history, closer := st.getCollection(settingsHistoryC)
+	defer closer()
+
+	var latest settingsHistoryDoc
+	err := history.Find(bson.D{{"globalkey", globalKey}}).Sort("-revision").One(&latest)
+	if err != nil && err != mgo.ErrNotFound {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	revision := latest.Revision + 1
+	changeDocs := make([]settingsChangeDoc, len(changes))
+	for i, change := range changes {
+		changeDocs[i] = settingsChangeDoc{
+			Type:     change.Type,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+	}
+	docID := st.docID(fmt.Sprintf("%s#%d", globalKey, revision))
+	ops := []txn.Op{{
+		C:      settingsHistoryC,
+		Id:     docID,
+		Assert: txn.DocMissing,
+		Insert: &settingsHistoryDoc{
+			DocID:     docID,
+			GlobalKey: globalKey,
+			Revision:  revision,
+			User:      user,
+			Time:      GetClock().Now().UnixNano(),
+			Changes:   changeDocs,
+			Settings:  settingsMap(copyMap(settings, escapeReplacer.Replace)),
+		},
+	}}
+
+	var expired []struct {
+		DocID string `bson:"_id"`
+	}
+	err = history.Find(bson.D{
+		{"globalkey", globalKey},
+		{"revision", bson.D{{"$lte", revision - maxSettingsHistory}}},
+	}).Select(bson.D{{"_id", 1}}).All(&expired)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	for _, doc := range expired {
+		ops = append(ops, txn.Op{
+			C:      settingsHistoryC,
+			Id:     doc.DocID,
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// encryptSettingsHistoryOps returns the operations that encrypt the
+// values of the given secret settings wherever the history of the
+// settings of the entity with the given global key records them in
+// plain text.
+func encryptSettingsHistoryOps(st *State, globalKey string, secrets set.Strings) ([]txn.Op, error) {
+	history, closer := st.getCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []settingsHistoryDoc
+	if err := history.Find(bson.D{{"globalkey", globalKey}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		encrypted := false
+		encrypt := func(name string, value interface{}) (interface{}, error) {
+			plain, ok := value.(string)
+			if !ok || !secrets.Contains(name) || isEncryptedConfigValue(plain) {
+				return value, nil
+			}
+			encrypted = true
+			return st.encryptConfigValue(plain)
+		}
+		settings := make(settingsMap, len(doc.Settings))
+		for key, value := range doc.Settings {
+			value, err := encrypt(key, value)
+			if err != nil {
+				return nil, errors.Annotatef(err, "settings revision %d", doc.Revision)
+			}
+			settings[escapeReplacer.Replace(key)] = value
+		}
+		changes := make([]settingsChangeDoc, len(doc.Changes))
+		for i, change := range doc.Changes {
+			var err error
+			if change.OldValue, err = encrypt(change.Key, change.OldValue); err != nil {
+				return nil, errors.Annotatef(err, "settings revision %d", doc.Revision)
+			}
+			if change.NewValue, err = encrypt(change.Key, change.NewValue); err != nil {
+				return nil, errors.Annotatef(err, "settings revision %d", doc.Revision)
+			}
+			changes[i] = change
+		}
+		if !encrypted {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      settingsHistoryC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"settings", settings},
+				{"changes", changes},
+			}}},
+		})
+	}
+	return ops, nil
+}
+
+// settingsHistory returns the recorded history of the settings of the
+// entity with the given global key, most recent revision first.
+func settingsHistory(st *State, globalKey string) ([]SettingsRevision, error) {
+	history, closer := st.getCollection(settingsHistoryC)
+	defer closer()
+
+	var docs []settingsHistoryDoc
+	err := history.Find(bson.D{{"globalkey", globalKey}}).Sort("-revision").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	revisions := make([]SettingsRevision, len(docs))
+	for i, doc := range docs {
+		revisions[i] = doc.revision()
+	}
+	return revisions, nil
+}
+
+// settingsHistoryRevision returns the given revision from the history
+// of the settings of the entity with the given global key.
+func settingsHistoryRevision(st *State, globalKey string, revision int) (*SettingsRevision, error) {
+	history, closer := st.getCollection(settingsHistoryC)
+	defer closer()
+
+	var doc settingsHistoryDoc
+	err := history.Find(bson.D{
+		{"globalkey", globalKey},
+		{"revision", revision},
+	}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("settings revision %d", revision)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read settings history")
+	}
+	result := doc.revision()
+	return &result, nil
+}
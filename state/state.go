@@ -84,6 +84,10 @@ type State struct {
 	policy             Policy
 	newPolicy          NewPolicyFunc
 
+	// secretConfigKey is the key with which secret config
+	// values are encrypted, if it has been set.
+	secretConfigKey []byte
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -306,6 +310,7 @@ func (st *State) ForModel(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretConfigKey = st.secretConfigKey
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -947,6 +952,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	} else if exists {
 		return nil, errors.Errorf("application already exists")
 	}
+	if exists, err := isNotDead(st, remoteApplicationsC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote application with same name already exists")
+	}
 	if err := checkModelActive(st); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1092,13 +1102,25 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	ops := []txn.Op{
 		assertModelActiveOp(st.ModelUUID()),
 		endpointBindingsOp,
+		{
+			C:      remoteApplicationsC,
+			Id:     applicationID,
+			Assert: txn.DocMissing,
+		},
+	}
+	if err := validateConfigValues(args.Settings); err != nil {
+		return nil, errors.Trace(err)
+	}
+	settings, err := st.encryptConfigSettings(secretConfigKeys(args.Charm.Config(), nil), args.Settings)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	addOps, err := addApplicationOps(st, addApplicationOpsArgs{
 		applicationDoc: svcDoc,
 		statusDoc:      statusDoc,
 		constraints:    args.Constraints,
 		storage:        args.Storage,
-		settings:       map[string]interface{}(args.Settings),
+		settings:       settings,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1492,7 +1514,7 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	} else {
 		return nil, errors.Errorf("invalid endpoint %q", name)
 	}
-	svc, err := st.Application(svcName)
+	svc, err := st.endpointProvider(svcName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1518,6 +1540,30 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	return final, nil
 }
 
+// endpointProvider is implemented by local and remote applications.
+type endpointProvider interface {
+	Endpoint(relationName string) (Endpoint, error)
+	Endpoints() ([]Endpoint, error)
+}
+
+// endpointProvider returns the local or, failing that, the remote
+// application with the given name.
+func (st *State) endpointProvider(name string) (endpointProvider, error) {
+	app, err := st.Application(name)
+	if err == nil {
+		return app, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	remoteApp, remoteErr := st.RemoteApplication(name)
+	if errors.IsNotFound(remoteErr) {
+		return nil, err
+	} else if remoteErr != nil {
+		return nil, errors.Trace(remoteErr)
+	}
+	return remoteApp, nil
+}
+
 // AddRelation creates a new relation with the given endpoints.
 func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	key := relationKey(eps)
@@ -1557,9 +1603,31 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		}
 		// Collect per-service operations, checking sanity as we go.
 		var ops []txn.Op
-		var subordinateCount int
+		var subordinateCount, remoteCount int
 		series := map[string]bool{}
 		for _, ep := range eps {
+			remoteApp, err := st.RemoteApplication(ep.ApplicationName)
+			if err == nil {
+				if remoteApp.doc.Life != Alive {
+					return nil, errors.Errorf("remote application %q is not alive", ep.ApplicationName)
+				}
+				if ep.Scope == charm.ScopeContainer {
+					return nil, errors.Errorf("remote application %q cannot take part in a container scoped relation", ep.ApplicationName)
+				}
+				if _, err := remoteApp.Endpoint(ep.Name); err != nil {
+					return nil, errors.Errorf("%q does not implement %q", ep.ApplicationName, ep)
+				}
+				remoteCount++
+				ops = append(ops, txn.Op{
+					C:      remoteApplicationsC,
+					Id:     st.docID(ep.ApplicationName),
+					Assert: isAliveDoc,
+					Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
+				})
+				continue
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
 			svc, err := st.Application(ep.ApplicationName)
 			if errors.IsNotFound(err) {
 				return nil, errors.Errorf("application %q does not exist", ep.ApplicationName)
@@ -1586,6 +1654,9 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 				Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
 			})
 		}
+		if remoteCount == len(eps) {
+			return nil, errors.Errorf("cannot relate remote applications to each other")
+		}
 		if matchSeries && len(series) != 1 {
 			return nil, errors.Errorf("principal and subordinate applications' series must match")
 		}
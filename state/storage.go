@@ -34,8 +34,9 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the application or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean indicating whether or not there is an owner.
+	// A storage instance that has been detached from its unit has no owner.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -114,14 +115,18 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
 		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// a means of setting the owner tag to anything
+		// other than a valid tag or the empty string.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -701,6 +706,16 @@ func removeStorageAttachmentOps(
 			return ops, nil
 		}
 	}
+	if si.doc.Owner == "" && si.doc.Life == Alive {
+		// The storage instance has been detached from the unit,
+		// so detach its volume or filesystem from the unit's
+		// machine too, leaving it free to be attached elsewhere.
+		detachOps, err := detachStorageMachineOps(st, si, names.NewUnitTag(s.doc.Unit))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	decrefOp := txn.Op{
 		C:      storageInstancesC,
 		Id:     si.doc.Id,
@@ -728,6 +743,461 @@ func removeStorageAttachmentOps(
 	return ops, nil
 }
 
+// DetachStorage ensures that the specified unit's attachment to the
+// storage instance will be removed at some point, while retaining the
+// storage instance and its volume or filesystem so that it may later be
+// attached to another unit with AttachStorage. Once the storage attachment
+// has been removed, the volume or filesystem will be detached from the
+// unit's machine.
+//
+// Only storage owned by the unit, and backed by a volume or filesystem
+// that is not bound to a machine, may be detached.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner != unit.String() {
+			return nil, errors.NotSupportedf("detaching shared storage")
+		}
+		if _, err := st.detachableStorageEntity(si, ""); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.validateStorageDetachCount(si, unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := destroyStorageAttachmentOps(storage, unit)
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"life", Alive}, {"owner", si.doc.Owner}},
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		})
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateStorageDetachCount checks that detaching the storage instance
+// from the unit will not leave the unit with fewer instances of the
+// storage than its charm requires.
+func (st *State) validateStorageDetachCount(si *storageInstance, unit names.UnitTag) error {
+	charmStorage, err := st.unitCharmStorage(unit, si.doc.StorageName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if int(count)-1 < charmStorage.CountMin {
+		return errors.Errorf(
+			"charm requires at least %d %q storage instance(s)",
+			charmStorage.CountMin, si.doc.StorageName,
+		)
+	}
+	return nil
+}
+
+// unitCharmStorage returns the metadata for the named storage, as
+// declared by the charm of the unit's application.
+func (st *State) unitCharmStorage(unit names.UnitTag, name string) (charm.Storage, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return charm.Storage{}, errors.Trace(err)
+	}
+	app, err := u.Application()
+	if err != nil {
+		return charm.Storage{}, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return charm.Storage{}, errors.Trace(err)
+	}
+	charmStorage, ok := ch.Meta().Storage[name]
+	if !ok {
+		return charm.Storage{}, errors.NotFoundf("charm storage %q", name)
+	}
+	return charmStorage, nil
+}
+
+// detachableStorageEntity returns the tag of the volume or filesystem
+// backing the storage instance, if it may be detached from one machine
+// and attached to another. Machine-scoped volumes and filesystems, and
+// those bound to a machine, cannot be moved between machines; if
+// machineId is non-empty, machine-scoped entities may still be attached
+// to the machine they are scoped to.
+func (st *State) detachableStorageEntity(si *storageInstance, machineId string) (names.Tag, error) {
+	var tag names.Tag
+	var binding names.Tag
+	var scope names.MachineTag
+	var machineScoped bool
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tag, binding = v.VolumeTag(), v.LifeBinding()
+		scope, machineScoped = names.VolumeMachine(v.VolumeTag())
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			// The storage may be backed by a volume on which no
+			// filesystem has been created yet, such as a volume
+			// imported into the model.
+			v, verr := st.storageInstanceVolume(si.StorageTag())
+			if verr != nil {
+				return nil, errors.Trace(err)
+			}
+			tag, binding = v.VolumeTag(), v.LifeBinding()
+			scope, machineScoped = names.VolumeMachine(v.VolumeTag())
+			break
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tag, binding = f.FilesystemTag(), f.LifeBinding()
+		scope, machineScoped = names.FilesystemMachine(f.FilesystemTag())
+	default:
+		return nil, errors.NotSupportedf("storage kind %q", si.doc.Kind)
+	}
+	if machineScoped && (machineId == "" || scope.Id() != machineId) {
+		return nil, errors.NotSupportedf("moving machine-scoped %s", names.ReadableString(tag))
+	}
+	if binding != nil && binding.Kind() == names.MachineTagKind {
+		return nil, errors.NotSupportedf(
+			"moving %s bound to %s",
+			names.ReadableString(tag), names.ReadableString(binding),
+		)
+	}
+	return tag, nil
+}
+
+// detachStorageMachineOps returns the operations to detach the volume or
+// filesystem backing the storage instance from the unit's machine.
+func detachStorageMachineOps(st *State, si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineTag := names.NewMachineTag(machineId)
+	tag, err := st.detachableStorageEntity(si, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var attachment Lifer
+	var detachOps []txn.Op
+	switch tag := tag.(type) {
+	case names.VolumeTag:
+		attachment, err = st.VolumeAttachment(machineTag, tag)
+		detachOps = detachVolumeOps(machineTag, tag)
+	case names.FilesystemTag:
+		attachment, err = st.FilesystemAttachment(machineTag, tag)
+		detachOps = detachFilesystemOps(machineTag, tag)
+	}
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if attachment.Life() != Alive {
+		return nil, nil
+	}
+	return detachOps, nil
+}
+
+// AttachStorage attaches the detached storage instance to the specified
+// unit, and its volume or filesystem to the unit's machine. The unit's
+// charm must declare storage with the same name and kind as the storage
+// instance, and must allow an additional instance of it. The storage
+// instance's volume or filesystem must already have been detached from
+// any machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner != "" || si.doc.AttachmentCount > 0 {
+			return nil, errors.New("storage is not detached")
+		}
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		charmStorage, err := st.unitCharmStorage(unit, si.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		kind := StorageKindBlock
+		if charmStorage.Type == charm.StorageFilesystem {
+			kind = StorageKindFilesystem
+		}
+		if kind != si.doc.Kind {
+			return nil, errors.Errorf(
+				"charm storage %q is of kind %s, storage is of kind %s",
+				si.doc.StorageName, kind, si.doc.Kind,
+			)
+		}
+		count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if charmStorage.CountMax >= 0 && int(count)+1 > charmStorage.CountMax {
+			return nil, errors.Errorf(
+				"charm allows at most %d %q storage instance(s)",
+				charmStorage.CountMax, si.doc.StorageName,
+			)
+		}
+		m, err := u.machine()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineOps, err := st.attachStorageMachineOps(si, m, charmStorage, u.Series())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"life", Alive}, {"owner", ""}, {"attachmentcount", 0}},
+			Update: bson.D{
+				{"$set", bson.D{{"owner", unit.String()}}},
+				{"$inc", bson.D{{"attachmentcount", 1}}},
+			},
+		}, {
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		},
+			createStorageAttachmentOp(storage, unit),
+		}
+		return append(ops, machineOps...), nil
+	}
+	return st.run(buildTxn)
+}
+
+// attachStorageMachineOps returns the operations to attach the volume or
+// filesystem backing the detached storage instance to the given machine.
+func (st *State) attachStorageMachineOps(
+	si *storageInstance, m *Machine, charmStorage charm.Storage, series string,
+) ([]txn.Op, error) {
+	tag, err := st.detachableStorageEntity(si, m.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var volumes []volumeAttachmentTemplate
+	var filesystems []filesystemAttachmentTemplate
+	var entityOp txn.Op
+	switch tag := tag.(type) {
+	case names.VolumeTag:
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.doc.AttachmentCount > 0 {
+			return nil, errors.Errorf("%s is still attached to a machine", names.ReadableString(tag))
+		}
+		if si.doc.Kind == StorageKindFilesystem {
+			return st.attachFilesystemOnVolumeOps(si, v, m, charmStorage, series)
+		}
+		volumes = append(volumes, volumeAttachmentTemplate{
+			tag, VolumeAttachmentParams{charmStorage.ReadOnly},
+		})
+		entityOp = txn.Op{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: bson.D{{"life", Alive}, {"attachmentcount", 0}},
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		}
+	case names.FilesystemTag:
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.doc.AttachmentCount > 0 {
+			return nil, errors.Errorf("%s is still attached to a machine", names.ReadableString(tag))
+		}
+		attachmentParams, err := storageFilesystemAttachmentParams(charmStorage, si, series)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		filesystems = append(filesystems, filesystemAttachmentTemplate{
+			tag, si.StorageTag(), attachmentParams,
+		})
+		entityOp = txn.Op{
+			C:      filesystemsC,
+			Id:     f.doc.FilesystemId,
+			Assert: bson.D{{"life", Alive}, {"attachmentcount", 0}},
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		}
+	}
+	ops := []txn.Op{entityOp}
+	ops = append(ops, createMachineVolumeAttachmentsOps(m.Id(), volumes)...)
+	ops = append(ops, createMachineFilesystemAttachmentsOps(m.Id(), filesystems)...)
+	machineOps, err := addMachineStorageAttachmentsOps(m, volumes, filesystems)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, machineOps...), nil
+}
+
+// attachFilesystemOnVolumeOps returns the operations to create a
+// filesystem on the existing volume backing the detached storage
+// instance, such as a volume imported into the model, and to attach
+// both to the given machine.
+func (st *State) attachFilesystemOnVolumeOps(
+	si *storageInstance, v *volume, m *Machine, charmStorage charm.Storage, series string,
+) ([]txn.Op, error) {
+	params, err := filesystemOnVolumeParams(v, si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachmentParams, err := storageFilesystemAttachmentParams(charmStorage, si, series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops, volumes, filesystems, err := st.machineStorageOps(&m.doc, &machineStorageParams{
+		filesystems: []MachineFilesystemParams{{params, attachmentParams}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineOps, err := addMachineStorageAttachmentsOps(m, volumes, filesystems)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, machineOps...), nil
+}
+
+// filesystemOnVolumeParams returns the parameters for creating a
+// filesystem on the existing volume backing a storage instance.
+func filesystemOnVolumeParams(v Volume, storageTag names.StorageTag) (FilesystemParams, error) {
+	info, err := v.Info()
+	if err != nil {
+		return FilesystemParams{}, errors.Trace(err)
+	}
+	return FilesystemParams{
+		storage: storageTag,
+		binding: storageTag,
+		volume:  v.VolumeTag(),
+		Pool:    info.Pool,
+		Size:    info.Size,
+	}, nil
+}
+
+// storageFilesystemAttachmentParams returns the parameters for
+// attaching the filesystem backing the storage instance to a machine,
+// as required by the charm storage metadata.
+func storageFilesystemAttachmentParams(
+	charmStorage charm.Storage, si *storageInstance, series string,
+) (FilesystemAttachmentParams, error) {
+	location, err := filesystemMountPoint(charmStorage, si.StorageTag(), series)
+	if err != nil {
+		return FilesystemAttachmentParams{}, errors.Annotatef(
+			err, "getting filesystem mount point for storage %s",
+			si.doc.StorageName,
+		)
+	}
+	return FilesystemAttachmentParams{
+		charmStorage.Location == "", // auto-generated location
+		location,
+		charmStorage.ReadOnly,
+	}, nil
+}
+
+// ReleaseStorageInstance ensures that the storage instance and all its
+// attachments will be removed at some point, as DestroyStorageInstance
+// does, except that the volume or filesystem backing the storage is
+// unbound from it and retained, rather than being destroyed along with
+// the storage instance.
+func (st *State) ReleaseStorageInstance(tag names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot release storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The volume or filesystem is unassigned from the storage
+		// instance once it has been released, so check the life of
+		// the storage instance first: releasing it again is a no-op.
+		ops, err := st.destroyStorageInstanceOps(s)
+		if err == errAlreadyDying {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entityTag, err := st.detachableStorageEntity(s, "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Unbind the volume or filesystem from the storage instance
+		// and unassign it, so that it is not destroyed when the
+		// storage instance is removed. The release operation must
+		// come first, as removing the storage instance checks the
+		// binding.
+		collection := volumesC
+		if entityTag.Kind() == names.FilesystemTagKind {
+			collection = filesystemsC
+		}
+		releaseOp := txn.Op{
+			C:  collection,
+			Id: entityTag.Id(),
+			Assert: bson.D{
+				{"binding", tag.String()},
+				{"storageid", tag.Id()},
+			},
+			Update: bson.D{
+				{"$unset", bson.D{{"binding", nil}}},
+				{"$set", bson.D{{"storageid", ""}}},
+			},
+		}
+		if s.doc.AttachmentCount == 0 {
+			// The storage instance is removed immediately. The
+			// operations returned by destroyStorageInstanceOps
+			// would destroy the volume or filesystem bound to it.
+			return []txn.Op{releaseOp, {
+				C:      storageInstancesC,
+				Id:     s.doc.Id,
+				Assert: append(bson.D{{"attachmentcount", 0}}, isAliveDoc...),
+				Remove: true,
+			}}, nil
+		}
+		return append([]txn.Op{releaseOp}, ops...), nil
+	}
+	return st.run(buildTxn)
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
@@ -1223,3 +1693,24 @@ func (st *State) countEntityStorageInstancesForName(
 	}
 	return uint64(result), err
 }
+
+// StoragePoolInUse reports whether any volumes or filesystems in the
+// model have been, or are to be, created from the named storage pool.
+func (st *State) StoragePoolInUse(name string) (bool, error) {
+	query := bson.D{{"$or", []bson.D{
+		{{"params.pool", name}},
+		{{"info.pool", name}},
+	}}}
+	for _, collection := range []string{volumesC, filesystemsC} {
+		coll, closer := st.getCollection(collection)
+		n, err := coll.Find(query).Count()
+		closer()
+		if err != nil {
+			return false, errors.Annotatef(err, "querying %s", collection)
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -546,7 +546,9 @@ func (s *StorageStateSuite) TestAllStorageInstances(c *gc.C) {
 	for _, one := range all {
 		c.Assert(one.Kind(), gc.DeepEquals, state.StorageKindBlock)
 		c.Assert(nameSet.Contains(one.StorageName()), jc.IsTrue)
-		c.Assert(ownerSet.Contains(one.Owner().String()), jc.IsTrue)
+		owner, ok := one.Owner()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ownerSet.Contains(owner.String()), jc.IsTrue)
 	}
 }
 
@@ -685,6 +687,162 @@ func (s *StorageStateSuite) TestConcurrentRemoveStorageAttachment(c *gc.C) {
 	c.Assert(exists, jc.IsFalse)
 }
 
+func (s *StorageStateSuite) setupDetachableStorage(c *gc.C) (*state.Unit, *state.Unit, names.StorageTag) {
+	ch := s.createStorageCharm(c, "storage-block", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageBlock,
+		CountMin: 0,
+		CountMax: -1,
+	})
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("persistent-block", 1024, 1),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	u0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	u1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range []*state.Unit{u0, u1} {
+		err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return u0, u1, names.NewStorageTag("data/0")
+}
+
+func (s *StorageStateSuite) assignedMachineTag(c *gc.C, u *state.Unit) names.MachineTag {
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return names.NewMachineTag(machineId)
+}
+
+func (s *StorageStateSuite) TestDetachStorage(c *gc.C) {
+	u, _, storageTag := s.setupDetachableStorage(c)
+	volume := s.storageInstanceVolume(c, storageTag)
+	machineTag := s.assignedMachineTag(c, u)
+
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+
+	sa, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Life(), gc.Equals, state.Dying)
+
+	// Removing the storage attachment leaves the storage instance in
+	// place, and detaches the volume from the unit's machine.
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsTrue)
+	attachment := s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+}
+
+func (s *StorageStateSuite) TestDetachStorageCountMin(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "persistent-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit storage-block/0: charm requires at least 1 "data" storage instance\(s\)`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageMachineScoped(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, "cannot detach storage data/0 from unit storage-block/0: moving machine-scoped volume 0/0 not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageStateSuite) TestAttachStorage(c *gc.C) {
+	u0, u1, storageTag := s.setupDetachableStorage(c)
+	volume := s.storageInstanceVolume(c, storageTag)
+	machine0 := s.assignedMachineTag(c, u0)
+	machine1 := s.assignedMachineTag(c, u1)
+
+	err := s.State.DetachStorage(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume must be detached from the original machine
+	// before it can be attached to another.
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, gc.ErrorMatches, "cannot attach storage data/0 to unit storage-block/1: volume 0 is still attached to a machine")
+
+	err = s.State.RemoveVolumeAttachment(machine0, volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u1.UnitTag())
+
+	_, err = s.State.StorageAttachment(storageTag, u1.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attachment := s.volumeAttachment(c, machine1, volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageStateSuite) TestAttachStorageNotDetached(c *gc.C) {
+	_, u1, storageTag := s.setupDetachableStorage(c)
+	err := s.State.AttachStorage(storageTag, u1.UnitTag())
+	c.Assert(err, gc.ErrorMatches, "cannot attach storage data/0 to unit storage-block/1: storage is not detached")
+}
+
+func (s *StorageStateSuite) TestReleaseStorageInstance(c *gc.C) {
+	u, _, storageTag := s.setupDetachableStorage(c)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err := s.State.ReleaseStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Dying)
+
+	// The storage is still attached, but the volume is released
+	// from it straight away.
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.LifeBinding(), gc.IsNil)
+	_, err = volume.StorageInstance()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+
+	// Releasing it again is a no-op.
+	err = s.State.ReleaseStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DestroyStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsFalse)
+
+	// The volume outlives the storage instance.
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Life(), gc.Equals, state.Alive)
+	c.Assert(volume.LifeBinding(), gc.IsNil)
+}
+
+func (s *StorageStateSuite) TestReleaseStorageInstanceMachineScoped(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ReleaseStorageInstance(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot release storage "data/0": moving machine-scoped volume 0/0 not supported`)
+}
+
 func (s *StorageStateSuite) TestRemoveAliveStorageAttachmentError(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 
@@ -942,3 +1100,20 @@ func (c byStorageConfigName) Swap(a, b int) {
 // - StorageInstance without attachments is removed by Destroy
 // - concurrent add-unit and StorageAttachment removal does not
 //   remove storage instance.
+
+func (s *StorageStateSuite) TestStoragePoolInUse(c *gc.C) {
+	inUse, err := s.State.StoragePoolInUse("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsFalse)
+
+	_, u, _ := s.setupSingleStorage(c, "block", "persistent-block")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	inUse, err = s.State.StoragePoolInUse("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsTrue)
+	inUse, err = s.State.StoragePoolInUse("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsFalse)
+}
@@ -66,6 +66,8 @@ func Initialize(c *gc.C, owner names.UserTag, cfg *config.Config, controllerInhe
 		NewPolicy:     newPolicy,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetSecretConfigKey(testing.SecretConfigKey)
+	c.Assert(err, jc.ErrorIsNil)
 	return st
 }
 
@@ -82,6 +82,7 @@ type unitDoc struct {
 	Application            string
 	Series                 string
 	CharmURL               *charm.URL
+	TargetCharmURL         *charm.URL `bson:",omitempty"`
 	Principal              string
 	Subordinates           []string
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
@@ -91,6 +92,9 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+	// UpgradeSeriesStatus records the progress of the unit through
+	// an upgrade of its machine's series.
+	UpgradeSeriesStatus UpgradeSeriesStatus `bson:"upgrade-series-status,omitempty"`
 }
 
 // Unit represents the state of a service unit.
@@ -128,8 +132,12 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	if err != nil {
 		return nil, err
 	}
+	values, err := u.st.decryptConfigSettings(settings.Map())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	result := chrm.Config().DefaultSettings()
-	for name, value := range settings.Map() {
+	for name, value := range values {
 		result[name] = value
 	}
 	return result, nil
@@ -889,10 +897,15 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 // opening the requested range conflicts with another already opened range on
 // the same subnet and and the unit's assigned machine.
 func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.openPorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+func (u *Unit) openPorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -903,6 +916,9 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	if err := u.checkSubnetAliveWhenSet(subnetID); err != nil {
 		return errors.Trace(err)
 	}
+	if err := u.checkEndpointWhenSet(endpoint); err != nil {
+		return errors.Trace(err)
+	}
 
 	machinePorts, err := getOrCreatePorts(u.st, machineID, subnetID)
 	if err != nil {
@@ -928,14 +944,30 @@ func (u *Unit) checkSubnetAliveWhenSet(subnetID string) error {
 	return nil
 }
 
+func (u *Unit) checkEndpointWhenSet(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.checkEndpointNames(endpoint)
+}
+
 // ClosePortsOnSubnet closes the given port range and protocol for the unit on
 // the given subnet, which can be empty. When non-empty, subnetID must refer to
 // an existing, alive subnet, otherwise an error is returned.
 func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.closePorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+func (u *Unit) closePorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -973,6 +1005,22 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) (err error) {
 	return u.ClosePortsOnSubnet("", protocol, fromPort, toPort)
 }
 
+// OpenEndpointPorts opens the given port range and protocol for the
+// unit's named endpoint. The ports are only opened in the firewall if
+// the application is exposed for all endpoints or for that endpoint.
+// An empty endpoint opens the ports for all endpoints, as with
+// OpenPorts.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return u.openPorts("", endpoint, protocol, fromPort, toPort)
+}
+
+// CloseEndpointPorts closes the given port range and protocol for the
+// unit's named endpoint. An empty endpoint closes the ports for all
+// endpoints they were opened for, as with ClosePorts.
+func (u *Unit) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return u.closePorts("", endpoint, protocol, fromPort, toPort)
+}
+
 // OpenPortOnSubnet opens the given port and protocol for the unit on the given
 // subnet, which can be empty. When non-empty, subnetID must refer to an
 // existing, alive subnet, otherwise an error is returned.
@@ -1026,12 +1074,19 @@ func (u *Unit) OpenedPortsOnSubnet(subnetID string) ([]network.PortRange, error)
 		return nil, errors.Annotatef(err, "failed getting ports for unit %q, subnet %q", u, subnetID)
 	}
 	ports := machinePorts.PortsForUnit(u.Name())
+	seen := make(map[network.PortRange]bool)
 	for _, port := range ports {
-		result = append(result, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: port.Protocol,
 			FromPort: port.FromPort,
 			ToPort:   port.ToPort,
-		})
+		}
+		// The same range may be opened for several endpoints.
+		if seen[portRange] {
+			continue
+		}
+		seen[portRange] = true
+		result = append(result, portRange)
 	}
 	network.SortPortRanges(result)
 	return result, nil
@@ -1753,6 +1808,7 @@ func machineStorageParamsForStorageInstance(
 ) (*machineStorageParams, error) {
 
 	charmStorage := charmMeta.Storage[storage.StorageName()]
+	owner, _ := storage.Owner()
 
 	var volumes []MachineVolumeParams
 	var filesystems []MachineFilesystemParams
@@ -1764,9 +1820,19 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a volume.
+		if unit == owner {
+			// The storage instance is owned by the unit. If it was
+			// attached to the unit after being imported or detached
+			// from another unit, then it already has a volume, for
+			// which we will just add an attachment.
+			volume, err := st.storageInstanceVolume(storage.StorageTag())
+			if err == nil {
+				volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
+			}
+			// Otherwise, we'll need to create a volume.
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
 				storage: storage.StorageTag(),
@@ -1800,15 +1866,37 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a filesystem.
-			cons := allCons[storage.StorageName()]
-			filesystemParams := FilesystemParams{
-				storage: storage.StorageTag(),
-				binding: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+		if unit == owner {
+			// The storage instance is owned by the unit. If it was
+			// attached to the unit after being imported or detached
+			// from another unit, then it may already have a filesystem,
+			// for which we will just add an attachment, or a volume on
+			// which we will create a filesystem.
+			filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+			if err == nil {
+				filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
+			}
+			var filesystemParams FilesystemParams
+			volume, err := st.storageInstanceVolume(storage.StorageTag())
+			if err == nil {
+				filesystemParams, err = filesystemOnVolumeParams(volume, storage.StorageTag())
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			} else if errors.IsNotFound(err) {
+				// Otherwise, we'll need to create a filesystem.
+				cons := allCons[storage.StorageName()]
+				filesystemParams = FilesystemParams{
+					storage: storage.StorageTag(),
+					binding: storage.StorageTag(),
+					Pool:    cons.Pool,
+					Size:    cons.Size,
+				}
+			} else {
+				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
 			}
 			filesystems = append(filesystems, MachineFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
@@ -1126,6 +1126,43 @@ func (s *UnitSuite) testOpenedPorts(c *gc.C, subnetID, expectedErrorCauseMatches
 	}
 }
 
+func (s *UnitSuite) TestOpenCloseEndpointPorts(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenEndpointPorts("admin-api", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenEndpointPorts("missing", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/0", endpoint "missing"\) for unit "wordpress/0" on subnet "": endpoint "missing" not found`)
+
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"admin-api", "url"},
+	})
+
+	err = s.unit.CloseEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ports.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"admin-api"},
+	})
+
+	err = s.unit.ClosePorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err = s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, gc.HasLen, 0)
+}
+
 func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,339 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/series"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// SetSeriesConfig holds the parameters for changing the series of an
+// application.
+type SetSeriesConfig struct {
+	// Series is the series to deploy new units of the application to.
+	Series string
+	// Charm, if set, replaces the charm of the application in the same
+	// transaction. It must be set when the current charm's URL names a
+	// series other than Series, and must then be the same charm for
+	// the new series.
+	Charm *Charm
+	// Force allows a series that the charm does not declare support
+	// for.
+	Force bool
+}
+
+// SetSeries changes the series of the application. Units added from
+// now on are deployed to machines running the new series; existing
+// units keep the series of their machines until those machines are
+// upgraded with PrepareUpgradeSeries and CompleteUpgradeSeries.
+func (s *Application) SetSeries(cfg SetSeriesConfig) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set series of application %q", s)
+	if _, err := series.GetOSFromSeries(cfg.Series); err != nil {
+		return errors.Trace(err)
+	}
+	ch := cfg.Charm
+	if ch == nil {
+		if ch, _, err = s.Charm(); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		if ch.URL().Name != s.doc.CharmURL.Name {
+			return errors.Errorf("charm %q is not a version of %q", ch.URL(), s.doc.CharmURL.WithRevision(-1))
+		}
+		if ch.Meta().Subordinate != s.doc.Subordinate {
+			return errors.Errorf("cannot change a service's subordinacy")
+		}
+	}
+	if err := checkCharmSeries(ch, cfg.Series, cfg.Force); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		sameCharm := *ch.URL() == *s.doc.CharmURL
+		if sameCharm && s.doc.Series == cfg.Series {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if _, err := s.CharmRollout(); err == nil {
+			return nil, errors.New("a rolling charm upgrade is in progress")
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:  applicationsC,
+			Id: s.doc.DocID,
+			Assert: append(isAliveDoc,
+				bson.DocElem{"charmurl", s.doc.CharmURL},
+				bson.DocElem{"series", s.doc.Series},
+			),
+			Update: bson.D{{"$set", bson.D{{"series", cfg.Series}}}},
+		}}
+		if !sameCharm {
+			chng, err := s.changeCharmOps(ch, s.doc.Channel, false, nil)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, chng...)
+		}
+		return ops, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	// Changing the charm increments the charm modified version, so
+	// reread the document rather than patching it up.
+	return s.Refresh()
+}
+
+// checkCharmSeries returns an error if the charm cannot be deployed to
+// machines running the given series.
+func checkCharmSeries(ch *Charm, toSeries string, force bool) error {
+	// Old style charms written for only one series name it in their
+	// URL, and can never be used for another.
+	if urlSeries := ch.URL().Series; urlSeries != "" {
+		if urlSeries != toSeries {
+			return errors.Errorf("charm %q is for series %q, not %q", ch.URL(), urlSeries, toSeries)
+		}
+		return nil
+	}
+	if force {
+		return nil
+	}
+	for _, supported := range ch.Meta().Series {
+		if supported == toSeries {
+			return nil
+		}
+	}
+	supportedSeries := "no series"
+	if len(ch.Meta().Series) > 0 {
+		supportedSeries = strings.Join(ch.Meta().Series, ", ")
+	}
+	return errors.Errorf("series %q not supported by charm %q, only these series are supported: %v", toSeries, ch.URL(), supportedSeries)
+}
+
+// UpgradeSeriesStatus describes the progress of an upgrade of the
+// series of a machine, and of the units running on it.
+type UpgradeSeriesStatus string
+
+const (
+	// UpgradeSeriesNotStarted means that no series upgrade is in
+	// progress.
+	UpgradeSeriesNotStarted UpgradeSeriesStatus = ""
+
+	// UpgradeSeriesPrepared means that the operating system of the
+	// machine is about to be upgraded. The agents of the units on it
+	// run no hooks until the upgrade is completed, and the machine
+	// agent rewrites the agents' service definitions for the new
+	// series before the machine reboots into it.
+	UpgradeSeriesPrepared UpgradeSeriesStatus = "prepared"
+
+	// UpgradeSeriesCompleted means that the operating system has been
+	// upgraded, and each unit runs its post-series-upgrade hook.
+	UpgradeSeriesCompleted UpgradeSeriesStatus = "completed"
+)
+
+// UpgradeSeriesStatus returns the progress of the machine's series
+// upgrade, and the series it is being upgraded to.
+func (m *Machine) UpgradeSeriesStatus() (UpgradeSeriesStatus, string) {
+	return m.doc.UpgradeSeriesStatus, m.doc.UpgradeSeriesTarget
+}
+
+// PrepareUpgradeSeries records that the operating system of the
+// machine is about to be upgraded to the given series, and pauses the
+// agents of the units on the machine until CompleteUpgradeSeries is
+// called. Unless force is true, the charm of each unit must support
+// the new series.
+func (m *Machine) PrepareUpgradeSeries(toSeries string, force bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot prepare machine %s for upgrade to series %q", m, toSeries)
+	toOS, err := series.GetOSFromSeries(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fromOS, err := series.GetOSFromSeries(m.doc.Series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if toOS != fromOS {
+		return errors.Errorf("cannot upgrade from %s to %s", fromOS, toOS)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if m.doc.UpgradeSeriesStatus != UpgradeSeriesNotStarted {
+			return nil, errors.Errorf("already being upgraded to series %q", m.doc.UpgradeSeriesTarget)
+		}
+		if m.doc.Series == toSeries {
+			return nil, errors.Errorf("already running series %q", toSeries)
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:  machinesC,
+			Id: m.doc.DocID,
+			Assert: append(isAliveDoc,
+				bson.DocElem{"principals", m.doc.Principals},
+				bson.DocElem{"upgrade-series-status", bson.D{{"$exists", false}}},
+			),
+			Update: bson.D{{"$set", bson.D{
+				{"upgrade-series-status", UpgradeSeriesPrepared},
+				{"upgrade-series-target", toSeries},
+			}}},
+		}}
+		for _, unit := range units {
+			if unit.doc.UpgradeSeriesStatus != UpgradeSeriesNotStarted {
+				return nil, errors.Errorf("unit %q has not finished its previous series upgrade", unit)
+			}
+			app, err := unit.Application()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ch, _, err := app.Charm()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := checkCharmSeries(ch, toSeries, force); err != nil {
+				return nil, errors.Annotatef(err, "unit %q", unit)
+			}
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     unit.doc.DocID,
+				Assert: bson.D{{"upgrade-series-status", bson.D{{"$exists", false}}}},
+				Update: bson.D{{"$set", bson.D{{"upgrade-series-status", UpgradeSeriesPrepared}}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	m.doc.UpgradeSeriesStatus = UpgradeSeriesPrepared
+	m.doc.UpgradeSeriesTarget = toSeries
+	return nil
+}
+
+// CompleteUpgradeSeries records that the operating system of the
+// machine has been upgraded to the series given to
+// PrepareUpgradeSeries. The series of the machine and its units is
+// updated, and the agents of the units resume, starting with their
+// post-series-upgrade hooks.
+func (m *Machine) CompleteUpgradeSeries() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete series upgrade of machine %s", m)
+	var toSeries string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.UpgradeSeriesStatus != UpgradeSeriesPrepared {
+			return nil, errors.New("machine not prepared for a series upgrade")
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		toSeries = m.doc.UpgradeSeriesTarget
+		ops := []txn.Op{{
+			C:  machinesC,
+			Id: m.doc.DocID,
+			Assert: bson.D{
+				{"principals", m.doc.Principals},
+				{"upgrade-series-status", UpgradeSeriesPrepared},
+			},
+			Update: bson.D{
+				{"$set", bson.D{
+					{"series", toSeries},
+					{"upgrade-series-status", UpgradeSeriesCompleted},
+				}},
+				{"$unset", bson.D{{"upgrade-series-target", nil}}},
+			},
+		}}
+		// Units added to the machine since it was prepared are
+		// completed too; running their post-series-upgrade hooks
+		// does no harm.
+		for _, unit := range units {
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     unit.doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"series", toSeries},
+					{"upgrade-series-status", UpgradeSeriesCompleted},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	m.doc.Series = toSeries
+	m.doc.UpgradeSeriesStatus = UpgradeSeriesCompleted
+	m.doc.UpgradeSeriesTarget = ""
+	return nil
+}
+
+// FinishUpgradeSeries records that the machine agent has seen the
+// series upgrade completed, ending the machine's part in it.
+func (m *Machine) FinishUpgradeSeries() error {
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: bson.D{{"upgrade-series-status", UpgradeSeriesCompleted}},
+		Update: bson.D{{"$unset", bson.D{{"upgrade-series-status", nil}}}},
+	}}
+	if err := m.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot finish series upgrade of machine %s: upgrade not completed", m)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot finish series upgrade of machine %s", m)
+	}
+	m.doc.UpgradeSeriesStatus = UpgradeSeriesNotStarted
+	return nil
+}
+
+// UpgradeSeriesStatus returns the progress of the unit through the
+// series upgrade of its machine.
+func (u *Unit) UpgradeSeriesStatus() UpgradeSeriesStatus {
+	return u.doc.UpgradeSeriesStatus
+}
+
+// FinishUpgradeSeries records that the unit has run its
+// post-series-upgrade hook, ending its part in the series upgrade of
+// its machine.
+func (u *Unit) FinishUpgradeSeries() error {
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: bson.D{{"upgrade-series-status", UpgradeSeriesCompleted}},
+		Update: bson.D{{"$unset", bson.D{{"upgrade-series-status", nil}}}},
+	}}
+	if err := u.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot finish series upgrade of unit %q: upgrade not completed", u)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot finish series upgrade of unit %q", u)
+	}
+	u.doc.UpgradeSeriesStatus = UpgradeSeriesNotStarted
+	return nil
+}
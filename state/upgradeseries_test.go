@@ -0,0 +1,172 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UpgradeSeriesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) TestSetSeriesMultiSeriesCharm(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := app.SetSeries(state.SetSeriesConfig{Series: "trusty"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "trusty")
+
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Series(), gc.Equals, "trusty")
+}
+
+func (s *UpgradeSeriesSuite) TestSetSeriesUnsupported(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := app.SetSeries(state.SetSeriesConfig{Series: "xenial"})
+	c.Assert(err, gc.ErrorMatches, `cannot set series of application "application": series "xenial" not supported by charm "cs:multi-series-1", only these series are supported: precise, trusty`)
+
+	err = app.SetSeries(state.SetSeriesConfig{Series: "xenial", Force: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "xenial")
+}
+
+func (s *UpgradeSeriesSuite) TestSetSeriesWrongOS(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := app.SetSeries(state.SetSeriesConfig{Series: "nonsense", Force: true})
+	c.Assert(err, gc.ErrorMatches, `cannot set series of application "application": unknown OS for series: "nonsense"`)
+}
+
+func (s *UpgradeSeriesSuite) TestSetSeriesSingleSeriesCharm(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	err := app.SetSeries(state.SetSeriesConfig{Series: "xenial"})
+	c.Assert(err, gc.ErrorMatches, `cannot set series of application "mysql": charm "local:quantal/quantal-mysql-1" is for series "quantal", not "xenial"`)
+
+	xenialMysql := s.AddSeriesCharm(c, "mysql", "xenial")
+	err = app.SetSeries(state.SetSeriesConfig{Series: "xenial", Charm: xenialMysql})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Series(), gc.Equals, "xenial")
+	curl, _ := app.CharmURL()
+	c.Assert(curl, gc.DeepEquals, xenialMysql.URL())
+}
+
+func (s *UpgradeSeriesSuite) TestSetSeriesOtherCharm(c *gc.C) {
+	app := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	err := app.SetSeries(state.SetSeriesConfig{
+		Series: "xenial",
+		Charm:  s.AddSeriesCharm(c, "wordpress", "xenial"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set series of application "mysql": charm "local:xenial/xenial-wordpress-3" is not a version of "local:quantal/quantal-mysql"`)
+}
+
+func (s *UpgradeSeriesSuite) setUpMachine(c *gc.C) (*state.Machine, *state.Unit) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	app := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	machine, err := s.State.AddMachine("precise", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	return machine, unit
+}
+
+func (s *UpgradeSeriesSuite) TestUpgradeSeries(c *gc.C) {
+	machine, unit := s.setUpMachine(c)
+
+	err := machine.PrepareUpgradeSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	status, target := machine.UpgradeSeriesStatus()
+	c.Assert(status, gc.Equals, state.UpgradeSeriesPrepared)
+	c.Assert(target, gc.Equals, "trusty")
+	c.Assert(machine.Series(), gc.Equals, "precise")
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.UpgradeSeriesStatus(), gc.Equals, state.UpgradeSeriesPrepared)
+
+	err = machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	status, target = machine.UpgradeSeriesStatus()
+	c.Assert(status, gc.Equals, state.UpgradeSeriesCompleted)
+	c.Assert(target, gc.Equals, "")
+	c.Assert(machine.Series(), gc.Equals, "trusty")
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.UpgradeSeriesStatus(), gc.Equals, state.UpgradeSeriesCompleted)
+	c.Assert(unit.Series(), gc.Equals, "trusty")
+
+	err = machine.FinishUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.FinishUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	status, _ = machine.UpgradeSeriesStatus()
+	c.Assert(status, gc.Equals, state.UpgradeSeriesNotStarted)
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.UpgradeSeriesStatus(), gc.Equals, state.UpgradeSeriesNotStarted)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesUnsupportedByCharm(c *gc.C) {
+	machine, unit := s.setUpMachine(c)
+
+	err := machine.PrepareUpgradeSeries("xenial", false)
+	c.Assert(err, gc.ErrorMatches, `cannot prepare machine 0 for upgrade to series "xenial": unit "application/0": series "xenial" not supported by charm "cs:multi-series-1", .*`)
+	status, _ := machine.UpgradeSeriesStatus()
+	c.Assert(status, gc.Equals, state.UpgradeSeriesNotStarted)
+
+	err = machine.PrepareUpgradeSeries("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.UpgradeSeriesStatus(), gc.Equals, state.UpgradeSeriesPrepared)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesErrors(c *gc.C) {
+	machine, _ := s.setUpMachine(c)
+
+	err := machine.PrepareUpgradeSeries("win2012r2", true)
+	c.Assert(err, gc.ErrorMatches, `cannot prepare machine 0 for upgrade to series "win2012r2": cannot upgrade from Ubuntu to Windows`)
+	err = machine.PrepareUpgradeSeries("precise", true)
+	c.Assert(err, gc.ErrorMatches, `cannot prepare machine 0 for upgrade to series "precise": already running series "precise"`)
+
+	err = machine.PrepareUpgradeSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.PrepareUpgradeSeries("xenial", true)
+	c.Assert(err, gc.ErrorMatches, `cannot prepare machine 0 for upgrade to series "xenial": already being upgraded to series "trusty"`)
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteUpgradeSeriesNotPrepared(c *gc.C) {
+	machine, _ := s.setUpMachine(c)
+
+	err := machine.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, "cannot complete series upgrade of machine 0: machine not prepared for a series upgrade")
+}
+
+func (s *UpgradeSeriesSuite) TestFinishUpgradeSeriesNotCompleted(c *gc.C) {
+	machine, unit := s.setUpMachine(c)
+	err := machine.PrepareUpgradeSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.FinishUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, "cannot finish series upgrade of machine 0: upgrade not completed")
+	err = unit.FinishUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot finish series upgrade of unit "application/0": upgrade not completed`)
+}
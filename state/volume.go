@@ -46,6 +46,11 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size in MiB that the volume has been
+	// requested to grow to, and true, if the volume has been provisioned
+	// and a resize is pending; otherwise it returns false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +96,7 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	RequestedSize   uint64        `bson:"requestedsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -117,6 +123,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -236,6 +246,14 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	if v.doc.Info == nil || v.doc.RequestedSize == 0 {
+		return 0, false
+	}
+	return v.doc.RequestedSize, true
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -964,7 +982,7 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 		// If the volume has parameters, unset them when
 		// we set info for the first time, ensuring that
 		// params and info are mutually exclusive.
-		var unsetParams bool
+		var unsetParams, grown bool
 		var ops []txn.Op
 		if params, ok := v.Params(); ok {
 			info.Pool = params.Pool
@@ -978,13 +996,115 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			if err := validateVolumeInfoChange(info, oldInfo); err != nil {
 				return nil, err
 			}
+			grown = info.Size > oldInfo.Size
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if grown {
+			// The volume has been resized, so any filesystem
+			// it backs has grown with it.
+			filesystemOps, err := st.growVolumeFilesystemOps(tag, info.Size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, filesystemOps...)
+		}
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			// The volume has been grown to the requested size,
+			// so there is no longer a resize pending.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
 }
 
+// ResizeVolume requests that the volume with the specified tag be grown
+// to the given size in MiB. If the volume has not yet been provisioned,
+// its provisioning parameters are updated; otherwise the requested size
+// is recorded for the storage provisioner to act on. Volumes cannot be
+// shrunk.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		if params, ok := v.Params(); ok {
+			if size <= params.Size {
+				return nil, errors.Errorf(
+					"new size %dMiB must be greater than current size %dMiB",
+					size, params.Size,
+				)
+			}
+			ops := []txn.Op{{
+				C:  volumesC,
+				Id: tag.Id(),
+				Assert: append(bson.D{
+					{"info", bson.D{{"$exists", false}}},
+					{"params.size", params.Size},
+				}, isAliveDoc...),
+				Update: bson.D{{"$set", bson.D{{"params.size", size}}}},
+			}}
+			filesystemOps, err := st.growVolumeFilesystemOps(tag, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return append(ops, filesystemOps...), nil
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be greater than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if requested, ok := v.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: append(bson.D{
+				{"info.size", info.Size},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeStorageInstance requests that the volume or filesystem
+// assigned to the storage instance with the specified tag be grown
+// to the given size in MiB. Filesystems backed by a volume are
+// resized by resizing the volume.
+func (st *State) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	v, err := st.storageInstanceVolume(tag)
+	if err == nil {
+		return st.ResizeVolume(v.VolumeTag(), size)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	f, err := st.storageInstanceFilesystem(tag)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("volume or filesystem for storage instance %q", tag.Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return st.ResizeFilesystem(f.FilesystemTag(), size)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
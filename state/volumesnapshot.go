@@ -0,0 +1,409 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot interface {
+	Lifer
+	status.StatusGetter
+
+	// Id returns the unique ID of the snapshot. Snapshots of
+	// machine-scoped volumes are scoped to the same machine.
+	Id() string
+
+	// Volume returns the tag of the volume that was snapshotted.
+	Volume() names.VolumeTag
+
+	// StorageInstance returns the tag of the storage instance that
+	// the snapshotted volume was assigned to, if any. If the volume
+	// was not assigned to a storage instance, an error satisfying
+	// errors.IsNotAssigned will be returned.
+	StorageInstance() (names.StorageTag, error)
+
+	// Pool returns the name of the storage pool of the snapshotted
+	// volume.
+	Pool() string
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+type volumeSnapshot struct {
+	st  *State
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot
+// in the model.
+type volumeSnapshotDoc struct {
+	DocID       string              `bson:"_id"`
+	Name        string              `bson:"name"`
+	ModelUUID   string              `bson:"model-uuid"`
+	Life        Life                `bson:"life"`
+	Volume      string              `bson:"volumeid"`
+	StorageId   string              `bson:"storageid,omitempty"`
+	StorageName string              `bson:"storagename,omitempty"`
+	StorageKind StorageKind         `bson:"storagekind,omitempty"`
+	Pool        string              `bson:"pool"`
+	Created     time.Time           `bson:"created"`
+	Info        *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Name
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// StorageInstance is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) StorageInstance() (names.StorageTag, error) {
+	if s.doc.StorageId == "" {
+		msg := fmt.Sprintf("volume snapshot %q is not assigned to any storage instance", s.doc.Name)
+		return names.StorageTag{}, errors.NewNotAssigned(nil, msg)
+	}
+	return names.NewStorageTag(s.doc.StorageId), nil
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+// Status is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Status() (status.StatusInfo, error) {
+	return s.st.VolumeSnapshotStatus(s.doc.Name)
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := st.volumeSnapshot(id)
+	return s, err
+}
+
+func (st *State) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"_id", id}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	}
+	return snapshots[0], nil
+}
+
+func (st *State) volumeSnapshots(query interface{}) ([]*volumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying volume snapshots")
+	}
+	snapshots := make([]*volumeSnapshot, len(docs))
+	for i := range docs {
+		snapshots[i] = &volumeSnapshot{st, docs[i]}
+	}
+	return snapshots, nil
+}
+
+func volumeSnapshotsToInterfaces(snapshots []*volumeSnapshot) []VolumeSnapshot {
+	result := make([]VolumeSnapshot, len(snapshots))
+	for i, s := range snapshots {
+		result[i] = s
+	}
+	return result
+}
+
+// AllVolumeSnapshots returns all VolumeSnapshots in the model.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	return volumeSnapshotsToInterfaces(snapshots), nil
+}
+
+// StorageInstanceVolumeSnapshots returns the VolumeSnapshots taken of
+// the volume assigned to the specified storage instance.
+func (st *State) StorageInstanceVolumeSnapshots(tag names.StorageTag) ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"storageid", tag.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshots for storage %q", tag.Id())
+	}
+	return volumeSnapshotsToInterfaces(snapshots), nil
+}
+
+// newVolumeSnapshotName returns a unique volume snapshot name. If the
+// machine ID supplied is non-empty, the snapshot ID will incorporate
+// it as the snapshot's machine scope.
+func newVolumeSnapshotName(st *State, machineId string) (string, error) {
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	if machineId != "" {
+		id = machineId + "/" + id
+	}
+	return id, nil
+}
+
+// SnapshotStorageInstance requests a point-in-time snapshot of the
+// volume backing the storage instance with the specified tag. The
+// snapshot is taken asynchronously by the storage provisioner, and
+// only once the volume has been provisioned. Storage instances that
+// are not backed by a volume cannot be snapshotted.
+func (st *State) SnapshotStorageInstance(tag names.StorageTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot storage %q", tag.Id())
+	var doc volumeSnapshotDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		v, err := st.storageInstanceVolume(tag)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotSupportedf("snapshotting storage without a backing volume")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var machineId string
+		if machine, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			machineId = machine.Id()
+		}
+		name, err := newVolumeSnapshotName(st, machineId)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot generate volume snapshot name")
+		}
+		doc = volumeSnapshotDoc{
+			Name:        name,
+			Volume:      v.doc.Name,
+			StorageId:   si.doc.Id,
+			StorageName: si.doc.StorageName,
+			StorageKind: si.doc.Kind,
+			Pool:        info.Pool,
+			Created:     nowToTheSecond(),
+		}
+		statusDoc := statusDoc{
+			Status:  status.StatusPending,
+			Updated: time.Now().UnixNano(),
+		}
+		return []txn.Op{
+			createStatusOp(st, volumeSnapshotGlobalKey(name), statusDoc),
+			{
+				C:      storageInstancesC,
+				Id:     si.doc.Id,
+				Assert: isAliveDoc,
+			}, {
+				C:      volumesC,
+				Id:     v.doc.Name,
+				Assert: append(bson.D{{"info", bson.D{{"$exists", true}}}}, isAliveDoc...),
+			}, {
+				C:      volumeSnapshotsC,
+				Id:     name,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			},
+		}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &volumeSnapshot{st, doc}, nil
+}
+
+// SetVolumeSnapshotInfo records information about the taken snapshot,
+// and marks it as available.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Info != nil {
+			if s.doc.Info.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf(
+					"cannot change snapshot ID from %q to %q",
+					s.doc.Info.SnapshotId, info.SnapshotId,
+				)
+			}
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return err
+	}
+	return st.SetVolumeSnapshotStatus(id, status.StatusAvailable, "", nil, nil)
+}
+
+// RestoreVolumeSnapshot creates a new, detached storage instance backed
+// by a new volume that will be created from the specified snapshot.
+// The volume is provisioned once the storage instance is attached to a
+// unit. The tag of the new storage instance is returned.
+func (st *State) RestoreVolumeSnapshot(id string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore volume snapshot %q", id)
+	var storageTag names.StorageTag
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := s.Info()
+		if errors.IsNotProvisioned(err) {
+			return nil, errors.New("snapshot is not available")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.StorageName == "" {
+			return nil, errors.NotSupportedf("restoring snapshot of volume without storage")
+		}
+		if s.doc.StorageKind != StorageKindBlock {
+			return nil, errors.NotSupportedf("restoring snapshot to %s storage", s.doc.StorageKind)
+		}
+		storageId, err := newStorageInstanceId(st, s.doc.StorageName)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot generate storage instance name")
+		}
+		storageTag = names.NewStorageTag(storageId)
+
+		// A snapshot of a machine-scoped volume can only be
+		// restored on that same machine.
+		var machineId string
+		if machine, ok := names.VolumeMachine(s.Volume()); ok {
+			machineId = machine.Id()
+		}
+		volumeName, err := newVolumeName(st, machineId)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot generate volume name")
+		}
+		volumeDoc := volumeDoc{
+			Name:      volumeName,
+			StorageId: storageId,
+			Binding:   storageTag.String(),
+			Params: &VolumeParams{
+				Pool:       s.doc.Pool,
+				Size:       info.Size,
+				SnapshotId: info.SnapshotId,
+			},
+		}
+		volumeStatus := statusDoc{
+			Status:  status.StatusPending,
+			Updated: time.Now().UnixNano(),
+		}
+		ops := []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info.snapshotid", info.SnapshotId}},
+		}, {
+			C:      storageInstancesC,
+			Id:     storageId,
+			Assert: txn.DocMissing,
+			Insert: &storageInstanceDoc{
+				Id:          storageId,
+				Kind:        StorageKindBlock,
+				StorageName: s.doc.StorageName,
+			},
+		}}
+		return append(ops, st.newVolumeOps(volumeDoc, volumeStatus)...), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return names.StorageTag{}, err
+	}
+	return storageTag, nil
+}
+
+func volumeSnapshotGlobalKey(name string) string {
+	return "vs#" + name
+}
+
+// VolumeSnapshotStatus returns the status of the specified volume snapshot.
+func (st *State) VolumeSnapshotStatus(id string) (status.StatusInfo, error) {
+	return getStatus(st, volumeSnapshotGlobalKey(id), "volume snapshot")
+}
+
+// SetVolumeSnapshotStatus sets the status of the specified volume snapshot.
+func (st *State) SetVolumeSnapshotStatus(id string, snapshotStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
+	switch snapshotStatus {
+	case status.StatusPending, status.StatusAvailable:
+	case status.StatusError:
+		if info == "" {
+			return errors.Errorf("cannot set status %q without info", snapshotStatus)
+		}
+	default:
+		return errors.Errorf("cannot set invalid status %q", snapshotStatus)
+	}
+	return setStatus(st, setStatusParams{
+		badge:     "volume snapshot",
+		globalKey: volumeSnapshotGlobalKey(id),
+		status:    snapshotStatus,
+		message:   info,
+		rawData:   data,
+		updated:   updated,
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) setupProvisionedVolume(c *gc.C, pool string) (names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 1024, VolumeId: "vol-ume", Pool: pool,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, volumeTag
+}
+
+func (s *VolumeSnapshotSuite) TestSnapshotStorageInstance(c *gc.C) {
+	storageTag, volumeTag := s.setupProvisionedVolume(c, "persistent-block")
+
+	snapshot, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "persistent-block")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	snapshotStorageTag, err := snapshot.StorageInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotStorageTag, gc.Equals, storageTag)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusPending)
+
+	snapshots, err := s.State.StorageInstanceVolumeSnapshots(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0")
+}
+
+func (s *VolumeSnapshotSuite) TestSnapshotStorageInstanceMachineScoped(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c, "loop-pool")
+	snapshot, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0")
+	c.Assert(snapshot.Volume(), gc.Equals, names.NewVolumeTag("0/0"))
+}
+
+func (s *VolumeSnapshotSuite) TestSnapshotStorageInstanceUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "persistent-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot storage "data/0": volume "0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestSnapshotStorageInstanceFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "static")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot storage "data/0": snapshotting storage without a backing volume not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c, "persistent-block")
+	snapshot, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+	// Setting the same info again is a no-op.
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	infoGet, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoGet, jc.DeepEquals, info)
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusAvailable)
+
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0": cannot change snapshot ID from "snap-0" to "snap-1"`)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotStatus(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c, "persistent-block")
+	snapshot, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetVolumeSnapshotStatus(snapshot.Id(), status.StatusError, "", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set status "error" without info`)
+	err = s.State.SetVolumeSnapshotStatus(snapshot.Id(), status.StatusAttached, "", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set invalid status "attached"`)
+	err = s.State.SetVolumeSnapshotStatus(snapshot.Id(), status.StatusError, "boom", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+	c.Assert(statusInfo.Message, gc.Equals, "boom")
+}
+
+func (s *VolumeSnapshotSuite) TestRestoreVolumeSnapshot(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c, "persistent-block")
+	snapshot, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.RestoreVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0": snapshot is not available`)
+
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0", Size: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	restoredTag, err := s.State.RestoreVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restoredTag, gc.Equals, names.NewStorageTag("data/1"))
+
+	// The restored storage is detached, ready to be attached to a unit.
+	si, err := s.State.StorageInstance(restoredTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindBlock)
+	_, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+
+	volume := s.storageInstanceVolume(c, restoredTag)
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params, jc.DeepEquals, state.VolumeParams{
+		Pool:       "persistent-block",
+		Size:       2048,
+		SnapshotId: "snap-0",
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestWatchModelVolumeSnapshots(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c, "persistent-block")
+
+	w := s.State.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	storageTag, _ := s.setupProvisionedVolume(c, "loop-pool")
+
+	w := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.SnapshotStorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+}
@@ -121,6 +121,37 @@ type VolumeSource interface {
 	// are detachable, and reject attempts to attach/detach on
 	// that basis.
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
+
+	// ResizeVolumes grows the volumes with the specified provider
+	// volume IDs to at least the requested sizes. Volumes may remain
+	// attached while they are resized; it is up to the machine agent
+	// to notify the charm, so that it can grow any filesystem on the
+	// volume.
+	//
+	// Shrinking volumes is not supported; if the requested size is
+	// not greater than the current size, ResizeVolumes must return
+	// an error for that volume.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeSnapshotter is an optional interface that a VolumeSource may
+// implement if it is capable of taking point-in-time snapshots of its
+// volumes. Volumes may subsequently be created from those snapshots by
+// specifying VolumeParams.SnapshotId.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of the volumes with the
+	// specified provider volume IDs.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+}
+
+// VolumeImporter is an optional interface that a VolumeSource may
+// implement if it is capable of bringing existing volumes, created
+// outside of Juju, under Juju's management.
+type VolumeImporter interface {
+	// ImportVolume validates that the volume with the specified
+	// provider volume ID exists and is not in use, tags it with the
+	// specified resource tags, and returns its information.
+	ImportVolume(volumeId string, resourceTags map[string]string) (VolumeInfo, error)
 }
 
 // FilesystemSource provides an interface for creating, destroying and
@@ -156,6 +187,16 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemImporter is an optional interface that a FilesystemSource
+// may implement if it is capable of bringing existing filesystems,
+// created outside of Juju, under Juju's management.
+type FilesystemImporter interface {
+	// ImportFilesystem validates that the filesystem with the specified
+	// provider filesystem ID exists and is not in use, tags it with the
+	// specified resource tags, and returns its information.
+	ImportFilesystem(filesystemId string, resourceTags map[string]string) (FilesystemInfo, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -190,6 +231,11 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId, if non-empty, is the unique provider-supplied ID of
+	// a snapshot from which the volume should be created. Only volume
+	// sources implementing VolumeSnapshotter support this.
+	SnapshotId string
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -206,6 +252,47 @@ type VolumeAttachmentParams struct {
 	VolumeId string
 }
 
+// VolumeResizeParams is a set of parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// Tag is a unique tag assigned by Juju for the volume that
+	// should be resized.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be resized.
+	VolumeId string
+
+	// Size is the minimum size of the resized volume in MiB.
+	Size uint64
+}
+
+// VolumeSnapshotParams is a set of parameters for snapshotting a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the requested snapshot.
+	Id string
+
+	// Volume is a unique tag assigned by Juju for the volume that
+	// should be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be snapshotted.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeSnapshot describes a snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the snapshotted volume in MiB.
+	Size uint64
+}
+
 // AttachmentParams describes the parameters for attaching a volume or
 // filesystem to a machine.
 type AttachmentParams struct {
@@ -295,6 +382,22 @@ type AttachVolumesResult struct {
 	Error            error
 }
 
+// ResizeVolumesResult contains the result of a VolumeSource.ResizeVolumes call
+// for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the volume in MiB after resizing.
+	Size  uint64
+	Error error
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
 // CreateFilesystemsResult contains the result of a FilesystemSource.CreateFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type CreateFilesystemsResult struct {
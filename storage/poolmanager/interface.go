@@ -15,6 +15,11 @@ type PoolManager interface {
 	// Create makes a new pool with the specified configuration and persists it to state.
 	Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error)
 
+	// Update replaces the attributes of the pool with name, and persists
+	// it to state. Attributes with empty values are removed from the
+	// pool's configuration; other existing attributes are retained.
+	Update(name string, attrs map[string]interface{}) (*storage.Config, error)
+
 	// Delete removes the pool with name from state.
 	Delete(name string) error
 
@@ -28,6 +33,7 @@ type PoolManager interface {
 type SettingsManager interface {
 	CreateSettings(key string, settings map[string]interface{}) error
 	ReadSettings(key string) (map[string]interface{}, error)
+	ReplaceSettings(key string, settings map[string]interface{}) error
 	RemoveSettings(key string) error
 	ListSettings(keyPrefix string) (map[string]map[string]interface{}, error)
 }
@@ -56,6 +62,15 @@ func (m MemSettings) ReadSettings(key string) (map[string]interface{}, error) {
 	return settings, nil
 }
 
+// ReplaceSettings is part of the SettingsManager interface.
+func (m MemSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	if _, ok := m.Settings[key]; !ok {
+		return errors.NotFoundf("settings with key %q", key)
+	}
+	m.Settings[key] = settings
+	return nil
+}
+
 // RemoveSettings is part of the SettingsManager interface.
 func (m MemSettings) RemoveSettings(key string) error {
 	if _, ok := m.Settings[key]; !ok {
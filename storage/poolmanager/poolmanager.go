@@ -69,6 +69,46 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	return cfg, nil
 }
 
+// Update is defined on PoolManager interface.
+func (pm *poolManager) Update(name string, attrs map[string]interface{}) (*storage.Config, error) {
+	existing, err := pm.Get(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newAttrs := existing.Attrs()
+	if newAttrs == nil {
+		newAttrs = make(map[string]interface{})
+	}
+	for key, value := range attrs {
+		if value == "" {
+			delete(newAttrs, key)
+			continue
+		}
+		newAttrs[key] = value
+	}
+
+	providerType := existing.Provider()
+	cfg, err := storage.NewConfig(name, providerType, newAttrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p, err := pm.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := provider.ValidateConfig(p, cfg); err != nil {
+		return nil, errors.Annotate(err, "validating storage provider config")
+	}
+
+	poolAttrs := cfg.Attrs()
+	poolAttrs[Name] = name
+	poolAttrs[Type] = string(providerType)
+	if err := pm.settings.ReplaceSettings(globalKey(name), poolAttrs); err != nil {
+		return nil, errors.Annotatef(err, "updating pool %q", name)
+	}
+	return cfg, nil
+}
+
 // Delete is defined on PoolManager interface.
 func (pm *poolManager) Delete(name string) error {
 	err := pm.settings.RemoveSettings(globalKey(name))
@@ -140,3 +140,41 @@ func (s *poolSuite) TestDelete(c *gc.C) {
 	err = s.poolManager.Delete("testpool")
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *poolSuite) TestUpdate(c *gc.C) {
+	s.createSettings(c)
+	cfg, err := s.poolManager.Update("testpool", map[string]interface{}{"foo": "", "baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := storage.NewConfig("testpool", "loop", map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, expected)
+
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p, jc.DeepEquals, expected)
+}
+
+func (s *poolSuite) TestUpdateNotFound(c *gc.C) {
+	_, err := s.poolManager.Update("testpool", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `pool "testpool" not found`)
+}
+
+func (s *poolSuite) TestUpdateInvalidConfig(c *gc.C) {
+	s.createSettings(c)
+	s.registry.Providers["loop"] = &dummystorage.StorageProvider{
+		ValidateConfigFunc: func(cfg *storage.Config) error {
+			if cfg.Attrs()["foo"] == "baz" {
+				return errors.New("no good")
+			}
+			return nil
+		},
+	}
+	_, err := s.poolManager.Update("testpool", map[string]interface{}{"foo": "baz"})
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+
+	// The pool remains unchanged.
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
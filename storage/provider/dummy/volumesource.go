@@ -23,6 +23,10 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+	ResizeVolumesFunc        func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+
+	CreateVolumeSnapshotsFunc func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	ImportVolumeFunc          func(string, map[string]string) (storage.VolumeInfo, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -87,3 +91,30 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// ResizeVolumes is defined on storage.VolumeSource.
+func (s *VolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
+
+// ImportVolume is defined on storage.VolumeImporter.
+func (s *VolumeSource) ImportVolume(volumeId string, resourceTags map[string]string) (storage.VolumeInfo, error) {
+	s.MethodCall(s, "ImportVolume", volumeId, resourceTags)
+	if s.ImportVolumeFunc != nil {
+		return s.ImportVolumeFunc(volumeId, resourceTags)
+	}
+	return storage.VolumeInfo{}, errors.NotImplementedf("ImportVolume")
+}
@@ -0,0 +1,411 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVMProviderType is the provider type of the LVM storage
+	// provider, which creates logical volumes in an existing
+	// volume group on the machine.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the pool attribute that specifies
+	// the volume group from which logical volumes are created. The
+	// volume group must already exist on the machine.
+	LVMVolumeGroup = "volume-group"
+
+	// LVMThinPool is the name of the pool attribute that specifies a
+	// thin pool in the volume group. If specified, logical volumes
+	// are thinly provisioned from the thin pool.
+	LVMThinPool = "thin-pool"
+
+	// LVMStripes is the name of the pool attribute that specifies the
+	// number of stripes for each logical volume.
+	LVMStripes = "stripes"
+
+	// LVMStripeSize is the name of the pool attribute that specifies
+	// the size of each stripe, in KiB.
+	LVMStripeSize = "stripe-size"
+)
+
+var lvmConfigFields = schema.Fields{
+	LVMVolumeGroup: schema.String(),
+	LVMThinPool:    schema.String(),
+	LVMStripes:     schema.ForceInt(),
+	LVMStripeSize:  schema.ForceInt(),
+}
+
+var lvmConfigChecker = schema.FieldMap(
+	lvmConfigFields,
+	schema.Defaults{
+		LVMThinPool:   "",
+		LVMStripes:    schema.Omit,
+		LVMStripeSize: schema.Omit,
+	},
+)
+
+type lvmConfig struct {
+	volumeGroup string
+	thinPool    string
+	stripes     int
+	stripeSize  int
+}
+
+func newLVMConfig(attrs map[string]interface{}) (*lvmConfig, error) {
+	out, err := lvmConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating LVM storage config")
+	}
+	coerced := out.(map[string]interface{})
+	stripes, _ := coerced[LVMStripes].(int)
+	stripeSize, _ := coerced[LVMStripeSize].(int)
+	lvmConfig := &lvmConfig{
+		volumeGroup: coerced[LVMVolumeGroup].(string),
+		thinPool:    coerced[LVMThinPool].(string),
+		stripes:     stripes,
+		stripeSize:  stripeSize,
+	}
+	if lvmConfig.volumeGroup == "" {
+		return nil, errors.New("volume group not specified")
+	}
+	if _, ok := coerced[LVMStripes]; ok && stripes < 1 {
+		return nil, errors.Errorf("stripes must be a positive integer, got %d", stripes)
+	}
+	if _, ok := coerced[LVMStripeSize]; ok {
+		if stripes < 2 {
+			return nil, errors.New("stripe size specified, but stripes is less than 2")
+		}
+		if stripeSize < 4 || stripeSize&(stripeSize-1) != 0 {
+			return nil, errors.Errorf(
+				"stripe size must be a power of 2 no less than 4 (KiB), got %d",
+				stripeSize,
+			)
+		}
+	}
+	if lvmConfig.thinPool != "" && stripes > 0 {
+		return nil, errors.New("stripes cannot be specified for thinly provisioned volumes")
+	}
+	return lvmConfig, nil
+}
+
+// lvmProvider creates volume sources which use logical volumes
+// carved from an existing LVM volume group on the machine.
+//
+// The volume group, and any thin pool within it, must be created by
+// the operator before the provider is used. For testing, a volume
+// group may be created on a loop device:
+//
+//	truncate -s 10G /var/lib/juju-lvm.img
+//	losetup -f --show /var/lib/juju-lvm.img
+//	vgcreate juju /dev/loop0
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newLVMConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	cfg, err := newLVMConfig(sourceConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &lvmVolumeSource{p.run, *cfg}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*lvmProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*lvmProvider) DefaultPools() []*storage.Config {
+	// There is no default volume group, so there
+	// can be no default pools.
+	return nil
+}
+
+// lvmVolumeSource creates, attaches, detaches, resizes and destroys
+// logical volumes in a volume group.
+//
+// Volume IDs have the form "<volume-group>/<logical-volume>", which
+// is how LVM commands identify logical volumes.
+type lvmVolumeSource struct {
+	run    runCommandFunc
+	config lvmConfig
+}
+
+var _ storage.VolumeSource = (*lvmVolumeSource)(nil)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	if params.SnapshotId != "" {
+		return nil, errors.NotSupportedf("creating volumes from snapshots")
+	}
+	lvName := params.Tag.String()
+	args := []string{"--yes", "-n", lvName}
+	if s.config.thinPool != "" {
+		args = append(args,
+			"-V", fmt.Sprintf("%dm", params.Size),
+			"--thinpool", s.config.thinPool,
+		)
+	} else {
+		args = append(args, "-L", fmt.Sprintf("%dm", params.Size))
+		if s.config.stripes > 0 {
+			args = append(args, "-i", fmt.Sprint(s.config.stripes))
+		}
+		if s.config.stripeSize > 0 {
+			args = append(args, "-I", fmt.Sprint(s.config.stripeSize))
+		}
+	}
+	args = append(args, s.config.volumeGroup)
+	if _, err := s.run("lvcreate", args...); err != nil {
+		return nil, errors.Annotatef(err, "creating logical volume %q", lvName)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: path.Join(s.config.volumeGroup, lvName),
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes() ([]string, error) {
+	stdout, err := s.run(
+		"lvs", "--noheadings", "-o", "lv_name",
+		s.config.volumeGroup,
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing logical volumes")
+	}
+	var volumeIds []string
+	for _, line := range strings.Split(stdout, "\n") {
+		lvName := strings.TrimSpace(line)
+		if _, err := names.ParseVolumeTag(lvName); err != nil {
+			// Only report logical volumes created by Juju.
+			continue
+		}
+		volumeIds = append(volumeIds, path.Join(s.config.volumeGroup, lvName))
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		info, err := s.describeVolume(volumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "describing %q", volumeId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) describeVolume(volumeId string) (*storage.VolumeInfo, error) {
+	if err := validateLVMVolumeId(volumeId); err != nil {
+		return nil, errors.Trace(err)
+	}
+	stdout, err := s.run(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m",
+		"-o", "lv_size", volumeId,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Failed to find logical volume") {
+			return nil, errors.NotFoundf("logical volume %q", volumeId)
+		}
+		return nil, errors.Annotate(err, "getting logical volume size")
+	}
+	size, err := strconv.ParseFloat(strings.TrimSpace(stdout), 64)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing logical volume size %q", stdout)
+	}
+	return &storage.VolumeInfo{
+		VolumeId: volumeId,
+		Size:     uint64(math.Ceil(size)),
+	}, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	if err := validateLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.run("lvremove", "-f", volumeId); err != nil {
+		if strings.Contains(err.Error(), "Failed to find logical volume") {
+			// The logical volume has already been removed.
+			return nil
+		}
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the volume group until we get to CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if err := validateLVMVolumeId(arg.VolumeId); err != nil {
+		return nil, errors.Trace(err)
+	}
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	if _, err := s.run("lvchange", "-p", permission, arg.VolumeId); err != nil {
+		// lvchange fails if the permission is unchanged,
+		// so we only log the error here; activation below
+		// will fail if there is a real problem.
+		logger.Debugf("setting permission of %q: %v", arg.VolumeId, err)
+	}
+	if _, err := s.run("lvchange", "-ay", arg.VolumeId); err != nil {
+		return nil, errors.Annotate(err, "activating logical volume")
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: path.Join("/dev", arg.VolumeId),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg.VolumeId); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) detachVolume(volumeId string) error {
+	if err := validateLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.run("lvchange", "-an", volumeId); err != nil {
+		return errors.Annotate(err, "deactivating logical volume")
+	}
+	return nil
+}
+
+// ResizeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := s.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	if err := validateLVMVolumeId(arg.VolumeId); err != nil {
+		return errors.Trace(err)
+	}
+	// lvextend refuses to shrink logical volumes, so
+	// there is no need to check the current size.
+	_, err := s.run("lvextend", "-L", fmt.Sprintf("%dm", arg.Size), arg.VolumeId)
+	if err != nil {
+		return errors.Annotate(err, "extending logical volume")
+	}
+	return nil
+}
+
+// validateLVMVolumeId checks that the given volume ID has the form
+// "<volume-group>/<logical-volume>".
+func validateLVMVolumeId(volumeId string) error {
+	parts := strings.Split(volumeId, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.Errorf("invalid LVM volume ID %q", volumeId)
+	}
+	return nil
+}
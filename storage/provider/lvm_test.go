@@ -0,0 +1,325 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+	provider storage.Provider
+}
+
+func (s *lvmSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.commands = &mockRunCommand{c: c}
+	s.provider = provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) volumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	cfg, err := storage.NewConfig("lvm", provider.LVMProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	for i, t := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+		err:   "validating LVM storage config: volume-group: .*",
+	}, {
+		attrs: map[string]interface{}{"volume-group": ""},
+		err:   "volume group not specified",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripes": "0"},
+		err:   "stripes must be a positive integer, got 0",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripes": "2", "stripe-size": "64"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripe-size": "64"},
+		err:   "stripe size specified, but stripes is less than 2",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "stripes": "2", "stripe-size": "48"},
+		err:   `stripe size must be a power of 2 no less than 4 \(KiB\), got 48`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "thin-pool": "pool0"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg0", "thin-pool": "pool0", "stripes": "2"},
+		err:   "stripes cannot be specified for thinly provisioned volumes",
+	}} {
+		c.Logf("test %d: %v", i, t.attrs)
+		cfg, err := storage.NewConfig("lvm", provider.LVMProviderType, t.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.provider.ValidateConfig(cfg)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *lvmSuite) TestDynamic(c *gc.C) {
+	c.Assert(s.provider.Dynamic(), jc.IsTrue)
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvcreate", "--yes", "-n", "volume-0-1", "-L", "1024m", "vg0")
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0/1"),
+		storage.VolumeInfo{
+			VolumeId: "vg0/volume-0-1",
+			Size:     1024,
+		},
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumesStriped(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{
+		"volume-group": "vg0",
+		"stripes":      2,
+		"stripe-size":  64,
+	})
+	s.commands.expect(
+		"lvcreate", "--yes", "-n", "volume-0-1", "-L", "1024m",
+		"-i", "2", "-I", "64", "vg0",
+	)
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestCreateVolumesThin(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{
+		"volume-group": "vg0",
+		"thin-pool":    "pool0",
+	})
+	s.commands.expect(
+		"lvcreate", "--yes", "-n", "volume-0-1", "-V", "1024m",
+		"--thinpool", "pool0", "vg0",
+	)
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestCreateVolumesErrors(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvcreate", "--yes", "-n", "volume-0-1", "-L", "1024m", "vg0")
+	cmd.respond("", errors.New("Volume group \"vg0\" has insufficient free space"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 1024,
+	}, {
+		Tag:        names.NewVolumeTag("0/2"),
+		Size:       1024,
+		SnapshotId: "snap-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`creating volume: creating logical volume "volume-0-1": Volume group "vg0" has insufficient free space`,
+	)
+	c.Assert(results[1].Error, gc.ErrorMatches, "creating volume: creating volumes from snapshots not supported")
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "vg0")
+	cmd.respond("  volume-0-1\n  pool0\n  volume-0-2\n", nil)
+
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"vg0/volume-0-1", "vg0/volume-0-2"})
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/volume-0-1")
+	cmd.respond("  1024.00\n", nil)
+	cmd = s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/volume-0-2")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/volume-0-2"`))
+
+	results, err := source.DescribeVolumes([]string{"vg0/volume-0-1", "vg0/volume-0-2", "bad"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "vg0/volume-0-1",
+		Size:     1024,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+	c.Assert(results[2].Error, gc.ErrorMatches, `describing "bad": invalid LVM volume ID "bad"`)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvremove", "-f", "vg0/volume-0-1")
+	cmd := s.commands.expect("lvremove", "-f", "vg0/volume-0-2")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/volume-0-2"`))
+	cmd = s.commands.expect("lvremove", "-f", "vg0/volume-0-3")
+	cmd.respond("", errors.New("Logical volume vg0/volume-0-3 in use."))
+
+	results, err := source.DestroyVolumes([]string{
+		"vg0/volume-0-1", "vg0/volume-0-2", "vg0/volume-0-3", "volume-0-4",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 4)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches,
+		`destroying "vg0/volume-0-3": removing logical volume: Logical volume vg0/volume-0-3 in use.`,
+	)
+	c.Assert(results[3], gc.ErrorMatches, `destroying "volume-0-4": invalid LVM volume ID "volume-0-4"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvchange", "-p", "rw", "vg0/volume-0-1")
+	s.commands.expect("lvchange", "-ay", "vg0/volume-0-1")
+	s.commands.expect("lvchange", "-p", "r", "vg0/volume-0-2")
+	s.commands.expect("lvchange", "-ay", "vg0/volume-0-2")
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "vg0/volume-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}, {
+		Volume:   names.NewVolumeTag("0/2"),
+		VolumeId: "vg0/volume-0-2",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0/1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-0-1",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0/2"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-0-2",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestAttachVolumesActivateError(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvchange", "-p", "rw", "vg0/volume-0-1")
+	cmd.respond("", errors.New(`Logical volume "volume-0-1" is already writable`))
+	cmd = s.commands.expect("lvchange", "-ay", "vg0/volume-0-1")
+	cmd.respond("", errors.New("device-mapper: reload ioctl failed"))
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "vg0/volume-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		"attaching volume 0/1: activating logical volume: device-mapper: reload ioctl failed",
+	)
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvchange", "-an", "vg0/volume-0-1")
+
+	results, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "vg0/volume-0-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestResizeVolumes(c *gc.C) {
+	source := s.volumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvextend", "-L", "2048m", "vg0/volume-0-1")
+	cmd := s.commands.expect("lvextend", "-L", "512m", "vg0/volume-0-2")
+	cmd.respond("", errors.New("New size given (128 extents) not larger than existing size (256 extents)"))
+
+	results, err := source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0/1"),
+		VolumeId: "vg0/volume-0-1",
+		Size:     2048,
+	}, {
+		Tag:      names.NewVolumeTag("0/2"),
+		VolumeId: "vg0/volume-0-2",
+		Size:     512,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Size, gc.Equals, uint64(2048))
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`resizing volume 0/2: extending logical volume: New size given \(128 extents\) not larger than existing size \(256 extents\)`,
+	)
+}
@@ -26,3 +26,7 @@ var LongAttempt = &utils.AttemptStrategy{
 	Total: LongWait,
 	Delay: ShortWait,
 }
+
+// SecretConfigKey is the key with which states opened in tests encrypt
+// secret config values.
+const SecretConfigKey = "anVqdSB0ZXN0IHNlY3JldCBjb25maWcga2V5IDAxMjM="
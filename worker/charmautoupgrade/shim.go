@@ -0,0 +1,15 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautoupgrade
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/charmrevisionupdater"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return charmrevisionupdater.NewState(apiCaller), nil
+}
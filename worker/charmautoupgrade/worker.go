@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautoupgrade
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/worker"
+)
+
+// Facade exposes the controller functionality needed by the worker.
+type Facade interface {
+
+	// AutoUpgradeCharms upgrades, to the latest revisions recorded by
+	// the charm revision updater, the charms of all applications whose
+	// auto-upgrade policy currently allows it.
+	AutoUpgradeCharms() error
+}
+
+// Config holds the dependencies and configuration of a charmautoupgrade
+// worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between attempts to upgrade charms. It
+	// should be short enough that maintenance windows are not
+	// missed.
+	Period time.Duration
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically asks the controller to
+// upgrade the charms of applications that have opted in to automatic
+// upgrades.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &upgradeWorker{
+		config: config,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type upgradeWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *upgradeWorker) loop() error {
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.Period):
+			if err := w.config.Facade.AutoUpgradeCharms(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *upgradeWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *upgradeWorker) Wait() error {
+	return w.tomb.Wait()
+}
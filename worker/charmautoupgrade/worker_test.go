@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmautoupgrade_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/charmautoupgrade"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	valid := charmautoupgrade.Config{
+		Facade: struct{ charmautoupgrade.Facade }{},
+		Clock:  struct{ clock.Clock }{},
+		Period: time.Minute,
+	}
+	c.Check(valid.Validate(), jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		mutate func(*charmautoupgrade.Config)
+		err    string
+	}{{
+		mutate: func(config *charmautoupgrade.Config) { config.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		mutate: func(config *charmautoupgrade.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		mutate: func(config *charmautoupgrade.Config) { config.Period = 0 },
+		err:    "non-positive Period not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := valid
+		test.mutate(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+
+		w, err := charmautoupgrade.NewWorker(config)
+		c.Check(w, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestUpgradesEachPeriod(c *gc.C) {
+	facade := newMockFacade()
+	clock := testing.NewClock(time.Now())
+	w, err := charmautoupgrade.NewWorker(charmautoupgrade.Config{
+		Facade: facade,
+		Clock:  clock,
+		Period: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	facade.waitNoCall(c)
+	advance(c, clock, time.Minute)
+	facade.waitCall(c)
+	facade.waitNoCall(c)
+	advance(c, clock, time.Minute)
+	facade.waitCall(c)
+
+	c.Check(worker.Stop(w), jc.ErrorIsNil)
+	facade.stub.CheckCallNames(c, "AutoUpgradeCharms", "AutoUpgradeCharms")
+}
+
+func (s *WorkerSuite) TestUpgradeError(c *gc.C) {
+	facade := newMockFacade()
+	facade.stub.SetErrors(errors.New("no upgrades for you"))
+	clock := testing.NewClock(time.Now())
+	w, err := charmautoupgrade.NewWorker(charmautoupgrade.Config{
+		Facade: facade,
+		Clock:  clock,
+		Period: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	advance(c, clock, time.Minute)
+	facade.waitCall(c)
+	c.Check(w.Wait(), gc.ErrorMatches, "no upgrades for you")
+}
+
+// advance waits for the worker to start waiting on the clock, and then
+// advances it by d.
+func advance(c *gc.C, clock *testing.Clock, d time.Duration) {
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for clock alarm")
+	}
+	clock.Advance(d)
+}
+
+type mockFacade struct {
+	stub  *testing.Stub
+	calls chan struct{}
+}
+
+func newMockFacade() mockFacade {
+	return mockFacade{
+		stub:  &testing.Stub{},
+		calls: make(chan struct{}, 1000),
+	}
+}
+
+func (mock mockFacade) AutoUpgradeCharms() error {
+	mock.stub.AddCall("AutoUpgradeCharms")
+	mock.calls <- struct{}{}
+	return mock.stub.NextErr()
+}
+
+func (mock mockFacade) waitCall(c *gc.C) {
+	select {
+	case <-mock.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out")
+	}
+}
+
+func (mock mockFacade) waitNoCall(c *gc.C) {
+	select {
+	case <-mock.calls:
+		c.Fatalf("unexpected AutoUpgradeCharms call")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/charmrollout"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := charmrollout.Manifold(charmrollout.ManifoldConfig{
+		APICallerName: "washington the terrible",
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"washington the terrible"})
+}
+
+func (s *ManifoldSuite) TestOutput(c *gc.C) {
+	manifold := charmrollout.Manifold(charmrollout.ManifoldConfig{})
+	c.Check(manifold.Output, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := charmrollout.Manifold(charmrollout.ManifoldConfig{
+		APICallerName: "api-caller",
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartFacadeError(c *gc.C) {
+	expectCaller := &fakeCaller{}
+	manifold := charmrollout.Manifold(charmrollout.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade: func(apiCaller base.APICaller) (charmrollout.Facade, error) {
+			c.Check(apiCaller, gc.Equals, expectCaller)
+			return nil, errors.New("blort")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": expectCaller,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "blort")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartWorkerError(c *gc.C) {
+	expectFacade := &fakeFacade{}
+	manifold := charmrollout.Manifold(charmrollout.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade: func(_ base.APICaller) (charmrollout.Facade, error) {
+			return expectFacade, nil
+		},
+		NewWorker: func(config charmrollout.Config) (worker.Worker, error) {
+			c.Check(config.Validate(), jc.ErrorIsNil)
+			c.Check(config.Facade, gc.Equals, expectFacade)
+			return nil, errors.New("splot")
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "splot")
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestSuccess(c *gc.C) {
+	expectWorker := &fakeWorker{}
+	manifold := charmrollout.Manifold(charmrollout.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade: func(_ base.APICaller) (charmrollout.Facade, error) {
+			return &fakeFacade{}, nil
+		},
+		NewWorker: func(_ charmrollout.Config) (worker.Worker, error) {
+			return expectWorker, nil
+		},
+	})
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": &fakeCaller{},
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeFacade struct {
+	charmrollout.Facade
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
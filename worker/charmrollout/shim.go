@@ -0,0 +1,19 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrollout
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/charmrollouts"
+	"github.com/juju/juju/api/watcher"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return charmrollouts.NewAPI(
+		apiCaller,
+		watcher.NewStringsWatcher,
+	), nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrollout provides a model worker that advances rolling
+// charm upgrades, letting the next batch of units upgrade once the
+// current batch has completed.
+package charmrollout
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// Watch returns a StringsWatcher reporting names of
+	// applications whose rolling charm upgrade may advance.
+	Watch() (watcher.StringsWatcher, error)
+
+	// Advance advances the rolling charm upgrades of the
+	// named applications.
+	Advance(applications []string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	return nil
+}
+
+// New returns a worker that will advance the rolling charm
+// upgrades of applications as their units complete the upgrade.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	swConfig := watcher.StringsConfig{
+		Handler: &handler{config},
+	}
+	return watcher.NewStringsWorker(swConfig)
+}
+
+// handler implements watcher.StringsHandler, backed by the
+// configured facade.
+type handler struct {
+	config Config
+}
+
+// SetUp is part of the watcher.StringsHandler interface.
+func (handler *handler) SetUp() (watcher.StringsWatcher, error) {
+	return handler.config.Facade.Watch()
+}
+
+// Handle is part of the watcher.StringsHandler interface.
+func (handler *handler) Handle(_ <-chan struct{}, applications []string) error {
+	return handler.config.Facade.Advance(applications)
+}
+
+// TearDown is part of the watcher.StringsHandler interface.
+func (handler *handler) TearDown() error {
+	return nil
+}
@@ -228,5 +228,8 @@ EOF`)
 	}, {
 		DeviceName: "loop0",
 		Size:       243,
+	}, {
+		DeviceName: "whatever",
+		Size:       243,
 	}})
 }
@@ -39,7 +39,7 @@ type Firewaller struct {
 	applicationids  map[names.ApplicationTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalPortRef   map[ingressKey]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -89,7 +89,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalPortRef = make(map[ingressKey]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -182,6 +182,8 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.ingressCIDRs = change.ingressCIDRs
+			change.serviced.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -200,8 +202,8 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
-		definedPorts: make(map[network.PortRange]names.UnitTag),
+		ingressRules: make([]network.IngressRule, 0),
+		definedPorts: make(map[network.PortRange]firewaller.PortRangeOwner),
 	}
 	m, err := machined.machine()
 	if params.IsCodeNotFound(err) {
@@ -313,16 +315,26 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	ingressCIDRs, err := service.ExposedIngressCIDRs()
+	if err != nil {
+		return err
+	}
+	exposedEndpoints, err := service.ExposedEndpoints()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      service,
+		exposed:          exposed,
+		ingressCIDRs:     ingressCIDRs,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, ingressCIDRs, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -339,43 +351,45 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	environFirewaller := environIngressFirewaller(fw.environ)
+	initialRules, err := environFirewaller.IngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	collector := make(map[ingressKey]bool)
 	for _, machined := range fw.machineds {
-		for portRange, unitTag := range machined.definedPorts {
-			unitd, known := machined.unitds[unitTag]
+		for portRange, owner := range machined.definedPorts {
+			unitd, known := machined.unitds[owner.UnitTag]
 			if !known {
-				delete(machined.unitds, unitTag)
+				delete(machined.unitds, owner.UnitTag)
 				continue
 			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
+			if unitd.serviced.exposesEndpoints(owner.Endpoints) {
+				for _, key := range unitd.serviced.ingressKeys(portRange) {
+					collector[key] = true
+				}
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedKeys := []ingressKey{}
+	for key := range collector {
+		wantedKeys = append(wantedKeys, key)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	wantedRules := ingressRulesFromKeys(wantedKeys)
+	// Check which ingress rules to open or to close.
+	toOpen := diffIngressRules(wantedRules, initialRules)
+	toClose := diffIngressRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := environFirewaller.OpenIngressRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := environFirewaller.CloseIngressRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
 	}
 	return nil
 }
@@ -411,31 +425,30 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		instanceFirewaller := instanceIngressFirewaller(instances[0])
+		initialRules, err := instanceFirewaller.IngressRules(machineId)
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		// Check which ingress rules to open or to close.
+		toOpen := diffIngressRules(machined.ingressRules, initialRules)
+		toClose := diffIngressRules(initialRules, machined.ingressRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := instanceFirewaller.OpenIngressRules(machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := instanceFirewaller.CloseIngressRules(machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
 		}
 	}
 	return nil
@@ -499,22 +512,21 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortRangeOwners(subnetTag)
 	if err != nil {
 		return err
 	}
 
-	newPortRanges := make(map[network.PortRange]names.UnitTag)
-	for portRange, unitTag := range ports {
-		unitd, ok := machined.unitds[unitTag]
-		if !ok {
+	newPortRanges := make(map[network.PortRange]firewaller.PortRangeOwner)
+	for portRange, owner := range ports {
+		if _, ok := machined.unitds[owner.UnitTag]; !ok {
 			// It is common to receive port change notification before
 			// registering a unit. Skip handling the port change - it will
 			// be handled when the unit is registered.
-			logger.Errorf("failed to lookup %q, skipping port change", unitTag)
+			logger.Errorf("failed to lookup %q, skipping port change", owner.UnitTag)
 			return nil
 		}
-		newPortRanges[portRange] = unitd.tag
+		newPortRanges[portRange] = owner
 	}
 
 	if !portMapsEqual(machined.definedPorts, newPortRanges) {
@@ -524,7 +536,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	return nil
 }
 
-func portMapsEqual(a, b map[network.PortRange]names.UnitTag) bool {
+func portMapsEqual(a, b map[network.PortRange]firewaller.PortRangeOwner) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -533,7 +545,10 @@ func portMapsEqual(a, b map[network.PortRange]names.UnitTag) bool {
 		if !exists {
 			return false
 		}
-		if valueA != valueB {
+		if valueA.UnitTag != valueB.UnitTag {
+			return false
+		}
+		if strings.Join(valueA.Endpoints, ",") != strings.Join(valueB.Endpoints, ",") {
 			return false
 		}
 	}
@@ -556,68 +571,71 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
-	for portRange, unitTag := range machined.definedPorts {
-		unitd, known := machined.unitds[unitTag]
+	// Gather ingress rules to open and close.
+	var wantKeys []ingressKey
+	for portRange, owner := range machined.definedPorts {
+		unitd, known := machined.unitds[owner.UnitTag]
 		if !known {
-			delete(machined.unitds, unitTag)
+			delete(machined.unitds, owner.UnitTag)
 			continue
 		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
+		if unitd.serviced.exposesEndpoints(owner.Endpoints) {
+			wantKeys = append(wantKeys, unitd.serviced.ingressKeys(portRange)...)
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	want := ingressRulesFromKeys(wantKeys)
+	toOpen := diffIngressRules(want, machined.ingressRules)
+	toClose := diffIngressRules(machined.ingressRules, want)
+	machined.ingressRules = want
 	if fw.globalMode {
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
 	return fw.flushInstancePorts(machined, toOpen, toClose)
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushGlobalPorts opens and closes global ingress rules in the
+// environment. It keeps a reference count for each port range and
+// source CIDR so that only 0-to-1 and 1-to-0 events modify the
+// environment.
+func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which ingress rules are really to open or close.
+	var openKeys, closeKeys []ingressKey
+	for _, key := range ingressKeys(rawOpen) {
+		if fw.globalPortRef[key] == 0 {
+			openKeys = append(openKeys, key)
 		}
-		fw.globalPortRef[portRange]++
+		fw.globalPortRef[key]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	for _, key := range ingressKeys(rawClose) {
+		fw.globalPortRef[key]--
+		if fw.globalPortRef[key] == 0 {
+			closeKeys = append(closeKeys, key)
+			delete(fw.globalPortRef, key)
 		}
 	}
-	// Open and close the ports.
+	toOpen := ingressRulesFromKeys(openKeys)
+	toClose := ingressRulesFromKeys(closeKeys)
+	// Open and close the ingress rules.
+	environFirewaller := environIngressFirewaller(fw.environ)
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := environFirewaller.OpenIngressRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		if err := environFirewaller.CloseIngressRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstancePorts opens and closes ingress rules global on the machine.
+func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,22 +659,21 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	// Open and close the ingress rules.
+	instanceFirewaller := instanceIngressFirewaller(instances[0])
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := instanceFirewaller.OpenIngressRules(machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := instanceFirewaller.CloseIngressRules(machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
@@ -751,13 +768,13 @@ type unitsChange struct {
 
 // machineData holds machine details and watches units added or removed.
 type machineData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	tag         names.MachineTag
-	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	tag          names.MachineTag
+	unitds       map[names.UnitTag]*unitData
+	ingressRules []network.IngressRule
 	// ports defined by units on this machine
-	definedPorts map[network.PortRange]names.UnitTag
+	definedPorts map[network.PortRange]firewaller.PortRangeOwner
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -805,23 +822,59 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag, ingress CIDRs
+// and exposed endpoints for one specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced         *serviceData
+	exposed          bool
+	ingressCIDRs     []string
+	exposedEndpoints []string
 }
 
 // serviceData holds service details and watches exposure changes.
 type serviceData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	ingressCIDRs     []string
+	exposedEndpoints []string
+	unitds           map[names.UnitTag]*unitData
+}
+
+// exposesEndpoints reports whether the service exposes the port ranges
+// opened for the given endpoints. Empty endpoints mean the ranges were
+// opened for all endpoints.
+func (sd *serviceData) exposesEndpoints(endpoints []string) bool {
+	if !sd.exposed {
+		return false
+	}
+	if len(sd.exposedEndpoints) == 0 || len(endpoints) == 0 {
+		return true
+	}
+	for _, endpoint := range endpoints {
+		for _, exposedEndpoint := range sd.exposedEndpoints {
+			if endpoint == exposedEndpoint {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ingressKeys returns the ingress keys for the given port range
+// when opened for the service.
+func (sd *serviceData) ingressKeys(portRange network.PortRange) []ingressKey {
+	keys := make([]ingressKey, len(sd.ingressCIDRs))
+	for i, cidr := range sd.ingressCIDRs {
+		keys[i] = ingressKey{portRange, cidr}
+	}
+	return keys
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag, ingress CIDRs and
+// exposed endpoints for changes.
+func (sd *serviceData) watchLoop(exposed bool, ingressCIDRs, exposedEndpoints []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +900,27 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			cidrsChange, err := sd.application.ExposedIngressCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			endpointsChange, err := sd.application.ExposedEndpoints()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			// The CIDRs are returned sorted, so we
+			// can compare them directly.
+			sameCIDRs := strings.Join(cidrsChange, ",") == strings.Join(ingressCIDRs, ",")
+			sameEndpoints := strings.Join(endpointsChange, ",") == strings.Join(exposedEndpoints, ",")
+			if change == exposed && sameCIDRs && sameEndpoints {
 				continue
 			}
 
 			exposed = change
+			ingressCIDRs = cidrsChange
+			exposedEndpoints = endpointsChange
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, cidrsChange, endpointsChange}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -871,20 +938,6 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
-next:
-	for _, a := range A {
-		for _, b := range B {
-			if a == b {
-				continue next
-			}
-		}
-		missing = append(missing, a)
-	}
-	return
-}
-
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
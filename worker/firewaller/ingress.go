@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// ingressKey identifies a single port range opened to a single
+// source CIDR. The firewaller compares and reference counts ingress
+// rules at this granularity, so that narrowing the sources of a rule
+// closes only the sources that are no longer wanted.
+type ingressKey struct {
+	portRange network.PortRange
+	cidr      string
+}
+
+// ingressKeys splits the given rules into ingress keys.
+func ingressKeys(rules []network.IngressRule) []ingressKey {
+	var keys []ingressKey
+	for _, rule := range rules {
+		cidrs := rule.SourceCIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{network.OpenIngressCIDR}
+		}
+		for _, cidr := range cidrs {
+			keys = append(keys, ingressKey{rule.PortRange, cidr})
+		}
+	}
+	return keys
+}
+
+// ingressRulesFromKeys combines the given ingress keys into rules,
+// one per port range, sorted by network.SortIngressRules.
+func ingressRulesFromKeys(keys []ingressKey) []network.IngressRule {
+	if len(keys) == 0 {
+		return nil
+	}
+	var portRanges []network.PortRange
+	cidrs := make(map[network.PortRange][]string)
+	for _, key := range keys {
+		if _, ok := cidrs[key.portRange]; !ok {
+			portRanges = append(portRanges, key.portRange)
+		}
+		cidrs[key.portRange] = append(cidrs[key.portRange], key.cidr)
+	}
+	rules := make([]network.IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = network.MustNewIngressRule(portRange, cidrs[portRange]...)
+	}
+	network.SortIngressRules(rules)
+	return rules
+}
+
+// diffIngressRules returns the ingress rules allowing access that
+// is allowed by A but not by B.
+func diffIngressRules(A, B []network.IngressRule) []network.IngressRule {
+	existing := make(map[ingressKey]bool)
+	for _, key := range ingressKeys(B) {
+		existing[key] = true
+	}
+	var missing []ingressKey
+	for _, key := range ingressKeys(A) {
+		if !existing[key] {
+			missing = append(missing, key)
+		}
+	}
+	return ingressRulesFromKeys(missing)
+}
+
+// environIngressFirewaller returns an environs.IngressFirewaller for
+// the given environ. If the environ does not support ingress rules,
+// ingress rules are translated to port ranges, and rules restricted
+// to specific source CIDRs are not opened.
+func environIngressFirewaller(env environs.Firewaller) environs.IngressFirewaller {
+	if fw, ok := env.(environs.IngressFirewaller); ok {
+		return fw
+	}
+	return portsEnvironFirewaller{env}
+}
+
+// instanceIngressFirewaller returns an instance.InstanceIngressFirewaller
+// for the given instance. If the instance does not support ingress
+// rules, ingress rules are translated to port ranges, and rules
+// restricted to specific source CIDRs are not opened.
+func instanceIngressFirewaller(inst instance.Instance) instance.InstanceIngressFirewaller {
+	if fw, ok := inst.(instance.InstanceIngressFirewaller); ok {
+		return fw
+	}
+	return portsInstanceFirewaller{inst}
+}
+
+type portsEnvironFirewaller struct {
+	environs.Firewaller
+}
+
+func (fw portsEnvironFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	if portRanges := openPortRanges(rules); len(portRanges) > 0 {
+		return fw.OpenPorts(portRanges)
+	}
+	return nil
+}
+
+func (fw portsEnvironFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	if portRanges := openPortRanges(rules); len(portRanges) > 0 {
+		return fw.ClosePorts(portRanges)
+	}
+	return nil
+}
+
+func (fw portsEnvironFirewaller) IngressRules() ([]network.IngressRule, error) {
+	portRanges, err := fw.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.NewOpenIngressRules(portRanges), nil
+}
+
+type portsInstanceFirewaller struct {
+	instance.Instance
+}
+
+func (fw portsInstanceFirewaller) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if portRanges := openPortRanges(rules); len(portRanges) > 0 {
+		return fw.OpenPorts(machineId, portRanges)
+	}
+	return nil
+}
+
+func (fw portsInstanceFirewaller) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if portRanges := openPortRanges(rules); len(portRanges) > 0 {
+		return fw.ClosePorts(machineId, portRanges)
+	}
+	return nil
+}
+
+func (fw portsInstanceFirewaller) IngressRules(machineId string) ([]network.IngressRule, error) {
+	portRanges, err := fw.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.NewOpenIngressRules(portRanges), nil
+}
+
+// openPortRanges returns the port ranges of the rules that allow
+// access from any source. Rules restricted to specific source CIDRs
+// cannot be expressed as port ranges; rather than opening them to
+// all sources, they are logged and skipped.
+func openPortRanges(rules []network.IngressRule) []network.PortRange {
+	var portRanges []network.PortRange
+	for _, rule := range rules {
+		if !rule.IsOpen() {
+			logger.Warningf(
+				"not opening %v: provider does not support restricting ingress to source CIDRs",
+				rule,
+			)
+			continue
+		}
+		portRanges = append(portRanges, rule.PortRange)
+	}
+	return portRanges
+}
@@ -42,6 +42,7 @@ var _ = gc.Suite(&Suite{})
 
 var (
 	fakeModelBytes      = []byte("model")
+	fakeSecretKey       = "secret-key"
 	targetControllerTag = names.NewControllerTag("controller-uuid")
 	modelUUID           = "model-uuid"
 	modelTag            = names.NewModelTag(modelUUID)
@@ -78,7 +79,7 @@ var (
 	importCall = jujutesting.StubCall{
 		"MigrationTarget.Import",
 		[]interface{}{
-			params.SerializedModel{Bytes: fakeModelBytes},
+			params.SerializedModel{Bytes: fakeModelBytes, SecretKey: fakeSecretKey},
 		},
 	}
 	activateCall = jujutesting.StubCall{
@@ -888,6 +889,7 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 		Tools: map[version.Binary]string{
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
 		},
+		SecretKey: fakeSecretKey,
 	}, nil
 }
 
@@ -17,14 +17,15 @@ type apiHostPortsSetter interface {
 }
 
 type publisher struct {
-	st apiHostPortsSetter
+	st   apiHostPortsSetter
+	pref network.AddressPreference
 
 	mu             sync.Mutex
 	lastAPIServers [][]network.HostPort
 }
 
-func newPublisher(st apiHostPortsSetter) *publisher {
-	return &publisher{st: st}
+func newPublisher(st apiHostPortsSetter, pref network.AddressPreference) *publisher {
+	return &publisher{st: st, pref: pref}
 }
 
 func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanceIds []instance.Id) error {
@@ -37,7 +38,7 @@ func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanc
 	sortedAPIServers := make([][]network.HostPort, len(apiServers))
 	for i, hostPorts := range apiServers {
 		sortedAPIServers[i] = append([]network.HostPort{}, hostPorts...)
-		network.SortHostPorts(sortedAPIServers[i])
+		network.SortHostPortsWithPreference(sortedAPIServers[i], pub.pref)
 	}
 	if apiServersEqual(sortedAPIServers, pub.lastAPIServers) {
 		logger.Debugf("API host ports have not changed")
@@ -30,7 +30,7 @@ func (s *mockAPIHostPortsSetter) SetAPIHostPorts(apiHostPorts [][]network.HostPo
 
 func (s *publishSuite) TestPublisherSetsAPIHostPortsOnce(c *gc.C) {
 	var mock mockAPIHostPortsSetter
-	statePublish := newPublisher(&mock)
+	statePublish := newPublisher(&mock, network.PreferIPv4)
 
 	hostPorts1 := network.NewHostPorts(1234, "testing1.invalid", "127.0.0.1")
 	hostPorts2 := network.NewHostPorts(1234, "testing2.invalid", "127.0.0.2")
@@ -60,7 +60,7 @@ func (s *publishSuite) TestPublisherSortsHostPorts(c *gc.C) {
 
 	check := func(publish, expect []network.HostPort) {
 		var mock mockAPIHostPortsSetter
-		statePublish := newPublisher(&mock)
+		statePublish := newPublisher(&mock, network.PreferIPv4)
 		for i := 0; i < 2; i++ {
 			err := statePublish.publishAPIServers([][]network.HostPort{publish}, nil)
 			c.Assert(err, jc.ErrorIsNil)
@@ -73,9 +73,20 @@ func (s *publishSuite) TestPublisherSortsHostPorts(c *gc.C) {
 	check(ipV4First, ipV4First)
 }
 
+func (s *publishSuite) TestPublisherSortsHostPortsPreferringIPv6(c *gc.C) {
+	ipV4First := network.NewHostPorts(1234, "testing1.invalid", "127.0.0.1", "::1")
+	ipV6First := network.NewHostPorts(1234, "testing1.invalid", "::1", "127.0.0.1")
+
+	var mock mockAPIHostPortsSetter
+	statePublish := newPublisher(&mock, network.PreferIPv6)
+	err := statePublish.publishAPIServers([][]network.HostPort{ipV4First}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.apiHostPorts, gc.DeepEquals, [][]network.HostPort{ipV6First})
+}
+
 func (s *publishSuite) TestPublisherRejectsNoServers(c *gc.C) {
 	var mock mockAPIHostPortsSetter
-	statePublish := newPublisher(&mock)
+	statePublish := newPublisher(&mock, network.PreferIPv4)
 	err := statePublish.PublishAPIServers(nil, nil)
 	c.Assert(err, gc.ErrorMatches, "no api servers specified")
 }
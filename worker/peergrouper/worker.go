@@ -110,12 +110,16 @@ func New(st *state.State, supportsSpaces bool) (worker.Worker, error) {
 	if err != nil {
 		return nil, err
 	}
+	modelCfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, err
+	}
 	shim := &stateShim{
 		State:     st,
 		mongoPort: cfg.StatePort(),
 		apiPort:   cfg.APIPort(),
 	}
-	return newWorker(shim, newPublisher(st), supportsSpaces)
+	return newWorker(shim, newPublisher(st, modelCfg.AddressPreference()), supportsSpaces)
 }
 
 func newWorker(st stateInterface, pub publisherInterface, supportsSpaces bool) (worker.Worker, error) {
@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"sync"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/workertest"
+)
+
+// mockFacade implements remoterelations.Facade, recording calls and
+// supplying canned results.
+type mockFacade struct {
+	mu   sync.Mutex
+	stub *testing.Stub
+
+	applications map[string]params.RemoteApplicationResult
+	relations    map[string]params.RemoteRelationResult
+
+	applicationsWatcher *mockStringsWatcher
+	relationsWatchers   map[string]*mockStringsWatcher
+	unitsWatchers       map[string]*mockRelationUnitsWatcher
+
+	published chan params.RemoteRelationChange
+	destroyed chan params.RemoteRelationArg
+}
+
+func newMockFacade(stub *testing.Stub) *mockFacade {
+	return &mockFacade{
+		stub:                stub,
+		applications:        make(map[string]params.RemoteApplicationResult),
+		relations:           make(map[string]params.RemoteRelationResult),
+		applicationsWatcher: newMockStringsWatcher(),
+		relationsWatchers:   make(map[string]*mockStringsWatcher),
+		unitsWatchers:       make(map[string]*mockRelationUnitsWatcher),
+		published:           make(chan params.RemoteRelationChange, 10),
+		destroyed:           make(chan params.RemoteRelationArg, 10),
+	}
+}
+
+func (f *mockFacade) relationsWatcher(application string) *mockStringsWatcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, ok := f.relationsWatchers[application]
+	if !ok {
+		w = newMockStringsWatcher()
+		f.relationsWatchers[application] = w
+	}
+	return w
+}
+
+func (f *mockFacade) unitsWatcher(modelUUID, key string) *mockRelationUnitsWatcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, ok := f.unitsWatchers[modelUUID+":"+key]
+	if !ok {
+		w = newMockRelationUnitsWatcher()
+		f.unitsWatchers[modelUUID+":"+key] = w
+	}
+	return w
+}
+
+func (f *mockFacade) WatchRemoteApplications() (watcher.StringsWatcher, error) {
+	f.stub.AddCall("WatchRemoteApplications")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.applicationsWatcher, nil
+}
+
+func (f *mockFacade) RemoteApplications(names []string) ([]params.RemoteApplicationResult, error) {
+	f.stub.AddCall("RemoteApplications", names)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make([]params.RemoteApplicationResult, len(names))
+	for i, name := range names {
+		result, ok := f.applications[name]
+		if !ok {
+			result.Error = &params.Error{Code: params.CodeNotFound}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (f *mockFacade) WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error) {
+	f.stub.AddCall("WatchRemoteApplicationRelations", application)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.relationsWatcher(application), nil
+}
+
+func (f *mockFacade) ExportRelations(keys []string) ([]params.RemoteRelationResult, error) {
+	f.stub.AddCall("ExportRelations", keys)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make([]params.RemoteRelationResult, len(keys))
+	for i, key := range keys {
+		result, ok := f.relations[key]
+		if !ok {
+			result.Error = &params.Error{Code: params.CodeNotFound}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (f *mockFacade) DestroyRelation(modelUUID, key string) error {
+	f.stub.AddCall("DestroyRelation", modelUUID, key)
+	if err := f.stub.NextErr(); err != nil {
+		return err
+	}
+	f.destroyed <- params.RemoteRelationArg{ModelUUID: modelUUID, RelationKey: key}
+	return nil
+}
+
+func (f *mockFacade) WatchRelationUnits(arg params.RemoteRelationUnitsArg) (watcher.RelationUnitsWatcher, error) {
+	f.stub.AddCall("WatchRelationUnits", arg)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.unitsWatcher(arg.ModelUUID, arg.RelationKey), nil
+}
+
+func (f *mockFacade) PublishRelationChange(change params.RemoteRelationChange) error {
+	f.stub.AddCall("PublishRelationChange", change)
+	if err := f.stub.NextErr(); err != nil {
+		return err
+	}
+	f.published <- change
+	return nil
+}
+
+// mockStringsWatcher delivers changes over an unbuffered channel, so
+// that a test sending a change knows the previous one was handled.
+type mockStringsWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func newMockStringsWatcher() *mockStringsWatcher {
+	return &mockStringsWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan []string),
+	}
+}
+
+// Changes is part of the watcher.StringsWatcher interface.
+func (w *mockStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+type mockRelationUnitsWatcher struct {
+	worker.Worker
+	changes chan watcher.RelationUnitsChange
+}
+
+func newMockRelationUnitsWatcher() *mockRelationUnitsWatcher {
+	return &mockRelationUnitsWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan watcher.RelationUnitsChange, 5),
+	}
+}
+
+// Changes is part of the watcher.RelationUnitsWatcher interface.
+func (w *mockRelationUnitsWatcher) Changes() watcher.RelationUnitsChannel {
+	return w.changes
+}
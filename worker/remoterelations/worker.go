@@ -0,0 +1,399 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides a worker that mirrors the relations
+// between local applications and consumed remote applications into the
+// models offering the remote applications, and exchanges the units and
+// settings of both sides, so that the units of each see the units of
+// the other as normal relation participants.
+package remoterelations
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoterelations")
+
+// Facade exposes the remote relations functionality of the API
+// needed by the worker.
+type Facade interface {
+	// WatchRemoteApplications returns a watcher notifying of changes
+	// to the remote applications of the model.
+	WatchRemoteApplications() (watcher.StringsWatcher, error)
+
+	// RemoteApplications returns the details of the named remote
+	// applications.
+	RemoteApplications(names []string) ([]params.RemoteApplicationResult, error)
+
+	// WatchRemoteApplicationRelations returns a watcher notifying of
+	// changes to the relations of the named remote application.
+	WatchRemoteApplicationRelations(application string) (watcher.StringsWatcher, error)
+
+	// ExportRelations ensures that the relations with the given keys
+	// have counterparts in the models offering their remote
+	// applications, and returns the details of both.
+	ExportRelations(keys []string) ([]params.RemoteRelationResult, error)
+
+	// DestroyRelation destroys the relation with the given key in
+	// the model offering an application consumed by the model.
+	DestroyRelation(modelUUID, key string) error
+
+	// WatchRelationUnits returns a watcher notifying of changes to
+	// the units of an application in a relation.
+	WatchRelationUnits(arg params.RemoteRelationUnitsArg) (watcher.RelationUnitsWatcher, error)
+
+	// PublishRelationChange mirrors units of a relation, with their
+	// settings, as remote units of its counterpart in another model.
+	PublishRelationChange(change params.RemoteRelationChange) error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	ModelUUID string
+	Facade    Facade
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.ModelUUID == "" {
+		return errors.NotValidf("empty ModelUUID")
+	}
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:       config,
+		applications: make(map[string]worker.Worker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker runs a worker for each remote application consumed by the
+// model, which in turn runs a worker for each of its relations.
+type Worker struct {
+	catacomb     catacomb.Catacomb
+	config       Config
+	applications map[string]worker.Worker
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	appsWatcher, err := w.config.Facade.WatchRemoteApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(appsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case names, ok := <-appsWatcher.Changes():
+			if !ok {
+				return errors.New("remote applications watcher closed")
+			}
+			if err := w.handleApplicationChanges(names); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *Worker) handleApplicationChanges(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	results, err := w.config.Facade.RemoteApplications(names)
+	if err != nil {
+		return errors.Annotate(err, "querying remote applications")
+	}
+	for i, result := range results {
+		name := names[i]
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				w.stopApplication(name)
+				continue
+			}
+			return errors.Annotatef(result.Error, "querying remote application %q", name)
+		}
+		if result.Result.IsConsumerProxy {
+			// Proxies for the consumers of the model's offers are
+			// managed by the consuming models.
+			continue
+		}
+		if _, ok := w.applications[name]; ok {
+			continue
+		}
+		logger.Debugf("starting worker for remote application %q", name)
+		appWorker, err := newRemoteApplicationWorker(name, w.config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(appWorker); err != nil {
+			return errors.Trace(err)
+		}
+		w.applications[name] = appWorker
+	}
+	return nil
+}
+
+func (w *Worker) stopApplication(name string) {
+	if appWorker, ok := w.applications[name]; ok {
+		logger.Debugf("stopping worker for remote application %q", name)
+		appWorker.Kill()
+		delete(w.applications, name)
+	}
+}
+
+// remoteApplicationWorker exports the relations of a remote
+// application, and runs a worker for each of them.
+type remoteApplicationWorker struct {
+	catacomb  catacomb.Catacomb
+	name      string
+	config    Config
+	relations map[string]worker.Worker
+}
+
+func newRemoteApplicationWorker(name string, config Config) (worker.Worker, error) {
+	w := &remoteApplicationWorker{
+		name:      name,
+		config:    config,
+		relations: make(map[string]worker.Worker),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *remoteApplicationWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *remoteApplicationWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *remoteApplicationWorker) loop() error {
+	relationsWatcher, err := w.config.Facade.WatchRemoteApplicationRelations(w.name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(relationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case keys, ok := <-relationsWatcher.Changes():
+			if !ok {
+				return errors.Errorf("relations watcher for remote application %q closed", w.name)
+			}
+			if err := w.handleRelationChanges(keys); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *remoteApplicationWorker) handleRelationChanges(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	results, err := w.config.Facade.ExportRelations(keys)
+	if err != nil {
+		return errors.Annotatef(err, "exporting relations of remote application %q", w.name)
+	}
+	for i, result := range results {
+		key := keys[i]
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				w.stopRelation(key)
+				continue
+			}
+			return errors.Annotatef(result.Error, "exporting relation %q", key)
+		}
+		rel := result.Result
+		if rel.OfferingRelationKey == "" {
+			// The relation is going away, and has no counterpart.
+			continue
+		}
+		if rel.Life != string(params.Alive) {
+			// Destroying the counterpart makes the offered units
+			// depart, which in turn lets this relation be removed.
+			logger.Debugf("destroying counterpart %q of relation %q", rel.OfferingRelationKey, key)
+			if err := w.config.Facade.DestroyRelation(rel.OfferingModelUUID, rel.OfferingRelationKey); err != nil {
+				return errors.Annotatef(err, "destroying counterpart of relation %q", key)
+			}
+		}
+		if _, ok := w.relations[key]; ok {
+			continue
+		}
+		logger.Debugf("starting worker for relation %q", key)
+		relWorker, err := newRelationWorker(*rel, w.config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(relWorker); err != nil {
+			return errors.Trace(err)
+		}
+		w.relations[key] = relWorker
+	}
+	return nil
+}
+
+func (w *remoteApplicationWorker) stopRelation(key string) {
+	if relWorker, ok := w.relations[key]; ok {
+		logger.Debugf("stopping worker for relation %q", key)
+		relWorker.Kill()
+		delete(w.relations, key)
+	}
+}
+
+// relationWorker publishes the changes to the units of each side of a
+// relation with a remote application to the other side.
+type relationWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	relation params.RemoteRelation
+}
+
+func newRelationWorker(relation params.RemoteRelation, config Config) (worker.Worker, error) {
+	w := &relationWorker{
+		config:   config,
+		relation: relation,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *relationWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *relationWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *relationWorker) loop() error {
+	local := params.RemoteRelationUnitsArg{
+		ModelUUID:       w.config.ModelUUID,
+		RelationKey:     w.relation.Key,
+		ApplicationName: w.relation.ApplicationName,
+	}
+	offering := params.RemoteRelationUnitsArg{
+		ModelUUID:       w.relation.OfferingModelUUID,
+		RelationKey:     w.relation.OfferingRelationKey,
+		ApplicationName: w.relation.OfferingApplicationName,
+	}
+	localWatcher, err := w.watchUnits(local)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offeringWatcher, err := w.watchUnits(offering)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case change, ok := <-localWatcher.Changes():
+			if !ok {
+				return errors.Errorf("units watcher for relation %q closed", local.RelationKey)
+			}
+			if err := w.publish(local, offering, change); err != nil {
+				return errors.Trace(err)
+			}
+		case change, ok := <-offeringWatcher.Changes():
+			if !ok {
+				return errors.Errorf("units watcher for relation %q closed", offering.RelationKey)
+			}
+			if err := w.publish(offering, local, change); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *relationWorker) watchUnits(arg params.RemoteRelationUnitsArg) (watcher.RelationUnitsWatcher, error) {
+	unitsWatcher, err := w.config.Facade.WatchRelationUnits(arg)
+	if err != nil {
+		return nil, errors.Annotatef(err, "watching units of %q in relation %q", arg.ApplicationName, arg.RelationKey)
+	}
+	if err := w.catacomb.Add(unitsWatcher); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unitsWatcher, nil
+}
+
+// publish mirrors the change to the source units as remote units of the
+// target relation.
+func (w *relationWorker) publish(source, target params.RemoteRelationUnitsArg, change watcher.RelationUnitsChange) error {
+	if len(change.Changed) == 0 && len(change.Departed) == 0 {
+		return nil
+	}
+	changed := make([]string, 0, len(change.Changed))
+	for unitName := range change.Changed {
+		changed = append(changed, unitName)
+	}
+	sort.Strings(changed)
+	logger.Debugf("publishing units %v changed and %v departed in relation %q to %q", changed, change.Departed, source.RelationKey, target.RelationKey)
+	err := w.config.Facade.PublishRelationChange(params.RemoteRelationChange{
+		Source:            source,
+		TargetModelUUID:   target.ModelUUID,
+		TargetRelationKey: target.RelationKey,
+		ChangedUnits:      changed,
+		DepartedUnits:     change.Departed,
+	})
+	return errors.Annotatef(err, "publishing changes to relation %q", target.RelationKey)
+}
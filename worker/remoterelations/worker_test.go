@@ -0,0 +1,193 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/workertest"
+)
+
+const (
+	consumingModelUUID = "consuming-model-uuid"
+	offeringModelUUID  = "offering-model-uuid"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.facade = newMockFacade(s.stub)
+	s.facade.applications["mysql"] = params.RemoteApplicationResult{
+		Result: &params.RemoteApplication{
+			Name:            "mysql",
+			Life:            params.Alive,
+			OfferURL:        "prod.hosted-mysql",
+			SourceModelUUID: offeringModelUUID,
+		},
+	}
+	s.facade.relations["wordpress:db mysql:server"] = s.relationResult(params.Alive)
+}
+
+func (s *WorkerSuite) relationResult(life params.Life) params.RemoteRelationResult {
+	return params.RemoteRelationResult{
+		Result: &params.RemoteRelation{
+			Key:                     "wordpress:db mysql:server",
+			Life:                    string(life),
+			ApplicationName:         "wordpress",
+			RemoteApplicationName:   "mysql",
+			OfferingModelUUID:       offeringModelUUID,
+			OfferingRelationKey:     "remote-0123:db mysql:server",
+			OfferingApplicationName: "mysql",
+		},
+	}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := remoterelations.New(remoterelations.Config{
+		ModelUUID: consumingModelUUID,
+		Facade:    s.facade,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := remoterelations.Config{Facade: s.facade}
+	err := config.Validate()
+	c.Check(err, gc.ErrorMatches, "empty ModelUUID not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	config = remoterelations.Config{ModelUUID: consumingModelUUID}
+	err = config.Validate()
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	w, err := remoterelations.New(config)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(w, gc.IsNil)
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.stub.SetErrors(errors.New("zap ouch"))
+	w := s.newWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "zap ouch")
+	s.stub.CheckCallNames(c, "WatchRemoteApplications")
+}
+
+func (s *WorkerSuite) TestPublishesChangesBothWays(c *gc.C) {
+	w := s.newWorker(c)
+	s.facade.applicationsWatcher.changes <- []string{"mysql"}
+	s.facade.relationsWatcher("mysql").changes <- []string{"wordpress:db mysql:server"}
+
+	local := s.facade.unitsWatcher(consumingModelUUID, "wordpress:db mysql:server")
+	local.changes <- watcher.RelationUnitsChange{
+		Changed: map[string]watcher.UnitSettings{
+			"wordpress/1": {Version: 1},
+			"wordpress/0": {Version: 2},
+		},
+	}
+	c.Check(s.nextPublished(c), jc.DeepEquals, params.RemoteRelationChange{
+		Source: params.RemoteRelationUnitsArg{
+			ModelUUID:       consumingModelUUID,
+			RelationKey:     "wordpress:db mysql:server",
+			ApplicationName: "wordpress",
+		},
+		TargetModelUUID:   offeringModelUUID,
+		TargetRelationKey: "remote-0123:db mysql:server",
+		ChangedUnits:      []string{"wordpress/0", "wordpress/1"},
+	})
+
+	offering := s.facade.unitsWatcher(offeringModelUUID, "remote-0123:db mysql:server")
+	offering.changes <- watcher.RelationUnitsChange{}
+	offering.changes <- watcher.RelationUnitsChange{Departed: []string{"mysql/0"}}
+	c.Check(s.nextPublished(c), jc.DeepEquals, params.RemoteRelationChange{
+		Source: params.RemoteRelationUnitsArg{
+			ModelUUID:       offeringModelUUID,
+			RelationKey:     "remote-0123:db mysql:server",
+			ApplicationName: "mysql",
+		},
+		TargetModelUUID:   consumingModelUUID,
+		TargetRelationKey: "wordpress:db mysql:server",
+		ChangedUnits:      []string{},
+		DepartedUnits:     []string{"mysql/0"},
+	})
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestDestroysCounterpartOfDyingRelation(c *gc.C) {
+	s.facade.relations["wordpress:db mysql:server"] = s.relationResult(params.Dying)
+	w := s.newWorker(c)
+	s.facade.applicationsWatcher.changes <- []string{"mysql"}
+	s.facade.relationsWatcher("mysql").changes <- []string{"wordpress:db mysql:server"}
+
+	select {
+	case arg := <-s.facade.destroyed:
+		c.Check(arg, jc.DeepEquals, params.RemoteRelationArg{
+			ModelUUID:   offeringModelUUID,
+			RelationKey: "remote-0123:db mysql:server",
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for relation to be destroyed")
+	}
+
+	// Departures from the offering side are still published, so the
+	// local relation can be removed.
+	offering := s.facade.unitsWatcher(offeringModelUUID, "remote-0123:db mysql:server")
+	offering.changes <- watcher.RelationUnitsChange{Departed: []string{"mysql/0"}}
+	change := s.nextPublished(c)
+	c.Check(change.DepartedUnits, jc.DeepEquals, []string{"mysql/0"})
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestSkipsConsumerProxies(c *gc.C) {
+	s.facade.applications["remote-0123"] = params.RemoteApplicationResult{
+		Result: &params.RemoteApplication{
+			Name:            "remote-0123",
+			Life:            params.Alive,
+			SourceModelUUID: offeringModelUUID,
+			IsConsumerProxy: true,
+		},
+	}
+	w := s.newWorker(c)
+	s.facade.applicationsWatcher.changes <- []string{"remote-0123", "gone"}
+	s.facade.applicationsWatcher.changes <- []string{}
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "WatchRemoteApplications",
+	}, {
+		FuncName: "RemoteApplications",
+		Args:     []interface{}{[]string{"remote-0123", "gone"}},
+	}})
+}
+
+func (s *WorkerSuite) nextPublished(c *gc.C) params.RemoteRelationChange {
+	select {
+	case change := <-s.facade.published:
+		return change
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for published change")
+	}
+	panic("unreachable")
+}
@@ -60,6 +60,15 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that pending resizes
+	// may be processed.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshots watches for changes to snapshots of volumes
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -75,6 +84,14 @@ type VolumeAccessor interface {
 	// with the specified tags.
 	VolumeParams([]names.VolumeTag) ([]params.VolumeParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeParamsResult, error)
+
+	// VolumeSnapshotParams returns the parameters for taking the
+	// volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -85,6 +102,13 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken
+	// volume snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshotInfo) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotStatus sets the status of volume snapshots.
+	SetVolumeSnapshotStatus([]params.VolumeSnapshotStatus) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -200,6 +224,8 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges               watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
@@ -245,6 +271,24 @@ func (w *storageProvisioner) loop() error {
 	}
 	volumeAttachmentsChanges = volumeAttachmentsWatcher.Changes()
 
+	volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+	if err != nil {
+		return errors.Annotate(err, "watching volume resizes")
+	}
+	if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	volumeResizesChanges = volumeResizesWatcher.Changes()
+
+	volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots()
+	if err != nil {
+		return errors.Annotate(err, "watching volume snapshots")
+	}
+	if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+
 	filesystemAttachmentsWatcher, err := w.config.Filesystems.WatchFilesystemAttachments()
 	if err != nil {
 		return errors.Annotate(err, "watching filesystem attachments")
@@ -299,6 +343,20 @@ func (w *storageProvisioner) loop() error {
 			if err := volumeAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemsChanges:
 			if !ok {
 				return errors.New("filesystems watcher closed")
@@ -340,6 +398,8 @@ func processSchedule(ctx *context) error {
 	destroyVolumeOps := make(map[names.VolumeTag]*destroyVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
@@ -356,6 +416,10 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[op.id] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *destroyFilesystemOp:
@@ -386,6 +450,16 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
 	if len(destroyFilesystemOps) > 0 {
 		if err := destroyFilesystems(ctx, destroyFilesystemOps); err != nil {
 			return errors.Annotate(err, "destroying filesystems")
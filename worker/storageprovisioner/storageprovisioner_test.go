@@ -1532,6 +1532,104 @@ func (s *storageProvisionerSuite) TestDestroyVolumes(c *gc.C) {
 	assertNoEvent(c, removedChan, "volumes removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	provisionedVolume := names.NewVolumeTag("1")
+	otherVolume := names.NewVolumeTag("2")
+
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(provisionedVolume)
+	volumeAccessor.requestedSizes[provisionedVolume.String()] = 2048
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].Size = arg.Size
+		}
+		return results, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Send the volume change twice, so that the worker has
+	// processed the first by the time it sees the resize.
+	volumeAccessor.volumesWatcher.changes <- []string{provisionedVolume.Id()}
+	volumeAccessor.volumesWatcher.changes <- []string{provisionedVolume.Id()}
+	volumeAccessor.resizesWatcher.changes <- []string{
+		provisionedVolume.Id(),
+		otherVolume.Id(),
+	}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      provisionedVolume,
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volumes resized")
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.pendingSnapshots["0"] = names.NewVolumeTag("1")
+
+	snapshotInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+		snapshotInfoSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	snapshottedChan := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		snapshottedChan <- args
+		results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+		for i, arg := range args {
+			results[i].Snapshot = &storage.VolumeSnapshot{SnapshotId: "snap-" + arg.Id, Size: 1024}
+		}
+		return results, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshots that have already been taken are ignored.
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1"}
+
+	snapshotted := waitChannel(c, snapshottedChan, "waiting for volume snapshot to be taken")
+	c.Assert(snapshotted, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+	}})
+	snapshots := waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshotInfo{{
+		Id:         "0",
+		SnapshotId: "snap-0",
+		Size:       1024,
+	}})
+	assertNoEvent(c, snapshottedChan, "volume snapshots taken")
+}
+
 func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	volume := names.NewVolumeTag("1")
 	volumeAccessor := newMockVolumeAccessor()
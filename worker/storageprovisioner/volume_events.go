@@ -95,11 +95,79 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided
+// IDs have been seen to have changed, and so may have pending resizes.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The volume has been removed, or
+				// there is no resize pending for it.
+				ctx.schedule.Remove(resizeVolumeKey(tags[i]))
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		volumeParams, err := volumeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting volume resize parameters")
+		}
+		ctx.schedule.Remove(resizeVolumeKey(tags[i]))
+		scheduleOperations(ctx, &resizeVolumeOp{args: volumeParams})
+	}
+	return nil
+}
+
+// volumeSnapshotsChanged is called when the volume snapshots with the
+// provided IDs have been seen to have changed, and so may be pending.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	for i, result := range paramsResults {
+		id := changes[i]
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The snapshot has already been taken.
+				ctx.schedule.Remove(createVolumeSnapshotKey(id))
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", id,
+			)
+		}
+		volumeParams, err := volumeParamsFromParams(result.Result.Volume)
+		if err != nil {
+			return errors.Annotate(err, "getting volume snapshot parameters")
+		}
+		ctx.schedule.Remove(createVolumeSnapshotKey(id))
+		scheduleOperations(ctx, &createVolumeSnapshotOp{
+			id:       id,
+			volumeId: result.Result.VolumeId,
+			volume:   volumeParams,
+		})
+	}
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
 	for _, tag := range tags {
 		removePendingVolume(ctx, tag.(names.VolumeTag))
+		ctx.schedule.Remove(resizeVolumeKey(tag.(names.VolumeTag)))
 	}
 	return nil
 }
@@ -475,6 +543,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -322,6 +324,201 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes to the sizes in the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, op.args)
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		logger.Debugf("resizing volumes from %q: %v", sourceName, volumeParams)
+		volumeSource := volumeSources[sourceName]
+		var resizeParams []storage.VolumeResizeParams
+		for _, p := range volumeParams {
+			volume, ok := ctx.volumes[p.Tag]
+			if !ok {
+				// We have not yet seen the provisioned volume;
+				// try again later.
+				reschedule = append(reschedule, ops[p.Tag])
+				continue
+			}
+			resizeParams = append(resizeParams, storage.VolumeResizeParams{
+				Tag:      p.Tag,
+				VolumeId: volume.VolumeId,
+				Size:     p.Size,
+			})
+		}
+		if len(resizeParams) == 0 {
+			continue
+		}
+		results, err := volumeSource.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				// Failed to resize volume; reschedule and update status.
+				reschedule = append(reschedule, ops[tag])
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.StatusError.String(),
+					Info:   result.Error.Error(),
+				})
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag), result.Error,
+				)
+				continue
+			}
+			volume := ctx.volumes[tag]
+			volume.Size = result.Size
+			volumes = append(volumes, volume)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: volumeAttachedStatus(ctx, tag).String(),
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
+// createVolumeSnapshots takes snapshots of volumes with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	volumeSources := make(map[string]storage.VolumeSource)
+	opsBySource := make(map[string][]*createVolumeSnapshotOp)
+	var statuses []params.VolumeSnapshotStatus
+	for _, op := range ops {
+		sourceName := string(op.volume.Provider)
+		if _, ok := volumeSources[sourceName]; !ok {
+			volumeSource, err := volumeSource(
+				ctx.config.StorageDir, sourceName, op.volume.Provider, ctx.config.Registry,
+			)
+			if errors.Cause(err) == errNonDynamic {
+				volumeSource = nil
+			} else if err != nil {
+				return errors.Annotate(err, "getting volume source")
+			}
+			volumeSources[sourceName] = volumeSource
+		}
+		if _, ok := volumeSources[sourceName].(storage.VolumeSnapshotter); !ok {
+			// Snapshots are not supported by the storage provider,
+			// so there is no point in retrying.
+			statuses = append(statuses, params.VolumeSnapshotStatus{
+				Id:     op.id,
+				Status: status.StatusError.String(),
+				Info:   fmt.Sprintf("storage provider %q does not support snapshots", sourceName),
+			})
+			continue
+		}
+		opsBySource[sourceName] = append(opsBySource[sourceName], op)
+	}
+
+	var reschedule []scheduleOp
+	var infos []params.VolumeSnapshotInfo
+	for sourceName, sourceOps := range opsBySource {
+		snapshotter := volumeSources[sourceName].(storage.VolumeSnapshotter)
+		snapshotParams := make([]storage.VolumeSnapshotParams, len(sourceOps))
+		for i, op := range sourceOps {
+			snapshotParams[i] = storage.VolumeSnapshotParams{
+				Id:           op.id,
+				Volume:       op.volume.Tag,
+				VolumeId:     op.volumeId,
+				ResourceTags: op.volume.ResourceTags,
+			}
+		}
+		logger.Debugf("creating volume snapshots from %q: %v", sourceName, snapshotParams)
+		results, err := snapshotter.CreateVolumeSnapshots(snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", sourceName)
+		}
+		for i, result := range results {
+			op := sourceOps[i]
+			if result.Error != nil {
+				// Failed to take the snapshot; reschedule and update status.
+				reschedule = append(reschedule, op)
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     op.id,
+					Status: status.StatusError.String(),
+					Info:   result.Error.Error(),
+				})
+				logger.Debugf("failed to create volume snapshot %q: %v", op.id, result.Error)
+				continue
+			}
+			infos = append(infos, params.VolumeSnapshotInfo{
+				Id:         op.id,
+				SnapshotId: result.Snapshot.SnapshotId,
+				Size:       result.Snapshot.Size,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(statuses) > 0 {
+		if _, err := ctx.config.Volumes.SetVolumeSnapshotStatus(statuses); err != nil {
+			logger.Errorf("failed to set volume snapshot status: %v", err)
+		}
+	}
+	if len(infos) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(infos)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				infos[i].Id, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// volumeAttachedStatus returns the status that a volume should have
+// once an operation on it has completed, based on whether or not it
+// is known to be attached to a machine.
+func volumeAttachedStatus(ctx *context, tag names.VolumeTag) status.Status {
+	for id := range ctx.volumeAttachments {
+		if id.AttachmentTag == tag.String() {
+			return status.StatusAttached
+		}
+	}
+	return status.StatusDetached
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -458,6 +655,34 @@ func (op *destroyVolumeOp) key() interface{} {
 	return op.tag
 }
 
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeParams
+}
+
+// resizeVolumeKey is the schedule key for resizeVolumeOp. A distinct
+// type is used so that a resize may be scheduled for a volume without
+// conflicting with the volume's other operations.
+type resizeVolumeKey names.VolumeTag
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey(op.args.Tag)
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	id       string
+	volumeId string
+	volume   storage.VolumeParams
+}
+
+// createVolumeSnapshotKey is the schedule key for createVolumeSnapshotOp.
+type createVolumeSnapshotKey string
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return createVolumeSnapshotKey(op.id)
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
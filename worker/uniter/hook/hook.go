@@ -16,8 +16,23 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	StorageResized        hooks.Kind = "storage-resized"
+	SecretChanged         hooks.Kind = "secret-changed"
+	SecretRotate          hooks.Kind = "secret-rotate"
+	PostSeriesUpgrade     hooks.Kind = "post-series-upgrade"
 )
 
+// IsSecret returns whether the specified hook kind relates to a secret.
+func IsSecret(kind hooks.Kind) bool {
+	return kind == SecretChanged || kind == SecretRotate
+}
+
+// IsStorage returns whether the specified hook kind relates to storage,
+// including the storage-resized hook not yet known to the charm package.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -37,6 +52,15 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// SecretId is the ID of the secret relevant to the hook. It is only
+	// set when Kind indicates a secret hook.
+	SecretId string `yaml:"secret-id,omitempty"`
+
+	// SecretRevision is the revision of the secret, or for a secret-rotate
+	// hook the rotation, that the hook is run for. It is only set when
+	// Kind indicates a secret hook.
+	SecretRevision int `yaml:"secret-revision,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -48,15 +72,20 @@ func (hi Info) Validate() error {
 		}
 		fallthrough
 	case hooks.Install, hooks.Start, hooks.ConfigChanged, hooks.UpgradeCharm, hooks.Stop, hooks.RelationBroken,
-		hooks.CollectMetrics, hooks.MeterStatusChanged, hooks.UpdateStatus:
+		hooks.CollectMetrics, hooks.MeterStatusChanged, hooks.UpdateStatus, PostSeriesUpgrade:
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
 		return nil
+	case SecretChanged, SecretRotate:
+		if hi.SecretId == "" {
+			return fmt.Errorf("%q hook requires a secret ID", hi.Kind)
+		}
+		return nil
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
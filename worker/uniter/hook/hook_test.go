@@ -50,6 +50,13 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.SecretChanged}, `"secret-changed" hook requires a secret ID`},
+	{hook.Info{Kind: hook.SecretChanged, SecretId: "secret:1"}, ""},
+	{hook.Info{Kind: hook.SecretRotate}, `"secret-rotate" hook requires a secret ID`},
+	{hook.Info{Kind: hook.SecretRotate, SecretId: "secret:1"}, ""},
+	{hook.Info{Kind: hook.PostSeriesUpgrade}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
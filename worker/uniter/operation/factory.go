@@ -136,3 +136,8 @@ func (f *factory) NewResignLeadership() (Operation, error) {
 func (f *factory) NewAcceptLeadership() (Operation, error) {
 	return &acceptLeadership{}, nil
 }
+
+// NewResetSeriesUpgrade is part of the Factory interface.
+func (f *factory) NewResetSeriesUpgrade() (Operation, error) {
+	return &resetSeriesUpgrade{}, nil
+}
@@ -210,3 +210,9 @@ func (s *FactorySuite) TestNewResignLeadershipString(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.String(), gc.Equals, "resign leadership")
 }
+
+func (s *FactorySuite) TestNewResetSeriesUpgradeString(c *gc.C) {
+	op, err := s.factory.NewResetSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "reset series upgrade")
+}
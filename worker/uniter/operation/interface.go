@@ -102,6 +102,10 @@ type Factory interface {
 	// NewResignLeadership creates an operation to ensure the uniter does not
 	// act as service leader.
 	NewResignLeadership() (Operation, error)
+
+	// NewResetSeriesUpgrade creates an operation to record that the series
+	// upgrade for which the post-series-upgrade hook last ran is over.
+	NewResetSeriesUpgrade() (Operation, error)
 }
 
 // CommandArgs stores the arguments for a Command operation.
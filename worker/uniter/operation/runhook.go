@@ -38,8 +38,10 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case hook.IsSecret(rh.info.Kind):
+		suffix = fmt.Sprintf(" (%s)", rh.info.SecretId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -220,7 +222,28 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		newState.Started = true
 	case hooks.Stop:
 		newState.Stopped = true
+	case hook.PostSeriesUpgrade:
+		newState.SeriesUpgraded = true
+	case hook.SecretChanged:
+		newState.SecretRevisions = withSecretRevision(
+			newState.SecretRevisions, rh.info.SecretId, rh.info.SecretRevision,
+		)
+	case hook.SecretRotate:
+		newState.SecretRotations = withSecretRevision(
+			newState.SecretRotations, rh.info.SecretId, rh.info.SecretRevision,
+		)
 	}
 
 	return newState, nil
 }
+
+// withSecretRevision returns a copy of revisions, recording the given
+// revision for the secret with the given ID.
+func withSecretRevision(revisions map[string]int, id string, revision int) map[string]int {
+	result := make(map[string]int, len(revisions)+1)
+	for k, v := range revisions {
+		result[k] = v
+	}
+	result[id] = revision
+	return result
+}
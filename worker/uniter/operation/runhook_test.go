@@ -528,6 +528,69 @@ func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_PostSeriesUpgrade_SetSeriesUpgraded(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.PostSeriesUpgrade},
+			operation.State{Started: true},
+			operation.State{
+				Started:        true,
+				Kind:           operation.Continue,
+				Step:           operation.Pending,
+				SeriesUpgraded: true,
+			},
+		)
+	}
+}
+
+func (s *RunHookSuite) TestCommitSuccess_SecretChanged_RecordRevision(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.SecretChanged, SecretId: "secret:1", SecretRevision: 3},
+			operation.State{
+				Started:         true,
+				SecretRevisions: map[string]int{"secret:1": 2, "secret:2": 1},
+			},
+			operation.State{
+				Started:         true,
+				Kind:            operation.Continue,
+				Step:            operation.Pending,
+				SecretRevisions: map[string]int{"secret:1": 3, "secret:2": 1},
+			},
+		)
+	}
+}
+
+func (s *RunHookSuite) TestCommitSuccess_SecretRotate_RecordRotation(c *gc.C) {
+	for i, newHook := range []newHook{
+		(operation.Factory).NewRunHook,
+		(operation.Factory).NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.SecretRotate, SecretId: "secret:1", SecretRevision: 5},
+			operation.State{Started: true},
+			operation.State{
+				Started:         true,
+				Kind:            operation.Continue,
+				Step:            operation.Pending,
+				SecretRotations: map[string]int{"secret:1": 5},
+			},
+		)
+	}
+}
+
 func (s *RunHookSuite) testQueueHook_BlankSlate(c *gc.C, cause hooks.Kind) {
 	for i, newHook := range []newHook{
 		(operation.Factory).NewRunHook,
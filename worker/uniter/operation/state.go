@@ -93,6 +93,18 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// SecretRevisions holds the revision of each secret, keyed by
+	// secret ID, for which a secret-changed hook has been committed.
+	SecretRevisions map[string]int `yaml:"secret-revisions,omitempty"`
+
+	// SecretRotations holds the rotation of each secret, keyed by
+	// secret ID, for which a secret-rotate hook has been committed.
+	SecretRotations map[string]int `yaml:"secret-rotations,omitempty"`
+
+	// SeriesUpgraded indicates whether the post-series-upgrade hook
+	// has run for the series upgrade of the unit's machine in progress.
+	SeriesUpgraded bool `yaml:"series-upgraded,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -188,6 +188,19 @@ var stateTests = []struct {
 			Step:   operation.Pending,
 			Leader: true,
 		},
+	}, {
+		st: operation.State{
+			Kind:            operation.Continue,
+			Step:            operation.Pending,
+			SecretRevisions: map[string]int{"secret:1": 2},
+			SecretRotations: map[string]int{"secret:2": 1},
+		},
+	}, {
+		st: operation.State{
+			Kind:           operation.Continue,
+			Step:           operation.Pending,
+			SeriesUpgraded: true,
+		},
 	},
 }
 
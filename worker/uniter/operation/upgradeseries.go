@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"github.com/juju/errors"
+)
+
+type resetSeriesUpgrade struct {
+	DoesNotRequireMachineLock
+}
+
+// String is part of the Operation interface.
+func (rs *resetSeriesUpgrade) String() string {
+	return "reset series upgrade"
+}
+
+// Prepare is part of the Operation interface.
+func (rs *resetSeriesUpgrade) Prepare(state State) (*State, error) {
+	return nil, ErrSkipExecute
+}
+
+// Execute is part of the Operation interface.
+func (rs *resetSeriesUpgrade) Execute(state State) (*State, error) {
+	return nil, errors.New("prepare always errors; Execute is never valid")
+}
+
+// Commit is part of the Operation interface.
+func (rs *resetSeriesUpgrade) Commit(state State) (*State, error) {
+	state.SeriesUpgraded = false
+	return &state, nil
+}
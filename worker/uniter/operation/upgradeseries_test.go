@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) TestResetSeriesUpgrade_Prepare(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewResetSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{SeriesUpgraded: true})
+	c.Check(newState, gc.IsNil)
+	c.Check(err, gc.Equals, operation.ErrSkipExecute)
+}
+
+func (s *UpgradeSeriesSuite) TestResetSeriesUpgrade_Commit(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewResetSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Commit(operation.State{
+		Kind:           operation.Continue,
+		Step:           operation.Pending,
+		Started:        true,
+		SeriesUpgraded: true,
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(newState, jc.DeepEquals, &operation.State{
+		Kind:    operation.Continue,
+		Step:    operation.Pending,
+		Started: true,
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestResetSeriesUpgrade_DoesNotNeedGlobalMachineLock(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewResetSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+}
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	apiuniter "github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
@@ -18,6 +19,10 @@ type Relationer struct {
 	ru    *apiuniter.RelationUnit
 	dir   *StateDir
 	dying bool
+
+	// settled holds whether the unit was last reported as having run
+	// all the relation hooks due so far, or nil if that is unknown.
+	settled *bool
 }
 
 // NewRelationer creates a new Relationer. The unit will not join the
@@ -84,6 +89,21 @@ func (r *Relationer) die() error {
 	return r.dir.Remove()
 }
 
+// setSettled reports whether the unit has run all the relation hooks
+// due so far, unless that is what was last reported.
+func (r *Relationer) setSettled(settled bool) error {
+	if r.settled != nil && *r.settled == settled {
+		return nil
+	}
+	// The unit may have left scope already, in which case there's
+	// no progress left to report.
+	if err := r.ru.SetSettled(settled); err != nil && !params.IsCodeNotFound(err) {
+		return err
+	}
+	r.settled = &settled
+	return nil
+}
+
 // PrepareHook checks that the relation is in a state such that it makes
 // sense to execute the supplied hook, and ensures that the relation context
 // contains the latest relation state as communicated in the hook.Info. It
@@ -183,7 +183,9 @@ func (r *relations) NextHook(
 		return hook.Info{}, resolver.ErrNoOperation
 	}
 
-	// See if any of the relations have operations to perform.
+	// See if any of the relations have operations to perform, and
+	// report which relations have none left.
+	var next *hook.Info
 	for relationId, relationSnapshot := range remoteState.Relations {
 		relationer, ok := r.relationers[relationId]
 		if !ok || relationer.IsImplicit() {
@@ -197,13 +199,23 @@ func (r *relations) NextHook(
 		}
 		// If either the unit or the relation are Dying,
 		// then the relation should be broken.
-		hook, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
-		if err == resolver.ErrNoOperation {
-			continue
+		info, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
+		if err != nil && err != resolver.ErrNoOperation {
+			return hook.Info{}, err
+		}
+		if !remoteBroken {
+			if err := relationer.setSettled(err == resolver.ErrNoOperation); err != nil {
+				return hook.Info{}, errors.Trace(err)
+			}
+		}
+		if err == nil && next == nil {
+			next = &info
 		}
-		return hook, err
 	}
-	return hook.Info{}, resolver.ErrNoOperation
+	if next == nil {
+		return hook.Info{}, resolver.ErrNoOperation
+	}
+	return *next, nil
 }
 
 // nextRelationHook returns the next hook op that should be executed in the
@@ -397,6 +409,10 @@ func (r *relations) update(remote map[int]remotestate.RelationSnapshot) error {
 		}
 		addErr := r.add(rel, dir)
 		if addErr == nil {
+			// The unit has only now entered scope, and so has not
+			// yet been reported as having run any relation hooks.
+			settled := false
+			r.relationers[id].settled = &settled
 			continue
 		}
 		removeErr := dir.Remove()
@@ -78,7 +78,7 @@ func mockAPICaller(c *gc.C, callNumber *int32, apiCalls ...apiCall) apitesting.A
 			c.Check(index < len(apiCalls), jc.IsTrue)
 			call := apiCalls[index]
 			c.Logf("request %d, %s", index, request)
-			c.Check(version, gc.Equals, 4)
+			c.Check(version, gc.Equals, 5)
 			c.Check(id, gc.Equals, "")
 			c.Check(request, gc.Equals, call.request)
 			c.Check(arg, jc.DeepEquals, call.args)
@@ -359,6 +359,63 @@ func (s *relationsSuite) TestHookRelationChanged(c *gc.C) {
 	}, &numCalls)
 }
 
+func relationSettledApiCall(settled bool) apiCall {
+	args := params.RelationUnitsSettled{RelationUnits: []params.RelationUnitSettled{
+		{Relation: "relation-wordpress.db#mysql.db", Unit: "unit-wordpress-0", Settled: settled},
+	}}
+	return uniterApiCall("SetRelationsSettled", args, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil)
+}
+
+func (s *relationsSuite) TestRelationSettled(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedApiCalls()
+	apiCalls = append(apiCalls, getPrincipalApiCalls(2)...)
+	apiCalls = append(apiCalls, relationSettledApiCall(true))
+	apiCalls = append(apiCalls, getPrincipalApiCalls(2)...)
+	apiCalls = append(apiCalls, relationSettledApiCall(false))
+	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
+	snapshot := remotestate.RelationSnapshot{
+		Life: params.Alive,
+		Members: map[string]int64{
+			"wordpress": 1,
+		},
+	}
+	s.assertHookRelationChanged(c, r, snapshot, &numCalls)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: snapshot,
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+
+	// With no hooks left to run, the unit is reported settled.
+	_, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 11)
+
+	// It is not reported again until that changes.
+	_, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 12)
+
+	remoteState.Relations[1] = remotestate.RelationSnapshot{
+		Life: params.Alive,
+		Members: map[string]int64{
+			"wordpress": 2,
+		},
+	}
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 14)
+	c.Assert(op.String(), gc.Equals, "run hook relation-changed on unit with relation 1")
+}
+
 func (s *relationsSuite) assertHookRelationDeparted(c *gc.C, numCalls *int32, apiCalls ...apiCall) relation.Relations {
 	r := s.assertHookRelationJoined(c, numCalls, apiCalls...)
 	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
@@ -191,6 +191,11 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	secretsWatcher        *mockNotifyWatcher
+	secretRevisions       map[string]int
+	secretRotations       map[string]int
+	targetCharmURL        *charm.URL
+	upgradeSeriesStatus   params.UpgradeSeriesStatus
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -213,6 +218,17 @@ func (u *mockUnit) Tag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) TargetCharmURL() (*charm.URL, bool, error) {
+	if u.targetCharmURL != nil {
+		return u.targetCharmURL, false, nil
+	}
+	return u.service.CharmURL()
+}
+
+func (u *mockUnit) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error) {
+	return u.upgradeSeriesStatus, nil
+}
+
 func (u *mockUnit) Watch() (watcher.NotifyWatcher, error) {
 	return u.unitWatcher, nil
 }
@@ -233,6 +249,14 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) WatchSecrets() (watcher.NotifyWatcher, error) {
+	return u.secretsWatcher, nil
+}
+
+func (u *mockUnit) SecretRevisions() (map[string]int, map[string]int, error) {
+	return u.secretRevisions, u.secretRotations, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
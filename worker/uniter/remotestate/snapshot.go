@@ -40,6 +40,10 @@ type Snapshot struct {
 	// hook execution errors.
 	ResolvedMode params.ResolvedMode
 
+	// UpgradeSeriesStatus reports the progress of the
+	// unit through the series upgrade of its machine.
+	UpgradeSeriesStatus params.UpgradeSeriesStatus
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -68,6 +72,17 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// SecretRevisions contains the latest revision of
+	// each secret the application has been granted
+	// access to, keyed by secret ID.
+	SecretRevisions map[string]int
+
+	// SecretRotations contains the revision of each
+	// secret owned by the application that is due to
+	// be rotated, keyed by secret ID. It is only
+	// populated for the leader.
+	SecretRotations map[string]int
 }
 
 type RelationSnapshot struct {
@@ -82,4 +97,5 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+	Size     uint64
 }
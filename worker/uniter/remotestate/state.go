@@ -27,11 +27,15 @@ type Unit interface {
 	Resolved() (params.ResolvedMode, error)
 	Application() (Application, error)
 	Tag() names.UnitTag
+	TargetCharmURL() (*charm.URL, bool, error)
+	UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error)
 	Watch() (watcher.NotifyWatcher, error)
 	WatchAddresses() (watcher.NotifyWatcher, error)
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	WatchSecrets() (watcher.NotifyWatcher, error)
+	SecretRevisions() (granted, toRotate map[string]int, _ error)
 }
 
 type Application interface {
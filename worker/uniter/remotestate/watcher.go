@@ -126,6 +126,8 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	// The secret maps are replaced, never modified, when the
+	// secrets change, so they can be shared with the snapshot.
 	return snapshot
 }
 
@@ -256,6 +258,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenSecretsChange bool
+	secretsw, err := w.unit.WatchSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(secretsw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -384,6 +396,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenStorageChange)
 
+		case _, ok := <-secretsw.Changes():
+			logger.Debugf("got secrets change: ok=%t", ok)
+			if !ok {
+				return errors.New("secrets watcher closed")
+			}
+			if err := w.secretsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenSecretsChange)
+
 		case <-waitMinion:
 			logger.Debugf("got leadership change: minion")
 			if err := w.leadershipChanged(false); err != nil {
@@ -397,6 +419,12 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.leadershipChanged(true); err != nil {
 				return errors.Trace(err)
 			}
+			// Only the leader is told of secrets due for rotation.
+			if seenSecretsChange {
+				if err := w.secretsChanged(); err != nil {
+					return errors.Trace(err)
+				}
+			}
 			waitLeader = nil
 			waitMinion = w.leadershipTracker.WaitMinion().Ready()
 
@@ -417,6 +445,14 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.updateStatusChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			// Secrets fall due for rotation as time passes, without
+			// any change being made to them, so we check for them
+			// each time the timer expires.
+			if seenSecretsChange {
+				if err := w.secretsChanged(); err != nil {
+					return errors.Trace(err)
+				}
+			}
 
 		case id, ok := <-w.commandChannel:
 			if !ok {
@@ -450,6 +486,20 @@ func (w *RemoteStateWatcher) updateStatusChanged() error {
 	return nil
 }
 
+// secretsChanged is called when secrets in the model change, and
+// when secrets may have fallen due for rotation.
+func (w *RemoteStateWatcher) secretsChanged() error {
+	granted, toRotate, err := w.unit.SecretRevisions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.SecretRevisions = granted
+	w.current.SecretRotations = toRotate
+	w.mu.Unlock()
+	return nil
+}
+
 // commandsChanged is called when a command is enqueued.
 func (w *RemoteStateWatcher) commandsChanged(id string) error {
 	w.mu.Lock()
@@ -475,10 +525,23 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// The unit may have been released, or held, by a rolling
+	// charm upgrade.
+	url, force, err := w.unit.TargetCharmURL()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	upgradeSeriesStatus, err := w.unit.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = resolved
+	w.current.UpgradeSeriesStatus = upgradeSeriesStatus
+	w.current.CharmURL = url
+	w.current.ForceCharmUpgrade = force
 	return nil
 }
 
@@ -487,7 +550,7 @@ func (w *RemoteStateWatcher) serviceChanged() error {
 	if err := w.service.Refresh(); err != nil {
 		return errors.Trace(err)
 	}
-	url, force, err := w.service.CharmURL()
+	url, force, err := w.unit.TargetCharmURL()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -53,6 +53,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			secretsWatcher:        newMockNotifyWatcher(),
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -107,6 +108,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
@@ -120,6 +122,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.secretsWatcher.changes <- struct{}{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -183,10 +186,61 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	s.st.unit.service.relationsWatcher.changes <- []string{}
 	assertOneChange()
 
+	s.st.unit.secretRevisions = map[string]int{"secret:1": 2}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().SecretRevisions, jc.DeepEquals, map[string]int{"secret:1": 2})
+
 	s.clock.Advance(statusTickDuration + 1)
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestSecretRotationsRefreshedOnTimer(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	s.st.unit.secretRotations = map[string]int{"secret:1": 1}
+	s.clock.Advance(statusTickDuration + 1)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, map[string]int{"secret:1": 1})
+}
+
+func (s *WatcherSuite) TestTargetCharmURLFollowsUnit(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().CharmURL, gc.DeepEquals, s.st.unit.service.curl)
+
+	// A unit held by a rolling charm upgrade is not asked to upgrade
+	// with its application.
+	held := s.st.unit.service.curl
+	s.st.unit.targetCharmURL = held
+	s.st.unit.service.curl = charm.MustParseURL("cs:trusty/mysql-2")
+	s.st.unit.service.forceUpgrade = true
+	s.st.unit.service.serviceWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().CharmURL, gc.DeepEquals, held)
+	c.Assert(s.watcher.Snapshot().ForceCharmUpgrade, jc.IsFalse)
+
+	// Once released, it follows the application.
+	s.st.unit.targetCharmURL = nil
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().CharmURL, gc.DeepEquals, s.st.unit.service.curl)
+	c.Assert(s.watcher.Snapshot().ForceCharmUpgrade, jc.IsTrue)
+}
+
+func (s *WatcherSuite) TestUpgradeSeriesStatusChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesNotStarted)
+
+	s.st.unit.upgradeSeriesStatus = params.UpgradeSeriesPrepared
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesPrepared)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
@@ -73,6 +75,17 @@ func (s *uniterResolver) NextOp(
 		s.retryHookTimerStarted = false
 	}
 
+	if localState.Kind == operation.Continue && remoteState.UpgradeSeriesStatus == params.UpgradeSeriesPrepared {
+		if localState.SeriesUpgraded {
+			// The hook ran for a previous series upgrade.
+			return opFactory.NewResetSeriesUpgrade()
+		}
+		// The operating system is being upgraded; run nothing,
+		// not even actions, until the upgrade is completed.
+		logger.Infof("waiting for series upgrade of machine to complete")
+		return nil, resolver.ErrWaiting
+	}
+
 	op, err := s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
@@ -256,6 +269,16 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.Install})
 	}
 
+	if remoteState.UpgradeSeriesStatus != params.UpgradeSeriesCompleted {
+		if localState.SeriesUpgraded {
+			return opFactory.NewResetSeriesUpgrade()
+		}
+	} else if !localState.SeriesUpgraded {
+		// Committing the hook records that the unit has
+		// finished its series upgrade.
+		return opFactory.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+	}
+
 	if charmModified(localState, remoteState) {
 		return opFactory.NewUpgrade(remoteState.CharmURL)
 	}
@@ -264,7 +287,12 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
 	}
 
-	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
+	op, err := s.nextSecretOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
+	op, err = s.config.Relations.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
 	}
@@ -276,3 +304,45 @@ func (s *uniterResolver) nextOp(
 
 	return nil, resolver.ErrNoOperation
 }
+
+// nextSecretOp returns an operation to run the secret-changed hook for
+// a secret the application has been granted access to that has a new
+// revision or, if the unit is the leader, the secret-rotate hook for a
+// secret the application owns that is due to be rotated.
+func (s *uniterResolver) nextSecretOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	for _, id := range sortedSecretIds(remoteState.SecretRevisions) {
+		if localState.SecretRevisions[id] != remoteState.SecretRevisions[id] {
+			return opFactory.NewRunHook(hook.Info{
+				Kind:           hook.SecretChanged,
+				SecretId:       id,
+				SecretRevision: remoteState.SecretRevisions[id],
+			})
+		}
+	}
+	if !remoteState.Leader {
+		return nil, resolver.ErrNoOperation
+	}
+	for _, id := range sortedSecretIds(remoteState.SecretRotations) {
+		if localState.SecretRotations[id] != remoteState.SecretRotations[id] {
+			return opFactory.NewRunHook(hook.Info{
+				Kind:           hook.SecretRotate,
+				SecretId:       id,
+				SecretRevision: remoteState.SecretRotations[id],
+			})
+		}
+	}
+	return nil, resolver.ErrNoOperation
+}
+
+func sortedSecretIds(revisions map[string]int) []string {
+	ids := make([]string, 0, len(revisions))
+	for id := range revisions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
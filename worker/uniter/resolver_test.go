@@ -252,3 +252,96 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) TestSecretHooks(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.SecretRevisions = map[string]int{"secret:2": 1}
+	s.remoteState.SecretRotations = map[string]int{"secret:1": 3}
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run secret-changed (secret:2) hook")
+
+	// Only the leader is asked to rotate secrets.
+	localState.SecretRevisions = map[string]int{"secret:2": 1}
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	s.remoteState.Leader = true
+	localState.Leader = true
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run secret-rotate (secret:1) hook")
+
+	localState.SecretRotations = map[string]int{"secret:1": 3}
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestUpgradeSeries(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+
+	// Nothing runs while the operating system is being upgraded.
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesPrepared
+	s.remoteState.ConfigVersion = 1
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrWaiting)
+
+	// Once completed, the post-series-upgrade hook runs first,
+	// and only once.
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesCompleted
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run post-series-upgrade hook")
+	localState.SeriesUpgraded = true
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+
+	// The record of the hook is reset once the upgrade is over,
+	// so that the hook runs again for the next series upgrade.
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesNotStarted
+	localState.ConfigVersion = 1
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "reset series upgrade")
+	localState.SeriesUpgraded = false
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesCompleted
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run post-series-upgrade hook")
+}
+
+func (s *resolverSuite) TestUpgradeSeriesResetBeforeWaiting(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:           operation.Continue,
+			Installed:      true,
+			Started:        true,
+			SeriesUpgraded: true,
+		},
+	}
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesPrepared
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "reset series upgrade")
+}
@@ -171,6 +171,11 @@ type HookContext struct {
 	// opened each range and the relevant relation.
 	machinePorts map[network.PortRange]params.RelationUnit
 
+	// portEndpoints contains cached information about the port ranges
+	// opened by the unit, mapped to the endpoints each range was opened
+	// for. An empty endpoint name means all endpoints.
+	portEndpoints map[network.PortRange][]string
+
 	// assignedMachineTag contains the tag of the unit's assigned
 	// machine.
 	assignedMachineTag names.MachineTag
@@ -189,6 +194,9 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// secretId is the ID of the secret associated with the running hook.
+	secretId string
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -434,16 +442,24 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenEndpointPorts("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+	return ctx.CloseEndpointPorts("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, endpoint,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
 }
 
-func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+func (ctx *HookContext) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryClosePorts(
-		protocol, fromPort, toPort,
+		protocol, fromPort, toPort, endpoint,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -460,6 +476,10 @@ func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	return unitRanges
 }
 
+func (ctx *HookContext) OpenedPortEndpoints() map[network.PortRange][]string {
+	return ctx.portEndpoints
+}
+
 func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	if ctx.configSettings == nil {
 		var err error
@@ -591,6 +611,9 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretId != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+context.secretId)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -677,14 +700,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenEndpointPorts(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
 				)
 				op = "open"
 			} else {
-				e = ctx.unit.ClosePorts(
+				e = ctx.unit.CloseEndpointPorts(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
@@ -792,6 +817,11 @@ func (ctx *HookContext) NetworkConfig(bindingName string) ([]params.NetworkConfi
 	return ctx.unit.NetworkConfig(bindingName)
 }
 
+// NetworkInfo returns the network info for the given bindings.
+func (ctx *HookContext) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	return ctx.unit.NetworkInfo(bindingNames)
+}
+
 // UnitWorkloadVersion returns the version of the workload reported by
 // the current unit.
 func (ctx *HookContext) UnitWorkloadVersion() (string, error) {
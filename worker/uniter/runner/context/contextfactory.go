@@ -202,7 +202,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -213,6 +213,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hook.IsSecret(hookInfo.Kind) {
+		ctx.secretId = hookInfo.SecretId
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -269,10 +272,11 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	if err != nil {
 		return err
 	}
-	ctx.machinePorts, err = f.state.AllMachinePorts(f.machineTag)
+	machinePortRanges, err := f.state.AllMachinePortRanges(f.machineTag)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	ctx.machinePorts, ctx.portEndpoints = machinePortsFromRanges(f.unit.Tag(), machinePortRanges)
 
 	statusCode, statusInfo, err := f.unit.MeterStatus()
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/utils/fs"
+	"github.com/juju/utils/keyvalues"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
@@ -161,6 +162,22 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestSecretHookContext(c *gc.C) {
+	hi := hook.Info{
+		Kind:     hook.SecretChanged,
+		SecretId: "secret:1",
+	}
+	ctx, err := s.factory.HookContext(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	vars, err := ctx.HookVars(s.paths)
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := keyvalues.Parse(vars, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env["JUJU_SECRET_ID"], gc.Equals, "secret:1")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
@@ -71,10 +71,11 @@ func NewHookContext(
 	if err != nil {
 		return nil, err
 	}
-	ctx.machinePorts, err = state.AllMachinePorts(ctx.assignedMachineTag)
+	machinePortRanges, err := state.AllMachinePortRanges(ctx.assignedMachineTag)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	ctx.machinePorts, ctx.portEndpoints = machinePortsFromRanges(unit.Tag(), machinePortRanges)
 
 	statusCode, statusInfo, err := unit.MeterStatus()
 	if err != nil {
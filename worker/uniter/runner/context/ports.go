@@ -21,11 +21,13 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
+// PortRange contains a port range, a relation id and the endpoint the
+// port range applies to (empty for all endpoints). Used as key to
 // pendingRelations and is only exported for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -41,9 +43,33 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 	return newRange, nil
 }
 
+// machinePortsFromRanges returns the given port ranges opened on a
+// machine mapped to the unit and relation that opened each of them,
+// and the port ranges opened by the given unit mapped to the endpoints
+// they were opened for.
+func machinePortsFromRanges(
+	unitTag names.UnitTag,
+	portRanges []params.MachinePortRange,
+) (map[network.PortRange]params.RelationUnit, map[network.PortRange][]string) {
+	machinePorts := make(map[network.PortRange]params.RelationUnit)
+	unitEndpoints := make(map[network.PortRange][]string)
+	for _, ports := range portRanges {
+		portRange := ports.PortRange.NetworkPortRange()
+		machinePorts[portRange] = params.RelationUnit{
+			Unit:     ports.UnitTag,
+			Relation: ports.RelationTag,
+		}
+		if ports.UnitTag == unitTag.String() {
+			unitEndpoints[portRange] = append(unitEndpoints[portRange], ports.Endpoint)
+		}
+	}
+	return machinePorts, unitEndpoints
+}
+
 func tryOpenPorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -61,6 +87,7 @@ func tryOpenPorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -87,9 +114,15 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				if endpoint == "" {
+					// The same unit trying to open the same range is
+					// just ignored.
+					return nil
+				}
+				// The range may not be opened for this endpoint yet;
+				// opening it again for the same endpoint is ignored
+				// when committing.
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -99,6 +132,10 @@ func tryOpenPorts(
 	}
 	// Ensure other pending port ranges do not conflict with this one.
 	for rangeKey, rangeInfo := range pendingPorts {
+		if rangeKey.Ports == newRange {
+			// The same range pending for another endpoint.
+			continue
+		}
 		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
@@ -116,6 +153,7 @@ func tryOpenPorts(
 func tryClosePorts(
 	protocol string,
 	fromPort, toPort int,
+	endpoint string,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
@@ -133,6 +171,17 @@ func tryClosePorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
+	}
+
+	if endpoint == "" {
+		// Closing the range for all endpoints also cancels any
+		// pending requests to open it for specific endpoints.
+		for pendingKey, pendingInfo := range pendingPorts {
+			if pendingKey.Ports == newRange && pendingKey.Endpoint != "" && pendingInfo.ShouldOpen {
+				delete(pendingPorts, pendingKey)
+			}
+		}
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
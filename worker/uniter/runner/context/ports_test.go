@@ -116,6 +116,12 @@ func makeMachinePorts(
 
 func makePendingPorts(
 	proto string, fromPort, toPort int, shouldOpen bool,
+) map[context.PortRange]context.PortRangeInfo {
+	return makePendingEndpointPorts("", proto, fromPort, toPort, shouldOpen)
+}
+
+func makePendingEndpointPorts(
+	endpoint, proto string, fromPort, toPort int, shouldOpen bool,
 ) map[context.PortRange]context.PortRangeInfo {
 	result := make(map[context.PortRange]context.PortRangeInfo)
 	portRange := network.PortRange{
@@ -126,6 +132,7 @@ func makePendingPorts(
 	key := context.PortRange{
 		Ports:      portRange,
 		RelationId: -1,
+		Endpoint:   endpoint,
 	}
 	result[key] = context.PortRangeInfo{
 		ShouldOpen: shouldOpen,
@@ -133,10 +140,23 @@ func makePendingPorts(
 	return result
 }
 
+func mergePendingPorts(
+	pending ...map[context.PortRange]context.PortRangeInfo,
+) map[context.PortRange]context.PortRangeInfo {
+	result := make(map[context.PortRange]context.PortRangeInfo)
+	for _, ports := range pending {
+		for key, info := range ports {
+			result[key] = info
+		}
+	}
+	return result
+}
+
 type portsTest struct {
 	about         string
 	proto         string
 	ports         []int
+	endpoint      string
 	machinePorts  map[network.PortRange]params.RelationUnit
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
@@ -196,6 +216,28 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:         "open a new range for an endpoint",
+		endpoint:      "website",
+		expectPending: makePendingEndpointPorts("website", "tcp", 10, 20, true),
+	}, {
+		about:         "open an existing range of the same unit for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makePendingEndpointPorts("website", "tcp", 10, 20, true),
+	}, {
+		about:        "open a range pending to be opened for another endpoint",
+		endpoint:     "website",
+		pendingPorts: makePendingEndpointPorts("admin", "tcp", 10, 20, true),
+		expectPending: mergePendingPorts(
+			makePendingEndpointPorts("admin", "tcp", 10, 20, true),
+			makePendingEndpointPorts("website", "tcp", 10, 20, true),
+		),
+	}, {
+		about:        "try opening a range for an endpoint conflicting with another unit",
+		endpoint:     "website",
+		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
+		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -205,6 +247,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
@@ -250,6 +293,24 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:         "close an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makePendingEndpointPorts("website", "tcp", 10, 20, false),
+	}, {
+		about:        "close a range pending to be opened for another endpoint",
+		endpoint:     "website",
+		machinePorts: makeMachinePorts("u/0", "tcp", 10, 20),
+		pendingPorts: makePendingEndpointPorts("admin", "tcp", 10, 20, true),
+		expectPending: mergePendingPorts(
+			makePendingEndpointPorts("admin", "tcp", 10, 20, true),
+			makePendingEndpointPorts("website", "tcp", 10, 20, false),
+		),
+	}, {
+		about:         "close a range for all endpoints cancels pending endpoint ranges",
+		pendingPorts:  makePendingEndpointPorts("admin", "tcp", 10, 20, true),
+		expectPending: map[context.PortRange]context.PortRangeInfo{},
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
@@ -259,6 +320,7 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 			test.proto,
 			test.ports[0],
 			test.ports[1],
+			test.endpoint,
 			names.NewUnitTag("u/0"),
 			test.machinePorts,
 			test.pendingPorts,
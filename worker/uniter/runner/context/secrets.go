@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) CreateSecret(args jujuc.SecretCreateArgs) (string, error) {
+	if err := ctx.checkLeader(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return ctx.unit.CreateSecret(args.Label, args.Description, args.RotateInterval, args.Data)
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) UpdateSecret(id string, data map[string]string) error {
+	if err := ctx.checkLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.unit.UpdateSecret(id, data)
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) GetSecret(id string, revision int) (map[string]string, error) {
+	return ctx.unit.SecretValue(id, revision)
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) GrantSecret(id string, relationId int) error {
+	if err := ctx.checkLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	r, found := ctx.relations[relationId]
+	if !found {
+		return errors.NotFoundf("relation %d", relationId)
+	}
+	return ctx.unit.GrantSecret(id, r.ru.Relation().Tag())
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) RevokeSecret(id string, relationId int) error {
+	if err := ctx.checkLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	r, found := ctx.relations[relationId]
+	if !found {
+		return errors.NotFoundf("relation %d", relationId)
+	}
+	return ctx.unit.RevokeSecret(id, r.ru.Relation().Tag())
+}
+
+// checkLeader returns ErrIsNotLeader if the unit is not the leader.
+func (ctx *HookContext) checkLeader() error {
+	isLeader, err := ctx.IsLeader()
+	if err != nil {
+		return errors.Annotatef(err, "cannot determine leadership")
+	}
+	if !isLeader {
+		return ErrIsNotLeader
+	}
+	return nil
+}
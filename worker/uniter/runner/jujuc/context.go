@@ -53,6 +53,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -159,6 +160,21 @@ type ContextNetworking interface {
 	// protocol, then by number.
 	OpenedPorts() []network.PortRange
 
+	// OpenEndpointPorts marks the supplied port range for opening
+	// for the given endpoint when the executing unit's service is
+	// exposed for all endpoints or for that endpoint.
+	OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
+	// CloseEndpointPorts ensures the supplied port range is closed
+	// for the given endpoint.
+	CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
+	// OpenedPortEndpoints returns all port ranges currently opened by
+	// this unit on its assigned machine, mapped to the sorted names of
+	// the endpoints each range was opened for. An empty endpoint name
+	// means the range was opened for all endpoints.
+	OpenedPortEndpoints() map[network.PortRange][]string
+
 	// NetworkConfig returns the network configuration for the unit and the
 	// given bindingName.
 	//
@@ -167,6 +183,12 @@ type ContextNetworking interface {
 	//
 	// LKK Card: https://canonical.leankit.com/Boards/View/101652562/119258804
 	NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
+
+	// NetworkInfo returns the network info for the unit and each of the
+	// given binding names: the addresses each binding is bound to, the
+	// addresses other units should use to reach it and the subnets its
+	// outgoing traffic originates from.
+	NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error)
 }
 
 // ContextLeadership is the part of a hook context related to the
@@ -213,6 +235,48 @@ type ContextStorage interface {
 	AddUnitStorage(map[string]params.StorageConstraints) error
 }
 
+// SecretCreateArgs holds the arguments for creating a secret.
+type SecretCreateArgs struct {
+	// Label is an optional name for the secret.
+	Label string
+
+	// Description is an optional description of the secret.
+	Description string
+
+	// RotateInterval is the interval at which the secret should be
+	// rotated, or zero if it need not be.
+	RotateInterval time.Duration
+
+	// Data holds the content of the secret.
+	Data map[string]string
+}
+
+// ContextSecrets is the part of a hook context related to secrets
+// owned by, or shared with, the unit's application.
+type ContextSecrets interface {
+	// CreateSecret creates a secret owned by the unit's application,
+	// and returns its ID. It fails if the unit is not the leader.
+	CreateSecret(args SecretCreateArgs) (string, error)
+
+	// UpdateSecret adds a new revision, with the supplied content, to
+	// a secret owned by the unit's application. It fails if the unit
+	// is not the leader.
+	UpdateSecret(id string, data map[string]string) error
+
+	// GetSecret returns the content of the given revision of a secret,
+	// or of its latest revision if revision is zero.
+	GetSecret(id string, revision int) (map[string]string, error)
+
+	// GrantSecret allows the application at the other end of the given
+	// relation to read a secret owned by the unit's application, for as
+	// long as the relation exists.
+	GrantSecret(id string, relationId int) error
+
+	// RevokeSecret removes the access to a secret granted to the
+	// application at the other end of the given relation.
+	RevokeSecret(id string, relationId int) error
+}
+
 // ContextComponents exposes modular Juju components as they relate to
 // the unit in the context of the hook.
 type ContextComponents interface {
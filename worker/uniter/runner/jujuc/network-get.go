@@ -18,6 +18,9 @@ type NetworkGetCommand struct {
 
 	bindingName    string
 	primaryAddress bool
+	bindAddress    bool
+	ingressAddress bool
+	egressSubnets  bool
 
 	out cmd.Output
 }
@@ -29,11 +32,20 @@ func NewNetworkGetCommand(ctx Context) (cmd.Command, error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> --primary-address"
+	args := "<binding-name> [--primary-address] [--bind-address] [--ingress-address] [--egress-subnets]"
 	doc := `
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns the addresses the binding is bound to (bind-addresses), the
+addresses other units should use to reach it (ingress-addresses) and the
+subnets its outgoing traffic originates from (egress-subnets).
+
+The --bind-address, --ingress-address and --egress-subnets flags select
+the first bind address, the first ingress address and the egress subnets
+respectively; when more than one is given, the selected values are
+returned keyed by name.
+
+--primary-address returns the IP address the local unit should advertise
+as its endpoint to its peers and cannot be combined with other flags.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -47,6 +59,9 @@ the IP address the local unit should advertise as its endpoint to its peers.
 func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.primaryAddress, "primary-address", false, "get the primary address for the binding")
+	f.BoolVar(&c.bindAddress, "bind-address", false, "get the address for the binding on which the unit should listen")
+	f.BoolVar(&c.ingressAddress, "ingress-address", false, "get the ingress address for the binding")
+	f.BoolVar(&c.egressSubnets, "egress-subnets", false, "get the egress subnets for the binding")
 }
 
 // Init is part of the cmd.Command interface.
@@ -60,25 +75,104 @@ func (c *NetworkGetCommand) Init(args []string) error {
 		return fmt.Errorf("no binding name specified")
 	}
 
-	if !c.primaryAddress {
-		return fmt.Errorf("--primary-address is currently required")
+	if c.primaryAddress && (c.bindAddress || c.ingressAddress || c.egressSubnets) {
+		return fmt.Errorf("--primary-address cannot be combined with other flags")
 	}
 
 	return cmd.CheckEmpty(args[1:])
 }
 
+// interfaceAddress is the output format of a single address of a
+// network interface.
+type interfaceAddress struct {
+	Address string `json:"value" yaml:"value"`
+	CIDR    string `json:"cidr,omitempty" yaml:"cidr,omitempty"`
+}
+
+// interfaceInfo is the output format of a network interface the binding
+// is bound to.
+type interfaceInfo struct {
+	MACAddress    string             `json:"macaddress,omitempty" yaml:"macaddress,omitempty"`
+	InterfaceName string             `json:"interfacename,omitempty" yaml:"interfacename,omitempty"`
+	Addresses     []interfaceAddress `json:"addresses" yaml:"addresses"`
+}
+
 func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
-	netConfig, err := c.ctx.NetworkConfig(c.bindingName)
+	if c.primaryAddress {
+		netConfig, err := c.ctx.NetworkConfig(c.bindingName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(netConfig) < 1 {
+			return fmt.Errorf("no network config found for binding %q", c.bindingName)
+		}
+		return c.out.Write(ctx, netConfig[0].Address)
+	}
+
+	results, err := c.ctx.NetworkInfo([]string{c.bindingName})
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if len(netConfig) < 1 {
+	info, ok := results[c.bindingName]
+	if !ok {
 		return fmt.Errorf("no network config found for binding %q", c.bindingName)
 	}
+	if info.Error != nil {
+		return errors.Trace(info.Error)
+	}
 
-	if c.primaryAddress {
-		return c.out.Write(ctx, netConfig[0].Address)
+	var bindAddress string
+	bindAddresses := []interfaceInfo{}
+	for _, iface := range info.Info {
+		out := interfaceInfo{
+			MACAddress:    iface.MACAddress,
+			InterfaceName: iface.InterfaceName,
+		}
+		for _, addr := range iface.Addresses {
+			if bindAddress == "" {
+				bindAddress = addr.Address
+			}
+			out.Addresses = append(out.Addresses, interfaceAddress{
+				Address: addr.Address,
+				CIDR:    addr.CIDR,
+			})
+		}
+		bindAddresses = append(bindAddresses, out)
+	}
+	var ingressAddress string
+	if len(info.IngressAddresses) > 0 {
+		ingressAddress = info.IngressAddresses[0]
+	}
+	egressSubnets := info.EgressSubnets
+	if egressSubnets == nil {
+		egressSubnets = []string{}
 	}
 
-	return nil // never reached as --primary-address is required.
+	selected := make(map[string]interface{})
+	if c.bindAddress {
+		selected["bind-address"] = bindAddress
+	}
+	if c.ingressAddress {
+		selected["ingress-address"] = ingressAddress
+	}
+	if c.egressSubnets {
+		selected["egress-subnets"] = egressSubnets
+	}
+	switch len(selected) {
+	case 0:
+		ingressAddresses := info.IngressAddresses
+		if ingressAddresses == nil {
+			ingressAddresses = []string{}
+		}
+		return c.out.Write(ctx, map[string]interface{}{
+			"bind-addresses":    bindAddresses,
+			"ingress-addresses": ingressAddresses,
+			"egress-subnets":    egressSubnets,
+		})
+	case 1:
+		for _, value := range selected {
+			return c.out.Write(ctx, value)
+		}
+	}
+	return c.out.Write(ctx, selected)
 }
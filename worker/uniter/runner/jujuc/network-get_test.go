@@ -40,6 +40,33 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 	}
 	hctx.info.NetworkInterface.BindingsToNetworkConfigs = presetBindings
 
+	presetInfo := make(map[string]params.NetworkInfoResult)
+	presetInfo["known-relation"] = params.NetworkInfoResult{
+		Info: []params.NetworkInfo{{
+			MACAddress:    "00:11:22:33:44:00",
+			InterfaceName: "eth0",
+			Addresses: []params.InterfaceAddress{
+				{Address: "10.10.0.23", CIDR: "10.10.0.0/24"},
+				{Address: "192.168.1.111", CIDR: "192.168.1.0/24"},
+			},
+		}},
+		IngressAddresses: []string{"10.10.0.23", "192.168.1.111"},
+		EgressSubnets:    []string{"10.10.0.23/32"},
+	}
+	presetInfo["behind-nat"] = params.NetworkInfoResult{
+		Info: []params.NetworkInfo{{
+			MACAddress:    "00:11:22:33:44:11",
+			InterfaceName: "eth1",
+			Addresses: []params.InterfaceAddress{
+				{Address: "10.33.1.8", CIDR: "10.33.0.0/16"},
+			},
+		}},
+		IngressAddresses: []string{"54.1.2.3", "10.33.1.8"},
+		EgressSubnets:    []string{"54.1.2.3/32"},
+	}
+	presetInfo["no-addresses"] = params.NetworkInfoResult{}
+	hctx.info.NetworkInterface.BindingsToNetworkInfo = presetInfo
+
 	com, err := jujuc.NewCommand(hctx, cmdString("network-get"))
 	c.Assert(err, jc.ErrorIsNil)
 	return com
@@ -62,10 +89,15 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		args:    []string{""},
 		out:     `no binding name specified`,
 	}, {
-		summary: "binding name given, no --primary-address given",
+		summary: "--primary-address combined with other flags",
 		code:    2,
-		args:    []string{"foo"},
-		out:     `--primary-address is currently required`,
+		args:    []string{"foo", "--primary-address", "--ingress-address"},
+		out:     `--primary-address cannot be combined with other flags`,
+	}, {
+		summary: "unknown binding given, no flags",
+		args:    []string{"unknown"},
+		code:    1,
+		out:     "insert server error for unknown binding here",
 	}, {
 		summary: "unknown binding given, with --primary-address",
 		args:    []string{"unknown", "--primary-address"},
@@ -88,6 +120,47 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		summary: "implicitly bound binding name given with --primary-address",
 		args:    []string{"known-unbound", "--primary-address"},
 		out:     "10.33.1.8", // preferred private address used for unspecified bindings.
+	}, {
+		summary: "binding name given with --bind-address",
+		args:    []string{"behind-nat", "--bind-address"},
+		out:     "10.33.1.8",
+	}, {
+		summary: "binding name given with --ingress-address",
+		args:    []string{"behind-nat", "--ingress-address"},
+		out:     "54.1.2.3",
+	}, {
+		summary: "binding name given with --egress-subnets",
+		args:    []string{"behind-nat", "--egress-subnets"},
+		out:     "54.1.2.3/32",
+	}, {
+		summary: "binding name given with several flags",
+		args:    []string{"behind-nat", "--bind-address", "--ingress-address", "--egress-subnets", "--format", "yaml"},
+		out: `
+bind-address: 10.33.1.8
+egress-subnets:
+- 54.1.2.3/32
+ingress-address: 54.1.2.3`[1:],
+	}, {
+		summary: "binding name given, no flags",
+		args:    []string{"known-relation", "--format", "yaml"},
+		out: `
+bind-addresses:
+- macaddress: "00:11:22:33:44:00"
+  interfacename: eth0
+  addresses:
+  - value: 10.10.0.23
+    cidr: 10.10.0.0/24
+  - value: 192.168.1.111
+    cidr: 192.168.1.0/24
+egress-subnets:
+- 10.10.0.23/32
+ingress-addresses:
+- 10.10.0.23
+- 192.168.1.111`[1:],
+	}, {
+		summary: "binding name without addresses given, no flags",
+		args:    []string{"no-addresses", "--format", "json"},
+		out:     `{"bind-addresses":[],"egress-subnets":[],"ingress-addresses":[]}`,
 	}} {
 		c.Logf("test %d: %s", i, t.summary)
 		com := s.createCommand(c)
@@ -112,23 +185,38 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 func (s *NetworkGetSuite) TestHelp(c *gc.C) {
 
 	var helpTemplate = `
-Usage: network-get [options] <binding-name> --primary-address
+Usage: network-get [options] <binding-name> [--primary-address] [--bind-address] [--ingress-address] [--egress-subnets]
 
 Summary:
 get network config
 
 Options:
+--bind-address  (= false)
+    get the address for the binding on which the unit should listen
+--egress-subnets  (= false)
+    get the egress subnets for the binding
 --format  (= smart)
     Specify output format (json|smart|yaml)
+--ingress-address  (= false)
+    get the ingress address for the binding
 -o, --output (= "")
     Specify an output file
 --primary-address  (= false)
     get the primary address for the binding
 
 Details:
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. By default
+it returns the addresses the binding is bound to (bind-addresses), the
+addresses other units should use to reach it (ingress-addresses) and the
+subnets its outgoing traffic originates from (egress-subnets).
+
+The --bind-address, --ingress-address and --egress-subnets flags select
+the first bind address, the first ingress address and the egress subnets
+respectively; when more than one is given, the selected values are
+returned keyed by name.
+
+--primary-address returns the IP address the local unit should advertise
+as its endpoint to its peers and cannot be combined with other flags.
 `[1:]
 
 	com := s.createCommand(c)
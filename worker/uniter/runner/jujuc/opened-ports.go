@@ -4,15 +4,21 @@
 package jujuc
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/network"
 )
 
 // OpenedPortsCommand implements the opened-ports command.
 type OpenedPortsCommand struct {
 	cmd.CommandBase
-	ctx Context
-	out cmd.Output
+	ctx           Context
+	out           cmd.Output
+	showEndpoints bool
 }
 
 func NewOpenedPortsCommand(ctx Context) (cmd.Command, error) {
@@ -21,7 +27,11 @@ func NewOpenedPortsCommand(ctx Context) (cmd.Command, error) {
 
 func (c *OpenedPortsCommand) Info() *cmd.Info {
 	doc := `Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
-<from>-<to>/<protocol> (e.g. "8080-8088/udp").`
+<from>-<to>/<protocol> (e.g. "8080-8088/udp").
+
+With --endpoints, each entry is followed by the endpoints the port range
+was opened for (e.g. "80/tcp (website,admin)"), or "*" if it was opened
+for all endpoints.`
 	return &cmd.Info{
 		Name:    "opened-ports",
 		Purpose: "lists all ports or ranges opened by the unit",
@@ -31,6 +41,7 @@ func (c *OpenedPortsCommand) Info() *cmd.Info {
 
 func (c *OpenedPortsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.BoolVar(&c.showEndpoints, "endpoints", false, "display the endpoints each port range was opened for")
 }
 
 func (c *OpenedPortsCommand) Init(args []string) error {
@@ -39,9 +50,30 @@ func (c *OpenedPortsCommand) Init(args []string) error {
 
 func (c *OpenedPortsCommand) Run(ctx *cmd.Context) error {
 	unitPorts := c.ctx.OpenedPorts()
+	var portEndpoints map[network.PortRange][]string
+	if c.showEndpoints {
+		portEndpoints = c.ctx.OpenedPortEndpoints()
+	}
 	results := make([]string, len(unitPorts))
 	for i, portRange := range unitPorts {
 		results[i] = portRange.String()
+		if c.showEndpoints {
+			results[i] = fmt.Sprintf("%s (%s)", results[i], formatPortEndpoints(portEndpoints[portRange]))
+		}
 	}
 	return c.out.Write(ctx, results)
 }
+
+// formatPortEndpoints returns the given endpoints as a comma-separated
+// list, or "*" if the port range was opened for all endpoints.
+func formatPortEndpoints(endpoints []string) string {
+	if len(endpoints) == 0 {
+		return "*"
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			return "*"
+		}
+	}
+	return strings.Join(endpoints, ",")
+}
@@ -80,9 +80,23 @@ lists all ports or ranges opened by the unit
 Details:
 Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
 <from>-<to>/<protocol> (e.g. "8080-8088/udp").
+
+With --endpoints, each entry is followed by the endpoints the port range
+was opened for (e.g. "80/tcp (website,admin)"), or "*" if it was opened
+for all endpoints.
 `[1:])
 }
 
+func (s *OpenedPortsSuite) TestRunWithEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.OpenPorts("tcp", 10, 20)
+	hctx.OpenEndpointPorts("website", "tcp", 80, 80)
+	hctx.OpenEndpointPorts("admin", "tcp", 80, 80)
+	stdout, stderr := s.runCommand(c, hctx, "--endpoints")
+	c.Check(stdout, gc.Equals, "10-20/tcp (*)\n80/tcp (admin,website)\n")
+	c.Check(stderr, gc.Equals, "")
+}
+
 func (s *OpenedPortsSuite) getContextAndOpenPorts(c *gc.C) *Context {
 	hctx := s.GetHookContext(c, -1, "")
 	hctx.OpenPorts("tcp", 80, 80)
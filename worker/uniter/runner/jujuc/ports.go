@@ -110,6 +110,7 @@ type portCommand struct {
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	Endpoints  []string
 	formatFlag string // deprecated
 }
 
@@ -119,6 +120,7 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.Var(cmd.NewStringsValue(nil, &c.Endpoints), "endpoints", "a comma-delimited list of endpoints the port range applies to")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -134,6 +136,11 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+	for _, endpoint := range c.Endpoints {
+		if endpoint == "" {
+			return errors.Errorf("invalid empty endpoint name")
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -148,14 +155,26 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the service is exposed.",
+	Doc: `The port range will only be open while the service is exposed.
+
+If --endpoints is given, the port range is opened for those endpoints
+only, and will only be open while the service is exposed for all
+endpoints or for one of them.`,
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.OpenEndpointPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
@@ -164,13 +183,23 @@ var closePortInfo = &cmd.Info{
 	Name:    "close-port",
 	Args:    portFormat,
 	Purpose: "ensure a port or range is always closed",
+	Doc: `If --endpoints is given, the port range is closed for those endpoints
+only; otherwise it is closed for all endpoints.`,
 }
 
 func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
 		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.CloseEndpointPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
@@ -99,6 +99,7 @@ var badPortsTests = []struct {
 	{[]string{"9999/foo"}, `protocol must be "tcp" or "udp"; got "foo"`},
 	{[]string{"80-90/http"}, `protocol must be "tcp" or "udp"; got "http"`},
 	{[]string{"20-10/tcp"}, `invalid port range 20-10/tcp; expected fromPort <= toPort`},
+	{[]string{"--endpoints", "website,", "80"}, `invalid empty endpoint name`},
 }
 
 func (s *PortsSuite) TestBadArgs(c *gc.C) {
@@ -126,6 +127,10 @@ register a port or range to open
 
 Details:
 The port range will only be open while the service is exposed.
+
+If --endpoints is given, the port range is opened for those endpoints
+only, and will only be open while the service is exposed for all
+endpoints or for one of them.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -135,9 +140,33 @@ Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>]
 
 Summary:
 ensure a port or range is always closed
+
+Details:
+If --endpoints is given, the port range is closed for those endpoints
+only; otherwise it is closed for all endpoints.
 `[1:])
 }
 
+func (s *PortsSuite) TestOpenCloseEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, args := range [][]string{
+		{"open-port", "--endpoints", "website,admin", "80"},
+		{"open-port", "443"},
+		{"close-port", "--endpoints", "admin", "80"},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString(args[0]))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, args[1:])
+		c.Assert(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+	hctx.info.CheckPorts(c, makeRanges("80/tcp", "443/tcp"))
+	c.Assert(hctx.info.PortEndpoints, jc.DeepEquals, map[network.PortRange][]string{
+		{80, 80, "tcp"}: {"website"},
+	})
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprectaionTests = []struct {
@@ -64,11 +64,29 @@ func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) erro
 // OpenedPorts implements jujuc.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
+// OpenEndpointPorts implements jujuc.Context.
+func (*RestrictedContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// CloseEndpointPorts implements jujuc.Context.
+func (*RestrictedContext) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// OpenedPortEndpoints implements jujuc.Context.
+func (*RestrictedContext) OpenedPortEndpoints() map[network.PortRange][]string { return nil }
+
 // NetworkConfig implements jujuc.Context.
 func (*RestrictedContext) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
 	return nil, ErrRestrictedContext
 }
 
+// NetworkInfo implements jujuc.Context.
+func (*RestrictedContext) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	return nil, ErrRestrictedContext
+}
+
 // IsLeader implements jujuc.Context.
 func (*RestrictedContext) IsLeader() (bool, error) { return false, ErrRestrictedContext }
 
@@ -101,6 +119,25 @@ func (*RestrictedContext) AddUnitStorage(map[string]params.StorageConstraints) e
 	return ErrRestrictedContext
 }
 
+// CreateSecret implements jujuc.Context.
+func (*RestrictedContext) CreateSecret(SecretCreateArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// UpdateSecret implements jujuc.Context.
+func (*RestrictedContext) UpdateSecret(string, map[string]string) error { return ErrRestrictedContext }
+
+// GetSecret implements jujuc.Context.
+func (*RestrictedContext) GetSecret(string, int) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.Context.
+func (*RestrictedContext) GrantSecret(string, int) error { return ErrRestrictedContext }
+
+// RevokeSecret implements jujuc.Context.
+func (*RestrictedContext) RevokeSecret(string, int) error { return ErrRestrictedContext }
+
 // Relation implements jujuc.Context.
 func (*RestrictedContext) Relation(id int) (ContextRelation, error) {
 	return nil, ErrRestrictedContext
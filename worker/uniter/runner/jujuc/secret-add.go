@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	label       string
+	description string
+	rotate      time.Duration
+	data        map[string]string
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add creates a secret owned by the unit's application, holding the
+supplied key/value pairs, and prints the ID of the new secret. The content
+is stored encrypted by the controller. Only the leader unit may add
+secrets.
+
+If --rotate is given, the leader is asked to rotate the secret, by running
+the secret-rotate hook, each time the interval elapses without a new
+revision being added with secret-set.
+
+Examples:
+    secret-add password=sekrit
+    secret-add --label db --rotate 720h username=admin password=sekrit
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<key>=<value> [...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.StringVar(&c.label, "label", "", "a label for the secret")
+	f.StringVar(&c.description, "description", "", "a description of the secret")
+	f.DurationVar(&c.rotate, "rotate", 0, "the interval at which to rotate the secret")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret content specified")
+	}
+	if c.rotate < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	c.data, err = keyvalues.Parse(args, false)
+	return errors.Trace(err)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.CreateSecret(SecretCreateArgs{
+		Label:          c.label,
+		Description:    c.description,
+		RotateInterval: c.rotate,
+		Data:           c.data,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	return c.out.Write(ctx, id)
+}
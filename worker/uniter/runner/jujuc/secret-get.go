@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	out      cmd.Output
+	id       string
+	key      string
+	revision int
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the content of a secret owned by the unit's application,
+or granted to it through a relation. If a key is given, only the value of
+that key is printed. The latest revision is printed unless --revision is
+given.
+
+Examples:
+    secret-get secret:1
+    secret-get secret:1 password
+    secret-get --revision 2 secret:1
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<ID> [<key>]",
+		Purpose: "print the content of a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.IntVar(&c.revision, "revision", 0, "the revision to print")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	if c.revision < 0 {
+		return errors.NotValidf("revision %d", c.revision)
+	}
+	c.id = args[0]
+	if len(args) > 1 {
+		c.key = args[1]
+		args = args[2:]
+	} else {
+		args = nil
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	data, err := c.ctx.GetSecret(c.id, c.revision)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.id)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, data)
+	}
+	if value, ok := data[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) newCommand(c *gc.C) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Secrets = map[string]map[string]string{
+		"secret:1": {"password": "sekrit", "username": "root"},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *SecretGetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no secret ID specified",
+	}, {
+		args: []string{"secret:1", "password", "username"},
+		err:  `unrecognized args: \["username"\]`,
+	}, {
+		args: []string{"--revision", "-1", "secret:1"},
+		err:  "revision -1 not valid",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := testing.InitCommand(s.newCommand(c), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretGetSuite) TestGetAll(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.newCommand(c), ctx, []string{"secret:1", "--format", "yaml"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "password: sekrit\nusername: root\n")
+	s.Stub.CheckCall(c, 0, "GetSecret", "secret:1", 0)
+}
+
+func (s *SecretGetSuite) TestGetKey(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.newCommand(c), ctx, []string{"--revision", "2", "secret:1", "password"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "sekrit\n")
+	s.Stub.CheckCall(c, 0, "GetSecret", "secret:1", 2)
+}
+
+func (s *SecretGetSuite) TestGetNotFound(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.newCommand(c), ctx, []string{"secret:2"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read secret \"secret:2\": secret \"secret:2\" not found\n")
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGrantCommand implements the secret-grant and secret-revoke
+// commands, which differ only in what they do to the grant.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	revoke          bool
+	id              string
+	relationId      int
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand returns a new secret-grant command with the
+// given context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return newSecretGrantCommand(ctx, false)
+}
+
+// NewSecretRevokeCommand returns a new secret-revoke command with the
+// given context.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return newSecretGrantCommand(ctx, true)
+}
+
+func newSecretGrantCommand(ctx Context, revoke bool) (cmd.Command, error) {
+	c := &secretGrantCommand{ctx: ctx, revoke: revoke}
+	rV, err := newRelationIdValue(ctx, &c.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	if c.revoke {
+		return &cmd.Info{
+			Name:    "secret-revoke",
+			Args:    "<ID>",
+			Purpose: "revoke access to a secret",
+			Doc: `
+secret-revoke removes the access to a secret owned by the unit's
+application that was granted to the application at the other end of a
+relation. Only the leader unit may revoke access to secrets.
+
+Examples:
+    secret-revoke secret:1 -r db:2
+`,
+		}
+	}
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<ID>",
+		Purpose: "grant access to a secret",
+		Doc: `
+secret-grant allows the application at the other end of a relation to read
+a secret owned by the unit's application. The grant lasts until it is
+revoked, or until the relation is removed. Units of the application
+granted access run the secret-changed hook. Only the leader unit may grant
+access to secrets.
+
+Examples:
+    secret-grant secret:1 -r db:2
+`,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	if c.relationId == -1 {
+		return errors.New("no relation id specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	if c.revoke {
+		err := c.ctx.RevokeSecret(c.id, c.relationId)
+		return errors.Annotatef(err, "cannot revoke access to secret %q", c.id)
+	}
+	err := c.ctx.GrantSecret(c.id, c.relationId)
+	return errors.Annotatef(err, "cannot grant access to secret %q", c.id)
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		relid int
+		args  []string
+		err   string
+	}{{
+		relid: 1,
+		err:   "no secret ID specified",
+	}, {
+		relid: -1,
+		args:  []string{"secret:1"},
+		err:   "no relation id specified",
+	}, {
+		relid: -1,
+		args:  []string{"secret:1", "-r", "peer1:9"},
+		err:   `invalid value "peer1:9" for flag -r: relation not found`,
+	}, {
+		relid: 1,
+		args:  []string{"secret:1", "secret:2"},
+		err:   `unrecognized args: \["secret:2"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		for _, name := range []string{"secret-grant", "secret-revoke"} {
+			hctx, _ := s.newHookContext(t.relid, "")
+			com, err := jujuc.NewCommand(hctx, cmdString(name))
+			c.Assert(err, jc.ErrorIsNil)
+			err = testing.InitCommand(com, t.args)
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *SecretGrantSuite) TestGrant(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:1", "-r", "peer1:1"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "GrantSecret", "secret:1", 1)
+}
+
+func (s *SecretGrantSuite) TestRevokeHookRelation(c *gc.C) {
+	hctx, _ := s.newHookContext(0, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:1"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, len(s.Stub.Calls())-1, "RevokeSecret", "secret:1", 0)
+}
+
+func (s *SecretGrantSuite) TestGrantError(c *gc.C) {
+	hctx, _ := s.newHookContext(0, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.SetErrors(errors.New("not the leader"))
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret:1"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot grant access to secret \"secret:1\": not the leader\n")
+}
@@ -4,6 +4,8 @@
 package testing
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -17,7 +19,9 @@ type NetworkInterface struct {
 	PublicAddress            string
 	PrivateAddress           string
 	Ports                    []network.PortRange
+	PortEndpoints            map[network.PortRange][]string
 	BindingsToNetworkConfigs map[string][]params.NetworkConfig
+	BindingsToNetworkInfo    map[string]params.NetworkInfoResult
 }
 
 // CheckPorts checks the current ports.
@@ -35,6 +39,60 @@ func (ni *NetworkInterface) AddPorts(protocol string, from, to int) {
 	network.SortPortRanges(ni.Ports)
 }
 
+// AddEndpointPorts adds the specified port range for the given endpoint.
+func (ni *NetworkInterface) AddEndpointPorts(endpoint, protocol string, from, to int) {
+	portRange := network.PortRange{
+		Protocol: protocol,
+		FromPort: from,
+		ToPort:   to,
+	}
+	if ni.PortEndpoints == nil {
+		ni.PortEndpoints = make(map[network.PortRange][]string)
+	}
+	known := false
+	for _, port := range ni.Ports {
+		if port == portRange {
+			known = true
+			break
+		}
+	}
+	if !known {
+		ni.AddPorts(protocol, from, to)
+	}
+	endpoints := ni.PortEndpoints[portRange]
+	for _, existing := range endpoints {
+		if existing == endpoint {
+			return
+		}
+	}
+	endpoints = append(endpoints, endpoint)
+	sort.Strings(endpoints)
+	ni.PortEndpoints[portRange] = endpoints
+}
+
+// RemoveEndpointPorts removes the specified port range for the given
+// endpoint, or for all endpoints if the endpoint is empty.
+func (ni *NetworkInterface) RemoveEndpointPorts(endpoint, protocol string, from, to int) {
+	portRange := network.PortRange{
+		Protocol: protocol,
+		FromPort: from,
+		ToPort:   to,
+	}
+	var remaining []string
+	if endpoint != "" {
+		for _, existing := range ni.PortEndpoints[portRange] {
+			if existing != endpoint {
+				remaining = append(remaining, existing)
+			}
+		}
+	}
+	if len(remaining) > 0 {
+		ni.PortEndpoints[portRange] = remaining
+		return
+	}
+	ni.RemovePorts(protocol, from, to)
+}
+
 // RemovePorts removes the specified port range.
 func (ni *NetworkInterface) RemovePorts(protocol string, from, to int) {
 	portRange := network.PortRange{
@@ -48,6 +106,7 @@ func (ni *NetworkInterface) RemovePorts(protocol string, from, to int) {
 			break
 		}
 	}
+	delete(ni.PortEndpoints, portRange)
 	network.SortPortRanges(ni.Ports)
 }
 
@@ -84,6 +143,28 @@ func (c *ContextNetworking) OpenPorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenEndpointPorts implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenEndpointPorts(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenEndpointPorts", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddEndpointPorts(endpoint, protocol, from, to)
+	return nil
+}
+
+// CloseEndpointPorts implements jujuc.ContextNetworking.
+func (c *ContextNetworking) CloseEndpointPorts(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("CloseEndpointPorts", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.RemoveEndpointPorts(endpoint, protocol, from, to)
+	return nil
+}
+
 // ClosePorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	c.stub.AddCall("ClosePorts", protocol, from, to)
@@ -103,6 +184,14 @@ func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	return c.info.Ports
 }
 
+// OpenedPortEndpoints implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenedPortEndpoints() map[network.PortRange][]string {
+	c.stub.AddCall("OpenedPortEndpoints")
+	c.stub.NextErr()
+
+	return c.info.PortEndpoints
+}
+
 // NetworkConfig implements jujuc.ContextNetworking.
 func (c *ContextNetworking) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
 	c.stub.AddCall("NetworkConfig", bindingName)
@@ -116,3 +205,21 @@ func (c *ContextNetworking) NetworkConfig(bindingName string) ([]params.NetworkC
 	}
 	return netConfig, nil
 }
+
+// NetworkInfo implements jujuc.ContextNetworking.
+func (c *ContextNetworking) NetworkInfo(bindingNames []string) (map[string]params.NetworkInfoResult, error) {
+	c.stub.AddCall("NetworkInfo", bindingNames)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make(map[string]params.NetworkInfoResult)
+	for _, name := range bindingNames {
+		info, isBindingKnown := c.info.BindingsToNetworkInfo[name]
+		if !isBindingKnown {
+			info.Error = &params.Error{Message: "insert server error for unknown binding here"}
+		}
+		results[name] = info
+	}
+	return results, nil
+}
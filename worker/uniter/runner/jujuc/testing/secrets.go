@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// Secrets holds the latest content of each secret, keyed by id.
+	Secrets map[string]map[string]string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(args jujuc.SecretCreateArgs) (string, error) {
+	c.stub.AddCall("CreateSecret", args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string]map[string]string)
+	}
+	id := fmt.Sprintf("secret:%d", len(c.info.Secrets)+1)
+	c.info.Secrets[id] = args.Data
+	return id, nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(id string, data map[string]string) error {
+	c.stub.AddCall("UpdateSecret", id, data)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := c.info.Secrets[id]; !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	c.info.Secrets[id] = data
+	return nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string, revision int) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", id, revision)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, ok := c.info.Secrets[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return data, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, relationId int) error {
+	c.stub.AddCall("GrantSecret", id, relationId)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(id string, relationId int) error {
+	c.stub.AddCall("RevokeSecret", id, relationId)
+	return errors.Trace(c.stub.NextErr())
+}
@@ -137,6 +137,7 @@ func (a *Attachments) init() error {
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				size:     attachment.Size,
 			},
 		}
 	}
@@ -210,10 +211,14 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := storageState.CommitHook(hi); err != nil {
+	storageTag := names.NewStorageTag(hi.StorageId)
+	size := storageState.size
+	if ctx, ok := a.storageAttachments[storageTag].ContextStorageAttachment.(*contextStorage); ok {
+		size = ctx.size
+	}
+	if err := storageState.commitHook(hi, size); err != nil {
 		return err
 	}
-	storageTag := names.NewStorageTag(hi.StorageId)
 	switch hi.Kind {
 	case hooks.StorageAttached:
 		a.pending.Remove(storageTag)
@@ -235,7 +240,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
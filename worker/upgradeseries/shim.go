@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiupgradeseries "github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/worker"
+)
+
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apiupgradeseries.NewFacade(apiCaller), nil
+}
+
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// NewService returns the service.Service for the given series.
+func NewService(name string, conf common.Conf, series string) (Service, error) {
+	svc, err := service.NewService(name, conf, series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	writable, ok := svc.(Service)
+	if !ok {
+		return nil, errors.NotSupportedf("rewriting services for series %q", series)
+	}
+	return writable, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries provides a worker that reconfigures the agents
+// on a machine for the new series while the machine is prepared for an
+// upgrade of its operating system, so that the agents are started by
+// the init system of the new series when the machine reboots.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/shell"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/common"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.upgradeseries")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	WatchUpgradeSeries(machineId string) (watcher.NotifyWatcher, error)
+	UpgradeSeriesInfo(machineId string) (params.UpgradeSeriesInfo, error)
+	FinishUpgradeSeries(machineId string) error
+}
+
+// Service is the part of a service used to replace the service
+// definition of an agent for one init system with that for another.
+type Service interface {
+	// WriteService writes the service definition, without reference
+	// to the running init system.
+	WriteService() error
+
+	// Remove removes the service definition.
+	Remove() error
+}
+
+// Config defines the parameters of the upgradeseries worker.
+type Config struct {
+	Facade    Facade
+	MachineId string
+
+	// DataDir and LogDir are the directories used by the agents on
+	// the machine.
+	DataDir string
+	LogDir  string
+
+	// ContainerType is the type of container the machine is, if
+	// any; it is passed on to the unit agents' services.
+	ContainerType string
+
+	// NewService returns the service for the agent with the given
+	// service name, for the init system of the given series.
+	NewService func(name string, conf common.Conf, series string) (Service, error)
+}
+
+// Validate returns an error if Config cannot drive an upgradeseries
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.MachineId == "" {
+		return errors.NotValidf("empty MachineId")
+	}
+	if config.DataDir == "" {
+		return errors.NotValidf("empty DataDir")
+	}
+	if config.LogDir == "" {
+		return errors.NotValidf("empty LogDir")
+	}
+	if config.NewService == nil {
+		return errors.NotValidf("nil NewService")
+	}
+	return nil
+}
+
+// New returns a Worker that, once the machine is prepared for a series
+// upgrade, writes the service definitions of the machine and unit
+// agents on it for the init system of the new series and removes those
+// of the old one. The definitions must be in place before the machine
+// reboots into the new series, since the agents are not running to
+// rewrite them afterwards if the init system has changed. Once the
+// upgrade is completed, the worker records that it is finished.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &handler{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type handler struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *handler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchUpgradeSeries(h.config.MachineId)
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *handler) Handle(_ <-chan struct{}) error {
+	info, err := h.config.Facade.UpgradeSeriesInfo(h.config.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch info.Status {
+	case params.UpgradeSeriesPrepared:
+		return errors.Trace(h.rewriteServices(info))
+	case params.UpgradeSeriesCompleted:
+		return h.config.Facade.FinishUpgradeSeries(h.config.MachineId)
+	}
+	return nil
+}
+
+// rewriteServices replaces the service definitions of the agents on
+// the machine for the init system of its current series with those for
+// the init system of the series it is being upgraded to.
+func (h *handler) rewriteServices(info params.UpgradeSeriesInfo) error {
+	fromInit, err := service.VersionInitSystem(info.Series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	toInit, err := service.VersionInitSystem(info.TargetSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if fromInit == toInit {
+		logger.Debugf("series %q uses init system %q too; nothing to rewrite", info.TargetSeries, toInit)
+		return nil
+	}
+	logger.Infof("rewriting agent services from %s for %s (series %q)", fromInit, toInit, info.TargetSeries)
+	renderer, err := shell.NewRenderer("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineInfo := service.NewMachineAgentInfo(h.config.MachineId, h.config.DataDir, h.config.LogDir)
+	machineTag := names.NewMachineTag(h.config.MachineId)
+	if err := h.rewrite(machineTag, service.AgentConf(machineInfo, renderer), info); err != nil {
+		return errors.Trace(err)
+	}
+	for _, unitName := range info.Units {
+		unitInfo := service.NewUnitAgentInfo(unitName, h.config.DataDir, h.config.LogDir)
+		conf := service.ContainerAgentConf(unitInfo, renderer, h.config.ContainerType)
+		if err := h.rewrite(names.NewUnitTag(unitName), conf, info); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (h *handler) rewrite(tag names.Tag, conf common.Conf, info params.UpgradeSeriesInfo) error {
+	name := "jujud-" + tag.String()
+	newSvc, err := h.config.NewService(name, conf, info.TargetSeries)
+	if err != nil {
+		return errors.Annotatef(err, "cannot create service %q", name)
+	}
+	if err := newSvc.WriteService(); err != nil {
+		return errors.Annotatef(err, "cannot write service %q", name)
+	}
+	oldSvc, err := h.config.NewService(name, conf, info.Series)
+	if err != nil {
+		return errors.Annotatef(err, "cannot create service %q", name)
+	}
+	// Removing the old definition leaves the running agent alone.
+	if err := oldSvc.Remove(); err != nil {
+		return errors.Annotatef(err, "cannot remove service %q", name)
+	}
+	logger.Debugf("rewrote service %q", name)
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/service/common"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/upgradeseries"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	facade *stubFacade
+	config upgradeseries.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = new(jujutesting.Stub)
+	s.facade = newStubFacade(s.stub)
+	s.config = upgradeseries.Config{
+		Facade:        s.facade,
+		MachineId:     "42",
+		DataDir:       "/var/lib/juju",
+		LogDir:        "/var/log/juju",
+		ContainerType: "lxd",
+		NewService: func(name string, conf common.Conf, series string) (upgradeseries.Service, error) {
+			s.stub.AddCall("NewService", name, series)
+			return &stubService{stub: s.stub, name: name}, s.stub.NextErr()
+		},
+	}
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.NewService = nil
+	_, err := upgradeseries.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil NewService not valid")
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) runWorker(c *gc.C) {
+	w, err := upgradeseries.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.facade.infoCalled:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for upgrade series info")
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *Suite) TestNotStarted(c *gc.C) {
+	s.facade.info = params.UpgradeSeriesInfo{
+		Series: "trusty",
+		Units:  []string{"mysql/0"},
+	}
+	s.runWorker(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeries", "UpgradeSeriesInfo")
+}
+
+func (s *Suite) TestPrepared(c *gc.C) {
+	s.facade.info = params.UpgradeSeriesInfo{
+		Status:       params.UpgradeSeriesPrepared,
+		Series:       "trusty",
+		TargetSeries: "xenial",
+		Units:        []string{"mysql/0", "wordpress/1"},
+	}
+	s.runWorker(c)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchUpgradeSeries", []interface{}{"42"}},
+		{"UpgradeSeriesInfo", []interface{}{"42"}},
+		{"NewService", []interface{}{"jujud-machine-42", "xenial"}},
+		{"WriteService", []interface{}{"jujud-machine-42"}},
+		{"NewService", []interface{}{"jujud-machine-42", "trusty"}},
+		{"Remove", []interface{}{"jujud-machine-42"}},
+		{"NewService", []interface{}{"jujud-unit-mysql-0", "xenial"}},
+		{"WriteService", []interface{}{"jujud-unit-mysql-0"}},
+		{"NewService", []interface{}{"jujud-unit-mysql-0", "trusty"}},
+		{"Remove", []interface{}{"jujud-unit-mysql-0"}},
+		{"NewService", []interface{}{"jujud-unit-wordpress-1", "xenial"}},
+		{"WriteService", []interface{}{"jujud-unit-wordpress-1"}},
+		{"NewService", []interface{}{"jujud-unit-wordpress-1", "trusty"}},
+		{"Remove", []interface{}{"jujud-unit-wordpress-1"}},
+	})
+}
+
+func (s *Suite) TestPreparedSameInitSystem(c *gc.C) {
+	s.facade.info = params.UpgradeSeriesInfo{
+		Status:       params.UpgradeSeriesPrepared,
+		Series:       "vivid",
+		TargetSeries: "xenial",
+		Units:        []string{"mysql/0"},
+	}
+	s.runWorker(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeries", "UpgradeSeriesInfo")
+}
+
+func (s *Suite) TestCompleted(c *gc.C) {
+	s.facade.info = params.UpgradeSeriesInfo{
+		Status: params.UpgradeSeriesCompleted,
+		Series: "xenial",
+		Units:  []string{"mysql/0", "wordpress/1"},
+	}
+	s.runWorker(c)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchUpgradeSeries", []interface{}{"42"}},
+		{"UpgradeSeriesInfo", []interface{}{"42"}},
+		{"FinishUpgradeSeries", []interface{}{"42"}},
+	})
+}
+
+func (s *Suite) TestWriteServiceError(c *gc.C) {
+	s.facade.info = params.UpgradeSeriesInfo{
+		Status:       params.UpgradeSeriesPrepared,
+		Series:       "trusty",
+		TargetSeries: "xenial",
+	}
+	s.stub.SetErrors(nil, nil, nil, errors.New("boom"))
+	w, err := upgradeseries.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, `cannot write service "jujud-machine-42": boom`)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeries", "UpgradeSeriesInfo", "NewService", "WriteService")
+}
+
+func newStubFacade(stub *jujutesting.Stub) *stubFacade {
+	return &stubFacade{
+		stub:       stub,
+		infoCalled: make(chan struct{}, 1),
+	}
+}
+
+type stubFacade struct {
+	stub       *jujutesting.Stub
+	info       params.UpgradeSeriesInfo
+	infoCalled chan struct{}
+}
+
+func (f *stubFacade) WatchUpgradeSeries(machineId string) (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("WatchUpgradeSeries", machineId)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return workertest.NewFakeWatcher(1, 1), nil
+}
+
+func (f *stubFacade) UpgradeSeriesInfo(machineId string) (params.UpgradeSeriesInfo, error) {
+	f.stub.AddCall("UpgradeSeriesInfo", machineId)
+	select {
+	case f.infoCalled <- struct{}{}:
+	default:
+	}
+	return f.info, f.stub.NextErr()
+}
+
+func (f *stubFacade) FinishUpgradeSeries(machineId string) error {
+	f.stub.AddCall("FinishUpgradeSeries", machineId)
+	return f.stub.NextErr()
+}
+
+type stubService struct {
+	stub *jujutesting.Stub
+	name string
+}
+
+func (s *stubService) WriteService() error {
+	s.stub.AddCall("WriteService", s.name)
+	return s.stub.NextErr()
+}
+
+func (s *stubService) Remove() error {
+	s.stub.AddCall("Remove", s.name)
+	return s.stub.NextErr()
+}